@@ -0,0 +1,121 @@
+// Command jwtkeys manages the asymmetric keys used to sign JWTs.
+//
+//	go run ./cmd/jwtkeys rotate          # add a new signing key
+//	go run ./cmd/jwtkeys prune -keep 2   # drop all but the newest keys
+//	go run ./cmd/jwtkeys list
+//
+// A rotated key only signs tokens after the API restarts; until then it is
+// already published in /.well-known/jwks.json by instances that loaded it.
+// Prune only once tokens signed by the old keys have expired
+// (JWT_REFRESH_TOKEN_EXPIRY).
+package main
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/utils"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg := config.Load()
+	if cfg.JWT.KeysDir == "" {
+		exitf("JWT_KEYS_DIR is not set")
+	}
+
+	switch os.Args[1] {
+	case "rotate":
+		rotate(cfg.JWT, os.Args[2:])
+	case "prune":
+		prune(cfg.JWT, os.Args[2:])
+	case "list":
+		list(cfg.JWT)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: jwtkeys <rotate|prune|list> [flags]")
+}
+
+func exitf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func rotate(cfg config.JWTConfig, args []string) {
+	fs := flag.NewFlagSet("rotate", flag.ExitOnError)
+	algorithm := fs.String("alg", cfg.Algorithm, "signing algorithm (RS256 or EdDSA)")
+	fs.Parse(args)
+
+	key, err := utils.GenerateSigningKey(*algorithm)
+	if err != nil {
+		exitf("failed to generate key: %v", err)
+	}
+
+	path, err := utils.WriteSigningKey(cfg.KeysDir, key)
+	if err != nil {
+		exitf("failed to write key: %v", err)
+	}
+
+	fmt.Printf("created %s key %s (%s)\n", key.Algorithm, key.ID, path)
+	if cfg.ActiveKeyID != "" {
+		fmt.Printf("JWT_ACTIVE_KEY_ID is pinned to %s, update it to start signing with the new key\n", cfg.ActiveKeyID)
+	}
+}
+
+func prune(cfg config.JWTConfig, args []string) {
+	fs := flag.NewFlagSet("prune", flag.ExitOnError)
+	keep := fs.Int("keep", 2, "number of newest keys to keep")
+	fs.Parse(args)
+
+	if *keep < 1 {
+		exitf("-keep must be at least 1")
+	}
+
+	ids, err := utils.ListSigningKeyIDs(cfg.KeysDir)
+	if err != nil {
+		exitf("failed to list keys: %v", err)
+	}
+
+	if len(ids) <= *keep {
+		fmt.Println("nothing to prune")
+		return
+	}
+
+	for _, id := range ids[:len(ids)-*keep] {
+		if id == cfg.ActiveKeyID {
+			fmt.Printf("skipping pinned active key %s\n", id)
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(cfg.KeysDir, id+".pem")); err != nil {
+			exitf("failed to remove key %s: %v", id, err)
+		}
+		fmt.Printf("removed key %s\n", id)
+	}
+}
+
+func list(cfg config.JWTConfig) {
+	keys, err := utils.LoadKeySet(cfg.KeysDir, cfg.ActiveKeyID)
+	if err != nil {
+		exitf("failed to load keys: %v", err)
+	}
+
+	for _, jwk := range keys.JWKS().Keys {
+		marker := " "
+		if jwk.KeyID == keys.Active().ID {
+			marker = "*"
+		}
+		fmt.Printf("%s %s %s\n", marker, jwk.KeyID, jwk.Algorithm)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"e-commerce/backend/internal/database"
 	"e-commerce/backend/internal/di"
 	"e-commerce/backend/internal/routes"
+	"e-commerce/backend/internal/services"
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -44,6 +46,29 @@ func initLogger() *zap.Logger {
 	return logger
 }
 
+// =======================
+// Audit Log Retention
+// =======================
+
+// runAuditRetention purges expired audit logs at startup and then once a day.
+func runAuditRetention(logger *zap.Logger, auditLogService services.AuditLogService, retention time.Duration) {
+	if retention <= 0 {
+		logger.Info("🗄️  Audit log retention disabled, keeping audit logs forever")
+		return
+	}
+
+	for {
+		deleted, err := auditLogService.PurgeExpired(retention)
+		if err != nil {
+			logger.Error("Failed to purge expired audit logs", zap.Error(err))
+		} else if deleted > 0 {
+			logger.Info("🗄️  Purged expired audit logs", zap.Int64("deleted", deleted), zap.Duration("retention", retention))
+		}
+
+		time.Sleep(24 * time.Hour)
+	}
+}
+
 func main() {
 	logger := initLogger()
 	defer logger.Sync()
@@ -76,6 +101,8 @@ func main() {
 
 	handler := di.InitializeAllHandler(cfg)
 
+	go runAuditRetention(logger, handler.AuditLogService, cfg.Audit.Retention)
+
 	router := routes.SetupRoutes(handler, logger, cfg.CORS)
 
 	port := cfg.Server.Port
@@ -17,7 +17,8 @@ func TestAppStartup(t *testing.T) {
 			Host: "localhost",
 		},
 		JWT: config.JWTConfig{
-			Secret: "testsecret",
+			Algorithm: "HS256",
+			Secret:    "testsecret",
 		},
 		CORS: config.CORSConfig{
 			AllowedOrigins: []string{"*"},
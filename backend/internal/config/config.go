@@ -16,6 +16,14 @@ type Config struct {
 	SMTP     SMTPConfig
 	System   SystemConfig
 	Supabase SupabaseConfig
+	Audit    AuditConfig
+	Password PasswordPolicyConfig
+	Session  SessionConfig
+	OIDC     OIDCConfig
+	Shipment ShipmentConfig
+	Tax      TaxConfig
+	Store    StoreConfig
+	Payment  PaymentConfig
 }
 
 type SupabaseConfig struct {
@@ -43,6 +51,91 @@ type JWTConfig struct {
 	RefreshSecret      string
 	AccessTokenExpiry  time.Duration
 	RefreshTokenExpiry time.Duration
+	// ImpersonationTokenExpiry limits "login as customer" sessions
+	ImpersonationTokenExpiry time.Duration
+	// Algorithm is HS256 (shared secrets), RS256 or EdDSA (keys in KeysDir);
+	// any other value, including none, stops startup
+	Algorithm   string
+	KeysDir     string
+	ActiveKeyID string
+}
+
+type AuditConfig struct {
+	// Retention is how long audit logs are kept, zero keeps them forever
+	Retention time.Duration
+}
+
+type PasswordPolicyConfig struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// HistorySize is how many previous passwords cannot be reused, zero disables the check
+	HistorySize int
+	// BannedListFile holds one breached or common password per line
+	BannedListFile string
+}
+
+type SessionConfig struct {
+	// CookieMode makes the admin login hand out its tokens as httpOnly
+	// cookies, protected by a double-submit CSRF token, instead of in the body
+	CookieMode   bool
+	CookieDomain string
+	CookieSecure bool
+	// SameSite is lax, strict or none
+	SameSite string
+}
+
+type OIDCConfig struct {
+	// Providers is keyed by the name used in the login URL, e.g. "google"
+	Providers map[string]OIDCProviderConfig
+	// StateTTL is how long a started login may take to complete
+	StateTTL time.Duration
+}
+
+// OIDCProviderConfig lists the endpoints explicitly instead of relying on
+// discovery, so a local mock OIDC server can stand in for the real provider.
+type OIDCProviderConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	JWKSURL      string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type ShipmentConfig struct {
+	// WebhookSecrets holds the HMAC secret of each carrier allowed to post
+	// tracking updates, keyed by carrier code, e.g. "jne"
+	WebhookSecrets map[string]string
+	// AutoCompleteOnDelivery completes the transaction and its orders when a
+	// carrier reports the shipment delivered
+	AutoCompleteOnDelivery bool
+}
+
+type TaxConfig struct {
+	// PricesIncludeTax means catalog prices already include tax, which is
+	// backed out of them; otherwise tax is added on top at checkout
+	PricesIncludeTax bool
+}
+
+// StoreConfig holds the seller details printed on invoices
+type StoreConfig struct {
+	Name    string
+	Address string
+	Phone   string
+	Email   string
+	// TaxID is the seller's NPWP
+	TaxID string
+}
+
+type PaymentConfig struct {
+	// UniqueCodeMax is the largest code added to a transfer's amount so that
+	// bank statement credits can be matched to transactions, zero disables it
+	UniqueCodeMax int64
 }
 
 type CORSConfig struct {
@@ -71,6 +164,9 @@ func Load() *Config {
 
 	accessTokenExpiry, _ := time.ParseDuration(viper.GetString("JWT_ACCESS_TOKEN_EXPIRY"))
 	refreshTokenExpiry, _ := time.ParseDuration(viper.GetString("JWT_REFRESH_TOKEN_EXPIRY"))
+	impersonationTokenExpiry, _ := time.ParseDuration(viper.GetString("JWT_IMPERSONATION_TOKEN_EXPIRY"))
+	auditRetention, _ := time.ParseDuration(viper.GetString("AUDIT_LOG_RETENTION"))
+	oidcStateTTL, _ := time.ParseDuration(viper.GetString("OIDC_STATE_TTL"))
 
 	allowedOrigins := strings.Split(viper.GetString("CORS_ALLOWED_ORIGINS"), ",")
 	for i := range allowedOrigins {
@@ -92,10 +188,14 @@ func Load() *Config {
 			Env:  viper.GetString("ENV"),
 		},
 		JWT: JWTConfig{
-			Secret:             viper.GetString("JWT_SECRET"),
-			RefreshSecret:      viper.GetString("JWT_REFRESH_SECRET"),
-			AccessTokenExpiry:  accessTokenExpiry,
-			RefreshTokenExpiry: refreshTokenExpiry,
+			Secret:                   viper.GetString("JWT_SECRET"),
+			RefreshSecret:            viper.GetString("JWT_REFRESH_SECRET"),
+			AccessTokenExpiry:        accessTokenExpiry,
+			RefreshTokenExpiry:       refreshTokenExpiry,
+			ImpersonationTokenExpiry: impersonationTokenExpiry,
+			Algorithm:                viper.GetString("JWT_ALGORITHM"),
+			KeysDir:                  viper.GetString("JWT_KEYS_DIR"),
+			ActiveKeyID:              viper.GetString("JWT_ACTIVE_KEY_ID"),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: allowedOrigins,
@@ -114,5 +214,96 @@ func Load() *Config {
 			Url: viper.GetString("SUPABASE_URL"),
 			Key: viper.GetString("SUPABASE_KEY"),
 		},
+		Audit: AuditConfig{
+			Retention: auditRetention,
+		},
+		Password: PasswordPolicyConfig{
+			MinLength:      viper.GetInt("PASSWORD_MIN_LENGTH"),
+			RequireUpper:   viper.GetBool("PASSWORD_REQUIRE_UPPER"),
+			RequireLower:   viper.GetBool("PASSWORD_REQUIRE_LOWER"),
+			RequireDigit:   viper.GetBool("PASSWORD_REQUIRE_DIGIT"),
+			RequireSymbol:  viper.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+			HistorySize:    viper.GetInt("PASSWORD_HISTORY_SIZE"),
+			BannedListFile: viper.GetString("PASSWORD_BANNED_LIST_FILE"),
+		},
+		Session: SessionConfig{
+			CookieMode:   viper.GetBool("SESSION_COOKIE_MODE"),
+			CookieDomain: viper.GetString("SESSION_COOKIE_DOMAIN"),
+			CookieSecure: viper.GetBool("SESSION_COOKIE_SECURE"),
+			SameSite:     viper.GetString("SESSION_COOKIE_SAMESITE"),
+		},
+		OIDC: OIDCConfig{
+			Providers: loadOIDCProviders(),
+			StateTTL:  oidcStateTTL,
+		},
+		Shipment: ShipmentConfig{
+			WebhookSecrets:         loadCarrierWebhookSecrets(),
+			AutoCompleteOnDelivery: viper.GetBool("SHIPMENT_AUTO_COMPLETE_ON_DELIVERY"),
+		},
+		Tax: TaxConfig{
+			PricesIncludeTax: viper.GetBool("TAX_PRICES_INCLUDE_TAX"),
+		},
+		Store: StoreConfig{
+			Name:    viper.GetString("STORE_NAME"),
+			Address: viper.GetString("STORE_ADDRESS"),
+			Phone:   viper.GetString("STORE_PHONE"),
+			Email:   viper.GetString("STORE_EMAIL"),
+			TaxID:   viper.GetString("STORE_TAX_ID"),
+		},
+		Payment: PaymentConfig{
+			UniqueCodeMax: viper.GetInt64("PAYMENT_UNIQUE_CODE_MAX"),
+		},
 	}
 }
+
+// loadOIDCProviders reads OIDC_<NAME>_* settings for every provider listed in
+// OIDC_PROVIDERS.
+func loadOIDCProviders() map[string]OIDCProviderConfig {
+	providers := map[string]OIDCProviderConfig{}
+
+	for _, name := range strings.Split(viper.GetString("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		scopes := strings.Fields(viper.GetString(prefix + "SCOPES"))
+		if len(scopes) == 0 {
+			scopes = []string{"openid", "email", "profile"}
+		}
+
+		providers[name] = OIDCProviderConfig{
+			Issuer:       viper.GetString(prefix + "ISSUER"),
+			ClientID:     viper.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: viper.GetString(prefix + "CLIENT_SECRET"),
+			AuthURL:      viper.GetString(prefix + "AUTH_URL"),
+			TokenURL:     viper.GetString(prefix + "TOKEN_URL"),
+			JWKSURL:      viper.GetString(prefix + "JWKS_URL"),
+			RedirectURL:  viper.GetString(prefix + "REDIRECT_URL"),
+			Scopes:       scopes,
+		}
+	}
+
+	return providers
+}
+
+// loadCarrierWebhookSecrets reads CARRIER_<NAME>_WEBHOOK_SECRET for every
+// carrier listed in SHIPMENT_CARRIERS. Carriers without a secret are left out
+// so their webhooks are refused.
+func loadCarrierWebhookSecrets() map[string]string {
+	secrets := map[string]string{}
+
+	for _, name := range strings.Split(viper.GetString("SHIPMENT_CARRIERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if secret := viper.GetString("CARRIER_" + strings.ToUpper(name) + "_WEBHOOK_SECRET"); secret != "" {
+			secrets[name] = secret
+		}
+	}
+
+	return secrets
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"e-commerce/backend/internal/config"
 	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
 	"fmt"
 	"log"
 
@@ -43,6 +44,9 @@ func Migrate() error {
 		&models.User{},
 		&models.ActivityLog{},
 		&models.PasswordResetToken{},
+		&models.PasswordHistory{},
+		&models.OIDCState{},
+		&models.UserIdentity{},
 		&models.Address{},
 		&models.Category{},
 		&models.Product{},
@@ -53,6 +57,28 @@ func Migrate() error {
 		&models.Transaction{},
 		&models.Order{},
 		&models.Payment{},
+		&models.Refund{},
+		&models.RefundItem{},
+		&models.ReturnRequest{},
+		&models.ReturnItem{},
+		&models.ReturnPhoto{},
+		&models.Shipment{},
+		&models.ShipmentEvent{},
+		&models.ShippingZone{},
+		&models.ShippingRate{},
+		&models.TaxClass{},
+		&models.TransactionTaxLine{},
+		&models.Currency{},
+		&models.Wishlist{},
+		&models.StockAlert{},
+		&models.StockAlertNotification{},
+		&models.Invoice{},
+		&models.InvoiceSequence{},
+		&models.PaymentProof{},
+		&models.BankStatement{},
+		&models.BankStatementLine{},
+		&models.APIKey{},
+		&models.AuditLog{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
@@ -64,6 +90,47 @@ func Migrate() error {
 		return fmt.Errorf("failed to create composite indexes: %w", err)
 	}
 
+	if err := backfillCategorySlugs(); err != nil {
+		return fmt.Errorf("failed to backfill category slugs: %w", err)
+	}
+
+	return nil
+}
+
+// backfillCategorySlugs gives categories created before slugs existed a
+// slug made from their name, numbered when two names make the same slug.
+func backfillCategorySlugs() error {
+	var categories []models.Category
+	if err := DB.Unscoped().Select("id", "name", "slug").Order("id asc").Find(&categories).Error; err != nil {
+		return err
+	}
+
+	taken := make(map[string]bool)
+	for _, category := range categories {
+		if category.Slug != "" {
+			taken[category.Slug] = true
+		}
+	}
+
+	for _, category := range categories {
+		if category.Slug != "" {
+			continue
+		}
+
+		base := utils.Slugify(category.Name)
+		if base == "" {
+			base = "category"
+		}
+		slug := base
+		for n := 2; taken[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		taken[slug] = true
+
+		if err := DB.Unscoped().Model(&models.Category{}).Where("id = ?", category.ID).Update("slug", slug).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -88,6 +155,13 @@ func createCompositeIndexes() error {
 		"CREATE INDEX IF NOT EXISTS idx_payment_method_active_created ON payment_methods(is_active, created_at)",
 
 		"CREATE INDEX IF NOT EXISTS idx_shipping_state ON shippings(state)",
+
+		// idx_categories_parent_name treats every NULL parent as distinct, so
+		// root category names need their own index
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_root_name ON categories(name) WHERE parent_id IS NULL",
+
+		// Reconciliation matches transfers by virtual account number
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_transaction_virtual_account ON transactions(payment_reference) WHERE payment_type = 'virtual_account'",
 	}
 
 	for _, indexSQL := range indexes {
@@ -13,6 +13,18 @@ func SeedDatabase(cfg *config.Config) error {
 	// Check if permissions already exist (simple check without seed tracker)
 	var permissionCount int64
 	DB.Model(&models.Permission{}).Count(&permissionCount)
+
+	// Permissions and role grants are synced on every start so that
+	// permissions added by later releases reach already seeded databases.
+	createdPermissions, err := seedPermissions()
+	if err != nil {
+		return err
+	}
+
+	if err := seedRoles(createdPermissions); err != nil {
+		return err
+	}
+
 	if permissionCount > 0 {
 		log.Println("Database already seeded, skipping seeding process")
 		return nil
@@ -20,31 +32,31 @@ func SeedDatabase(cfg *config.Config) error {
 
 	log.Println("Starting database seeding process...")
 
-	if err := seedPermissions(); err != nil {
+	if err := seedDefaultAdmin(cfg); err != nil {
 		return err
 	}
 
-	if err := seedRoles(); err != nil {
+	if err := seedCategories(); err != nil {
 		return err
 	}
 
-	if err := seedDefaultAdmin(cfg); err != nil {
+	if err := seedProducts(); err != nil {
 		return err
 	}
 
-	if err := seedCategories(); err != nil {
+	if err := seedPaymentMethods(); err != nil {
 		return err
 	}
 
-	if err := seedProducts(); err != nil {
+	if err := seedShippingMethods(); err != nil {
 		return err
 	}
 
-	if err := seedPaymentMethods(); err != nil {
+	if err := seedTaxClasses(); err != nil {
 		return err
 	}
 
-	if err := seedShippingMethods(); err != nil {
+	if err := seedCurrencies(); err != nil {
 		return err
 	}
 
@@ -52,7 +64,10 @@ func SeedDatabase(cfg *config.Config) error {
 	return nil
 }
 
-func seedPermissions() error {
+// seedPermissions inserts every permission that is missing, matched by
+// resource and action, and returns the names of the ones it created.
+// Existing rows are left untouched.
+func seedPermissions() ([]string, error) {
 	permissions := []models.Permission{
 		// User permissions
 		{Name: "users.create", Resource: "users", Action: "create", Description: "Create new users"},
@@ -117,29 +132,85 @@ func seedPermissions() error {
 		{Name: "payments.delete", Resource: "payments", Action: "delete", Description: "Delete payments"},
 		{Name: "payments.read_own", Resource: "payments", Action: "read_own", Description: "View own payments"},
 
+		// Payment proof permissions
+		{Name: "payment_proofs.create", Resource: "payment_proofs", Action: "create", Description: "Upload transfer receipts"},
+		{Name: "payment_proofs.read", Resource: "payment_proofs", Action: "read", Description: "View the payment proof review queue"},
+		{Name: "payment_proofs.update", Resource: "payment_proofs", Action: "update", Description: "Approve or reject payment proofs"},
+		{Name: "payment_proofs.read_own", Resource: "payment_proofs", Action: "read_own", Description: "View own payment proofs"},
+
+		// Invoice permissions
+		{Name: "invoices.read", Resource: "invoices", Action: "read", Description: "Download invoices and packing slips of any transaction"},
+
+		// Refund permissions
+		{Name: "refunds.create", Resource: "refunds", Action: "create", Description: "Issue refunds"},
+		{Name: "refunds.read", Resource: "refunds", Action: "read", Description: "View refunds"},
+
+		// Return permissions
+		{Name: "returns.create", Resource: "returns", Action: "create", Description: "Request returns"},
+		{Name: "returns.read", Resource: "returns", Action: "read", Description: "View returns"},
+		{Name: "returns.update", Resource: "returns", Action: "update", Description: "Process returns"},
+		{Name: "returns.read_own", Resource: "returns", Action: "read_own", Description: "View own returns"},
+
+		// Shipment permissions
+		{Name: "shipments.create", Resource: "shipments", Action: "create", Description: "Ship transactions and add tracking events"},
+		{Name: "shipments.read", Resource: "shipments", Action: "read", Description: "View shipments"},
+		{Name: "shipments.read_own", Resource: "shipments", Action: "read_own", Description: "Track own shipments"},
+
+		// Tax permissions
+		{Name: "tax_classes.create", Resource: "tax_classes", Action: "create", Description: "Create tax classes"},
+		{Name: "tax_classes.read", Resource: "tax_classes", Action: "read", Description: "View tax classes"},
+		{Name: "tax_classes.update", Resource: "tax_classes", Action: "update", Description: "Update tax classes and rates"},
+		{Name: "tax_classes.delete", Resource: "tax_classes", Action: "delete", Description: "Delete tax classes"},
+
+		// Currency permissions
+		{Name: "currencies.create", Resource: "currencies", Action: "create", Description: "Add display currencies and import exchange rates"},
+		{Name: "currencies.read", Resource: "currencies", Action: "read", Description: "View all currencies, including inactive ones"},
+		{Name: "currencies.update", Resource: "currencies", Action: "update", Description: "Update exchange rates"},
+		{Name: "currencies.delete", Resource: "currencies", Action: "delete", Description: "Delete display currencies"},
+
+		// Bank statement reconciliation permissions
+		{Name: "bank_statements.create", Resource: "bank_statements", Action: "create", Description: "Import bank statements"},
+		{Name: "bank_statements.read", Resource: "bank_statements", Action: "read", Description: "View bank statement lines"},
+		{Name: "bank_statements.update", Resource: "bank_statements", Action: "update", Description: "Resolve unmatched bank statement lines"},
+
 		// Dashboard & Analytics
 		{Name: "dashboard.read", Resource: "dashboard", Action: "read", Description: "View dashboard"},
 		{Name: "analytics.read", Resource: "analytics", Action: "read", Description: "View analytics"},
 		{Name: "activity_logs.read", Resource: "activity_logs", Action: "read", Description: "View activity logs"},
+		{Name: "audit_logs.read", Resource: "audit_logs", Action: "read", Description: "View audit logs of admin changes"},
+
+		// API key permissions
+		{Name: "api_keys.manage", Resource: "api_keys", Action: "manage", Description: "View and revoke API keys of other users"},
 	}
 
+	var created []string
 	for _, permission := range permissions {
-		var existingPermission models.Permission
-		err := DB.Where("name = ?", permission.Name).First(&existingPermission).Error
-		if err != nil {
-			if err := DB.Create(&permission).Error; err != nil {
-				log.Printf("Error creating permission %s: %v", permission.Name, err)
-				return err
-			}
-			log.Printf("Created permission: %s", permission.Name)
-		} else {
-			log.Printf("Permission already exists: %s", permission.Name)
+		var existingCount int64
+		if err := DB.Model(&models.Permission{}).
+			Where("resource = ? AND action = ?", permission.Resource, permission.Action).
+			Count(&existingCount).Error; err != nil {
+			return nil, err
+		}
+		if existingCount > 0 {
+			continue
 		}
+
+		if err := DB.Create(&permission).Error; err != nil {
+			log.Printf("Error creating permission %s: %v", permission.Name, err)
+			return nil, err
+		}
+		log.Printf("Created permission: %s", permission.Name)
+		created = append(created, permission.Name)
 	}
 
-	return nil
+	return created, nil
 }
-func seedRoles() error {
+
+// seedRoles creates the missing system roles with their default
+// permissions. Roles that already exist are only granted the permissions
+// in created that belong to their defaults, so grants an admin removed
+// earlier are not restored.
+func seedRoles(created []string) error {
 	roles := []models.Role{
 		{
 			Name:         "super_admin",
@@ -180,7 +251,16 @@ func seedRoles() error {
 			"shipping.create", "shipping.read", "shipping.update", "shipping.delete",
 			"transactions.create", "transactions.read", "transactions.update",
 			"payments.create", "payments.read", "payments.update", "payments.delete",
-			"dashboard.read", "analytics.read", "activity_logs.read",
+			"payment_proofs.read", "payment_proofs.update",
+			"invoices.read",
+			"refunds.create", "refunds.read",
+			"returns.read", "returns.update",
+			"shipments.create", "shipments.read",
+			"tax_classes.create", "tax_classes.read", "tax_classes.update", "tax_classes.delete",
+			"currencies.create", "currencies.read", "currencies.update", "currencies.delete",
+			"bank_statements.create", "bank_statements.read", "bank_statements.update",
+			"dashboard.read", "analytics.read", "activity_logs.read", "audit_logs.read",
+			"api_keys.manage",
 		},
 		"admin": {
 			"users.read", "users.update",
@@ -193,7 +273,15 @@ func seedRoles() error {
 			"shipping.read", "shipping.update",
 			"transactions.read", "transactions.update",
 			"payments.read", "payments.update",
-			"dashboard.read", "analytics.read", "activity_logs.read",
+			"payment_proofs.read", "payment_proofs.update",
+			"invoices.read",
+			"refunds.create", "refunds.read",
+			"returns.read", "returns.update",
+			"shipments.create", "shipments.read",
+			"tax_classes.read", "tax_classes.update",
+			"currencies.read", "currencies.update",
+			"bank_statements.create", "bank_statements.read", "bank_statements.update",
+			"dashboard.read", "analytics.read", "activity_logs.read", "audit_logs.read",
 		},
 		"vendor": {
 			"profile.read", "profile.update",
@@ -214,6 +302,9 @@ func seedRoles() error {
 			"shipping.read",
 			"transactions.create", "transactions.read_own",
 			"payments.create", "payments.read_own",
+			"payment_proofs.create", "payment_proofs.read_own",
+			"returns.create", "returns.read_own",
+			"shipments.read_own",
 		},
 	}
 
@@ -222,7 +313,9 @@ func seedRoles() error {
 
 		err := DB.Where("name = ?", role.Name).First(&existingRole).Error
 		if err == nil {
-			log.Printf("Role already exists: %s", role.Name)
+			if err := grantNewPermissions(&existingRole, rolePermissions[role.Name], created); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -254,6 +347,37 @@ func seedRoles() error {
 	return nil
 }
 
+// grantNewPermissions appends the permissions that are both in defaults
+// and newly created to an existing role.
+func grantNewPermissions(role *models.Role, defaults, created []string) error {
+	isCreated := make(map[string]bool, len(created))
+	for _, name := range created {
+		isCreated[name] = true
+	}
+
+	var names []string
+	for _, name := range defaults {
+		if isCreated[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	var permissions []models.Permission
+	if err := DB.Where("name IN ?", names).Find(&permissions).Error; err != nil {
+		return err
+	}
+
+	if err := DB.Model(role).Association("Permissions").Append(&permissions); err != nil {
+		return err
+	}
+
+	log.Printf("Granted %d new permissions to role: %s", len(permissions), role.Name)
+	return nil
+}
+
 func seedDefaultAdmin(cfg *config.Config) error {
 	if cfg.System.DefaultAdminEmail == "" || cfg.System.DefaultAdminPassword == "" {
 		log.Println("Skipping default admin creation - credentials not provided")
@@ -315,6 +439,7 @@ func seedCategories() error {
 		err := DB.Where("name = ?", category.Name).First(&existingCategory).Error
 		if err != nil {
 			// Jika record not found (belum ada), create baru
+			category.Slug = utils.Slugify(category.Name)
 			if err := DB.Create(&category).Error; err != nil {
 				log.Printf("Error creating category %s: %v", category.Name, err)
 				return err
@@ -349,7 +474,7 @@ func seedProducts() error {
 		product1 := models.Product{
 			Name:        "Gamis Syari Premium",
 			Description: "Gamis syari berbahan wolfis premium, nyaman dipakai seharian. Cocok untuk acara formal maupun santai",
-			Price:       275000,
+			Price:       models.Rupiah(275000),
 			CategoryID:  gamisCategory.ID,
 			Images:      "https://www.static-src.com/wcsstore/Indraprastha/images/catalog/full//92/MTA-75004802/no_brand_abaya_zahra_limitid_edition_full01_gdime8vw.jpg",
 			Rating:      4.8,
@@ -404,7 +529,7 @@ func seedProducts() error {
 		product2 := models.Product{
 			Name:        "Gamis Set Busui Friendly",
 			Description: "Gamis set dengan khimar, busui friendly dengan resleting depan. Bahan katun rayon adem dan tidak mudah kusut",
-			Price:       320000,
+			Price:       models.Rupiah(320000),
 			CategoryID:  gamisCategory.ID,
 			Images:      "https://www.static-src.com/wcsstore/Indraprastha/images/catalog/full/catalog-image/106/MTA-157206948/br-m036969-01094_gamis-o-ring-o-ring-rempel-busui-friendly-cod-wanita-premium-berkualitas_full01-efdded63.jpg",
 			Rating:      4.9,
@@ -459,7 +584,7 @@ func seedProducts() error {
 		product3 := models.Product{
 			Name:        "Hijab Voal Premium",
 			Description: "Hijab voal import premium, bahan lembut dan adem, tidak licin. Ukuran 115x115cm",
-			Price:       45000,
+			Price:       models.Rupiah(45000),
 			CategoryID:  hijabCategory.ID,
 			Images:      "https://www.umamascarves.co.id/wp-content/uploads/2024/04/Cover-4.jpg",
 			Rating:      4.7,
@@ -513,7 +638,7 @@ func seedProducts() error {
 		product4 := models.Product{
 			Name:        "Pashmina Diamond Italiano",
 			Description: "Pashmina diamond italiano, bahan premium tidak menerawang, tekstur diamond yang elegan. Ukuran 75x200cm",
-			Price:       65000,
+			Price:       models.Rupiah(65000),
 			CategoryID:  hijabCategory.ID,
 			Images:      "https://admincerdas.s3.ap-southeast-1.amazonaws.com/20201128/15515-78e6c6bf5b353eb5e89e53ba6bfe104b.jpg",
 			Rating:      4.8,
@@ -565,7 +690,7 @@ func seedProducts() error {
 		product5 := models.Product{
 			Name:        "Kemeja Wanita Premium",
 			Description: "Kemeja wanita berbahan katun supernova, nyaman dan tidak gerah. Cocok untuk kerja dan hangout",
-			Price:       135000,
+			Price:       models.Rupiah(135000),
 			CategoryID:  kemejaCategory.ID,
 			Images:      "https://www.hijup.com/magazine/wp-content/uploads/2022/12/4b8d043b-oversize-katun-premium.jpeg",
 			Rating:      4.6,
@@ -620,7 +745,7 @@ func seedProducts() error {
 		product6 := models.Product{
 			Name:        "Dress Casual Wanita",
 			Description: "Dress casual dengan model trendy, bahan katun rayon yang adem dan nyaman. Bisa untuk daily maupun hangout",
-			Price:       165000,
+			Price:       models.Rupiah(165000),
 			CategoryID:  dressCategory.ID,
 			Images:      "https://parasayu.net/wp-content/uploads/2020/12/Rok-kaos-dan-outer.jpg",
 			Rating:      4.7,
@@ -675,35 +800,46 @@ func seedProducts() error {
 func seedPaymentMethods() error {
 	paymentMethods := []models.PaymentMethod{
 		{
+			Type:          models.PaymentTypeBankTransfer,
 			AccountName:   "PT E-Commerce Indonesia",
 			AccountNumber: "1234567890",
 			BankName:      "Bank BCA",
 			BankImages:    "https://www.bca.co.id/-/media/Feature/Card/List-Card/Tentang-BCA/Brand-Assets/Logo-BCA/Logo-BCA_Biru.png",
 		},
 		{
+			Type:          models.PaymentTypeBankTransfer,
 			AccountName:   "PT E-Commerce Indonesia",
 			AccountNumber: "9876543210",
 			BankName:      "Bank Mandiri",
 			BankImages:    "https://e7.pngegg.com/pngimages/24/85/png-clipart-bank-mandiri-logo-credit-card-bank-text-logo.png",
 		},
 		{
+			Type:          models.PaymentTypeBankTransfer,
 			AccountName:   "PT E-Commerce Indonesia",
 			AccountNumber: "5555666677",
 			BankName:      "Bank BRI",
 			BankImages:    "https://logobase.net/wp-content/uploads/2025/09/Bank-Rakyat-Indonesia-BRI-Logo.webp",
 		},
 		{
+			Type:          models.PaymentTypeBankTransfer,
 			AccountName:   "PT E-Commerce Indonesia",
 			AccountNumber: "1111222233",
 			BankName:      "Bank BNI",
 			BankImages:    "https://upload.wikimedia.org/wikipedia/commons/thumb/f/f0/Bank_Negara_Indonesia_logo_%282004%29.svg/1280px-Bank_Negara_Indonesia_logo_%282004%29.svg.png",
 		},
 		{
+			Type:          models.PaymentTypeBankTransfer,
 			AccountName:   "PT E-Commerce Indonesia",
 			AccountNumber: "7777888899",
 			BankName:      "Bank CIMB Niaga",
 			BankImages:    "https://e7.pngegg.com/pngimages/98/783/png-clipart-logo-cimb-brand-font-text-loan-text-logo.png",
 		},
+		{
+			Type:        models.PaymentTypeCOD,
+			AccountName: "Cash on Delivery",
+			BankName:    "COD",
+			MaxAmount:   models.Rupiah(5000000),
+		},
 	}
 
 	for _, method := range paymentMethods {
@@ -729,42 +865,42 @@ func seedShippingMethods() error {
 	shippingMethods := []models.Shipping{
 		{
 			Name:  "JNE Regular",
-			Price: 15000,
+			Price: models.Rupiah(15000),
 			State: "active",
 		},
 		{
 			Name:  "JNE Express",
-			Price: 25000,
+			Price: models.Rupiah(25000),
 			State: "active",
 		},
 		{
 			Name:  "J&T Regular",
-			Price: 12000,
+			Price: models.Rupiah(12000),
 			State: "active",
 		},
 		{
 			Name:  "J&T Express",
-			Price: 20000,
+			Price: models.Rupiah(20000),
 			State: "active",
 		},
 		{
 			Name:  "SiCepat Regular",
-			Price: 13000,
+			Price: models.Rupiah(13000),
 			State: "active",
 		},
 		{
 			Name:  "SiCepat Express",
-			Price: 22000,
+			Price: models.Rupiah(22000),
 			State: "active",
 		},
 		{
 			Name:  "Anteraja Regular",
-			Price: 10000,
+			Price: models.Rupiah(10000),
 			State: "active",
 		},
 		{
 			Name:  "Anteraja Same Day",
-			Price: 30000,
+			Price: models.Rupiah(30000),
 			State: "active",
 		},
 	}
@@ -789,6 +925,55 @@ func seedShippingMethods() error {
 	return nil
 }
 
+// seedTaxClasses creates PPN at the standard rate as the default tax class.
+func seedTaxClasses() error {
+	taxClasses := []models.TaxClass{
+		{Name: "PPN", Code: "PPN", Rate: 11, IsDefault: true},
+		{Name: "Bebas Pajak", Code: "EXEMPT", Rate: 0},
+	}
+
+	for _, class := range taxClasses {
+		var existing models.TaxClass
+		err := DB.Where("code = ?", class.Code).First(&existing).Error
+		if err != nil {
+			if err := DB.Create(&class).Error; err != nil {
+				log.Printf("Error creating tax class %s: %v", class.Code, err)
+				return err
+			}
+			log.Printf("Created tax class: %s", class.Code)
+		} else {
+			log.Printf("Tax class already exists: %s", class.Code)
+		}
+	}
+
+	return nil
+}
+
+// seedCurrencies adds the display currencies for overseas visitors. The
+// rates are only a starting point; admins keep them up to date.
+func seedCurrencies() error {
+	currencies := []models.Currency{
+		{Code: "USD", Name: "US Dollar", Symbol: "$", Rate: 16250, IsActive: true},
+		{Code: "SGD", Name: "Singapore Dollar", Symbol: "S$", Rate: 12600, IsActive: true},
+	}
+
+	for _, currency := range currencies {
+		var existing models.Currency
+		err := DB.Where("code = ?", currency.Code).First(&existing).Error
+		if err != nil {
+			if err := DB.Create(&currency).Error; err != nil {
+				log.Printf("Error creating currency %s: %v", currency.Code, err)
+				return err
+			}
+			log.Printf("Created currency: %s", currency.Code)
+		} else {
+			log.Printf("Currency already exists: %s", currency.Code)
+		}
+	}
+
+	return nil
+}
+
 // ResetSeedingStatus resets the seeding status - useful for fresh migrations
 func ResetSeedingStatus() error {
 	// Simply truncate permissions to trigger re-seeding
@@ -803,11 +988,12 @@ func ResetSeedingStatus() error {
 func ForceSeedDatabase(cfg *config.Config) error {
 	log.Println("Force seeding database...")
 
-	if err := seedPermissions(); err != nil {
+	createdPermissions, err := seedPermissions()
+	if err != nil {
 		return err
 	}
 
-	if err := seedRoles(); err != nil {
+	if err := seedRoles(createdPermissions); err != nil {
 		return err
 	}
 
@@ -831,6 +1017,14 @@ func ForceSeedDatabase(cfg *config.Config) error {
 		return err
 	}
 
+	if err := seedTaxClasses(); err != nil {
+		return err
+	}
+
+	if err := seedCurrencies(); err != nil {
+		return err
+	}
+
 	log.Println("Force seeding completed successfully")
 	return nil
 }
@@ -0,0 +1,112 @@
+package database_test
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/testhelper"
+	"testing"
+)
+
+func TestSeedDatabase_SyncsPermissionsIntoSeededDatabase(t *testing.T) {
+	testDB := testhelper.SetupTestSuite(t)
+	defer testhelper.TeardownTestSuite(testDB)
+
+	dbWrapper := testhelper.SetTestDB(testDB)
+	defer dbWrapper.Restore()
+
+	// A database seeded by an earlier release: some permissions and the
+	// system roles exist, but none of the returns permissions do.
+	productsRead := models.Permission{Name: "products.read", Resource: "products", Action: "read", Description: "Custom description"}
+	ordersRead := models.Permission{Name: "orders.read", Resource: "orders", Action: "read"}
+	if err := testDB.Create(&productsRead).Error; err != nil {
+		t.Fatalf("Failed to create permission: %v", err)
+	}
+	if err := testDB.Create(&ordersRead).Error; err != nil {
+		t.Fatalf("Failed to create permission: %v", err)
+	}
+
+	superAdmin := models.Role{Name: "super_admin", Level: 4, IsSystemRole: true}
+	customer := models.Role{Name: "customer", Level: 1, IsSystemRole: true}
+	if err := testDB.Create(&superAdmin).Error; err != nil {
+		t.Fatalf("Failed to create role: %v", err)
+	}
+	if err := testDB.Create(&customer).Error; err != nil {
+		t.Fatalf("Failed to create role: %v", err)
+	}
+	// super_admin had orders.read revoked by an admin; the sync must not
+	// grant it back because the permission itself is not new.
+	if err := testDB.Model(&superAdmin).Association("Permissions").Append([]*models.Permission{&productsRead}); err != nil {
+		t.Fatalf("Failed to grant permission: %v", err)
+	}
+
+	if err := database.SeedDatabase(&config.Config{}); err != nil {
+		t.Fatalf("SeedDatabase() error = %v", err)
+	}
+
+	for _, name := range []string{"returns.create", "returns.read_own", "returns.read", "returns.update"} {
+		var count int64
+		testDB.Model(&models.Permission{}).Where("name = ?", name).Count(&count)
+		if count != 1 {
+			t.Errorf("permission %s count = %d, want 1", name, count)
+		}
+	}
+
+	var unchanged models.Permission
+	testDB.First(&unchanged, productsRead.ID)
+	if unchanged.Description != "Custom description" {
+		t.Errorf("existing permission description = %q, want it untouched", unchanged.Description)
+	}
+
+	grants := func(role models.Role) map[string]bool {
+		var permissions []models.Permission
+		if err := testDB.Model(&role).Association("Permissions").Find(&permissions); err != nil {
+			t.Fatalf("Failed to load permissions of %s: %v", role.Name, err)
+		}
+		names := make(map[string]bool, len(permissions))
+		for _, p := range permissions {
+			names[p.Name] = true
+		}
+		return names
+	}
+
+	superAdminGrants := grants(superAdmin)
+	for _, name := range []string{"products.read", "returns.read", "returns.update", "audit_logs.read", "api_keys.manage"} {
+		if !superAdminGrants[name] {
+			t.Errorf("super_admin is missing %s", name)
+		}
+	}
+	if superAdminGrants["orders.read"] {
+		t.Error("super_admin was granted orders.read again, want revoked grant left alone")
+	}
+
+	customerGrants := grants(customer)
+	for _, name := range []string{"returns.create", "returns.read_own"} {
+		if !customerGrants[name] {
+			t.Errorf("customer is missing %s", name)
+		}
+	}
+	for _, name := range []string{"returns.update", "api_keys.manage"} {
+		if customerGrants[name] {
+			t.Errorf("customer was granted %s", name)
+		}
+	}
+
+	// Sample data is only seeded into an empty database.
+	var categoryCount int64
+	testDB.Model(&models.Category{}).Count(&categoryCount)
+	if categoryCount != 0 {
+		t.Errorf("category count = %d, want sample data skipped", categoryCount)
+	}
+
+	// A second start changes nothing.
+	var before, after int64
+	testDB.Model(&models.Permission{}).Count(&before)
+	if err := database.SeedDatabase(&config.Config{}); err != nil {
+		t.Fatalf("SeedDatabase() second run error = %v", err)
+	}
+	testDB.Model(&models.Permission{}).Count(&after)
+	if before != after {
+		t.Errorf("permission count after second run = %d, want %d", after, before)
+	}
+}
@@ -25,6 +25,11 @@ func ProvideJWTService(config *config.Config) *utils.JWTService {
 	return utils.NewJWTService(config)
 }
 
+// ProvideOIDCClient provides the OpenID Connect client for social login
+func ProvideOIDCClient(config *config.Config) *utils.OIDCClient {
+	return utils.NewOIDCClient(config)
+}
+
 // Repository Providers
 var repositorySet = wire.NewSet(
 	ProvideDB,
@@ -43,6 +48,22 @@ var repositorySet = wire.NewSet(
 	repository.NewActivityLogRepository,
 	repository.NewRoleRepository,
 	repository.NewDashboardRepository,
+	repository.NewAPIKeyRepository,
+	repository.NewAuditLogRepository,
+	repository.NewPasswordHistoryRepository,
+	repository.NewAccountRepository,
+	repository.NewOIDCRepository,
+	repository.NewRefundRepository,
+	repository.NewReturnRepository,
+	repository.NewShipmentRepository,
+	repository.NewShippingZoneRepository,
+	repository.NewTaxRepository,
+	repository.NewInvoiceRepository,
+	repository.NewPaymentProofRepository,
+	repository.NewReconciliationRepository,
+	repository.NewCurrencyRepository,
+	repository.NewWishlistRepository,
+	repository.NewStockAlertRepository,
 )
 
 // Service Providers
@@ -60,11 +81,27 @@ var serviceSet = wire.NewSet(
 	services.NewTransactionService,
 	services.NewUserService,
 	services.NewDashboardService,
+	services.NewAPIKeyService,
+	services.NewAuditLogService,
+	services.NewImpersonationService,
+	services.NewPasswordPolicyService,
+	services.NewAccountService,
+	services.NewRefundService,
+	services.NewReturnService,
+	services.NewShipmentService,
+	services.NewTaxService,
+	services.NewInvoiceService,
+	services.NewPaymentProofService,
+	services.NewReconciliationService,
+	services.NewCurrencyService,
+	services.NewWishlistService,
+	services.NewStockAlertService,
 )
 
 // Utils Providers
 var utilsSet = wire.NewSet(
 	ProvideJWTService,
+	ProvideOIDCClient,
 )
 
 // Handler Providers
@@ -82,6 +119,21 @@ var handlerSet = wire.NewSet(
 	handler.NewTransactionHandler,
 	handler.NewHealthHandler,
 	handler.NewDashboardHandler,
+	handler.NewJWKSHandler,
+	handler.NewAPIKeyHandler,
+	handler.NewAuditLogHandler,
+	handler.NewImpersonationHandler,
+	handler.NewAccountHandler,
+	handler.NewRefundHandler,
+	handler.NewReturnHandler,
+	handler.NewShipmentHandler,
+	handler.NewTaxHandler,
+	handler.NewInvoiceHandler,
+	handler.NewPaymentProofHandler,
+	handler.NewReconciliationHandler,
+	handler.NewCurrencyHandler,
+	handler.NewWishlistHandler,
+	handler.NewStockAlertHandler,
 )
 
 // InitializeAllHandler initializes all handler with config
@@ -98,24 +150,41 @@ func InitializeAllHandler(config *config.Config) *Handler {
 
 // Handler struct contains all handler and services
 type Handler struct {
-	CategoryHandler      *handler.CategoryHandler
-	ProductHandler       *handler.ProductHandler
-	AddressHandler       *handler.AddressHandler
-	AuthHandler          *handler.AuthHandler
-	UserHandler          *handler.UserHandler
-	RoleHandler          *handler.RoleHandler
-	OrderHandler         *handler.OrderHandler
-	PaymentHandler       *handler.PaymentHandler
-	PaymentMethodHandler *handler.PaymentMethodHandler
-	ShippingHandler      *handler.ShippingHandler
-	TransactionHandler   *handler.TransactionHandler
-	HealthHandler        *handler.HealthHandler
-	DashboardHandler     *handler.DashboardHandler
+	CategoryHandler       *handler.CategoryHandler
+	ProductHandler        *handler.ProductHandler
+	AddressHandler        *handler.AddressHandler
+	AuthHandler           *handler.AuthHandler
+	UserHandler           *handler.UserHandler
+	RoleHandler           *handler.RoleHandler
+	OrderHandler          *handler.OrderHandler
+	PaymentHandler        *handler.PaymentHandler
+	PaymentMethodHandler  *handler.PaymentMethodHandler
+	ShippingHandler       *handler.ShippingHandler
+	TransactionHandler    *handler.TransactionHandler
+	HealthHandler         *handler.HealthHandler
+	DashboardHandler      *handler.DashboardHandler
+	JWKSHandler           *handler.JWKSHandler
+	APIKeyHandler         *handler.APIKeyHandler
+	AuditLogHandler       *handler.AuditLogHandler
+	ImpersonationHandler  *handler.ImpersonationHandler
+	AccountHandler        *handler.AccountHandler
+	RefundHandler         *handler.RefundHandler
+	ReturnHandler         *handler.ReturnHandler
+	ShipmentHandler       *handler.ShipmentHandler
+	TaxHandler            *handler.TaxHandler
+	InvoiceHandler        *handler.InvoiceHandler
+	PaymentProofHandler   *handler.PaymentProofHandler
+	ReconciliationHandler *handler.ReconciliationHandler
+	CurrencyHandler       *handler.CurrencyHandler
+	WishlistHandler       *handler.WishlistHandler
+	StockAlertHandler     *handler.StockAlertHandler
 
 	// Services untuk middleware
-	RBACService services.RBACService
-	UserService services.UserService
-	JWTService  *utils.JWTService
+	RBACService     services.RBACService
+	UserService     services.UserService
+	APIKeyService   services.APIKeyService
+	AuditLogService services.AuditLogService
+	JWTService      *utils.JWTService
 }
 
 // NewHandler creates new Handler instance
@@ -134,26 +203,60 @@ func NewHandler(
 	transactionHandler *handler.TransactionHandler,
 	healthHandler *handler.HealthHandler,
 	dashboardHandler *handler.DashboardHandler,
+	jwksHandler *handler.JWKSHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	auditLogHandler *handler.AuditLogHandler,
+	impersonationHandler *handler.ImpersonationHandler,
+	accountHandler *handler.AccountHandler,
+	refundHandler *handler.RefundHandler,
+	returnHandler *handler.ReturnHandler,
+	shipmentHandler *handler.ShipmentHandler,
+	taxHandler *handler.TaxHandler,
+	invoiceHandler *handler.InvoiceHandler,
+	paymentProofHandler *handler.PaymentProofHandler,
+	reconciliationHandler *handler.ReconciliationHandler,
+	currencyHandler *handler.CurrencyHandler,
+	wishlistHandler *handler.WishlistHandler,
+	stockAlertHandler *handler.StockAlertHandler,
 	rbacService services.RBACService,
 	userService services.UserService,
+	apiKeyService services.APIKeyService,
+	auditLogService services.AuditLogService,
 	jwtService *utils.JWTService,
 ) *Handler {
 	return &Handler{
-		CategoryHandler:      categoryHandler,
-		ProductHandler:       productHandler,
-		AddressHandler:       addressHandler,
-		AuthHandler:          authHandler,
-		UserHandler:          userHandler,
-		RoleHandler:          roleHandler,
-		OrderHandler:         orderHandler,
-		PaymentHandler:       paymentHandler,
-		PaymentMethodHandler: paymentMethodHandler,
-		ShippingHandler:      shippingHandler,
-		TransactionHandler:   transactionHandler,
-		HealthHandler:        healthHandler,
-		DashboardHandler:     dashboardHandler,
-		RBACService:          rbacService,
-		UserService:          userService,
-		JWTService:           jwtService,
+		CategoryHandler:       categoryHandler,
+		ProductHandler:        productHandler,
+		AddressHandler:        addressHandler,
+		AuthHandler:           authHandler,
+		UserHandler:           userHandler,
+		RoleHandler:           roleHandler,
+		OrderHandler:          orderHandler,
+		PaymentHandler:        paymentHandler,
+		PaymentMethodHandler:  paymentMethodHandler,
+		ShippingHandler:       shippingHandler,
+		TransactionHandler:    transactionHandler,
+		HealthHandler:         healthHandler,
+		DashboardHandler:      dashboardHandler,
+		JWKSHandler:           jwksHandler,
+		APIKeyHandler:         apiKeyHandler,
+		AuditLogHandler:       auditLogHandler,
+		ImpersonationHandler:  impersonationHandler,
+		AccountHandler:        accountHandler,
+		RefundHandler:         refundHandler,
+		ReturnHandler:         returnHandler,
+		ShipmentHandler:       shipmentHandler,
+		TaxHandler:            taxHandler,
+		InvoiceHandler:        invoiceHandler,
+		PaymentProofHandler:   paymentProofHandler,
+		ReconciliationHandler: reconciliationHandler,
+		CurrencyHandler:       currencyHandler,
+		WishlistHandler:       wishlistHandler,
+		StockAlertHandler:     stockAlertHandler,
+		RBACService:           rbacService,
+		UserService:           userService,
+		APIKeyService:         apiKeyService,
+		AuditLogService:       auditLogService,
+		JWTService:            jwtService,
 	}
 }
@@ -22,22 +22,32 @@ import (
 // InitializeAllHandler initializes all handler with config
 func InitializeAllHandler(config2 *config.Config) *Handler {
 	categoryRepository := repository.NewCategoryRepository()
-	categoryService := services.NewCategoryService(categoryRepository)
+	activityLogRepository := repository.NewActivityLogRepository()
+	categoryService := services.NewCategoryService(categoryRepository, activityLogRepository)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
 	productRepository := repository.NewProductRepository()
-	productService := services.NewProductService(categoryRepository, productRepository)
-	productHandler := handler.NewProductHandler(productService)
+	stockAlertRepository := repository.NewStockAlertRepository()
+	stockAlertService := services.NewStockAlertService(stockAlertRepository, productRepository)
+	productService := services.NewProductService(categoryRepository, productRepository, stockAlertService)
+	currencyRepository := repository.NewCurrencyRepository()
+	currencyService := services.NewCurrencyService(currencyRepository, activityLogRepository)
+	wishlistRepository := repository.NewWishlistRepository()
+	wishlistService := services.NewWishlistService(wishlistRepository, productRepository)
+	productHandler := handler.NewProductHandler(productService, currencyService, wishlistService)
 	addressRepository := repository.NewAddressRepository()
 	userRepository := repository.NewUserReposiory()
 	addressService := services.NewAddressService(addressRepository, userRepository)
 	addressHandler := handler.NewAddressHandler(addressService)
 	jwtService := ProvideJWTService(config2)
-	activityLogRepository := repository.NewActivityLogRepository()
 	roleRepository := repository.NewRoleRepository()
 	passwordResetTokenRepository := repository.NewPasswordResetTokenRepository()
-	authService := services.NewAuthService(jwtService, userRepository, activityLogRepository, roleRepository, passwordResetTokenRepository)
-	authHandler := handler.NewAuthHandler(authService)
-	userService := services.NewUserService(userRepository, activityLogRepository, roleRepository)
+	passwordHistoryRepository := repository.NewPasswordHistoryRepository()
+	passwordPolicyService := services.NewPasswordPolicyService(config2, passwordHistoryRepository)
+	oidcClient := ProvideOIDCClient(config2)
+	oidcRepository := repository.NewOIDCRepository()
+	authService := services.NewAuthService(jwtService, userRepository, activityLogRepository, roleRepository, passwordResetTokenRepository, passwordPolicyService, oidcClient, oidcRepository)
+	authHandler := handler.NewAuthHandler(authService, config2)
+	userService := services.NewUserService(userRepository, activityLogRepository, roleRepository, passwordPolicyService)
 	userHandler := handler.NewUserHandler(userService)
 	permissionRepository := repository.NewPermissionRepository()
 	roleService := services.NewRoleService(roleRepository, permissionRepository)
@@ -53,18 +63,55 @@ func InitializeAllHandler(config2 *config.Config) *Handler {
 	paymentMethodService := services.NewPaymentMethodService(paymentMethodRepository)
 	paymentMethodHandler := handler.NewPaymentMethodHandler(paymentMethodService)
 	shippingRepository := repository.NewShippingRepository()
-	shippingService := services.NewShippingService(shippingRepository)
+	shippingZoneRepository := repository.NewShippingZoneRepository()
+	shippingService := services.NewShippingService(shippingRepository, shippingZoneRepository, addressRepository, productRepository)
 	shippingHandler := handler.NewShippingHandler(shippingService)
-	transactionService := services.NewTransactionService(transactionRepository, shippingRepository, addressRepository, paymentMethodRepository, orderRepository, productRepository)
-	transactionHandler := handler.NewTransactionHandler(transactionService)
+	taxRepository := repository.NewTaxRepository()
+	taxService := services.NewTaxService(config2, taxRepository, categoryRepository, activityLogRepository)
+	reconciliationRepository := repository.NewReconciliationRepository()
+	paymentProofRepository := repository.NewPaymentProofRepository()
+	reconciliationService := services.NewReconciliationService(config2, reconciliationRepository, paymentProofRepository, paymentRepository, paymentMethodRepository, transactionRepository, activityLogRepository)
+	transactionService := services.NewTransactionService(transactionRepository, shippingRepository, addressRepository, paymentMethodRepository, orderRepository, productRepository, shippingService, taxService, taxRepository, reconciliationService, currencyService)
+	transactionHandler := handler.NewTransactionHandler(transactionService, currencyService)
 	healthHandler := handler.NewHealthHandler()
 	db := ProvideDB()
 	dashboardRepository := repository.NewDashboardRepository(db)
 	dashboardService := services.NewDashboardService(dashboardRepository)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService)
+	jwksHandler := handler.NewJWKSHandler(jwtService)
+	apiKeyRepository := repository.NewAPIKeyRepository()
 	rbacRepository := repository.NewRBACRepository(db)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepository, rbacRepository, activityLogRepository)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	auditLogRepository := repository.NewAuditLogRepository()
+	auditLogService := services.NewAuditLogService(auditLogRepository)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogService)
 	rbacService := services.NewRBACService(rbacRepository)
-	diHandler := NewHandler(categoryHandler, productHandler, addressHandler, authHandler, userHandler, roleHandler, orderHandler, paymentHandler, paymentMethodHandler, shippingHandler, transactionHandler, healthHandler, dashboardHandler, rbacService, userService, jwtService)
+	impersonationService := services.NewImpersonationService(jwtService, userRepository, rbacService, activityLogRepository)
+	impersonationHandler := handler.NewImpersonationHandler(impersonationService)
+	accountRepository := repository.NewAccountRepository()
+	accountService := services.NewAccountService(userRepository, accountRepository, activityLogRepository)
+	accountHandler := handler.NewAccountHandler(accountService)
+	refundRepository := repository.NewRefundRepository()
+	refundService := services.NewRefundService(refundRepository, paymentRepository, transactionRepository, productRepository, activityLogRepository, stockAlertService)
+	refundHandler := handler.NewRefundHandler(refundService)
+	returnRepository := repository.NewReturnRepository()
+	returnService := services.NewReturnService(returnRepository, orderRepository, categoryRepository, productRepository, refundService, activityLogRepository, stockAlertService)
+	returnHandler := handler.NewReturnHandler(returnService)
+	shipmentRepository := repository.NewShipmentRepository()
+	shipmentService := services.NewShipmentService(config2, shipmentRepository, transactionRepository, paymentRepository, activityLogRepository)
+	shipmentHandler := handler.NewShipmentHandler(shipmentService)
+	taxHandler := handler.NewTaxHandler(taxService)
+	invoiceRepository := repository.NewInvoiceRepository()
+	invoiceService := services.NewInvoiceService(config2, invoiceRepository)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceService)
+	paymentProofService := services.NewPaymentProofService(paymentProofRepository, paymentRepository, transactionRepository, activityLogRepository)
+	paymentProofHandler := handler.NewPaymentProofHandler(paymentProofService)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService)
+	currencyHandler := handler.NewCurrencyHandler(currencyService)
+	wishlistHandler := handler.NewWishlistHandler(wishlistService)
+	stockAlertHandler := handler.NewStockAlertHandler(stockAlertService)
+	diHandler := NewHandler(categoryHandler, productHandler, addressHandler, authHandler, userHandler, roleHandler, orderHandler, paymentHandler, paymentMethodHandler, shippingHandler, transactionHandler, healthHandler, dashboardHandler, jwksHandler, apiKeyHandler, auditLogHandler, impersonationHandler, accountHandler, refundHandler, returnHandler, shipmentHandler, taxHandler, invoiceHandler, paymentProofHandler, reconciliationHandler, currencyHandler, wishlistHandler, stockAlertHandler, rbacService, userService, apiKeyService, auditLogService, jwtService)
 	return diHandler
 }
 
@@ -80,42 +127,65 @@ func ProvideJWTService(config2 *config.Config) *utils.JWTService {
 	return utils.NewJWTService(config2)
 }
 
+// ProvideOIDCClient provides the OpenID Connect client for social login
+func ProvideOIDCClient(config2 *config.Config) *utils.OIDCClient {
+	return utils.NewOIDCClient(config2)
+}
+
 // Repository Providers
 var repositorySet = wire.NewSet(
-	ProvideDB, repository.NewCategoryRepository, repository.NewProductRepository, repository.NewAddressRepository, repository.NewOrderRepository, repository.NewPasswordResetTokenRepository, repository.NewPaymentMethodRepository, repository.NewPaymentRepository, repository.NewPermissionRepository, repository.NewRBACRepository, repository.NewShippingRepository, repository.NewTransactionRepository, repository.NewUserReposiory, repository.NewActivityLogRepository, repository.NewRoleRepository, repository.NewDashboardRepository,
+	ProvideDB, repository.NewCategoryRepository, repository.NewProductRepository, repository.NewAddressRepository, repository.NewOrderRepository, repository.NewPasswordResetTokenRepository, repository.NewPaymentMethodRepository, repository.NewPaymentRepository, repository.NewPermissionRepository, repository.NewRBACRepository, repository.NewShippingRepository, repository.NewTransactionRepository, repository.NewUserReposiory, repository.NewActivityLogRepository, repository.NewRoleRepository, repository.NewDashboardRepository, repository.NewAPIKeyRepository, repository.NewAuditLogRepository, repository.NewPasswordHistoryRepository, repository.NewAccountRepository, repository.NewOIDCRepository, repository.NewRefundRepository, repository.NewReturnRepository, repository.NewShipmentRepository, repository.NewShippingZoneRepository, repository.NewTaxRepository, repository.NewInvoiceRepository, repository.NewPaymentProofRepository, repository.NewReconciliationRepository, repository.NewCurrencyRepository, repository.NewWishlistRepository, repository.NewStockAlertRepository,
 )
 
 // Service Providers
-var serviceSet = wire.NewSet(services.NewCategoryService, services.NewProductService, services.NewAddressService, services.NewAuthService, services.NewOrderService, services.NewPaymentMethodService, services.NewPaymentService, services.NewRBACService, services.NewRoleService, services.NewShippingService, services.NewTransactionService, services.NewUserService, services.NewDashboardService)
+var serviceSet = wire.NewSet(services.NewCategoryService, services.NewProductService, services.NewAddressService, services.NewAuthService, services.NewOrderService, services.NewPaymentMethodService, services.NewPaymentService, services.NewRBACService, services.NewRoleService, services.NewShippingService, services.NewTransactionService, services.NewUserService, services.NewDashboardService, services.NewAPIKeyService, services.NewAuditLogService, services.NewImpersonationService, services.NewPasswordPolicyService, services.NewAccountService, services.NewRefundService, services.NewReturnService, services.NewShipmentService, services.NewTaxService, services.NewInvoiceService, services.NewPaymentProofService, services.NewReconciliationService, services.NewCurrencyService, services.NewWishlistService, services.NewStockAlertService)
 
 // Utils Providers
 var utilsSet = wire.NewSet(
 	ProvideJWTService,
+	ProvideOIDCClient,
 )
 
 // Handler Providers
-var handlerSet = wire.NewSet(handler.NewCategoryHandler, handler.NewProductHandler, handler.NewAddressHandler, handler.NewUserHandler, handler.NewAuthHandler, handler.NewRoleHandler, handler.NewOrderHandler, handler.NewShippingHandler, handler.NewPaymentHandler, handler.NewPaymentMethodHandler, handler.NewTransactionHandler, handler.NewHealthHandler, handler.NewDashboardHandler)
+var handlerSet = wire.NewSet(handler.NewCategoryHandler, handler.NewProductHandler, handler.NewAddressHandler, handler.NewUserHandler, handler.NewAuthHandler, handler.NewRoleHandler, handler.NewOrderHandler, handler.NewShippingHandler, handler.NewPaymentHandler, handler.NewPaymentMethodHandler, handler.NewTransactionHandler, handler.NewHealthHandler, handler.NewDashboardHandler, handler.NewJWKSHandler, handler.NewAPIKeyHandler, handler.NewAuditLogHandler, handler.NewImpersonationHandler, handler.NewAccountHandler, handler.NewRefundHandler, handler.NewReturnHandler, handler.NewShipmentHandler, handler.NewTaxHandler, handler.NewInvoiceHandler, handler.NewPaymentProofHandler, handler.NewReconciliationHandler, handler.NewCurrencyHandler, handler.NewWishlistHandler, handler.NewStockAlertHandler)
 
 // Handler struct contains all handler and services
 type Handler struct {
-	CategoryHandler      *handler.CategoryHandler
-	ProductHandler       *handler.ProductHandler
-	AddressHandler       *handler.AddressHandler
-	AuthHandler          *handler.AuthHandler
-	UserHandler          *handler.UserHandler
-	RoleHandler          *handler.RoleHandler
-	OrderHandler         *handler.OrderHandler
-	PaymentHandler       *handler.PaymentHandler
-	PaymentMethodHandler *handler.PaymentMethodHandler
-	ShippingHandler      *handler.ShippingHandler
-	TransactionHandler   *handler.TransactionHandler
-	HealthHandler        *handler.HealthHandler
-	DashboardHandler     *handler.DashboardHandler
+	CategoryHandler       *handler.CategoryHandler
+	ProductHandler        *handler.ProductHandler
+	AddressHandler        *handler.AddressHandler
+	AuthHandler           *handler.AuthHandler
+	UserHandler           *handler.UserHandler
+	RoleHandler           *handler.RoleHandler
+	OrderHandler          *handler.OrderHandler
+	PaymentHandler        *handler.PaymentHandler
+	PaymentMethodHandler  *handler.PaymentMethodHandler
+	ShippingHandler       *handler.ShippingHandler
+	TransactionHandler    *handler.TransactionHandler
+	HealthHandler         *handler.HealthHandler
+	DashboardHandler      *handler.DashboardHandler
+	JWKSHandler           *handler.JWKSHandler
+	APIKeyHandler         *handler.APIKeyHandler
+	AuditLogHandler       *handler.AuditLogHandler
+	ImpersonationHandler  *handler.ImpersonationHandler
+	AccountHandler        *handler.AccountHandler
+	RefundHandler         *handler.RefundHandler
+	ReturnHandler         *handler.ReturnHandler
+	ShipmentHandler       *handler.ShipmentHandler
+	TaxHandler            *handler.TaxHandler
+	InvoiceHandler        *handler.InvoiceHandler
+	PaymentProofHandler   *handler.PaymentProofHandler
+	ReconciliationHandler *handler.ReconciliationHandler
+	CurrencyHandler       *handler.CurrencyHandler
+	WishlistHandler       *handler.WishlistHandler
+	StockAlertHandler     *handler.StockAlertHandler
 
 	// Services untuk middleware
-	RBACService services.RBACService
-	UserService services.UserService
-	JWTService  *utils.JWTService
+	RBACService     services.RBACService
+	UserService     services.UserService
+	APIKeyService   services.APIKeyService
+	AuditLogService services.AuditLogService
+	JWTService      *utils.JWTService
 }
 
 // NewHandler creates new Handler instance
@@ -134,26 +204,60 @@ func NewHandler(
 	transactionHandler *handler.TransactionHandler,
 	healthHandler *handler.HealthHandler,
 	dashboardHandler *handler.DashboardHandler,
+	jwksHandler *handler.JWKSHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	auditLogHandler *handler.AuditLogHandler,
+	impersonationHandler *handler.ImpersonationHandler,
+	accountHandler *handler.AccountHandler,
+	refundHandler *handler.RefundHandler,
+	returnHandler *handler.ReturnHandler,
+	shipmentHandler *handler.ShipmentHandler,
+	taxHandler *handler.TaxHandler,
+	invoiceHandler *handler.InvoiceHandler,
+	paymentProofHandler *handler.PaymentProofHandler,
+	reconciliationHandler *handler.ReconciliationHandler,
+	currencyHandler *handler.CurrencyHandler,
+	wishlistHandler *handler.WishlistHandler,
+	stockAlertHandler *handler.StockAlertHandler,
 	rbacService services.RBACService,
 	userService services.UserService,
+	apiKeyService services.APIKeyService,
+	auditLogService services.AuditLogService,
 	jwtService *utils.JWTService,
 ) *Handler {
 	return &Handler{
-		CategoryHandler:      categoryHandler,
-		ProductHandler:       productHandler,
-		AddressHandler:       addressHandler,
-		AuthHandler:          authHandler,
-		UserHandler:          userHandler,
-		RoleHandler:          roleHandler,
-		OrderHandler:         orderHandler,
-		PaymentHandler:       paymentHandler,
-		PaymentMethodHandler: paymentMethodHandler,
-		ShippingHandler:      shippingHandler,
-		TransactionHandler:   transactionHandler,
-		HealthHandler:        healthHandler,
-		DashboardHandler:     dashboardHandler,
-		RBACService:          rbacService,
-		UserService:          userService,
-		JWTService:           jwtService,
+		CategoryHandler:       categoryHandler,
+		ProductHandler:        productHandler,
+		AddressHandler:        addressHandler,
+		AuthHandler:           authHandler,
+		UserHandler:           userHandler,
+		RoleHandler:           roleHandler,
+		OrderHandler:          orderHandler,
+		PaymentHandler:        paymentHandler,
+		PaymentMethodHandler:  paymentMethodHandler,
+		ShippingHandler:       shippingHandler,
+		TransactionHandler:    transactionHandler,
+		HealthHandler:         healthHandler,
+		DashboardHandler:      dashboardHandler,
+		JWKSHandler:           jwksHandler,
+		APIKeyHandler:         apiKeyHandler,
+		AuditLogHandler:       auditLogHandler,
+		ImpersonationHandler:  impersonationHandler,
+		AccountHandler:        accountHandler,
+		RefundHandler:         refundHandler,
+		ReturnHandler:         returnHandler,
+		ShipmentHandler:       shipmentHandler,
+		TaxHandler:            taxHandler,
+		InvoiceHandler:        invoiceHandler,
+		PaymentProofHandler:   paymentProofHandler,
+		ReconciliationHandler: reconciliationHandler,
+		CurrencyHandler:       currencyHandler,
+		WishlistHandler:       wishlistHandler,
+		StockAlertHandler:     stockAlertHandler,
+		RBACService:           rbacService,
+		UserService:           userService,
+		APIKeyService:         apiKeyService,
+		AuditLogService:       auditLogService,
+		JWTService:            jwtService,
 	}
 }
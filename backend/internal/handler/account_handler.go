@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type AccountHandler struct {
+	accountService services.AccountService
+}
+
+func NewAccountHandler(accountService services.AccountService) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+	}
+}
+
+// ExportData - GET /api/v1/account/export
+// @Summary Export my data
+// @Description Download profile, addresses, transactions, orders, payments and activity logs of the current user as a JSON file or a ZIP archive
+// @Tags Account
+// @Produce json
+// @Produce application/zip
+// @Param format query string false "json (default) or zip"
+// @Success 200 {object} models.UserDataExport "Data export"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /account/export [get]
+// @Security Bearer
+func (h *AccountHandler) ExportData(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r)
+	filename := fmt.Sprintf("account-data-%d-%s", userID, time.Now().Format("20060102"))
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		export, err := h.accountService.ExportData(userID)
+		if err != nil {
+			writeAccountError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".json"))
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(export)
+	case "zip":
+		archive, err := h.accountService.ExportArchive(userID)
+		if err != nil {
+			writeAccountError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".zip"))
+		w.WriteHeader(http.StatusOK)
+		w.Write(archive)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, "Invalid format, expected json or zip", fmt.Errorf("unsupported format %q", format))
+	}
+}
+
+// DeleteAccount - DELETE /api/v1/account
+// @Summary Delete my account
+// @Description Close the current user's account. Personal data is anonymised, orders and payments are kept for accounting.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param request body models.AccountDeletionRequest true "Password and the word DELETE; accounts without a password sign in with their provider first"
+// @Success 200 {object} utils.Response "Account deleted"
+// @Failure 401 {object} utils.Response "Invalid password or sign-in too long ago"
+// @Failure 409 {object} utils.Response "Account has open orders"
+// @Router /account [delete]
+// @Security Bearer
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	var req models.AccountDeletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := h.accountService.DeleteAccount(middleware.GetActivityContext(r), req); err != nil {
+		writeAccountError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Account deleted successfully", nil)
+}
+
+func writeAccountError(w http.ResponseWriter, err error) {
+	statusCode := http.StatusBadRequest
+	switch err.Error() {
+	case "user not found":
+		statusCode = http.StatusNotFound
+	case "invalid password", "sign in again to delete your account":
+		statusCode = http.StatusUnauthorized
+	case "account has open orders":
+		statusCode = http.StatusConflict
+	case "cannot delete an account while impersonating":
+		statusCode = http.StatusForbidden
+	}
+	utils.WriteError(w, statusCode, err.Error(), err)
+}
@@ -0,0 +1,123 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountHandler_ExportData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAccountService(ctrl)
+	accountHandler := handler.NewAccountHandler(mockService)
+
+	newRequest := func(query string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/account/export"+query, nil)
+		return req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, uint(7)))
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		mockService.EXPECT().
+			ExportData(uint(7)).
+			Return(&models.UserDataExport{Profile: models.UserResponse{ID: 7}}, nil)
+
+		w := httptest.NewRecorder()
+		accountHandler.ExportData(w, newRequest(""))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Header().Get("Content-Disposition"), ".json") {
+			t.Errorf("Expected a JSON attachment, got %q", w.Header().Get("Content-Disposition"))
+		}
+	})
+
+	t.Run("Zip", func(t *testing.T) {
+		mockService.EXPECT().
+			ExportArchive(uint(7)).
+			Return([]byte("PK"), nil)
+
+		w := httptest.NewRecorder()
+		accountHandler.ExportData(w, newRequest("?format=zip"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+		if w.Header().Get("Content-Type") != "application/zip" {
+			t.Errorf("Expected application/zip, got %q", w.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		accountHandler.ExportData(w, newRequest("?format=xml"))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAccountService(ctrl)
+	accountHandler := handler.NewAccountHandler(mockService)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/account", bytes.NewBufferString(`{"password":"secret","confirmation":"DELETE"}`))
+		return req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, uint(7)))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			DeleteAccount(gomock.Any(), models.AccountDeletionRequest{Password: "secret", Confirmation: "DELETE"}).
+			Return(nil)
+
+		w := httptest.NewRecorder()
+		accountHandler.DeleteAccount(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("OpenOrders", func(t *testing.T) {
+		mockService.EXPECT().
+			DeleteAccount(gomock.Any(), gomock.Any()).
+			Return(errors.New("account has open orders"))
+
+		w := httptest.NewRecorder()
+		accountHandler.DeleteAccount(w, newRequest())
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidPassword", func(t *testing.T) {
+		mockService.EXPECT().
+			DeleteAccount(gomock.Any(), gomock.Any()).
+			Return(errors.New("invalid password"))
+
+		w := httptest.NewRecorder()
+		accountHandler.DeleteAccount(w, newRequest())
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type APIKeyHandler struct {
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// GetAPIKeys - GET /api/v1/api-keys
+// @Summary List API keys
+// @Description Get the API keys of the current admin, or every API key with the api_keys.manage permission
+// @Tags APIKey
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]models.APIKeyResponse} "Success"
+// @Router /api-keys [get]
+// @Security Bearer
+func (h *APIKeyHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := h.apiKeyService.GetAPIKeys(middleware.GetActivityContext(r))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch API keys", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "API keys retrieved successfully", apiKeys)
+}
+
+// CreateAPIKey - POST /api/v1/api-keys
+// @Summary Create an API key
+// @Description Issue a scoped API key acting as the current admin. The key is only returned once.
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param request body models.APIKeyInput true "API key request"
+// @Success 201 {object} utils.Response{data=models.APIKeyCreatedResponse} "API key created successfully"
+// @Router /api-keys [post]
+// @Security Bearer
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req models.APIKeyInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	apiKey, err := h.apiKeyService.CreateAPIKey(middleware.GetActivityContext(r), req)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "API key created successfully", apiKey)
+}
+
+// RevokeAPIKey - DELETE /api/v1/api-keys/{id}
+// @Summary Revoke an API key
+// @Description Revoke one of the current admin's API keys, or any API key with the api_keys.manage permission
+// @Tags APIKey
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} utils.Response "API key revoked successfully"
+// @Router /api-keys/{id} [delete]
+// @Security Bearer
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid API key ID", err)
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(uint(id), middleware.GetActivityContext(r)); err != nil {
+		statusCode := http.StatusBadRequest
+		if err.Error() == "api key not found" {
+			statusCode = http.StatusNotFound
+		}
+		utils.WriteError(w, statusCode, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "API key revoked successfully", nil)
+}
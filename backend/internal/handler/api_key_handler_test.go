@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyService(ctrl)
+	apiKeyHandler := handler.NewAPIKeyHandler(mockService)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, uint(1)))
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateAPIKey(gomock.Any(), models.APIKeyInput{Name: "erp", Scopes: []string{"orders.read"}}).
+			Return(&models.APIKeyCreatedResponse{Key: "ek_0011aabb_secret"}, nil)
+
+		w := httptest.NewRecorder()
+		apiKeyHandler.CreateAPIKey(w, newRequest(`{"name":"erp","scopes":["orders.read"]}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+		if !bytes.Contains(w.Body.Bytes(), []byte("ek_0011aabb_secret")) {
+			t.Errorf("Expected raw key in response, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("ScopeNotHeld", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateAPIKey(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New(`cannot grant scope "users.delete" you do not have`))
+
+		w := httptest.NewRecorder()
+		apiKeyHandler.CreateAPIKey(w, newRequest(`{"name":"erp","scopes":["users.delete"]}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyService(ctrl)
+	apiKeyHandler := handler.NewAPIKeyHandler(mockService)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/api-keys/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().RevokeAPIKey(uint(2), gomock.Any()).Return(nil)
+
+		w := httptest.NewRecorder()
+		apiKeyHandler.RevokeAPIKey(w, newRequest("2"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().RevokeAPIKey(uint(9), gomock.Any()).Return(errors.New("api key not found"))
+
+		w := httptest.NewRecorder()
+		apiKeyHandler.RevokeAPIKey(w, newRequest("9"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AuditLogHandler struct {
+	auditLogService services.AuditLogService
+}
+
+func NewAuditLogHandler(auditLogService services.AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditLogService: auditLogService,
+	}
+}
+
+// GetAuditLogs - GET /api/v1/audit-logs
+// @Summary List audit logs
+// @Description Get audit logs of admin mutations, newest first
+// @Tags AuditLog
+// @Produce json
+// @Param user_id query int false "Actor user ID"
+// @Param api_key_id query int false "API key ID"
+// @Param action query string false "create, update or delete"
+// @Param resource_type query string false "Resource type, e.g. products"
+// @Param resource_id query string false "Resource ID"
+// @Param request_id query string false "Request ID"
+// @Param from query string false "Start time (RFC3339)"
+// @Param to query string false "End time (RFC3339)"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.AuditLogListResponse} "Success"
+// @Router /audit-logs [get]
+// @Security Bearer
+func (h *AuditLogHandler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	req := models.AuditLogListRequest{
+		Action:       query.Get("action"),
+		ResourceType: query.Get("resource_type"),
+		ResourceID:   query.Get("resource_id"),
+		RequestID:    query.Get("request_id"),
+		Page:         page,
+		Limit:        limit,
+	}
+
+	var err error
+	if req.UserID, err = parseOptionalUint(query.Get("user_id")); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid user_id", err)
+		return
+	}
+	if req.APIKeyID, err = parseOptionalUint(query.Get("api_key_id")); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid api_key_id", err)
+		return
+	}
+	if req.From, err = parseOptionalTime(query.Get("from")); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid from, expected RFC3339", err)
+		return
+	}
+	if req.To, err = parseOptionalTime(query.Get("to")); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid to, expected RFC3339", err)
+		return
+	}
+
+	auditLogs, err := h.auditLogService.GetAuditLogs(&req)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Audit logs retrieved successfully", auditLogs)
+}
+
+func parseOptionalUint(value string) (*uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || parsed == 0 {
+		return nil, errors.New("must be a positive integer")
+	}
+
+	result := uint(parsed)
+	return &result, nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,55 @@
+package handler_test
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditLogHandler_GetAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuditLogService(ctrl)
+	auditLogHandler := handler.NewAuditLogHandler(mockService)
+
+	t.Run("Filters", func(t *testing.T) {
+		mockService.EXPECT().
+			GetAuditLogs(gomock.Any()).
+			DoAndReturn(func(req *models.AuditLogListRequest) (*models.AuditLogListResponse, error) {
+				if req.UserID == nil || *req.UserID != 2 {
+					t.Errorf("Expected user_id 2, got %v", req.UserID)
+				}
+				if req.ResourceType != "products" || req.ResourceID != "9" || req.Action != "update" {
+					t.Errorf("Unexpected filters %+v", req)
+				}
+				if req.From == nil || req.From.Year() != 2024 {
+					t.Errorf("Expected from to be parsed, got %v", req.From)
+				}
+				return &models.AuditLogListResponse{Page: 1, Limit: 20}, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/audit-logs?user_id=2&resource_type=products&resource_id=9&action=update&from=2024-01-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+		auditLogHandler.GetAuditLogs(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidFrom", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/audit-logs?from=yesterday", nil)
+		w := httptest.NewRecorder()
+		auditLogHandler.GetAuditLogs(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
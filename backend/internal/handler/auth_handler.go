@@ -1,22 +1,32 @@
 package handler
 
 import (
+	"e-commerce/backend/internal/config"
 	"e-commerce/backend/internal/middleware"
 	"e-commerce/backend/internal/models"
 	"e-commerce/backend/internal/services"
 	"e-commerce/backend/internal/utils"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type AuthHandler struct {
-	authService services.AuthService
+	authService        services.AuthService
+	session            config.SessionConfig
+	refreshTokenExpiry time.Duration
 }
 
-func NewAuthHandler(authService services.AuthService) *AuthHandler {
+func NewAuthHandler(authService services.AuthService, cfg *config.Config) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:        authService,
+		session:            cfg.Session,
+		refreshTokenExpiry: cfg.JWT.RefreshTokenExpiry,
 	}
 }
 
@@ -86,7 +96,7 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 // @Param request body models.LoginRequest true "Login request"
 // @Success 200 {object} utils.Response{data=models.TokenResponse} "Login successful"
 // @Failure 401 {object} utils.Response "Invalid credentials"
-// @Router /auth/login [post]
+// @Router /auth/admin/login [post]
 func (h *AuthHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 	var req models.LoginRequest
 
@@ -104,6 +114,17 @@ func (h *AuthHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// In cookie mode the tokens never reach JavaScript, so an XSS cannot
+	// steal them from browser storage.
+	if h.session.CookieMode {
+		if err := utils.SetSessionCookies(w, h.session, resp.AccessToken, resp.ExpiresAt, resp.RefreshToken, time.Now().Add(h.refreshTokenExpiry)); err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to create session", err)
+			return
+		}
+		resp.AccessToken = ""
+		resp.RefreshToken = ""
+	}
+
 	utils.WriteJSON(w, http.StatusOK, "Login successful", resp)
 }
 
@@ -116,6 +137,10 @@ func (h *AuthHandler) AdminLogin(w http.ResponseWriter, r *http.Request) {
 // @Router /auth/logout [post]
 // @Security Bearer
 func (h *AuthHandler) SignOut(w http.ResponseWriter, r *http.Request) {
+	// Cookies are cleared even when the access token already expired.
+	if h.session.CookieMode {
+		utils.ClearSessionCookies(w, h.session)
+	}
 
 	userID := middleware.GetUserIDFromContext(r)
 	if userID == 0 {
@@ -130,29 +155,108 @@ func (h *AuthHandler) SignOut(w http.ResponseWriter, r *http.Request) {
 
 // Refresh - POST /api/v1/auth/refresh
 // @Summary Refresh token
-// @Description Get a new access token using refresh token
+// @Description Get a new access token using refresh token. In cookie session mode the body may be empty, the refresh token cookie is used instead.
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param request body models.RefreshTokenRequest true "Refresh token request"
+// @Param request body models.RefreshTokenRequest false "Refresh token request"
 // @Success 200 {object} utils.Response{data=models.RefreshTokenResponse} "Token refreshed successfully"
 // @Router /auth/refresh [post]
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	var req models.RefreshTokenRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !(h.session.CookieMode && errors.Is(err, io.EOF)) {
 		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
 
+	fromCookie := false
+	if req.RefreshToken == "" && h.session.CookieMode {
+		if cookie, err := r.Cookie(utils.RefreshTokenCookie); err == nil {
+			if !utils.ValidCSRFToken(r) {
+				utils.WriteError(w, http.StatusForbidden, "Invalid CSRF token", errors.New("invalid CSRF token"))
+				return
+			}
+			req.RefreshToken = cookie.Value
+			fromCookie = true
+		}
+	}
+
 	resp, err := h.authService.RefreshToken(req)
 	if err != nil {
 		utils.WriteError(w, http.StatusUnauthorized, err.Error(), err)
 		return
 	}
 
+	if fromCookie {
+		if err := utils.SetSessionCookies(w, h.session, resp.AccessToken, resp.ExpiresAt, resp.RefreshToken, time.Now().Add(h.refreshTokenExpiry)); err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to create session", err)
+			return
+		}
+		resp.AccessToken = ""
+		resp.RefreshToken = ""
+	}
+
 	utils.WriteJSON(w, http.StatusOK, "Token refreshed successfully", resp)
 }
 
+// OIDCAuthorize - GET /api/v1/auth/oidc/{provider}/authorize
+// @Summary Start social login
+// @Description Start an OpenID Connect login (authorization code with PKCE). Open the returned URL, then send the code and state the provider redirects back with to the callback endpoint.
+// @Tags Auth
+// @Produce json
+// @Param provider path string true "Provider name, e.g. google"
+// @Success 200 {object} utils.Response{data=models.OIDCAuthorizeResponse} "Authorization URL created"
+// @Failure 404 {object} utils.Response "Unknown identity provider"
+// @Router /auth/oidc/{provider}/authorize [get]
+func (h *AuthHandler) OIDCAuthorize(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.authService.OIDCAuthorize(chi.URLParam(r, "provider"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "unknown identity provider" {
+			statusCode = http.StatusNotFound
+		}
+		utils.WriteError(w, statusCode, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Authorization URL created", resp)
+}
+
+// OIDCCallback - POST /api/v1/auth/oidc/{provider}/callback
+// @Summary Complete social login
+// @Description Exchange the authorization code for tokens. Signs in the linked user, links an existing user with the same verified email, or creates a new customer account.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name, e.g. google"
+// @Param request body models.OIDCCallbackRequest true "Code and state from the provider redirect"
+// @Success 200 {object} utils.Response{data=models.TokenResponse} "Login successful"
+// @Failure 400 {object} utils.Response "Invalid or expired state"
+// @Failure 401 {object} utils.Response "Provider rejected the login"
+// @Router /auth/oidc/{provider}/callback [post]
+func (h *AuthHandler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	var req models.OIDCCallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	resp, err := h.authService.OIDCCallback(chi.URLParam(r, "provider"), req, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		statusCode := http.StatusUnauthorized
+		switch err.Error() {
+		case "unknown identity provider":
+			statusCode = http.StatusNotFound
+		case "code and state are required", "invalid or expired state":
+			statusCode = http.StatusBadRequest
+		}
+		utils.WriteError(w, statusCode, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Login successful", resp)
+}
+
 // ForgotPassword - POST /api/v1/auth/forgot-password
 // @Summary Forgot password
 // @Description Request password reset token
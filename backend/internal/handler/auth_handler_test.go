@@ -2,15 +2,21 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
+	"e-commerce/backend/internal/config"
 	"e-commerce/backend/internal/handler"
 	"e-commerce/backend/internal/mocks"
 	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"go.uber.org/mock/gomock"
 )
 
@@ -19,7 +25,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockAuthService(ctrl)
-	authHandler := handler.NewAuthHandler(mockService)
+	authHandler := handler.NewAuthHandler(mockService, &config.Config{})
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := models.LoginRequest{
@@ -71,7 +77,7 @@ func TestAuthHandler_SignUp(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockAuthService(ctrl)
-	authHandler := handler.NewAuthHandler(mockService)
+	authHandler := handler.NewAuthHandler(mockService, &config.Config{})
 
 	t.Run("Success", func(t *testing.T) {
 		reqBody := models.RegisterRequest{
@@ -99,3 +105,118 @@ func TestAuthHandler_SignUp(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthHandler_AdminLogin_CookieMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthService(ctrl)
+	cfg := &config.Config{
+		Session: config.SessionConfig{CookieMode: true, SameSite: "strict"},
+		JWT:     config.JWTConfig{RefreshTokenExpiry: time.Hour},
+	}
+	authHandler := handler.NewAuthHandler(mockService, cfg)
+
+	reqBody := models.LoginRequest{
+		Email:    "admin@example.com",
+		Password: "password",
+	}
+
+	mockService.EXPECT().LoginAdmin(reqBody, gomock.Any(), gomock.Any()).Return(&models.TokenResponse{
+		AccessToken:  "access_token",
+		RefreshToken: "refresh_token",
+		ExpiresAt:    time.Now().Add(15 * time.Minute),
+	}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/auth/admin/login", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+
+	authHandler.AdminLogin(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	if strings.Contains(w.Body.String(), "access_token\":\"access_token") {
+		t.Error("Expected tokens to be left out of the body in cookie mode")
+	}
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range w.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+
+	for _, name := range []string{utils.AccessTokenCookie, utils.RefreshTokenCookie} {
+		cookie, ok := cookies[name]
+		if !ok {
+			t.Fatalf("Expected %s cookie to be set", name)
+		}
+		if !cookie.HttpOnly || cookie.SameSite != http.SameSiteStrictMode {
+			t.Errorf("Expected %s cookie to be httpOnly and SameSite=Strict", name)
+		}
+	}
+
+	csrf, ok := cookies[utils.CSRFTokenCookie]
+	if !ok || csrf.HttpOnly {
+		t.Fatal("Expected a CSRF cookie readable by the web app")
+	}
+	if w.Header().Get(utils.CSRFTokenHeader) != csrf.Value {
+		t.Error("Expected the CSRF header to match the CSRF cookie")
+	}
+}
+
+func TestAuthHandler_OIDCCallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthService(ctrl)
+	authHandler := handler.NewAuthHandler(mockService, &config.Config{})
+
+	newRequest := func() *http.Request {
+		body, _ := json.Marshal(models.OIDCCallbackRequest{Code: "code", State: "state"})
+		req := httptest.NewRequest(http.MethodPost, "/auth/oidc/google/callback", bytes.NewBuffer(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("provider", "google")
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			OIDCCallback("google", models.OIDCCallbackRequest{Code: "code", State: "state"}, gomock.Any(), gomock.Any()).
+			Return(&models.TokenResponse{AccessToken: "access_token"}, nil)
+
+		w := httptest.NewRecorder()
+		authHandler.OIDCCallback(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("ExpiredState", func(t *testing.T) {
+		mockService.EXPECT().
+			OIDCCallback("google", gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("invalid or expired state"))
+
+		w := httptest.NewRecorder()
+		authHandler.OIDCCallback(w, newRequest())
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidIDToken", func(t *testing.T) {
+		mockService.EXPECT().
+			OIDCCallback("google", gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("invalid id token"))
+
+		w := httptest.NewRecorder()
+		authHandler.OIDCCallback(w, newRequest())
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", w.Code)
+		}
+	})
+}
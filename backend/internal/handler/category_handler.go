@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"e-commerce/backend/internal/middleware"
 	"e-commerce/backend/internal/models"
 	"e-commerce/backend/internal/services"
 	"e-commerce/backend/internal/utils"
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -30,9 +32,13 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	result, err := h.service.Create(req)
+	result, err := h.service.Create(req, middleware.GetActivityContext(r))
 	if err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "Failed to create category", err)
+		statusCode := http.StatusBadRequest
+		if err.Error() == "category slug is already used" {
+			statusCode = http.StatusConflict
+		}
+		utils.WriteError(w, statusCode, "Failed to create category", err)
 		return
 	}
 
@@ -56,11 +62,14 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 
 	req.ID = id
 
-	result, err := h.service.Update(req)
+	result, err := h.service.Update(req, middleware.GetActivityContext(r))
 	if err != nil {
 		statusCode := http.StatusBadRequest
-		if err.Error() == "category not found" {
+		switch err.Error() {
+		case "category not found":
 			statusCode = http.StatusNotFound
+		case "category slug is already used":
+			statusCode = http.StatusConflict
 		}
 		utils.WriteError(w, statusCode, "Failed to update category", err)
 		return
@@ -69,7 +78,7 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 	utils.WriteJSON(w, http.StatusOK, "Category updated successfully", result)
 }
 
-// DeleteCategory handles DELETE /api/v1/categories/{id}
+// DeleteCategory handles DELETE /api/v1/categories/{id}?reassign_to={category_id}
 func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -78,11 +87,23 @@ func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.service.Delete(id)
+	reassignTo := int64(0)
+	if reassignStr := r.URL.Query().Get("reassign_to"); reassignStr != "" {
+		reassignTo, err = strconv.ParseInt(reassignStr, 10, 64)
+		if err != nil || reassignTo <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid reassign_to category ID", err)
+			return
+		}
+	}
+
+	err = h.service.Delete(id, reassignTo, middleware.GetActivityContext(r))
 	if err != nil {
 		statusCode := http.StatusBadRequest
-		if err.Error() == "category not found" {
+		switch err.Error() {
+		case "category not found":
 			statusCode = http.StatusNotFound
+		case "category is still used by products", "category still has subcategories":
+			statusCode = http.StatusConflict
 		}
 		utils.WriteError(w, statusCode, "Failed to delete category", err)
 		return
@@ -113,6 +134,34 @@ func (h *CategoryHandler) GetCategoryById(w http.ResponseWriter, r *http.Request
 	utils.WriteJSON(w, http.StatusOK, "Category retrieved successfully", result)
 }
 
+// GetCategoryBySlug handles GET /api/v1/categories/slug/{slug}
+func (h *CategoryHandler) GetCategoryBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := strings.ToLower(chi.URLParam(r, "slug"))
+
+	result, err := h.service.GetBySlug(slug)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if err.Error() == "category not found" {
+			statusCode = http.StatusNotFound
+		}
+		utils.WriteError(w, statusCode, "Failed to get category", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Category retrieved successfully", result)
+}
+
+// GetCategoryTree handles GET /api/v1/categories/tree
+func (h *CategoryHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetTree()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to get category tree", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Category tree retrieved successfully", result)
+}
+
 // GetAllCategories handles GET /api/v1/categories
 func (h *CategoryHandler) GetAllCategories(w http.ResponseWriter, r *http.Request) {
 	page := int64(1)
@@ -0,0 +1,162 @@
+package handler_test
+
+import (
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCategoryHandler_DeleteCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCategoryService(ctrl)
+	categoryHandler := handler.NewCategoryHandler(mockService)
+
+	newRequest := func(target string, id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, target, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, uint(1)))
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			Delete(int64(3), int64(0), gomock.Any()).
+			Return(nil)
+
+		w := httptest.NewRecorder()
+		categoryHandler.DeleteCategory(w, newRequest("/categories/3", "3"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("ReassignProducts", func(t *testing.T) {
+		mockService.EXPECT().
+			Delete(int64(3), int64(5), gomock.Any()).
+			Return(nil)
+
+		w := httptest.NewRecorder()
+		categoryHandler.DeleteCategory(w, newRequest("/categories/3?reassign_to=5", "3"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("StillUsedByProducts", func(t *testing.T) {
+		mockService.EXPECT().
+			Delete(int64(3), int64(0), gomock.Any()).
+			Return(errors.New("category is still used by products"))
+
+		w := httptest.NewRecorder()
+		categoryHandler.DeleteCategory(w, newRequest("/categories/3", "3"))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("HasSubcategories", func(t *testing.T) {
+		mockService.EXPECT().
+			Delete(int64(3), int64(0), gomock.Any()).
+			Return(errors.New("category still has subcategories"))
+
+		w := httptest.NewRecorder()
+		categoryHandler.DeleteCategory(w, newRequest("/categories/3", "3"))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidReassignTarget", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		categoryHandler.DeleteCategory(w, newRequest("/categories/3?reassign_to=abc", "3"))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestCategoryHandler_GetCategoryTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCategoryService(ctrl)
+	categoryHandler := handler.NewCategoryHandler(mockService)
+
+	parentID := int64(1)
+	mockService.EXPECT().GetTree().Return([]models.CategoryTreeNode{
+		{
+			CategoryResponse: models.CategoryResponse{ID: 1, Name: "Men", Slug: "men"},
+			Children: []models.CategoryTreeNode{
+				{CategoryResponse: models.CategoryResponse{ID: 2, ParentID: &parentID, Name: "Shoes", Slug: "shoes"}, Children: []models.CategoryTreeNode{}},
+			},
+		},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	categoryHandler.GetCategoryTree(w, httptest.NewRequest(http.MethodGet, "/categories/tree", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	for _, want := range []string{`"slug":"men"`, `"children":[{"id":2,"parent_id":1`, `"children":[]`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("Expected %s in response, got %s", want, w.Body.String())
+		}
+	}
+}
+
+func TestCategoryHandler_GetCategoryBySlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCategoryService(ctrl)
+	categoryHandler := handler.NewCategoryHandler(mockService)
+
+	newRequest := func(slug string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/categories/slug/"+slug, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("slug", slug)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().GetBySlug("sneakers").Return(models.Category{ID: 3, Name: "Sneakers", Slug: "sneakers"}, nil)
+
+		w := httptest.NewRecorder()
+		categoryHandler.GetCategoryBySlug(w, newRequest("Sneakers"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().GetBySlug("nope").Return(models.Category{}, errors.New("category not found"))
+
+		w := httptest.NewRecorder()
+		categoryHandler.GetCategoryBySlug(w, newRequest("nope"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CurrencyHandler struct {
+	currencyService services.CurrencyService
+}
+
+func NewCurrencyHandler(currencyService services.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{
+		currencyService: currencyService,
+	}
+}
+
+// GetCurrencies - GET /api/v1/currencies
+// @Summary List display currencies
+// @Description Get the currencies prices can be shown in, with their rate in the store currency. Prices are always charged in the store currency.
+// @Tags Currency
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]models.CurrencyResponse} "Success"
+// @Router /currencies [get]
+func (h *CurrencyHandler) GetCurrencies(w http.ResponseWriter, r *http.Request) {
+	currencies, err := h.currencyService.FindAll(true)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch currencies", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Currencies retrieved successfully", currencies)
+}
+
+// GetAllCurrencies - GET /api/v1/currencies/all
+// @Summary List all currencies
+// @Description Get all currencies including inactive ones
+// @Tags Currency
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]models.CurrencyResponse} "Success"
+// @Router /currencies/all [get]
+// @Security Bearer
+func (h *CurrencyHandler) GetAllCurrencies(w http.ResponseWriter, r *http.Request) {
+	currencies, err := h.currencyService.FindAll(false)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch currencies", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Currencies retrieved successfully", currencies)
+}
+
+// CreateCurrency - POST /api/v1/currencies
+// @Summary Create a currency
+// @Description Add a currency prices can be shown in, with the value of one unit in the store currency
+// @Tags Currency
+// @Accept json
+// @Produce json
+// @Param request body models.CreateCurrency true "Currency"
+// @Success 201 {object} utils.Response{data=models.CurrencyResponse} "Currency created successfully"
+// @Failure 409 {object} utils.Response "Currency already exists"
+// @Router /currencies [post]
+// @Security Bearer
+func (h *CurrencyHandler) CreateCurrency(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateCurrency
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	currency, err := h.currencyService.CreateCurrency(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeCurrencyError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Currency created successfully", currency)
+}
+
+// UpdateCurrency - PUT /api/v1/currencies/{code}
+// @Summary Update a currency
+// @Description Update a currency's rate, name or status; transactions keep the rate they were placed with
+// @Tags Currency
+// @Accept json
+// @Produce json
+// @Param code path string true "Currency code"
+// @Param request body models.CreateCurrency true "Currency"
+// @Success 200 {object} utils.Response{data=models.CurrencyResponse} "Currency updated successfully"
+// @Failure 404 {object} utils.Response "Currency not found"
+// @Router /currencies/{code} [put]
+// @Security Bearer
+func (h *CurrencyHandler) UpdateCurrency(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateCurrency
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.Code = chi.URLParam(r, "code")
+
+	currency, err := h.currencyService.UpdateCurrency(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeCurrencyError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Currency updated successfully", currency)
+}
+
+// DeleteCurrency - DELETE /api/v1/currencies/{code}
+// @Summary Delete a currency
+// @Tags Currency
+// @Produce json
+// @Param code path string true "Currency code"
+// @Success 200 {object} utils.Response "Currency deleted successfully"
+// @Failure 404 {object} utils.Response "Currency not found"
+// @Router /currencies/{code} [delete]
+// @Security Bearer
+func (h *CurrencyHandler) DeleteCurrency(w http.ResponseWriter, r *http.Request) {
+	if err := h.currencyService.DeleteCurrency(middleware.GetActivityContext(r), chi.URLParam(r, "code")); err != nil {
+		writeCurrencyError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Currency deleted successfully", nil)
+}
+
+// ImportRates - POST /api/v1/currencies/import
+// @Summary Import exchange rates
+// @Description Import a CSV of currency codes and their rate in the store currency, optionally with name and symbol. Unknown currencies are added; an invalid line rejects the whole file.
+// @Tags Currency
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Rates file"
+// @Success 200 {object} utils.Response{data=models.ExchangeRateImportResponse} "Exchange rates imported successfully"
+// @Failure 400 {object} utils.Response "Invalid rates file"
+// @Router /currencies/import [post]
+// @Security Bearer
+func (h *CurrencyHandler) ImportRates(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Failed to parse form data", err)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Rates file is required", err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Failed to read rates file", err)
+		return
+	}
+
+	param := models.ImportExchangeRates{
+		Filename: header.Filename,
+		Data:     data,
+	}
+
+	result, err := h.currencyService.ImportRates(middleware.GetActivityContext(r), param)
+	if err != nil {
+		writeCurrencyError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Exchange rates imported successfully", result)
+}
+
+// requestedCurrency resolves the currency a client wants prices in. A
+// currency query parameter must name an available currency. Otherwise the
+// Accept-Currency entries are tried from the highest q-value down, and the
+// store currency is used when none of them is available.
+func requestedCurrency(currencyService services.CurrencyService, r *http.Request) (*models.Currency, error) {
+	if code := strings.TrimSpace(r.URL.Query().Get("currency")); code != "" {
+		return currencyService.Resolve(code)
+	}
+
+	for _, code := range acceptedCurrencies(r.Header.Get("Accept-Currency")) {
+		if currency, err := currencyService.Resolve(code); err == nil {
+			return currency, nil
+		}
+	}
+	return models.StoreCurrencyInfo(), nil
+}
+
+// acceptedCurrencies returns the codes of an Accept-Currency header ordered
+// by q-value, keeping the header order between equal values. Entries with a
+// q-value of 0 or one that does not parse are left out; * stands for the
+// store currency.
+func acceptedCurrencies(header string) []string {
+	type entry struct {
+		code string
+		q    float64
+	}
+
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		code, params, _ := strings.Cut(part, ";")
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				q = 0
+			} else {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		if code == "*" {
+			code = models.StoreCurrency
+		}
+		entries = append(entries, entry{code: code, q: q})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+
+	codes := make([]string, len(entries))
+	for i, e := range entries {
+		codes[i] = e.code
+	}
+	return codes
+}
+
+func writeCurrencyError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "currency not found":
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case errMsg == "currency already exists":
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
@@ -0,0 +1,121 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurrencyHandler_UpdateCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCurrencyService(ctrl)
+	currencyHandler := handler.NewCurrencyHandler(mockService)
+
+	newRequest := func(code string) *http.Request {
+		body := []byte(`{"name":"US Dollar","symbol":"$","rate":16300.5}`)
+		req := httptest.NewRequest(http.MethodPut, "/currencies/"+code, bytes.NewBuffer(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("code", code)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			UpdateCurrency(gomock.Any(), models.CreateCurrency{Code: "USD", Name: "US Dollar", Symbol: "$", Rate: 16300.5}).
+			Return(&models.CurrencyResponse{Code: "USD", Rate: 16300.5, IsActive: true}, nil)
+
+		w := httptest.NewRecorder()
+		currencyHandler.UpdateCurrency(w, newRequest("USD"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().
+			UpdateCurrency(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("currency not found"))
+
+		w := httptest.NewRecorder()
+		currencyHandler.UpdateCurrency(w, newRequest("EUR"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestCurrencyHandler_ImportRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCurrencyService(ctrl)
+	currencyHandler := handler.NewCurrencyHandler(mockService)
+
+	newRequest := func(withFile bool) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		if withFile {
+			part, _ := writer.CreateFormFile("file", "kurs.csv")
+			part.Write([]byte("code,rate\nUSD,16250\nSGD,12600\n"))
+		}
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/currencies/import", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			ImportRates(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ models.ActivityContext, param models.ImportExchangeRates) (*models.ExchangeRateImportResponse, error) {
+				if param.Filename != "kurs.csv" || len(param.Data) == 0 {
+					t.Errorf("Unexpected import request %+v", param)
+				}
+				return &models.ExchangeRateImportResponse{Updated: 2, Codes: []string{"USD", "SGD"}}, nil
+			})
+
+		w := httptest.NewRecorder()
+		currencyHandler.ImportRates(w, newRequest(true))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		currencyHandler.ImportRates(w, newRequest(false))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		mockService.EXPECT().
+			ImportRates(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New(`line 2: invalid rate "abc"`))
+
+		w := httptest.NewRecorder()
+		currencyHandler.ImportRates(w, newRequest(true))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
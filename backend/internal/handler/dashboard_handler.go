@@ -175,6 +175,43 @@ func (h *DashboardHandler) GetLowStockProducts(w http.ResponseWriter, r *http.Re
 	utils.WriteJSON(w, http.StatusOK, "Low stock products retrieved successfully", products)
 }
 
+// GetMostWishedProducts retrieves the products most customers wishlisted
+func (h *DashboardHandler) GetMostWishedProducts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parse days from query parameter
+	daysStr := r.URL.Query().Get("days")
+	days := 30 // default
+	if daysStr != "" {
+		parsedDays, err := strconv.Atoi(daysStr)
+		if err != nil || parsedDays <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid days parameter", err)
+			return
+		}
+		days = parsedDays
+	}
+
+	// Parse limit from query parameter
+	limitStr := r.URL.Query().Get("limit")
+	limit := 10 // default
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid limit parameter", err)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	products, err := h.dashboardService.GetMostWishedProducts(ctx, days, limit)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to get most wished products", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Most wished products retrieved successfully", products)
+}
+
 // GetOrderAnalytics retrieves order analytics
 func (h *DashboardHandler) GetOrderAnalytics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
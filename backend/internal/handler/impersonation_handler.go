@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+)
+
+type ImpersonationHandler struct {
+	impersonationService services.ImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService services.ImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+// Impersonate - POST /api/v1/impersonations
+// @Summary Impersonate a user
+// @Description Issue a short-lived access token to act as a lower ranked user. Super admin only, every request made with it is logged.
+// @Tags Impersonation
+// @Accept json
+// @Produce json
+// @Param request body models.ImpersonationRequest true "Impersonation request"
+// @Success 201 {object} utils.Response{data=models.ImpersonationResponse} "Impersonation started"
+// @Failure 403 {object} utils.Response "Not allowed to impersonate this user"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /impersonations [post]
+// @Security Bearer
+func (h *ImpersonationHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	var req models.ImpersonationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	resp, err := h.impersonationService.Impersonate(middleware.GetActivityContext(r), req)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		switch err.Error() {
+		case "user not found":
+			statusCode = http.StatusNotFound
+		case "only super admins can impersonate users", "cannot impersonate a user with an equal or higher role":
+			statusCode = http.StatusForbidden
+		}
+		utils.WriteError(w, statusCode, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Impersonation started", resp)
+}
@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestImpersonationHandler_Impersonate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockImpersonationService(ctrl)
+	impersonationHandler := handler.NewImpersonationHandler(mockService)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/impersonations", bytes.NewBufferString(`{"user_id":5,"reason":"ticket 42"}`))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, uint(1)))
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			Impersonate(gomock.Any(), models.ImpersonationRequest{UserID: 5, Reason: "ticket 42"}).
+			DoAndReturn(func(actor models.ActivityContext, req models.ImpersonationRequest) (*models.ImpersonationResponse, error) {
+				if actor.UserID != 1 {
+					t.Errorf("Expected actor 1, got %d", actor.UserID)
+				}
+				return &models.ImpersonationResponse{AccessToken: "token", ImpersonatorID: 1}, nil
+			})
+
+		w := httptest.NewRecorder()
+		impersonationHandler.Impersonate(w, newRequest())
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("HigherRole", func(t *testing.T) {
+		mockService.EXPECT().
+			Impersonate(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("cannot impersonate a user with an equal or higher role"))
+
+		w := httptest.NewRecorder()
+		impersonationHandler.Impersonate(w, newRequest())
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		mockService.EXPECT().
+			Impersonate(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("user not found"))
+
+		w := httptest.NewRecorder()
+		impersonationHandler.Impersonate(w, newRequest())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
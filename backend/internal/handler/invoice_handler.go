@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type InvoiceHandler struct {
+	invoiceService services.InvoiceService
+}
+
+func NewInvoiceHandler(invoiceService services.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+	}
+}
+
+// GetMyDocument - GET /api/v1/documents/mine/{tx_id}/{document}
+// @Summary Download my invoice or packing slip
+// @Description Download the invoice or packing slip of one of my paid transactions as a PDF
+// @Tags Document
+// @Produce application/pdf
+// @Param tx_id path string true "Transaction ID"
+// @Param document path string true "invoice or packing_slip"
+// @Success 200 {file} file "PDF document"
+// @Failure 404 {object} utils.Response "Transaction not found"
+// @Failure 409 {object} utils.Response "Transaction not paid"
+// @Router /documents/mine/{tx_id}/{document} [get]
+// @Security Bearer
+func (h *InvoiceHandler) GetMyDocument(w http.ResponseWriter, r *http.Request) {
+	txID := chi.URLParam(r, "tx_id")
+	document := chi.URLParam(r, "document")
+
+	pdf, err := h.invoiceService.RenderMine(document, txID, middleware.GetUserIDFromContext(r))
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	writePDF(w, fmt.Sprintf("%s-%s.pdf", document, txID), pdf)
+}
+
+// GetDocument - GET /api/v1/documents/transaction/{tx_id}/{document}
+// @Summary Download the invoice or packing slip of a transaction
+// @Description Download the invoice or packing slip of a paid transaction as a PDF. The invoice number is issued the first time the invoice is generated.
+// @Tags Document
+// @Produce application/pdf
+// @Param tx_id path string true "Transaction ID"
+// @Param document path string true "invoice or packing_slip"
+// @Success 200 {file} file "PDF document"
+// @Failure 404 {object} utils.Response "Transaction not found"
+// @Failure 409 {object} utils.Response "Transaction not paid"
+// @Router /documents/transaction/{tx_id}/{document} [get]
+// @Security Bearer
+func (h *InvoiceHandler) GetDocument(w http.ResponseWriter, r *http.Request) {
+	txID := chi.URLParam(r, "tx_id")
+	document := chi.URLParam(r, "document")
+
+	pdf, err := h.invoiceService.Render(document, txID)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	writePDF(w, fmt.Sprintf("%s-%s.pdf", document, txID), pdf)
+}
+
+// GetBulkDocuments - POST /api/v1/documents/bulk
+// @Summary Download documents of many transactions
+// @Description Download the invoices or packing slips of up to 100 transactions as one PDF, one transaction per page
+// @Tags Document
+// @Accept json
+// @Produce application/pdf
+// @Param request body models.BulkDocumentRequest true "Transactions and document type"
+// @Success 200 {file} file "PDF document"
+// @Failure 404 {object} utils.Response "Transaction not found"
+// @Failure 409 {object} utils.Response "Transaction not paid"
+// @Router /documents/bulk [post]
+// @Security Bearer
+func (h *InvoiceHandler) GetBulkDocuments(w http.ResponseWriter, r *http.Request) {
+	var input models.BulkDocumentRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	pdf, err := h.invoiceService.RenderBulk(input)
+	if err != nil {
+		writeInvoiceError(w, err)
+		return
+	}
+
+	writePDF(w, fmt.Sprintf("%ss-%s.pdf", input.Document, time.Now().Format("20060102-150405")), pdf)
+}
+
+func writePDF(w http.ResponseWriter, filename string, pdf []byte) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdf)
+}
+
+func writeInvoiceError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "transaction not found":
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case strings.Contains(errMsg, " has no "):
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInvoiceHandler_GetDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockInvoiceService(ctrl)
+	invoiceHandler := handler.NewInvoiceHandler(mockService)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/documents/transaction/TX-1/invoice", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("tx_id", "TX-1")
+		rctx.URLParams.Add("document", "invoice")
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			Render("invoice", "TX-1").
+			Return([]byte("%PDF-1.4"), nil)
+
+		w := httptest.NewRecorder()
+		invoiceHandler.GetDocument(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+		if w.Header().Get("Content-Type") != "application/pdf" {
+			t.Errorf("Expected application/pdf, got %q", w.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(w.Header().Get("Content-Disposition"), "invoice-TX-1.pdf") {
+			t.Errorf("Expected a PDF attachment, got %q", w.Header().Get("Content-Disposition"))
+		}
+	})
+
+	t.Run("NotPaid", func(t *testing.T) {
+		mockService.EXPECT().
+			Render("invoice", "TX-1").
+			Return(nil, errors.New("transaction TX-1 with status waiting_payment has no invoice"))
+
+		w := httptest.NewRecorder()
+		invoiceHandler.GetDocument(w, newRequest())
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+}
+
+func TestInvoiceHandler_GetBulkDocuments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockInvoiceService(ctrl)
+	invoiceHandler := handler.NewInvoiceHandler(mockService)
+
+	newRequest := func() *http.Request {
+		body := []byte(`{"transaction_ids":["TX-1","TX-2"],"document":"packing_slip"}`)
+		return httptest.NewRequest(http.MethodPost, "/documents/bulk", bytes.NewBuffer(body))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			RenderBulk(models.BulkDocumentRequest{TransactionIDs: []string{"TX-1", "TX-2"}, Document: "packing_slip"}).
+			Return([]byte("%PDF-1.4"), nil)
+
+		w := httptest.NewRecorder()
+		invoiceHandler.GetBulkDocuments(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("TransactionNotFound", func(t *testing.T) {
+		mockService.EXPECT().
+			RenderBulk(gomock.Any()).
+			Return(nil, errors.New("transaction not found"))
+
+		w := httptest.NewRecorder()
+		invoiceHandler.GetBulkDocuments(w, newRequest())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+)
+
+type JWKSHandler struct {
+	jwtService *utils.JWTService
+}
+
+func NewJWKSHandler(jwtService *utils.JWTService) *JWKSHandler {
+	return &JWKSHandler{
+		jwtService: jwtService,
+	}
+}
+
+// GetJWKS - GET /.well-known/jwks.json
+// @Summary JSON Web Key Set
+// @Description Public keys other services use to verify access tokens
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} utils.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.jwtService.JWKS())
+}
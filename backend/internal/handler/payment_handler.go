@@ -171,7 +171,7 @@ func (h *PaymentHandler) UpdatePayment(w http.ResponseWriter, r *http.Request) {
 	input.ID = id
 
 	// Validate status
-	validStatuses := []string{"pending", "confirmed", "rejected", "cancelled", "completed", "refunded"}
+	validStatuses := []string{"pending", "success", "failed", "confirmed", "rejected", "cancelled", "completed", "refunded"}
 	isValid := false
 	for _, status := range validStatuses {
 		if input.Status == status {
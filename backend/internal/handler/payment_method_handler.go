@@ -103,14 +103,22 @@ func (h *PaymentMethodHandler) GetPaymentMethodByID(w http.ResponseWriter, r *ht
 
 // CreatePaymentMethod - POST /api/v1/payment-methods
 // @Summary Create a new payment method
-// @Description Create a new payment method with bank details and image
+// @Description Create a bank transfer, virtual account, e-wallet, QRIS or cash on delivery payment method. Each type needs its own fields: bank transfers an account number, virtual accounts a va_prefix, e-wallets a phone number as account number and QRIS the static qris_payload.
 // @Tags Payment Method
 // @Accept multipart/form-data
 // @Produce json
-// @Param account_name formData string true "Account holder name"
-// @Param account_number formData string true "Account number"
-// @Param bank_name formData string true "Bank name"
-// @Param bank_image formData file true "Bank logo/image"
+// @Param type formData string false "bank_transfer (default), virtual_account, ewallet, qris or cod"
+// @Param account_name formData string false "Account holder or merchant name"
+// @Param account_number formData string false "Account number or e-wallet phone number"
+// @Param bank_name formData string false "Bank or e-wallet provider name"
+// @Param va_prefix formData string false "Company code virtual account numbers start with"
+// @Param qris_payload formData string false "Content of the store's static QRIS"
+// @Param fee_flat formData number false "Flat fee charged on top"
+// @Param fee_percent formData number false "Fee charged on top as a percentage of the order"
+// @Param min_amount formData number false "Smallest order the method can pay"
+// @Param max_amount formData number false "Largest order the method can pay, 0 for no limit"
+// @Param instructions formData string false "Extra payment instructions shown at checkout"
+// @Param bank_image formData file false "Bank logo/image, required except for cod"
 // @Success 201 {object} utils.Response{data=models.PaymentMethod} "Payment method created successfully"
 // @Router /payment-methods [post]
 // @Security Bearer
@@ -122,26 +130,45 @@ func (h *PaymentMethodHandler) CreatePaymentMethod(w http.ResponseWriter, r *htt
 		return
 	}
 
-	accountName := r.FormValue("account_name")
-	accountNumber := r.FormValue("account_number")
-	bankName := r.FormValue("bank_name")
+	param := models.CreatePaymentMethod{
+		Type:          r.FormValue("type"),
+		AccountName:   r.FormValue("account_name"),
+		AccountNumber: r.FormValue("account_number"),
+		BankName:      r.FormValue("bank_name"),
+		VAPrefix:      r.FormValue("va_prefix"),
+		QRISPayload:   r.FormValue("qris_payload"),
+		Instructions:  r.FormValue("instructions"),
+	}
 
-	if accountName == "" || accountNumber == "" || bankName == "" {
-		utils.WriteError(w, http.StatusBadRequest, "All fields are required", err)
-		return
+	for name, target := range map[string]*models.Money{
+		"fee_flat":   &param.FeeFlat,
+		"min_amount": &param.MinAmount,
+		"max_amount": &param.MaxAmount,
+	} {
+		value, err := formMoney(r, name)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid "+name, err)
+			return
+		}
+		if value != nil {
+			*target = *value
+		}
 	}
 
-	file, fileHeader, err := r.FormFile("bank_image")
+	feePercent, err := formFloat(r, "fee_percent")
 	if err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "Bank image is required", err)
+		utils.WriteError(w, http.StatusBadRequest, "Invalid fee_percent", err)
 		return
 	}
-	defer file.Close()
+	if feePercent != nil {
+		param.FeePercent = *feePercent
+	}
 
-	param := models.CreatePaymentMethod{
-		AccountName:   accountName,
-		AccountNumber: accountNumber,
-		BankName:      bankName,
+	var fileHeader *multipart.FileHeader
+	file, header, err := r.FormFile("bank_image")
+	if err == nil {
+		defer file.Close()
+		fileHeader = header
 	}
 
 	paymentMethod, err := h.paymentMethodService.CreatePaymentMethod(param, fileHeader)
@@ -186,8 +213,29 @@ func (h *PaymentMethodHandler) UpdatePaymentMethod(w http.ResponseWriter, r *htt
 		AccountName:   accountName,
 		AccountNumber: accountNumber,
 		BankName:      bankName,
+		VAPrefix:      r.FormValue("va_prefix"),
+		QRISPayload:   r.FormValue("qris_payload"),
 		IsActive:      isActive,
 	}
+	if _, ok := r.MultipartForm.Value["instructions"]; ok {
+		instructions := r.FormValue("instructions")
+		param.Instructions = &instructions
+	}
+
+	for name, target := range map[string]**models.Money{
+		"fee_flat":   &param.FeeFlat,
+		"min_amount": &param.MinAmount,
+		"max_amount": &param.MaxAmount,
+	} {
+		if *target, err = formMoney(r, name); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid "+name, err)
+			return
+		}
+	}
+	if param.FeePercent, err = formFloat(r, "fee_percent"); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid fee_percent", err)
+		return
+	}
 
 	var fileHeader *multipart.FileHeader
 	file, header, err := r.FormFile("bank_image")
@@ -230,3 +278,32 @@ func (h *PaymentMethodHandler) DeletePaymentMethod(w http.ResponseWriter, r *htt
 
 	utils.WriteJSON(w, http.StatusOK, "Payment method deleted successfully", nil)
 }
+
+// formFloat reads an optional number from the form, nil when it is not sent.
+// formMoney reads an optional amount from the form; nil means it was not
+// sent.
+func formMoney(r *http.Request, name string) (*models.Money, error) {
+	raw := r.FormValue(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := models.ParseMoney(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func formFloat(r *http.Request, name string) (*float64, error) {
+	raw := r.FormValue(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PaymentProofHandler struct {
+	proofService services.PaymentProofService
+}
+
+func NewPaymentProofHandler(proofService services.PaymentProofService) *PaymentProofHandler {
+	return &PaymentProofHandler{
+		proofService: proofService,
+	}
+}
+
+// SubmitProof - POST /api/v1/payment-proofs
+// @Summary Upload a transfer receipt
+// @Description Upload the receipt of a manual bank transfer for one of my transactions waiting for payment
+// @Tags Payment Proof
+// @Accept multipart/form-data
+// @Produce json
+// @Param transaction_id formData string true "Transaction ID"
+// @Param sender_bank_name formData string true "Bank the money was sent from"
+// @Param sender_account_name formData string true "Name on the sending account"
+// @Param image formData file true "Transfer receipt image"
+// @Success 201 {object} utils.Response{data=models.PaymentProofResponse} "Payment proof uploaded successfully"
+// @Failure 404 {object} utils.Response "Transaction not found"
+// @Failure 409 {object} utils.Response "Transaction not waiting for payment"
+// @Router /payment-proofs [post]
+// @Security Bearer
+func (h *PaymentProofHandler) SubmitProof(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Failed to parse form data", err)
+		return
+	}
+
+	_, image, err := r.FormFile("image")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Transfer receipt image is required", err)
+		return
+	}
+
+	param := models.CreatePaymentProof{
+		TransactionID:     r.FormValue("transaction_id"),
+		SenderBankName:    r.FormValue("sender_bank_name"),
+		SenderAccountName: r.FormValue("sender_account_name"),
+	}
+
+	proof, err := h.proofService.SubmitProof(middleware.GetActivityContext(r), param, image)
+	if err != nil {
+		writePaymentProofError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Payment proof uploaded successfully", proof)
+}
+
+// GetMyProofs - GET /api/v1/payment-proofs/mine
+// @Summary List my payment proofs
+// @Description Get the transfer receipts I uploaded with their review status and rejection reasons, newest first
+// @Tags Payment Proof
+// @Produce json
+// @Param transaction_id query string false "Filter by transaction"
+// @Param status query string false "Filter by status"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.PaymentProofListResponse} "Success"
+// @Router /payment-proofs/mine [get]
+// @Security Bearer
+func (h *PaymentProofHandler) GetMyProofs(w http.ResponseWriter, r *http.Request) {
+	param := paymentProofListRequest(r)
+	param.UserID = middleware.GetUserIDFromContext(r)
+
+	proofs, err := h.proofService.FindAllProof(param)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch payment proofs", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Payment proofs retrieved successfully", proofs)
+}
+
+// GetAllProofs - GET /api/v1/payment-proofs
+// @Summary Payment proof review queue
+// @Description List transfer receipts to review. Without a status filter pending proofs are listed, oldest first.
+// @Tags Payment Proof
+// @Produce json
+// @Param transaction_id query string false "Filter by transaction"
+// @Param status query string false "pending (default), approved, rejected or all"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.PaymentProofListResponse} "Success"
+// @Router /payment-proofs [get]
+// @Security Bearer
+func (h *PaymentProofHandler) GetAllProofs(w http.ResponseWriter, r *http.Request) {
+	param := paymentProofListRequest(r)
+	switch param.Status {
+	case "":
+		param.Status = "pending"
+	case "all":
+		param.Status = ""
+	}
+
+	proofs, err := h.proofService.FindAllProof(param)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch payment proofs", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Payment proofs retrieved successfully", proofs)
+}
+
+// GetProofByID - GET /api/v1/payment-proofs/{id}
+// @Summary Get payment proof by ID
+// @Description Get a transfer receipt with its review status
+// @Tags Payment Proof
+// @Produce json
+// @Param id path int true "Payment proof ID"
+// @Success 200 {object} utils.Response{data=models.PaymentProofResponse} "Success"
+// @Failure 404 {object} utils.Response "Payment proof not found"
+// @Router /payment-proofs/{id} [get]
+// @Security Bearer
+func (h *PaymentProofHandler) GetProofByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := paymentProofID(w, r)
+	if !ok {
+		return
+	}
+
+	proof, err := h.proofService.FindById(id)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Payment proof retrieved successfully", proof)
+}
+
+// ApproveProof - PATCH /api/v1/payment-proofs/{id}/approve
+// @Summary Approve a payment proof
+// @Description Accept a transfer receipt, confirming its payment and transaction
+// @Tags Payment Proof
+// @Produce json
+// @Param id path int true "Payment proof ID"
+// @Success 200 {object} utils.Response{data=models.PaymentProofResponse} "Payment proof approved successfully"
+// @Failure 404 {object} utils.Response "Payment proof not found"
+// @Failure 409 {object} utils.Response "Payment proof already reviewed"
+// @Router /payment-proofs/{id}/approve [patch]
+// @Security Bearer
+func (h *PaymentProofHandler) ApproveProof(w http.ResponseWriter, r *http.Request) {
+	id, ok := paymentProofID(w, r)
+	if !ok {
+		return
+	}
+
+	proof, err := h.proofService.ApproveProof(middleware.GetActivityContext(r), id)
+	if err != nil {
+		writePaymentProofError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Payment proof approved successfully", proof)
+}
+
+// RejectProof - PATCH /api/v1/payment-proofs/{id}/reject
+// @Summary Reject a payment proof
+// @Description Reject a transfer receipt with a reason shown to the customer, who may then upload a new one
+// @Tags Payment Proof
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment proof ID"
+// @Param request body models.RejectPaymentProof true "Rejection reason"
+// @Success 200 {object} utils.Response{data=models.PaymentProofResponse} "Payment proof rejected successfully"
+// @Failure 404 {object} utils.Response "Payment proof not found"
+// @Failure 409 {object} utils.Response "Payment proof already reviewed"
+// @Router /payment-proofs/{id}/reject [patch]
+// @Security Bearer
+func (h *PaymentProofHandler) RejectProof(w http.ResponseWriter, r *http.Request) {
+	id, ok := paymentProofID(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.RejectPaymentProof
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ID = id
+
+	proof, err := h.proofService.RejectProof(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writePaymentProofError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Payment proof rejected successfully", proof)
+}
+
+func paymentProofID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid payment proof ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+func paymentProofListRequest(r *http.Request) models.PaymentProofListRequest {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	return models.PaymentProofListRequest{
+		TransactionID: query.Get("transaction_id"),
+		Status:        query.Get("status"),
+		Page:          page,
+		Limit:         limit,
+	}
+}
+
+func writePaymentProofError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "transaction not found", errMsg == "payment not found", errMsg == "payment proof not found":
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case strings.HasSuffix(errMsg, "is not waiting for payment"),
+		strings.HasPrefix(errMsg, "payment proof has already been"),
+		errMsg == "a payment proof is already waiting for review":
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
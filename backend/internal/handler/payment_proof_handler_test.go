@@ -0,0 +1,119 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPaymentProofHandler_SubmitProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPaymentProofService(ctrl)
+	proofHandler := handler.NewPaymentProofHandler(mockService)
+
+	newRequest := func(withImage bool) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("transaction_id", "TX-1")
+		writer.WriteField("sender_bank_name", "BCA")
+		writer.WriteField("sender_account_name", "Budi Santoso")
+		if withImage {
+			part, _ := writer.CreateFormFile("image", "receipt.jpg")
+			part.Write([]byte("jpeg"))
+		}
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/payment-proofs", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			SubmitProof(gomock.Any(), models.CreatePaymentProof{TransactionID: "TX-1", SenderBankName: "BCA", SenderAccountName: "Budi Santoso"}, gomock.Not(gomock.Nil())).
+			Return(&models.PaymentProofResponse{ID: 1, Status: "pending"}, nil)
+
+		w := httptest.NewRecorder()
+		proofHandler.SubmitProof(w, newRequest(true))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("MissingImage", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		proofHandler.SubmitProof(w, newRequest(false))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotWaitingForPayment", func(t *testing.T) {
+		mockService.EXPECT().
+			SubmitProof(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("transaction with status confirmed is not waiting for payment"))
+
+		w := httptest.NewRecorder()
+		proofHandler.SubmitProof(w, newRequest(true))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+}
+
+func TestPaymentProofHandler_RejectProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPaymentProofService(ctrl)
+	proofHandler := handler.NewPaymentProofHandler(mockService)
+
+	newRequest := func() *http.Request {
+		body := []byte(`{"reason":"Amount does not match"}`)
+		req := httptest.NewRequest(http.MethodPatch, "/payment-proofs/1/reject", bytes.NewBuffer(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "1")
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			RejectProof(gomock.Any(), models.RejectPaymentProof{ID: 1, Reason: "Amount does not match"}).
+			Return(&models.PaymentProofResponse{ID: 1, Status: "rejected", RejectionReason: "Amount does not match"}, nil)
+
+		w := httptest.NewRecorder()
+		proofHandler.RejectProof(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("AlreadyReviewed", func(t *testing.T) {
+		mockService.EXPECT().
+			RejectProof(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("payment proof has already been approved"))
+
+		w := httptest.NewRecorder()
+		proofHandler.RejectProof(w, newRequest())
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+}
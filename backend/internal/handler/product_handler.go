@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"e-commerce/backend/internal/middleware"
 	"e-commerce/backend/internal/models"
 	"e-commerce/backend/internal/services"
 	"e-commerce/backend/internal/utils"
@@ -8,17 +9,22 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type ProductHandler struct {
-	productService services.ProductService
+	productService  services.ProductService
+	currencyService services.CurrencyService
+	wishlistService services.WishlistService
 }
 
-func NewProductHandler(productService services.ProductService) *ProductHandler {
+func NewProductHandler(productService services.ProductService, currencyService services.CurrencyService, wishlistService services.WishlistService) *ProductHandler {
 	return &ProductHandler{
-		productService: productService,
+		productService:  productService,
+		currencyService: currencyService,
+		wishlistService: wishlistService,
 	}
 }
 
@@ -32,6 +38,11 @@ func NewProductHandler(productService services.ProductService) *ProductHandler {
 // @Param description formData string true "Product description"
 // @Param price formData number true "Product price"
 // @Param category_id formData int true "Category ID"
+// @Param tax_class_id formData int false "Tax class ID, overrides the category's"
+// @Param weight_grams formData int false "Packed weight in grams"
+// @Param length_cm formData number false "Packed length in centimetres"
+// @Param width_cm formData number false "Packed width in centimetres"
+// @Param height_cm formData number false "Packed height in centimetres"
 // @Param image formData file true "Main product image"
 // @Param color_varian formData string true "JSON string of color variants"
 // @Success 201 {object} utils.Response{data=models.Product} "Product created successfully"
@@ -49,12 +60,28 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	price, err := strconv.ParseFloat(r.FormValue("price"), 64)
+	price, err := models.ParseMoney(r.FormValue("price"))
 	if err != nil {
 		utils.WriteError(w, http.StatusBadRequest, "Price tidak valid", err)
 		return
 	}
 
+	var taxClassID *int64
+	if taxStr := r.FormValue("tax_class_id"); taxStr != "" {
+		id, err := strconv.ParseInt(taxStr, 10, 64)
+		if err != nil || id <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "Tax class ID tidak valid", err)
+			return
+		}
+		taxClassID = &id
+	}
+
+	dimensions, err := parseProductDimensions(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Dimensi produk tidak valid", err)
+		return
+	}
+
 	_, mainImageHeader, err := r.FormFile("image")
 	if err != nil {
 		utils.WriteError(w, http.StatusBadRequest, "Gambar produk wajib diisi", err)
@@ -88,13 +115,15 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		Name:        r.FormValue("name"),
 		Description: r.FormValue("description"),
 		Price:       price,
+		TaxClassID:  taxClassID,
+		Dimensions:  dimensions,
 		Image:       mainImageHeader,
 		ColorVarian: colorVariants,
 	}
 
 	result, err := h.productService.CreateProduct(param)
 	if err != nil {
-		utils.WriteError(w, http.StatusInternalServerError, "Gagal membuat produk", err)
+		writeProductError(w, "Gagal membuat produk", err)
 		return
 	}
 
@@ -108,7 +137,9 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
+// @Param currency query string false "Currency to show the price in, e.g. USD; also read from the Accept-Currency header"
 // @Success 200 {object} utils.Response{data=models.Product} "Product found"
+// @Failure 400 {object} utils.Response "Currency not available"
 // @Failure 404 {object} utils.Response "Product not found"
 // @Router /products/{id} [get]
 func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
@@ -118,11 +149,19 @@ func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	currency, err := requestedCurrency(h.currencyService, r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
 	result, err := h.productService.FindProductById(id)
 	if err != nil {
 		utils.WriteError(w, http.StatusNotFound, "Produk tidak ditemukan", err)
 		return
 	}
+	result.InCurrency(currency)
+	result.IsWishlisted = h.wishlisted(r, []int64{result.ID})[result.ID]
 
 	utils.WriteJSON(w, http.StatusOK, "Produk ditemukan", result)
 }
@@ -138,9 +177,17 @@ func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request)
 // @Param search query string false "Search by name"
 // @Param category_id query int false "Filter by category"
 // @Param sort_by query string false "Sort by field" default(created_at)
+// @Param currency query string false "Currency to show prices in, e.g. USD; also read from the Accept-Currency header"
 // @Success 200 {object} utils.Response{data=[]models.Product} "Data produk berhasil diambil"
+// @Failure 400 {object} utils.Response "Currency not available"
 // @Router /products [get]
 func (h *ProductHandler) GetAllProducts(w http.ResponseWriter, r *http.Request) {
+	currency, err := requestedCurrency(h.currencyService, r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	if page < 1 {
 		page = 1
@@ -168,19 +215,50 @@ func (h *ProductHandler) GetAllProducts(w http.ResponseWriter, r *http.Request)
 		Limit:      limit,
 		Search:     r.URL.Query().Get("search"),
 		CategoryID: categoryID,
+		// category memfilter berdasarkan slug kategori
+		CategorySlug: strings.ToLower(r.URL.Query().Get("category")),
 
 		SortBy: sortBy,
 	}
 
 	result, err := h.productService.FindAllProduct(param)
 	if err != nil {
-		utils.WriteError(w, http.StatusInternalServerError, "Gagal mengambil data produk", err)
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "tidak ditemukan") {
+			statusCode = http.StatusNotFound
+		}
+		utils.WriteError(w, statusCode, "Gagal mengambil data produk", err)
 		return
 	}
+	productIDs := make([]int64, len(*result))
+	for i := range *result {
+		productIDs[i] = (*result)[i].ID
+	}
+	wishlisted := h.wishlisted(r, productIDs)
+	for i := range *result {
+		(*result)[i].InCurrency(currency)
+		(*result)[i].IsWishlisted = wishlisted[(*result)[i].ID]
+	}
 
 	utils.WriteJSON(w, http.StatusOK, "Data produk berhasil diambil", result)
 }
 
+// wishlisted returns which of the products the signed-in user has in their
+// wishlist. Anonymous visitors have none, and a failed lookup only hides
+// the indicator.
+func (h *ProductHandler) wishlisted(r *http.Request, productIDs []int64) map[int64]bool {
+	userID, ok := r.Context().Value(middleware.UserIDContextKey).(uint)
+	if !ok || userID == 0 {
+		return nil
+	}
+
+	wishlisted, err := h.wishlistService.WishlistedProducts(userID, productIDs)
+	if err != nil {
+		return nil
+	}
+	return wishlisted
+}
+
 func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
@@ -214,7 +292,7 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if priceStr := r.FormValue("price"); priceStr != "" {
-		price, err := strconv.ParseFloat(priceStr, 64)
+		price, err := models.ParseMoney(priceStr)
 		if err == nil {
 			param.Price = &price
 		}
@@ -227,6 +305,24 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if taxStr := r.FormValue("tax_class_id"); taxStr != "" {
+		taxClassID, err := strconv.ParseInt(taxStr, 10, 64)
+		if err == nil && taxClassID > 0 {
+			param.TaxClassID = &taxClassID
+		}
+	}
+
+	if weightStr := r.FormValue("weight_grams"); weightStr != "" {
+		weight, err := strconv.ParseInt(weightStr, 10, 64)
+		if err == nil && weight >= 0 {
+			param.WeightGrams = &weight
+		}
+	}
+
+	param.LengthCm = parseOptionalDimension(r.FormValue("length_cm"))
+	param.WidthCm = parseOptionalDimension(r.FormValue("width_cm"))
+	param.HeightCm = parseOptionalDimension(r.FormValue("height_cm"))
+
 	// Get main image file header if exists
 	if _, fileHeader, err := r.FormFile("image"); err == nil {
 		param.Image = fileHeader
@@ -261,7 +357,7 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.productService.UpdateProduct(param)
 	if err != nil {
-		utils.WriteError(w, http.StatusInternalServerError, "Gagal update produk", err)
+		writeProductError(w, "Gagal update produk", err)
 		return
 	}
 
@@ -322,9 +418,121 @@ func (h *ProductHandler) AddColorVariant(w http.ResponseWriter, r *http.Request)
 
 	result, err := h.productService.AddColorVarianProduct(id, param)
 	if err != nil {
-		utils.WriteError(w, http.StatusInternalServerError, "Gagal menambahkan color variant", err)
+		writeProductError(w, "Gagal menambahkan color variant", err)
 		return
 	}
 
 	utils.WriteJSON(w, http.StatusOK, "Color variant berhasil ditambahkan", result)
 }
+
+// GetSizeVarianBySKU - GET /api/v1/products/sku/{sku}
+// @Summary Find a size variant by SKU
+// @Description Find the product, color and size with a stock-keeping unit, for warehouse staff
+// @Tags Product
+// @Produce json
+// @Param sku path string true "SKU, case-insensitive"
+// @Success 200 {object} utils.Response{data=models.SizeVarianLookupResponse} "Size variant found"
+// @Failure 400 {object} utils.Response "Invalid SKU"
+// @Failure 404 {object} utils.Response "No size variant has the SKU"
+// @Router /products/sku/{sku} [get]
+// @Security Bearer
+func (h *ProductHandler) GetSizeVarianBySKU(w http.ResponseWriter, r *http.Request) {
+	result, err := h.productService.FindSizeVarianBySKU(chi.URLParam(r, "sku"))
+	if err != nil {
+		writeSizeVarianLookupError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Size variant ditemukan", result)
+}
+
+// GetSizeVarianByBarcode - GET /api/v1/products/barcode/{barcode}
+// @Summary Find a size variant by barcode
+// @Description Find the product, color and size with an EAN-8, UPC-A, EAN-13 or GTIN-14 barcode, for scanners
+// @Tags Product
+// @Produce json
+// @Param barcode path string true "Barcode digits"
+// @Success 200 {object} utils.Response{data=models.SizeVarianLookupResponse} "Size variant found"
+// @Failure 400 {object} utils.Response "Invalid barcode"
+// @Failure 404 {object} utils.Response "No size variant has the barcode"
+// @Router /products/barcode/{barcode} [get]
+// @Security Bearer
+func (h *ProductHandler) GetSizeVarianByBarcode(w http.ResponseWriter, r *http.Request) {
+	result, err := h.productService.FindSizeVarianByBarcode(chi.URLParam(r, "barcode"))
+	if err != nil {
+		writeSizeVarianLookupError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Size variant ditemukan", result)
+}
+
+func writeSizeVarianLookupError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case strings.HasSuffix(errMsg, "tidak ditemukan"):
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case strings.Contains(errMsg, "tidak valid"):
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusInternalServerError, "Gagal mencari size variant", err)
+	}
+}
+
+// writeProductError reports SKU and barcode conflicts and invalid size
+// codes or prices to the client; anything else is a server error.
+func writeProductError(w http.ResponseWriter, message string, err error) {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "sudah dipakai"):
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.Contains(errMsg, "tidak valid"):
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusInternalServerError, message, err)
+	}
+}
+
+// parseProductDimensions reads the optional weight and size form fields of a
+// new product. Missing fields are zero.
+func parseProductDimensions(r *http.Request) (models.ProductDimensions, error) {
+	var dimensions models.ProductDimensions
+	if weightStr := r.FormValue("weight_grams"); weightStr != "" {
+		weight, err := strconv.ParseInt(weightStr, 10, 64)
+		if err != nil || weight < 0 {
+			return dimensions, fmt.Errorf("invalid weight_grams %q", weightStr)
+		}
+		dimensions.WeightGrams = weight
+	}
+
+	fields := map[string]*float64{
+		"length_cm": &dimensions.LengthCm,
+		"width_cm":  &dimensions.WidthCm,
+		"height_cm": &dimensions.HeightCm,
+	}
+	for name, target := range fields {
+		value := r.FormValue(name)
+		if value == "" {
+			continue
+		}
+		size, err := strconv.ParseFloat(value, 64)
+		if err != nil || size < 0 {
+			return dimensions, fmt.Errorf("invalid %s %q", name, value)
+		}
+		*target = size
+	}
+	return dimensions, nil
+}
+
+// parseOptionalDimension parses a size field of a product update, ignoring
+// empty and invalid values like the other optional fields.
+func parseOptionalDimension(value string) *float64 {
+	if value == "" {
+		return nil
+	}
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil || size < 0 {
+		return nil
+	}
+	return &size
+}
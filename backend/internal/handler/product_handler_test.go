@@ -2,14 +2,19 @@ package handler_test
 
 import (
 	"context"
+	"e-commerce/backend/internal/config"
 	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
 	"e-commerce/backend/internal/mocks"
 	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/mock/gomock"
@@ -20,7 +25,11 @@ func TestProductHandler_GetProductByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockProductService(ctrl)
-	productHandler := handler.NewProductHandler(mockService)
+	mockCurrencyService := mocks.NewMockCurrencyService(ctrl)
+	mockWishlistService := mocks.NewMockWishlistService(ctrl)
+	productHandler := handler.NewProductHandler(mockService, mockCurrencyService, mockWishlistService)
+
+	mockCurrencyService.EXPECT().Resolve("").Return(models.StoreCurrencyInfo(), nil).AnyTimes()
 
 	t.Run("Success", func(t *testing.T) {
 		productID := int64(123)
@@ -28,7 +37,7 @@ func TestProductHandler_GetProductByID(t *testing.T) {
 		expectedProduct := models.ProductDetailResponse{
 			ID: productID,
 			Name: "Test Product",
-			Price: 10000,
+			Price: models.Rupiah(10000),
 		}
 
 		mockService.EXPECT().FindProductById(productID).Return(&expectedProduct, nil)
@@ -65,4 +74,325 @@ func TestProductHandler_GetProductByID(t *testing.T) {
 			t.Errorf("Expected status 404, got %d", w.Code)
 		}
 	})
+
+	t.Run("InCurrency", func(t *testing.T) {
+		productID := int64(123)
+		usd := &models.Currency{Code: "USD", Rate: 16000, IsActive: true}
+
+		mockCurrencyService.EXPECT().Resolve("USD").Return(usd, nil)
+		mockService.EXPECT().FindProductById(productID).Return(&models.ProductDetailResponse{
+			ID:       productID,
+			Price:    models.Rupiah(200000),
+			Currency: models.StoreCurrency,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/products/"+strconv.FormatInt(productID, 10), nil)
+		req.Header.Set("Accept-Currency", "USD, SGD;q=0.5")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(productID, 10))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+
+		productHandler.GetProductByID(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		body := w.Body.String()
+		for _, want := range []string{`"price":12.5`, `"currency":"USD"`, `"store_price":200000`, `"exchange_rate":16000`} {
+			if !strings.Contains(body, want) {
+				t.Errorf("Expected %s in response, got %s", want, body)
+			}
+		}
+	})
+
+	t.Run("UnknownCurrency", func(t *testing.T) {
+		mockCurrencyService.EXPECT().Resolve("XYZ").Return(nil, errors.New("currency XYZ is not available"))
+
+		req := httptest.NewRequest(http.MethodGet, "/products/123?currency=XYZ", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "123")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+
+		productHandler.GetProductByID(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("AcceptCurrencyFallback", func(t *testing.T) {
+		productID := int64(123)
+		usd := &models.Currency{Code: "USD", Rate: 16000, IsActive: true}
+
+		// SGD ranks below USD despite coming first; EUR is not enabled
+		gomock.InOrder(
+			mockCurrencyService.EXPECT().Resolve("EUR").Return(nil, errors.New("currency EUR is not available")),
+			mockCurrencyService.EXPECT().Resolve("USD").Return(usd, nil),
+		)
+		mockService.EXPECT().FindProductById(productID).Return(&models.ProductDetailResponse{
+			ID:       productID,
+			Price:    models.Rupiah(200000),
+			Currency: models.StoreCurrency,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/products/"+strconv.FormatInt(productID, 10), nil)
+		req.Header.Set("Accept-Currency", "SGD;q=0.2, EUR, USD;q=0.5, JPY;q=0")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(productID, 10))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+
+		productHandler.GetProductByID(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"currency":"USD"`) {
+			t.Errorf("Expected prices in USD, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("AcceptCurrencyUnavailable", func(t *testing.T) {
+		productID := int64(123)
+
+		mockCurrencyService.EXPECT().Resolve("EUR").Return(nil, errors.New("currency EUR is not available"))
+		mockService.EXPECT().FindProductById(productID).Return(&models.ProductDetailResponse{
+			ID:       productID,
+			Price:    models.Rupiah(200000),
+			Currency: models.StoreCurrency,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/products/"+strconv.FormatInt(productID, 10), nil)
+		req.Header.Set("Accept-Currency", "EUR")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(productID, 10))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+
+		productHandler.GetProductByID(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"currency":"IDR"`) {
+			t.Errorf("Expected prices in the store currency, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("Wishlisted", func(t *testing.T) {
+		productID := int64(123)
+
+		mockService.EXPECT().FindProductById(productID).Return(&models.ProductDetailResponse{ID: productID}, nil)
+		mockWishlistService.EXPECT().WishlistedProducts(uint(7), []int64{productID}).Return(map[int64]bool{productID: true}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/products/"+strconv.FormatInt(productID, 10), nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(productID, 10))
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, middleware.UserIDContextKey, uint(7))
+		req = req.WithContext(ctx)
+
+		w := httptest.NewRecorder()
+
+		productHandler.GetProductByID(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"is_wishlisted":true`) {
+			t.Errorf("Expected product to be wishlisted, got %s", w.Body.String())
+		}
+	})
+}
+
+func TestProductHandler_GetSizeVarianBySKU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductService(ctrl)
+	productHandler := handler.NewProductHandler(mockService, mocks.NewMockCurrencyService(ctrl), mocks.NewMockWishlistService(ctrl))
+
+	newRequest := func(sku string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/products/sku/"+sku, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("sku", sku)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		sku := "TS-001-XL"
+		mockService.EXPECT().FindSizeVarianBySKU("ts-001-xl").Return(&models.SizeVarianLookupResponse{
+			ProductID:  12,
+			SizeVarian: models.SizeVarianResponse{ID: 9, SKU: &sku, Price: models.Rupiah(120000), PriceOverride: true},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		productHandler.GetSizeVarianBySKU(w, newRequest("ts-001-xl"))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"sku":"TS-001-XL"`) {
+			t.Errorf("Expected the SKU in the response, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().FindSizeVarianBySKU("NOPE").Return(nil, errors.New("size variant dengan SKU NOPE tidak ditemukan"))
+
+		w := httptest.NewRecorder()
+		productHandler.GetSizeVarianBySKU(w, newRequest("NOPE"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestProductHandler_GetSizeVarianByBarcode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductService(ctrl)
+	productHandler := handler.NewProductHandler(mockService, mocks.NewMockCurrencyService(ctrl), mocks.NewMockWishlistService(ctrl))
+
+	req := httptest.NewRequest(http.MethodGet, "/products/barcode/8992761166017", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("barcode", "8992761166017")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	mockService.EXPECT().FindSizeVarianByBarcode("8992761166017").
+		Return(nil, errors.New("barcode tidak valid: barcode check digit is wrong"))
+
+	w := httptest.NewRecorder()
+	productHandler.GetSizeVarianByBarcode(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestProductHandler_GetAllProducts_OptionalAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductService(ctrl)
+	mockCurrencyService := mocks.NewMockCurrencyService(ctrl)
+	mockWishlistService := mocks.NewMockWishlistService(ctrl)
+	mockUserService := mocks.NewMockUserService(ctrl)
+	productHandler := handler.NewProductHandler(mockService, mockCurrencyService, mockWishlistService)
+
+	cfg := &config.Config{JWT: config.JWTConfig{Algorithm: "HS256", Secret: "test", AccessTokenExpiry: time.Hour, RefreshSecret: "refresh", RefreshTokenExpiry: time.Hour}}
+	jwtService := utils.NewJWTService(cfg)
+	h := middleware.OptionalAuthMiddleware(mockUserService, jwtService)(http.HandlerFunc(productHandler.GetAllProducts))
+
+	mockCurrencyService.EXPECT().Resolve("").Return(models.StoreCurrencyInfo(), nil).AnyTimes()
+	products := []models.ProductResponse{{ID: 12, Name: "Kaos Polos"}}
+	mockService.EXPECT().FindAllProduct(gomock.Any()).DoAndReturn(func(models.ProductListRequest) (*[]models.ProductResponse, error) {
+		result := append([]models.ProductResponse(nil), products...)
+		return &result, nil
+	}).AnyTimes()
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"is_wishlisted":false`) {
+			t.Errorf("Expected product not to be wishlisted, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("InvalidCookie", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.AddCookie(&http.Cookie{Name: utils.AccessTokenCookie, Value: "expired"})
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		token, _, err := jwtService.GenerateAccessToken(&models.User{ID: 7, Email: "gone@example.com", RoleID: 2})
+		if err != nil {
+			t.Fatalf("GenerateAccessToken failed: %v", err)
+		}
+		mockUserService.EXPECT().GetUserById(uint(7)).Return(nil, errors.New("user not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("ValidToken", func(t *testing.T) {
+		user := &models.User{ID: 7, Email: "user@example.com", RoleID: 2, IsActive: true}
+		token, _, err := jwtService.GenerateAccessToken(user)
+		if err != nil {
+			t.Fatalf("GenerateAccessToken failed: %v", err)
+		}
+		mockUserService.EXPECT().GetUserById(uint(7)).Return(user, nil)
+		mockWishlistService.EXPECT().WishlistedProducts(uint(7), []int64{12}).Return(map[int64]bool{12: true}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"is_wishlisted":true`) {
+			t.Errorf("Expected product to be wishlisted, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("Impersonated", func(t *testing.T) {
+		user := &models.User{ID: 7, Email: "user@example.com", RoleID: 2, IsActive: true}
+		token, _, err := jwtService.GenerateImpersonationToken(user, 1)
+		if err != nil {
+			t.Fatalf("GenerateImpersonationToken failed: %v", err)
+		}
+		mockUserService.EXPECT().GetUserById(uint(7)).Return(user, nil)
+		mockWishlistService.EXPECT().WishlistedProducts(uint(7), []int64{12}).Return(map[int64]bool{12: true}, nil)
+		mockUserService.EXPECT().RecordActivity(gomock.Any()).DoAndReturn(func(activity models.ActivityLog) error {
+			if activity.UserID != 7 || activity.ImpersonatorID == nil || *activity.ImpersonatorID != 1 || activity.Action != "impersonated_request" {
+				t.Errorf("Unexpected activity %+v", activity)
+			}
+			return nil
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if w.Header().Get("X-Impersonated-By") != "1" {
+			t.Errorf("Expected X-Impersonated-By header, got %q", w.Header().Get("X-Impersonated-By"))
+		}
+	})
 }
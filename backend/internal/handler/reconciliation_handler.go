@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService services.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService services.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// ImportStatement - POST /api/v1/bank-statements/import
+// @Summary Import a bank statement
+// @Description Import a CSV or MT940 statement of a store bank account. Credits are matched to transactions waiting for payment by their ID or unique payable amount and marked paid; the others are listed as unmatched.
+// @Tags Reconciliation
+// @Accept multipart/form-data
+// @Produce json
+// @Param payment_method_id formData int true "Payment method (bank account) the statement belongs to"
+// @Param format formData string false "csv or mt940, detected from the file when empty"
+// @Param file formData file true "Statement file"
+// @Success 201 {object} utils.Response{data=models.BankStatementImportResponse} "Bank statement imported successfully"
+// @Failure 400 {object} utils.Response "Invalid statement"
+// @Failure 404 {object} utils.Response "Payment method not found"
+// @Router /bank-statements/import [post]
+// @Security Bearer
+func (h *ReconciliationHandler) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Failed to parse form data", err)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Statement file is required", err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Failed to read statement file", err)
+		return
+	}
+
+	paymentMethodID, _ := strconv.ParseInt(r.FormValue("payment_method_id"), 10, 64)
+	param := models.ImportBankStatement{
+		PaymentMethodID: paymentMethodID,
+		Format:          r.FormValue("format"),
+		Filename:        header.Filename,
+		Data:            data,
+	}
+
+	result, err := h.reconciliationService.ImportStatement(middleware.GetActivityContext(r), param)
+	if err != nil {
+		writeReconciliationError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Bank statement imported successfully", result)
+}
+
+// GetLines - GET /api/v1/bank-statements/lines
+// @Summary List bank statement lines
+// @Description List imported statement credits. Without a status filter unmatched lines are listed, oldest first.
+// @Tags Reconciliation
+// @Produce json
+// @Param statement_id query int false "Filter by statement"
+// @Param status query string false "unmatched (default), matched, resolved, ignored or all"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.StatementLineListResponse} "Success"
+// @Router /bank-statements/lines [get]
+// @Security Bearer
+func (h *ReconciliationHandler) GetLines(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	statementID, _ := strconv.ParseInt(query.Get("statement_id"), 10, 64)
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	param := models.StatementLineListRequest{
+		StatementID: statementID,
+		Status:      query.Get("status"),
+		Page:        page,
+		Limit:       limit,
+	}
+	switch param.Status {
+	case "":
+		param.Status = "unmatched"
+	case "all":
+		param.Status = ""
+	}
+
+	lines, err := h.reconciliationService.FindAllLines(param)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch statement lines", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Statement lines retrieved successfully", lines)
+}
+
+// ResolveLine - PATCH /api/v1/bank-statements/lines/{id}/resolve
+// @Summary Resolve an unmatched statement line
+// @Description Match an unmatched credit to the transaction it pays, marking it paid, or ignore it with a note
+// @Tags Reconciliation
+// @Accept json
+// @Produce json
+// @Param id path int true "Statement line ID"
+// @Param request body models.ResolveStatementLine true "Resolution"
+// @Success 200 {object} utils.Response{data=models.BankStatementLine} "Statement line resolved successfully"
+// @Failure 404 {object} utils.Response "Statement line or transaction not found"
+// @Failure 409 {object} utils.Response "Statement line already resolved"
+// @Router /bank-statements/lines/{id}/resolve [patch]
+// @Security Bearer
+func (h *ReconciliationHandler) ResolveLine(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid statement line ID", err)
+		return
+	}
+
+	var input models.ResolveStatementLine
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ID = id
+
+	line, err := h.reconciliationService.ResolveLine(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeReconciliationError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Statement line resolved successfully", line)
+}
+
+func writeReconciliationError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case strings.HasSuffix(errMsg, "not found"):
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case strings.HasSuffix(errMsg, "is not waiting for payment"),
+		strings.HasPrefix(errMsg, "statement line has already been"):
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
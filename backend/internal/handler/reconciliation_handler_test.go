@@ -0,0 +1,149 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconciliationHandler_ImportStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReconciliationService(ctrl)
+	reconciliationHandler := handler.NewReconciliationHandler(mockService)
+
+	newRequest := func(withFile bool) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("payment_method_id", "2")
+		if withFile {
+			part, _ := writer.CreateFormFile("file", "mutasi.csv")
+			part.Write([]byte("Tanggal,Keterangan,Jumlah\n19/10/2026,TRX-AB12CD34EF,150123\n"))
+		}
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/bank-statements/import", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			ImportStatement(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ models.ActivityContext, param models.ImportBankStatement) (*models.BankStatementImportResponse, error) {
+				if param.PaymentMethodID != 2 || param.Filename != "mutasi.csv" || len(param.Data) == 0 {
+					t.Errorf("Unexpected import request %+v", param)
+				}
+				return &models.BankStatementImportResponse{Imported: 1, Matched: 1}, nil
+			})
+
+		w := httptest.NewRecorder()
+		reconciliationHandler.ImportStatement(w, newRequest(true))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reconciliationHandler.ImportStatement(w, newRequest(false))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("PaymentMethodNotFound", func(t *testing.T) {
+		mockService.EXPECT().
+			ImportStatement(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("payment method not found"))
+
+		w := httptest.NewRecorder()
+		reconciliationHandler.ImportStatement(w, newRequest(true))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestReconciliationHandler_GetLines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReconciliationService(ctrl)
+	reconciliationHandler := handler.NewReconciliationHandler(mockService)
+
+	mockService.EXPECT().
+		FindAllLines(models.StatementLineListRequest{Status: "unmatched"}).
+		Return(&models.StatementLineListResponse{}, nil)
+
+	w := httptest.NewRecorder()
+	reconciliationHandler.GetLines(w, httptest.NewRequest(http.MethodGet, "/bank-statements/lines", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+}
+
+func TestReconciliationHandler_ResolveLine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReconciliationService(ctrl)
+	reconciliationHandler := handler.NewReconciliationHandler(mockService)
+
+	newRequest := func(id, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/bank-statements/lines/"+id+"/resolve", bytes.NewBufferString(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			ResolveLine(gomock.Any(), models.ResolveStatementLine{ID: 4, Action: "match", TransactionID: "TRX-AB12CD34EF"}).
+			Return(&models.BankStatementLine{ID: 4, Status: "resolved"}, nil)
+
+		w := httptest.NewRecorder()
+		reconciliationHandler.ResolveLine(w, newRequest("4", `{"action":"match","transaction_id":"TRX-AB12CD34EF"}`))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("AlreadyResolved", func(t *testing.T) {
+		mockService.EXPECT().
+			ResolveLine(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("statement line has already been matched"))
+
+		w := httptest.NewRecorder()
+		reconciliationHandler.ResolveLine(w, newRequest("4", `{"action":"ignore","note":"interest"}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		reconciliationHandler.ResolveLine(w, newRequest("abc", `{}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
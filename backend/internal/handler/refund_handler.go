@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type RefundHandler struct {
+	refundService services.RefundService
+}
+
+func NewRefundHandler(refundService services.RefundService) *RefundHandler {
+	return &RefundHandler{
+		refundService: refundService,
+	}
+}
+
+// CreateRefund - POST /api/v1/refunds
+// @Summary Refund a payment
+// @Description Refund all or part of a payment, optionally for specific order lines and putting their stock back. The last refund that uses up the paid amount marks the payment and transaction as refunded.
+// @Tags Refund
+// @Accept json
+// @Produce json
+// @Param request body models.CreateRefund true "Refund request"
+// @Success 201 {object} utils.Response{data=models.PaymentRefundSummary} "Refund created successfully"
+// @Failure 404 {object} utils.Response "Payment not found"
+// @Failure 409 {object} utils.Response "Payment already fully refunded"
+// @Router /refunds [post]
+// @Security Bearer
+func (h *RefundHandler) CreateRefund(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateRefund
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	summary, err := h.refundService.CreateRefund(middleware.GetActivityContext(r), input)
+	if err != nil {
+		errMsg := err.Error()
+		switch {
+		case errMsg == "payment not found":
+			utils.WriteError(w, http.StatusNotFound, errMsg, err)
+		case errMsg == "payment is already fully refunded":
+			utils.WriteError(w, http.StatusConflict, errMsg, err)
+		case strings.HasPrefix(errMsg, "failed to"):
+			utils.WriteError(w, http.StatusInternalServerError, "Failed to create refund", err)
+		default:
+			utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+		}
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Refund created successfully", summary)
+}
+
+// GetAllRefunds - GET /api/v1/refunds
+// @Summary List refunds
+// @Description Get a paginated list of refunds, newest first
+// @Tags Refund
+// @Produce json
+// @Param payment_id query int false "Only refunds of this payment"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.RefundListResponse} "Success"
+// @Router /refunds [get]
+// @Security Bearer
+func (h *RefundHandler) GetAllRefunds(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	param := models.RefundListRequest{
+		Page:  page,
+		Limit: limit,
+	}
+
+	if paymentID := query.Get("payment_id"); paymentID != "" {
+		id, err := strconv.ParseInt(paymentID, 10, 64)
+		if err != nil || id <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid payment_id", err)
+			return
+		}
+		param.PaymentID = id
+	}
+
+	refunds, err := h.refundService.FindAllRefund(param)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch refunds", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Refunds retrieved successfully", refunds)
+}
+
+// GetRefundByID - GET /api/v1/refunds/{id}
+// @Summary Get refund by ID
+// @Description Get a refund with its order lines
+// @Tags Refund
+// @Produce json
+// @Param id path int true "Refund ID"
+// @Success 200 {object} utils.Response{data=models.RefundResponse} "Success"
+// @Failure 404 {object} utils.Response "Refund not found"
+// @Router /refunds/{id} [get]
+// @Security Bearer
+func (h *RefundHandler) GetRefundByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid refund ID", err)
+		return
+	}
+
+	refund, err := h.refundService.FindById(id)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Refund retrieved successfully", refund)
+}
@@ -0,0 +1,84 @@
+package handler_test
+
+import (
+	"bytes"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestRefundHandler_CreateRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockRefundService(ctrl)
+	refundHandler := handler.NewRefundHandler(mockService)
+
+	newRequest := func() *http.Request {
+		body := []byte(`{"payment_id":1,"amount":50000,"reason":"damaged item"}`)
+		return httptest.NewRequest(http.MethodPost, "/refunds", bytes.NewBuffer(body))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateRefund(gomock.Any(), gomock.Any()).
+			Return(&models.PaymentRefundSummary{
+				PaymentStatus:   "success",
+				TotalPayment:    models.Rupiah(150000),
+				RefundedAmount:  models.Rupiah(50000),
+				RemainingAmount: models.Rupiah(100000),
+			}, nil)
+
+		w := httptest.NewRecorder()
+		refundHandler.CreateRefund(w, newRequest())
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("PaymentNotFound", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateRefund(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("payment not found"))
+
+		w := httptest.NewRecorder()
+		refundHandler.CreateRefund(w, newRequest())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("AlreadyRefunded", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateRefund(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("payment is already fully refunded"))
+
+		w := httptest.NewRecorder()
+		refundHandler.CreateRefund(w, newRequest())
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("ExceedsPaidAmount", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateRefund(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("refund amount exceeds the remaining paid amount"))
+
+		w := httptest.NewRecorder()
+		refundHandler.CreateRefund(w, newRequest())
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
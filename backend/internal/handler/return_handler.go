@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReturnHandler struct {
+	returnService services.ReturnService
+}
+
+func NewReturnHandler(returnService services.ReturnService) *ReturnHandler {
+	return &ReturnHandler{
+		returnService: returnService,
+	}
+}
+
+// CreateReturn - POST /api/v1/returns
+// @Summary Open a return
+// @Description Request a return of delivered order lines from one transaction, with photos of the items
+// @Tags Return
+// @Accept multipart/form-data
+// @Produce json
+// @Param reason formData string true "Why the items are returned"
+// @Param resolution formData string true "refund or exchange"
+// @Param items formData string true "JSON array of {order_id, quantity}"
+// @Param photos formData file false "Photos of the items (up to 5)"
+// @Success 201 {object} utils.Response{data=models.ReturnResponse} "Return requested successfully"
+// @Failure 400 {object} utils.Response "Invalid input or return window expired"
+// @Router /returns [post]
+// @Security Bearer
+func (h *ReturnHandler) CreateReturn(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Failed to parse form data", err)
+		return
+	}
+
+	param := models.CreateReturnRequest{
+		Reason:     r.FormValue("reason"),
+		Resolution: r.FormValue("resolution"),
+	}
+
+	if err := json.Unmarshal([]byte(r.FormValue("items")), &param.Items); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid items", err)
+		return
+	}
+
+	photos := r.MultipartForm.File["photos"]
+
+	result, err := h.returnService.CreateReturn(middleware.GetActivityContext(r), param, photos)
+	if err != nil {
+		writeReturnError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Return requested successfully", result)
+}
+
+// GetMyReturns - GET /api/v1/returns/mine
+// @Summary List my returns
+// @Description Get the returns opened by the authenticated customer, newest first
+// @Tags Return
+// @Produce json
+// @Param status query string false "Filter by status"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.ReturnListResponse} "Success"
+// @Router /returns/mine [get]
+// @Security Bearer
+func (h *ReturnHandler) GetMyReturns(w http.ResponseWriter, r *http.Request) {
+	param := returnListRequest(r)
+	param.UserID = middleware.GetUserIDFromContext(r)
+
+	returns, err := h.returnService.FindAllReturn(param)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch returns", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Returns retrieved successfully", returns)
+}
+
+// GetAllReturns - GET /api/v1/returns
+// @Summary List returns
+// @Description Get a paginated list of all returns, newest first
+// @Tags Return
+// @Produce json
+// @Param status query string false "Filter by status"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.ReturnListResponse} "Success"
+// @Router /returns [get]
+// @Security Bearer
+func (h *ReturnHandler) GetAllReturns(w http.ResponseWriter, r *http.Request) {
+	returns, err := h.returnService.FindAllReturn(returnListRequest(r))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch returns", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Returns retrieved successfully", returns)
+}
+
+// GetReturnByID - GET /api/v1/returns/{id}
+// @Summary Get return by ID
+// @Description Get a return with its items and photos
+// @Tags Return
+// @Produce json
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse} "Success"
+// @Failure 404 {object} utils.Response "Return not found"
+// @Router /returns/{id} [get]
+// @Security Bearer
+func (h *ReturnHandler) GetReturnByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := returnID(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.returnService.FindById(id)
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Return retrieved successfully", result)
+}
+
+// CancelReturn - PATCH /api/v1/returns/{id}/cancel
+// @Summary Cancel my return
+// @Description Cancel one of my returns before the items are received
+// @Tags Return
+// @Produce json
+// @Param id path int true "Return ID"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse} "Return cancelled successfully"
+// @Failure 404 {object} utils.Response "Return not found"
+// @Failure 409 {object} utils.Response "Return can no longer be cancelled"
+// @Router /returns/{id}/cancel [patch]
+// @Security Bearer
+func (h *ReturnHandler) CancelReturn(w http.ResponseWriter, r *http.Request) {
+	id, ok := returnID(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.returnService.CancelReturn(middleware.GetActivityContext(r), id)
+	if err != nil {
+		writeReturnError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Return cancelled successfully", result)
+}
+
+// UpdateReturnStatus - PATCH /api/v1/returns/{id}/status
+// @Summary Approve, reject or receive a return
+// @Description Move a return to approved, rejected (with a note) or received
+// @Tags Return
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param request body models.UpdateReturnStatus true "New status"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse} "Return updated successfully"
+// @Failure 409 {object} utils.Response "Status change not allowed"
+// @Router /returns/{id}/status [patch]
+// @Security Bearer
+func (h *ReturnHandler) UpdateReturnStatus(w http.ResponseWriter, r *http.Request) {
+	id, ok := returnID(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateReturnStatus
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ID = id
+
+	result, err := h.returnService.UpdateStatus(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeReturnError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Return updated successfully", result)
+}
+
+// InspectReturn - PATCH /api/v1/returns/{id}/inspect
+// @Summary Inspect a received return
+// @Description Record which items can be sold again; restockable items go back into stock
+// @Tags Return
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param request body models.InspectReturn true "Inspection result"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse} "Return inspected successfully"
+// @Failure 409 {object} utils.Response "Return has not been received"
+// @Router /returns/{id}/inspect [patch]
+// @Security Bearer
+func (h *ReturnHandler) InspectReturn(w http.ResponseWriter, r *http.Request) {
+	id, ok := returnID(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.InspectReturn
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ID = id
+
+	result, err := h.returnService.InspectReturn(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeReturnError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Return inspected successfully", result)
+}
+
+// RefundReturn - POST /api/v1/returns/{id}/refund
+// @Summary Refund an inspected return
+// @Description Refund the returned items against the transaction's payment. Amount defaults to the value of the items.
+// @Tags Return
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param request body models.RefundReturn false "Refund amount"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse} "Return refunded successfully"
+// @Failure 409 {object} utils.Response "Return has not been inspected"
+// @Router /returns/{id}/refund [post]
+// @Security Bearer
+func (h *ReturnHandler) RefundReturn(w http.ResponseWriter, r *http.Request) {
+	id, ok := returnID(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.RefundReturn
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+			return
+		}
+	}
+	input.ID = id
+
+	result, err := h.returnService.RefundReturn(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeReturnError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Return refunded successfully", result)
+}
+
+// ExchangeReturn - POST /api/v1/returns/{id}/exchange
+// @Summary Exchange an inspected return
+// @Description Swap each returned item for another size or colour of the same product
+// @Tags Return
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param request body models.ExchangeReturn true "Replacement variants"
+// @Success 200 {object} utils.Response{data=models.ReturnResponse} "Return exchanged successfully"
+// @Failure 409 {object} utils.Response "Return has not been inspected"
+// @Router /returns/{id}/exchange [post]
+// @Security Bearer
+func (h *ReturnHandler) ExchangeReturn(w http.ResponseWriter, r *http.Request) {
+	id, ok := returnID(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.ExchangeReturn
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ID = id
+
+	result, err := h.returnService.ExchangeReturn(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeReturnError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Return exchanged successfully", result)
+}
+
+func returnID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid return ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+func returnListRequest(r *http.Request) models.ReturnListRequest {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	return models.ReturnListRequest{
+		Status: query.Get("status"),
+		Page:   page,
+		Limit:  limit,
+	}
+}
+
+func writeReturnError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "return not found", errMsg == "payment not found":
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case strings.HasPrefix(errMsg, "cannot move return"), strings.HasPrefix(errMsg, "return resolution is"),
+		errMsg == "payment is already fully refunded":
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
@@ -0,0 +1,167 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReturnHandler_CreateReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReturnService(ctrl)
+	returnHandler := handler.NewReturnHandler(mockService)
+
+	newRequest := func(items string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("reason", "wrong size")
+		writer.WriteField("resolution", "exchange")
+		writer.WriteField("items", items)
+		part, _ := writer.CreateFormFile("photos", "photo.jpg")
+		part.Write([]byte("jpeg"))
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/returns", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateReturn(gomock.Any(), gomock.Any(), gomock.Len(1)).
+			DoAndReturn(func(_ models.ActivityContext, param models.CreateReturnRequest, _ interface{}) (*models.ReturnResponse, error) {
+				if len(param.Items) != 1 || param.Items[0].Quantity != 1 {
+					t.Errorf("unexpected items %+v", param.Items)
+				}
+				return &models.ReturnResponse{ID: 1, Status: "requested"}, nil
+			})
+
+		w := httptest.NewRecorder()
+		returnHandler.CreateReturn(w, newRequest(`[{"order_id":"order-1","quantity":1}]`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidItems", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		returnHandler.CreateReturn(w, newRequest("not json"))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("WindowExpired", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateReturn(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("return window for order order-1 has expired"))
+
+		w := httptest.NewRecorder()
+		returnHandler.CreateReturn(w, newRequest(`[{"order_id":"order-1","quantity":1}]`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestReturnHandler_UpdateReturnStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReturnService(ctrl)
+	returnHandler := handler.NewReturnHandler(mockService)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/returns/"+id+"/status", bytes.NewBufferString(`{"status":"received"}`))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			UpdateStatus(gomock.Any(), models.UpdateReturnStatus{ID: 3, Status: "received"}).
+			Return(&models.ReturnResponse{ID: 3, Status: "received"}, nil)
+
+		w := httptest.NewRecorder()
+		returnHandler.UpdateReturnStatus(w, newRequest("3"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidTransition", func(t *testing.T) {
+		mockService.EXPECT().
+			UpdateStatus(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("cannot move return from requested to received"))
+
+		w := httptest.NewRecorder()
+		returnHandler.UpdateReturnStatus(w, newRequest("3"))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().
+			UpdateStatus(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("return not found"))
+
+		w := httptest.NewRecorder()
+		returnHandler.UpdateReturnStatus(w, newRequest("99"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		returnHandler.UpdateReturnStatus(w, newRequest("abc"))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestReturnHandler_RefundReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReturnService(ctrl)
+	returnHandler := handler.NewReturnHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/returns/3/refund", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "3")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	mockService.EXPECT().
+		RefundReturn(gomock.Any(), models.RefundReturn{ID: 3}).
+		Return(nil, errors.New("return resolution is exchange, not refund"))
+
+	w := httptest.NewRecorder()
+	returnHandler.RefundReturn(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", w.Code)
+	}
+}
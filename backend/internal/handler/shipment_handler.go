@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CarrierSignatureHeader carries the HMAC-SHA256 of a carrier webhook body.
+const CarrierSignatureHeader = "X-Carrier-Signature"
+
+type ShipmentHandler struct {
+	shipmentService services.ShipmentService
+}
+
+func NewShipmentHandler(shipmentService services.ShipmentService) *ShipmentHandler {
+	return &ShipmentHandler{
+		shipmentService: shipmentService,
+	}
+}
+
+// MarkShipped - POST /api/v1/shipments
+// @Summary Mark a transaction as shipped
+// @Description Record the carrier and tracking number of a paid transaction and move it and its orders to shipped
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param request body models.CreateShipment true "Shipment details"
+// @Success 201 {object} utils.Response{data=models.ShipmentResponse} "Transaction shipped successfully"
+// @Failure 404 {object} utils.Response "Transaction not found"
+// @Failure 409 {object} utils.Response "Transaction already shipped"
+// @Router /shipments [post]
+// @Security Bearer
+func (h *ShipmentHandler) MarkShipped(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateShipment
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	shipment, err := h.shipmentService.MarkShipped(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeShipmentError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Transaction shipped successfully", shipment)
+}
+
+// AddShipmentEvent - POST /api/v1/shipments/{id}/events
+// @Summary Add a tracking event
+// @Description Add an event to a shipment's timeline by hand, e.g. to mark it delivered
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipment ID"
+// @Param request body models.CreateShipmentEvent true "Tracking event"
+// @Success 201 {object} utils.Response{data=models.ShipmentResponse} "Event added successfully"
+// @Failure 404 {object} utils.Response "Shipment not found"
+// @Router /shipments/{id}/events [post]
+// @Security Bearer
+func (h *ShipmentHandler) AddShipmentEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid shipment ID", err)
+		return
+	}
+
+	var input models.CreateShipmentEvent
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ShipmentID = id
+
+	shipment, err := h.shipmentService.AddEvent(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeShipmentError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Event added successfully", shipment)
+}
+
+// GetShipmentByTransaction - GET /api/v1/shipments/transaction/{tx_id}
+// @Summary Get the shipment of a transaction
+// @Description Get a transaction's shipment with its tracking timeline
+// @Tags Shipment
+// @Produce json
+// @Param tx_id path string true "Transaction ID"
+// @Success 200 {object} utils.Response{data=models.ShipmentResponse} "Success"
+// @Failure 404 {object} utils.Response "Shipment not found"
+// @Router /shipments/transaction/{tx_id} [get]
+// @Security Bearer
+func (h *ShipmentHandler) GetShipmentByTransaction(w http.ResponseWriter, r *http.Request) {
+	shipment, err := h.shipmentService.FindByTransaction(chi.URLParam(r, "tx_id"))
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Shipment retrieved successfully", shipment)
+}
+
+// GetMyShipment - GET /api/v1/shipments/mine/{tx_id}
+// @Summary Track my order
+// @Description Get the shipment and tracking timeline of one of my transactions
+// @Tags Shipment
+// @Produce json
+// @Param tx_id path string true "Transaction ID"
+// @Success 200 {object} utils.Response{data=models.ShipmentResponse} "Success"
+// @Failure 404 {object} utils.Response "Shipment not found"
+// @Router /shipments/mine/{tx_id} [get]
+// @Security Bearer
+func (h *ShipmentHandler) GetMyShipment(w http.ResponseWriter, r *http.Request) {
+	shipment, err := h.shipmentService.FindMine(chi.URLParam(r, "tx_id"), middleware.GetUserIDFromContext(r))
+	if err != nil {
+		utils.WriteError(w, http.StatusNotFound, err.Error(), err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Shipment retrieved successfully", shipment)
+}
+
+// CarrierWebhook - POST /api/v1/webhooks/carriers/{carrier}
+// @Summary Receive a carrier tracking update
+// @Description Carriers post tracking events signed with an HMAC-SHA256 of the body in the X-Carrier-Signature header
+// @Tags Shipment
+// @Accept json
+// @Produce json
+// @Param carrier path string true "Carrier code"
+// @Param request body models.CarrierWebhookPayload true "Tracking event"
+// @Success 200 {object} utils.Response "Event received"
+// @Failure 401 {object} utils.Response "Invalid signature"
+// @Failure 404 {object} utils.Response "Shipment not found"
+// @Router /webhooks/carriers/{carrier} [post]
+func (h *ShipmentHandler) CarrierWebhook(w http.ResponseWriter, r *http.Request) {
+	carrier := chi.URLParam(r, "carrier")
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Failed to read request body", err)
+		return
+	}
+
+	if err := h.shipmentService.VerifyCarrierWebhook(carrier, body, r.Header.Get(CarrierSignatureHeader)); err != nil {
+		utils.WriteError(w, http.StatusUnauthorized, err.Error(), err)
+		return
+	}
+
+	var input models.CarrierWebhookPayload
+	if err := json.Unmarshal(body, &input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := h.shipmentService.HandleCarrierWebhook(carrier, input); err != nil {
+		writeShipmentError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Event received", nil)
+}
+
+func writeShipmentError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "transaction not found", errMsg == "shipment not found":
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case errMsg == "transaction has already been shipped", strings.HasSuffix(errMsg, "cannot be shipped"):
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
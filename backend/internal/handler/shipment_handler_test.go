@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestShipmentHandler_MarkShipped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockShipmentService(ctrl)
+	shipmentHandler := handler.NewShipmentHandler(mockService)
+
+	newRequest := func() *http.Request {
+		body := []byte(`{"transaction_id":"TX-1","carrier":"jne","service":"REG","tracking_number":"JNE123"}`)
+		return httptest.NewRequest(http.MethodPost, "/shipments", bytes.NewBuffer(body))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			MarkShipped(gomock.Any(), models.CreateShipment{TransactionID: "TX-1", Carrier: "jne", Service: "REG", TrackingNumber: "JNE123"}).
+			Return(&models.ShipmentResponse{ID: 1, Status: "shipped"}, nil)
+
+		w := httptest.NewRecorder()
+		shipmentHandler.MarkShipped(w, newRequest())
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("AlreadyShipped", func(t *testing.T) {
+		mockService.EXPECT().
+			MarkShipped(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("transaction has already been shipped"))
+
+		w := httptest.NewRecorder()
+		shipmentHandler.MarkShipped(w, newRequest())
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+}
+
+func TestShipmentHandler_CarrierWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockShipmentService(ctrl)
+	shipmentHandler := handler.NewShipmentHandler(mockService)
+
+	body := []byte(`{"tracking_number":"JNE123","status":"delivered","location":"Jakarta"}`)
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/carriers/jne", bytes.NewBuffer(body))
+		req.Header.Set(handler.CarrierSignatureHeader, "signature")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("carrier", "jne")
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			VerifyCarrierWebhook("jne", body, "signature").
+			Return(nil)
+		mockService.EXPECT().
+			HandleCarrierWebhook("jne", models.CarrierWebhookPayload{TrackingNumber: "JNE123", Status: "delivered", Location: "Jakarta"}).
+			Return(nil)
+
+		w := httptest.NewRecorder()
+		shipmentHandler.CarrierWebhook(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		mockService.EXPECT().
+			VerifyCarrierWebhook("jne", body, "signature").
+			Return(errors.New("invalid signature"))
+
+		w := httptest.NewRecorder()
+		shipmentHandler.CarrierWebhook(w, newRequest())
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("UnknownTrackingNumber", func(t *testing.T) {
+		mockService.EXPECT().
+			VerifyCarrierWebhook("jne", body, "signature").
+			Return(nil)
+		mockService.EXPECT().
+			HandleCarrierWebhook("jne", gomock.Any()).
+			Return(errors.New("shipment not found"))
+
+		w := httptest.NewRecorder()
+		shipmentHandler.CarrierWebhook(w, newRequest())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"e-commerce/backend/internal/middleware"
 	"e-commerce/backend/internal/models"
 	"e-commerce/backend/internal/services"
 	"e-commerce/backend/internal/utils"
@@ -8,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -192,3 +194,217 @@ func (h *ShippingHandler) DeleteShipping(w http.ResponseWriter, r *http.Request)
 
 	utils.WriteJSON(w, http.StatusOK, "Shipping method deleted successfully", nil)
 }
+
+// QuoteShipping - POST /api/v1/shipping/quote
+// @Summary Quote shipping options
+// @Description Get the available shipping methods and their prices for items delivered to one of my addresses
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param request body models.ShippingQuoteRequest true "Address and items"
+// @Success 200 {object} utils.Response{data=models.ShippingQuoteResponse} "Success"
+// @Failure 404 {object} utils.Response "Address not found"
+// @Router /shipping/quote [post]
+// @Security Bearer
+func (h *ShippingHandler) QuoteShipping(w http.ResponseWriter, r *http.Request) {
+	var input models.ShippingQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.UserID = int64(middleware.GetUserIDFromContext(r))
+
+	quote, err := h.shippingService.Quote(input)
+	if err != nil {
+		writeShippingZoneError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Shipping quote retrieved successfully", quote)
+}
+
+// GetAllZones - GET /api/v1/shipping/zones
+// @Summary List shipping zones
+// @Description Get all shipping zones with their weight tiers
+// @Tags Shipping
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]models.ShippingZoneResponse} "Success"
+// @Router /shipping/zones [get]
+// @Security Bearer
+func (h *ShippingHandler) GetAllZones(w http.ResponseWriter, r *http.Request) {
+	zones, err := h.shippingService.FindAllZones()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch shipping zones", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Shipping zones retrieved successfully", zones)
+}
+
+// CreateZone - POST /api/v1/shipping/zones
+// @Summary Create a shipping zone
+// @Description Create a zone for a province, a city of a province, or the default zone when both are empty
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param request body models.CreateShippingZone true "Shipping zone"
+// @Success 201 {object} utils.Response{data=models.ShippingZoneResponse} "Shipping zone created successfully"
+// @Failure 409 {object} utils.Response "Shipping zone already exists for this area"
+// @Router /shipping/zones [post]
+// @Security Bearer
+func (h *ShippingHandler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateShippingZone
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	zone, err := h.shippingService.CreateZone(input)
+	if err != nil {
+		writeShippingZoneError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Shipping zone created successfully", zone)
+}
+
+// UpdateZone - PUT /api/v1/shipping/zones/{id}
+// @Summary Update a shipping zone
+// @Description Update a zone's area and free-shipping threshold
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping zone ID"
+// @Param request body models.CreateShippingZone true "Shipping zone"
+// @Success 200 {object} utils.Response{data=models.ShippingZoneResponse} "Shipping zone updated successfully"
+// @Failure 404 {object} utils.Response "Shipping zone not found"
+// @Router /shipping/zones/{id} [put]
+// @Security Bearer
+func (h *ShippingHandler) UpdateZone(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid shipping zone ID", err)
+		return
+	}
+
+	var input models.CreateShippingZone
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ID = id
+
+	zone, err := h.shippingService.UpdateZone(input)
+	if err != nil {
+		writeShippingZoneError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Shipping zone updated successfully", zone)
+}
+
+// DeleteZone - DELETE /api/v1/shipping/zones/{id}
+// @Summary Delete a shipping zone
+// @Description Delete a zone and its weight tiers
+// @Tags Shipping
+// @Produce json
+// @Param id path int true "Shipping zone ID"
+// @Success 200 {object} utils.Response "Shipping zone deleted successfully"
+// @Failure 404 {object} utils.Response "Shipping zone not found"
+// @Router /shipping/zones/{id} [delete]
+// @Security Bearer
+func (h *ShippingHandler) DeleteZone(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid shipping zone ID", err)
+		return
+	}
+
+	if err := h.shippingService.DeleteZone(id); err != nil {
+		writeShippingZoneError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Shipping zone deleted successfully", nil)
+}
+
+// CreateRate - POST /api/v1/shipping/zones/{id}/rates
+// @Summary Add a weight tier to a shipping zone
+// @Description Set the price of a shipping method in the zone for parcels up to max_weight_grams (0 for no limit)
+// @Tags Shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping zone ID"
+// @Param request body models.CreateShippingRate true "Shipping rate"
+// @Success 201 {object} utils.Response{data=models.ShippingZoneResponse} "Shipping rate created successfully"
+// @Failure 404 {object} utils.Response "Shipping zone not found"
+// @Failure 409 {object} utils.Response "Shipping rate already exists for this weight"
+// @Router /shipping/zones/{id}/rates [post]
+// @Security Bearer
+func (h *ShippingHandler) CreateRate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid shipping zone ID", err)
+		return
+	}
+
+	var input models.CreateShippingRate
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ZoneID = id
+
+	zone, err := h.shippingService.CreateRate(input)
+	if err != nil {
+		writeShippingZoneError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Shipping rate created successfully", zone)
+}
+
+// DeleteRate - DELETE /api/v1/shipping/zones/{id}/rates/{rate_id}
+// @Summary Delete a weight tier
+// @Tags Shipping
+// @Produce json
+// @Param id path int true "Shipping zone ID"
+// @Param rate_id path int true "Shipping rate ID"
+// @Success 200 {object} utils.Response "Shipping rate deleted successfully"
+// @Failure 404 {object} utils.Response "Shipping rate not found"
+// @Router /shipping/zones/{id}/rates/{rate_id} [delete]
+// @Security Bearer
+func (h *ShippingHandler) DeleteRate(w http.ResponseWriter, r *http.Request) {
+	zoneID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || zoneID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid shipping zone ID", err)
+		return
+	}
+
+	rateID, err := strconv.ParseInt(chi.URLParam(r, "rate_id"), 10, 64)
+	if err != nil || rateID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid shipping rate ID", err)
+		return
+	}
+
+	if err := h.shippingService.DeleteRate(zoneID, rateID); err != nil {
+		writeShippingZoneError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Shipping rate deleted successfully", nil)
+}
+
+func writeShippingZoneError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case strings.HasSuffix(errMsg, "not found"), strings.Contains(errMsg, "not found at index"):
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case strings.HasPrefix(errMsg, "shipping zone already exists"), strings.HasPrefix(errMsg, "shipping rate already exists"):
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestShippingHandler_QuoteShipping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockShippingService(ctrl)
+	shippingHandler := handler.NewShippingHandler(mockService)
+
+	newRequest := func() *http.Request {
+		body := []byte(`{"address_id":3,"items":[{"product_id":7,"quantity":2}]}`)
+		return httptest.NewRequest(http.MethodPost, "/shipping/quote", bytes.NewBuffer(body))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			Quote(models.ShippingQuoteRequest{
+				AddressID: 3,
+				Items:     []models.ShippingQuoteItem{{ProductID: 7, Quantity: 2}},
+			}).
+			Return(&models.ShippingQuoteResponse{
+				Zone:        "Jawa Barat",
+				WeightGrams: 1200,
+				Options:     []models.ShippingQuoteOption{{ShippingID: 1, Name: "JNE REG", Price: models.Rupiah(18000), BasePrice: models.Rupiah(18000)}},
+			}, nil)
+
+		w := httptest.NewRecorder()
+		shippingHandler.QuoteShipping(w, newRequest())
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("AddressNotFound", func(t *testing.T) {
+		mockService.EXPECT().
+			Quote(gomock.Any()).
+			Return(nil, errors.New("address not found"))
+
+		w := httptest.NewRecorder()
+		shippingHandler.QuoteShipping(w, newRequest())
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestShippingHandler_CreateRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockShippingService(ctrl)
+	shippingHandler := handler.NewShippingHandler(mockService)
+
+	newRequest := func() *http.Request {
+		body := []byte(`{"shipping_id":1,"max_weight_grams":1000,"price":15000}`)
+		req := httptest.NewRequest(http.MethodPost, "/shipping/zones/2/rates", bytes.NewBuffer(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "2")
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateRate(models.CreateShippingRate{ZoneID: 2, ShippingID: 1, MaxWeightGrams: 1000, Price: models.Rupiah(15000)}).
+			Return(&models.ShippingZoneResponse{ID: 2}, nil)
+
+		w := httptest.NewRecorder()
+		shippingHandler.CreateRate(w, newRequest())
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("DuplicateTier", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateRate(gomock.Any()).
+			Return(nil, errors.New("shipping rate already exists for this weight"))
+
+		w := httptest.NewRecorder()
+		shippingHandler.CreateRate(w, newRequest())
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+}
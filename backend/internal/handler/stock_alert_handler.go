@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type StockAlertHandler struct {
+	stockAlertService services.StockAlertService
+}
+
+func NewStockAlertHandler(stockAlertService services.StockAlertService) *StockAlertHandler {
+	return &StockAlertHandler{
+		stockAlertService: stockAlertService,
+	}
+}
+
+// GetStockAlerts - GET /api/v1/stock-alerts
+// @Summary List my stock alerts
+// @Description Get my back-in-stock and price-drop subscriptions, including back-in-stock alerts that already fired
+// @Tags Stock Alerts
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]models.StockAlertResponse} "Success"
+// @Router /stock-alerts [get]
+// @Security Bearer
+func (h *StockAlertHandler) GetStockAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := h.stockAlertService.FindAll(middleware.GetUserIDFromContext(r))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch stock alerts", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Stock alerts retrieved successfully", alerts)
+}
+
+// Subscribe - POST /api/v1/stock-alerts
+// @Summary Subscribe to a stock alert
+// @Description Get notified once when a sold out product or size is back in stock, or every time a product gets cheaper
+// @Tags Stock Alerts
+// @Accept json
+// @Produce json
+// @Param request body models.CreateStockAlert true "Alert; type is back_in_stock or price_drop"
+// @Success 201 {object} utils.Response{data=models.StockAlertResponse} "Subscribed"
+// @Failure 400 {object} utils.Response "Invalid alert, or the product is in stock"
+// @Failure 404 {object} utils.Response "Product or size not found"
+// @Failure 409 {object} utils.Response "Already subscribed"
+// @Router /stock-alerts [post]
+// @Security Bearer
+func (h *StockAlertHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateStockAlert
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if input.ProductID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Product ID is required", nil)
+		return
+	}
+
+	alert, err := h.stockAlertService.Subscribe(middleware.GetUserIDFromContext(r), input)
+	if err != nil {
+		writeStockAlertError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Subscribed to stock alert", alert)
+}
+
+// Unsubscribe - DELETE /api/v1/stock-alerts/{id}
+// @Summary Unsubscribe from a stock alert
+// @Tags Stock Alerts
+// @Produce json
+// @Param id path int true "Stock alert ID"
+// @Success 200 {object} utils.Response "Unsubscribed"
+// @Failure 404 {object} utils.Response "Stock alert not found"
+// @Router /stock-alerts/{id} [delete]
+// @Security Bearer
+func (h *StockAlertHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid stock alert ID", err)
+		return
+	}
+
+	if err := h.stockAlertService.Unsubscribe(middleware.GetUserIDFromContext(r), id); err != nil {
+		writeStockAlertError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Unsubscribed from stock alert", nil)
+}
+
+// GetNotifications - GET /api/v1/stock-alerts/notifications
+// @Summary List my stock alert notifications
+// @Description Get the back-in-stock and price-drop notifications queued for me, newest first
+// @Tags Stock Alerts
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.StockAlertNotificationListResponse} "Success"
+// @Router /stock-alerts/notifications [get]
+// @Security Bearer
+func (h *StockAlertHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+
+	notifications, err := h.stockAlertService.FindNotifications(models.StockAlertNotificationListRequest{
+		UserID:     middleware.GetUserIDFromContext(r),
+		UnreadOnly: unreadOnly,
+		Page:       page,
+		Limit:      limit,
+	})
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch notifications", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Notifications retrieved successfully", notifications)
+}
+
+// MarkNotificationRead - PUT /api/v1/stock-alerts/notifications/{id}/read
+// @Summary Mark a stock alert notification as read
+// @Tags Stock Alerts
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} utils.Response "Notification marked as read"
+// @Failure 404 {object} utils.Response "Notification not found"
+// @Router /stock-alerts/notifications/{id}/read [put]
+// @Security Bearer
+func (h *StockAlertHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid notification ID", err)
+		return
+	}
+
+	if err := h.stockAlertService.MarkNotificationRead(middleware.GetUserIDFromContext(r), id); err != nil {
+		writeStockAlertError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Notification marked as read", nil)
+}
+
+func writeStockAlertError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case strings.HasSuffix(errMsg, "not found"):
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case errMsg == "you are already subscribed to this alert":
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
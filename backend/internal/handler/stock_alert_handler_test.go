@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStockAlertHandler_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStockAlertService(ctrl)
+	stockAlertHandler := handler.NewStockAlertHandler(mockService)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/stock-alerts", bytes.NewBufferString(body))
+		return req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, uint(7)))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			Subscribe(uint(7), models.CreateStockAlert{ProductID: 12, Type: models.StockAlertPriceDrop}).
+			Return(&models.StockAlertResponse{ID: 1, ProductID: 12, Type: models.StockAlertPriceDrop, Active: true}, nil)
+
+		w := httptest.NewRecorder()
+		stockAlertHandler.Subscribe(w, newRequest(`{"product_id":12,"type":"price_drop"}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("AlreadySubscribed", func(t *testing.T) {
+		mockService.EXPECT().
+			Subscribe(uint(7), gomock.Any()).
+			Return(nil, errors.New("you are already subscribed to this alert"))
+
+		w := httptest.NewRecorder()
+		stockAlertHandler.Subscribe(w, newRequest(`{"product_id":12,"type":"price_drop"}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("InStock", func(t *testing.T) {
+		mockService.EXPECT().
+			Subscribe(uint(7), gomock.Any()).
+			Return(nil, errors.New("product is in stock"))
+
+		w := httptest.NewRecorder()
+		stockAlertHandler.Subscribe(w, newRequest(`{"product_id":12,"type":"back_in_stock"}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestStockAlertHandler_MarkNotificationRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStockAlertService(ctrl)
+	stockAlertHandler := handler.NewStockAlertHandler(mockService)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/stock-alerts/notifications/"+id+"/read", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		return req.WithContext(context.WithValue(ctx, middleware.UserIDContextKey, uint(7)))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().MarkNotificationRead(uint(7), int64(3)).Return(nil)
+
+		w := httptest.NewRecorder()
+		stockAlertHandler.MarkNotificationRead(w, newRequest("3"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotOwned", func(t *testing.T) {
+		mockService.EXPECT().MarkNotificationRead(uint(7), int64(4)).Return(errors.New("notification not found"))
+
+		w := httptest.NewRecorder()
+		stockAlertHandler.MarkNotificationRead(w, newRequest("4"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type TaxHandler struct {
+	taxService services.TaxService
+}
+
+func NewTaxHandler(taxService services.TaxService) *TaxHandler {
+	return &TaxHandler{
+		taxService: taxService,
+	}
+}
+
+// GetAllTaxClasses - GET /api/v1/tax-classes
+// @Summary List tax classes
+// @Description Get all tax classes and their rates
+// @Tags Tax
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]models.TaxClassResponse} "Success"
+// @Router /tax-classes [get]
+// @Security Bearer
+func (h *TaxHandler) GetAllTaxClasses(w http.ResponseWriter, r *http.Request) {
+	classes, err := h.taxService.FindAllClasses()
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch tax classes", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Tax classes retrieved successfully", classes)
+}
+
+// GetTaxClassByID - GET /api/v1/tax-classes/{id}
+// @Summary Get a tax class
+// @Tags Tax
+// @Produce json
+// @Param id path int true "Tax class ID"
+// @Success 200 {object} utils.Response{data=models.TaxClassResponse} "Success"
+// @Failure 404 {object} utils.Response "Tax class not found"
+// @Router /tax-classes/{id} [get]
+// @Security Bearer
+func (h *TaxHandler) GetTaxClassByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid tax class ID", err)
+		return
+	}
+
+	class, err := h.taxService.FindClassByID(id)
+	if err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Tax class retrieved successfully", class)
+}
+
+// CreateTaxClass - POST /api/v1/tax-classes
+// @Summary Create a tax class
+// @Description Create a tax rate that categories and products can be assigned to; a default class applies to everything unassigned
+// @Tags Tax
+// @Accept json
+// @Produce json
+// @Param request body models.CreateTaxClass true "Tax class"
+// @Success 201 {object} utils.Response{data=models.TaxClassResponse} "Tax class created successfully"
+// @Failure 409 {object} utils.Response "Tax class code already exists"
+// @Router /tax-classes [post]
+// @Security Bearer
+func (h *TaxHandler) CreateTaxClass(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateTaxClass
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	class, err := h.taxService.CreateClass(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Tax class created successfully", class)
+}
+
+// UpdateTaxClass - PUT /api/v1/tax-classes/{id}
+// @Summary Update a tax class
+// @Description Update a tax class; a new rate only applies to new transactions
+// @Tags Tax
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax class ID"
+// @Param request body models.CreateTaxClass true "Tax class"
+// @Success 200 {object} utils.Response{data=models.TaxClassResponse} "Tax class updated successfully"
+// @Failure 404 {object} utils.Response "Tax class not found"
+// @Router /tax-classes/{id} [put]
+// @Security Bearer
+func (h *TaxHandler) UpdateTaxClass(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid tax class ID", err)
+		return
+	}
+
+	var input models.CreateTaxClass
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	input.ID = id
+
+	class, err := h.taxService.UpdateClass(middleware.GetActivityContext(r), input)
+	if err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Tax class updated successfully", class)
+}
+
+// DeleteTaxClass - DELETE /api/v1/tax-classes/{id}
+// @Summary Delete a tax class
+// @Description Delete a tax class that no category or product uses
+// @Tags Tax
+// @Produce json
+// @Param id path int true "Tax class ID"
+// @Success 200 {object} utils.Response "Tax class deleted successfully"
+// @Failure 404 {object} utils.Response "Tax class not found"
+// @Failure 409 {object} utils.Response "Tax class is still assigned"
+// @Router /tax-classes/{id} [delete]
+// @Security Bearer
+func (h *TaxHandler) DeleteTaxClass(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid tax class ID", err)
+		return
+	}
+
+	if err := h.taxService.DeleteClass(middleware.GetActivityContext(r), id); err != nil {
+		writeTaxError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Tax class deleted successfully", nil)
+}
+
+func writeTaxError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "tax class not found":
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case errMsg == "tax class code already exists", strings.HasPrefix(errMsg, "tax class is still assigned"):
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaxHandler_CreateTaxClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaxService(ctrl)
+	taxHandler := handler.NewTaxHandler(mockService)
+
+	newRequest := func() *http.Request {
+		body := []byte(`{"name":"PPN","code":"PPN","rate":11,"is_default":true}`)
+		return httptest.NewRequest(http.MethodPost, "/tax-classes", bytes.NewBuffer(body))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateClass(gomock.Any(), models.CreateTaxClass{Name: "PPN", Code: "PPN", Rate: 11, IsDefault: true}).
+			Return(&models.TaxClassResponse{ID: 1, Code: "PPN", Rate: 11, IsDefault: true}, nil)
+
+		w := httptest.NewRecorder()
+		taxHandler.CreateTaxClass(w, newRequest())
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("DuplicateCode", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateClass(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("tax class code already exists"))
+
+		w := httptest.NewRecorder()
+		taxHandler.CreateTaxClass(w, newRequest())
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+}
+
+func TestTaxHandler_DeleteTaxClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaxService(ctrl)
+	taxHandler := handler.NewTaxHandler(mockService)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/tax-classes/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("StillAssigned", func(t *testing.T) {
+		mockService.EXPECT().
+			DeleteClass(gomock.Any(), int64(2)).
+			Return(errors.New("tax class is still assigned to 3 categories or products"))
+
+		w := httptest.NewRecorder()
+		taxHandler.DeleteTaxClass(w, newRequest("2"))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		taxHandler.DeleteTaxClass(w, newRequest("abc"))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
@@ -1,30 +1,35 @@
 package handler
 
 import (
+	"e-commerce/backend/internal/middleware"
 	"e-commerce/backend/internal/models"
 	"e-commerce/backend/internal/services"
 	"e-commerce/backend/internal/utils"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type TransactionHandler struct {
 	transactionService services.TransactionService
+	currencyService    services.CurrencyService
 }
 
-func NewTransactionHandler(transactionService services.TransactionService) *TransactionHandler {
+func NewTransactionHandler(transactionService services.TransactionService, currencyService services.CurrencyService) *TransactionHandler {
 	return &TransactionHandler{
 		transactionService: transactionService,
+		currencyService:    currencyService,
 	}
 }
 
 // CreateTransaction - POST /api/v1/transactions
 // @Summary Create a new transaction
-// @Description Create a new transaction with product orders
+// @Description Create a new transaction with product orders. It is charged in the store currency; the currency prices were shown in, from the body or the currency query or Accept-Currency header, is kept with its rate.
 // @Tags Transaction
 // @Accept json
 // @Produce json
@@ -56,6 +61,14 @@ func (h *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Re
 		utils.WriteError(w, http.StatusBadRequest, "At least one product order is required", fmt.Errorf("At least one product order is required"))
 		return
 	}
+	if input.Currency == "" {
+		currency, err := requestedCurrency(h.currencyService, r)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		input.Currency = currency.Code
+	}
 
 	// Get context from request
 	ctx := r.Context()
@@ -69,7 +82,7 @@ func (h *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Re
 			utils.WriteError(w, http.StatusNotFound, errMsg, err)
 			return
 		}
-		if contains(errMsg, "insufficient stock") {
+		if contains(errMsg, "insufficient stock") || contains(errMsg, "is not available") || contains(errMsg, "does not belong") {
 			utils.WriteError(w, http.StatusBadRequest, errMsg, err)
 			return
 		}
@@ -167,6 +180,66 @@ func (h *TransactionHandler) GetTransactionByID(w http.ResponseWriter, r *http.R
 	utils.WriteJSON(w, http.StatusOK, "Transaction retrieved successfully", transaction)
 }
 
+// GetTransactionQRIS - GET /api/v1/transactions/{tx_id}/qris
+// @Summary Get the QRIS of my transaction
+// @Description Get the dynamic QRIS of one of my transactions paid with QRIS, carrying the exact amount due and the transaction ID as reference. With format=png the QR code image is returned instead of JSON.
+// @Tags Transaction
+// @Produce json
+// @Produce image/png
+// @Param tx_id path string true "Transaction ID"
+// @Param format query string false "json (default) or png"
+// @Success 200 {object} utils.Response{data=models.TransactionQRISResponse} "Success"
+// @Failure 400 {object} utils.Response "Transaction not paid with QRIS"
+// @Failure 404 {object} utils.Response "Transaction not found"
+// @Failure 409 {object} utils.Response "Transaction not waiting for payment"
+// @Router /transactions/{tx_id}/qris [get]
+// @Security Bearer
+func (h *TransactionHandler) GetTransactionQRIS(w http.ResponseWriter, r *http.Request) {
+	txID := chi.URLParam(r, "tx_id")
+
+	qris, err := h.transactionService.FindQRIS(txID, middleware.GetUserIDFromContext(r))
+	if err != nil {
+		writeQRISError(w, err)
+		return
+	}
+
+	code, err := utils.NewQRCode([]byte(qris.Payload))
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to generate QR code", err)
+		return
+	}
+	image, err := code.PNG(8)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to generate QR code", err)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "png" {
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		w.Write(image)
+		return
+	}
+
+	qris.Image = "data:image/png;base64," + base64.StdEncoding.EncodeToString(image)
+	utils.WriteJSON(w, http.StatusOK, "Transaction QRIS retrieved successfully", qris)
+}
+
+func writeQRISError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "transaction not found":
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case strings.HasSuffix(errMsg, "is not waiting for payment"):
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
+
 // @Router /transactions/{tx_id} [put]
 func (h *TransactionHandler) UpdateTransaction(w http.ResponseWriter, r *http.Request) {
 	txID := chi.URLParam(r, "tx_id")
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
 	"e-commerce/backend/internal/mocks"
 	"e-commerce/backend/internal/models"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -21,23 +23,23 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockTransactionService(ctrl)
-	transactionHandler := handler.NewTransactionHandler(mockService)
+	transactionHandler := handler.NewTransactionHandler(mockService, mocks.NewMockCurrencyService(ctrl))
 
 	t.Run("Success", func(t *testing.T) {
 		input := models.CreateTransaction{
 			AddressID: 1,
 			ShippingID: 1,
 			PaymentMethodID: 1,
-			ShippingPrice: 5000,
-			TotalPrice: 15000,
+			ShippingPrice: models.Rupiah(5000),
+			TotalPrice: models.Rupiah(15000),
 			ProductOrders: []models.CreateOrder{
 				{
 					ProductID: 1, 
 					Quantity: 1,
 					ColorVarianID: 1,
 					SizeVarianID: 1,
-					UnitPrice: 10000,
-					Subtotal: 10000,
+					UnitPrice: models.Rupiah(10000),
+					Subtotal: models.Rupiah(10000),
 				},
 			},
 		}
@@ -83,7 +85,7 @@ func TestTransactionHandler_GetTransactionByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockTransactionService(ctrl)
-	transactionHandler := handler.NewTransactionHandler(mockService)
+	transactionHandler := handler.NewTransactionHandler(mockService, mocks.NewMockCurrencyService(ctrl))
 
 	t.Run("Success", func(t *testing.T) {
 		txID := "TRX-123"
@@ -121,3 +123,74 @@ func TestTransactionHandler_GetTransactionByID(t *testing.T) {
 		}
 	})
 }
+
+func TestTransactionHandler_GetTransactionQRIS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTransactionService(ctrl)
+	transactionHandler := handler.NewTransactionHandler(mockService, mocks.NewMockCurrencyService(ctrl))
+
+	newRequest := func(txID, query string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/transactions/"+txID+"/qris"+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("tx_id", txID)
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		return req.WithContext(context.WithValue(ctx, middleware.UserIDContextKey, uint(7)))
+	}
+	qris := func() *models.TransactionQRISResponse {
+		return &models.TransactionQRISResponse{TxID: "TRX-AB12CD34EF", Amount: models.Rupiah(150000), Payload: "00020101021254061500006304ABCD"}
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		mockService.EXPECT().FindQRIS("TRX-AB12CD34EF", uint(7)).Return(qris(), nil)
+
+		w := httptest.NewRecorder()
+		transactionHandler.GetTransactionQRIS(w, newRequest("TRX-AB12CD34EF", ""))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"image":"data:image/png;base64,`) {
+			t.Errorf("Expected a PNG data URL, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("PNG", func(t *testing.T) {
+		mockService.EXPECT().FindQRIS("TRX-AB12CD34EF", uint(7)).Return(qris(), nil)
+
+		w := httptest.NewRecorder()
+		transactionHandler.GetTransactionQRIS(w, newRequest("TRX-AB12CD34EF", "?format=png"))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "image/png" {
+			t.Errorf("Expected image/png, got %s", ct)
+		}
+	})
+
+	t.Run("NotWaitingForPayment", func(t *testing.T) {
+		mockService.EXPECT().
+			FindQRIS("TRX-AB12CD34EF", uint(7)).
+			Return(nil, errors.New("transaction with status paid is not waiting for payment"))
+
+		w := httptest.NewRecorder()
+		transactionHandler.GetTransactionQRIS(w, newRequest("TRX-AB12CD34EF", ""))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().FindQRIS("TRX-0000000000", uint(7)).Return(nil, errors.New("transaction not found"))
+
+		w := httptest.NewRecorder()
+		transactionHandler.GetTransactionQRIS(w, newRequest("TRX-0000000000", ""))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WishlistHandler struct {
+	wishlistService services.WishlistService
+}
+
+func NewWishlistHandler(wishlistService services.WishlistService) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistService: wishlistService,
+	}
+}
+
+// GetWishlist - GET /api/v1/wishlist
+// @Summary List my wishlist
+// @Description Get the products I saved for later, newest first, with whether they can still be bought and are in stock
+// @Tags Wishlist
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Success 200 {object} utils.Response{data=models.WishlistListResponse} "Success"
+// @Router /wishlist [get]
+// @Security Bearer
+func (h *WishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	param := models.WishlistListRequest{
+		UserID: middleware.GetUserIDFromContext(r),
+		Page:   page,
+		Limit:  limit,
+	}
+
+	wishlist, err := h.wishlistService.FindAll(param)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, "Failed to fetch wishlist", err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Wishlist retrieved successfully", wishlist)
+}
+
+// AddToWishlist - POST /api/v1/wishlist
+// @Summary Add a product to my wishlist
+// @Description Save a product for later, optionally in a specific color and size
+// @Tags Wishlist
+// @Accept json
+// @Produce json
+// @Param request body models.CreateWishlist true "Wishlist item"
+// @Success 201 {object} utils.Response{data=models.WishlistResponse} "Added to wishlist"
+// @Failure 404 {object} utils.Response "Product or variant not found"
+// @Failure 409 {object} utils.Response "Product is already in the wishlist"
+// @Router /wishlist [post]
+// @Security Bearer
+func (h *WishlistHandler) AddToWishlist(w http.ResponseWriter, r *http.Request) {
+	var input models.CreateWishlist
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if input.ProductID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Product ID is required", nil)
+		return
+	}
+
+	item, err := h.wishlistService.AddItem(middleware.GetUserIDFromContext(r), input)
+	if err != nil {
+		writeWishlistError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, "Added to wishlist", item)
+}
+
+// UpdateWishlistItem - PUT /api/v1/wishlist/{id}
+// @Summary Change the variant of a wishlist item
+// @Description Change the color and size saved with a wishlist item
+// @Tags Wishlist
+// @Accept json
+// @Produce json
+// @Param id path int true "Wishlist item ID"
+// @Param request body models.CreateWishlist true "Color and size; the product cannot change"
+// @Success 200 {object} utils.Response{data=models.WishlistResponse} "Wishlist item updated"
+// @Failure 404 {object} utils.Response "Wishlist item not found"
+// @Router /wishlist/{id} [put]
+// @Security Bearer
+func (h *WishlistHandler) UpdateWishlistItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid wishlist item ID", err)
+		return
+	}
+
+	var input models.CreateWishlist
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	item, err := h.wishlistService.UpdateItem(middleware.GetUserIDFromContext(r), id, input)
+	if err != nil {
+		writeWishlistError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Wishlist item updated", item)
+}
+
+// RemoveFromWishlist - DELETE /api/v1/wishlist/{id}
+// @Summary Remove a wishlist item
+// @Tags Wishlist
+// @Produce json
+// @Param id path int true "Wishlist item ID"
+// @Success 200 {object} utils.Response "Removed from wishlist"
+// @Failure 404 {object} utils.Response "Wishlist item not found"
+// @Router /wishlist/{id} [delete]
+// @Security Bearer
+func (h *WishlistHandler) RemoveFromWishlist(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid wishlist item ID", err)
+		return
+	}
+
+	if err := h.wishlistService.RemoveItem(middleware.GetUserIDFromContext(r), id); err != nil {
+		writeWishlistError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Removed from wishlist", nil)
+}
+
+// RemoveProductFromWishlist - DELETE /api/v1/wishlist/products/{product_id}
+// @Summary Remove a product from my wishlist
+// @Description Remove a product from my wishlist in every variant it was saved in
+// @Tags Wishlist
+// @Produce json
+// @Param product_id path int true "Product ID"
+// @Success 200 {object} utils.Response "Removed from wishlist"
+// @Failure 404 {object} utils.Response "Product is not in the wishlist"
+// @Router /wishlist/products/{product_id} [delete]
+// @Security Bearer
+func (h *WishlistHandler) RemoveProductFromWishlist(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "product_id"), 10, 64)
+	if err != nil || productID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "Invalid product ID", err)
+		return
+	}
+
+	if err := h.wishlistService.RemoveProduct(middleware.GetUserIDFromContext(r), productID); err != nil {
+		writeWishlistError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, "Removed from wishlist", nil)
+}
+
+func writeWishlistError(w http.ResponseWriter, err error) {
+	errMsg := err.Error()
+	switch {
+	case strings.HasSuffix(errMsg, "not found"):
+		utils.WriteError(w, http.StatusNotFound, errMsg, err)
+	case errMsg == "product is already in your wishlist":
+		utils.WriteError(w, http.StatusConflict, errMsg, err)
+	case strings.HasPrefix(errMsg, "failed to"):
+		utils.WriteError(w, http.StatusInternalServerError, errMsg, err)
+	default:
+		utils.WriteError(w, http.StatusBadRequest, errMsg, err)
+	}
+}
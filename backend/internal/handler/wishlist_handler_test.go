@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWishlistHandler_AddToWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWishlistService(ctrl)
+	wishlistHandler := handler.NewWishlistHandler(mockService)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/wishlist", bytes.NewBufferString(body))
+		return req.WithContext(context.WithValue(req.Context(), middleware.UserIDContextKey, uint(7)))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		colorID := int64(3)
+		mockService.EXPECT().
+			AddItem(uint(7), models.CreateWishlist{ProductID: 12, ColorVarianID: &colorID}).
+			Return(&models.WishlistResponse{ID: 1, ProductID: 12, ColorVarianID: &colorID, Available: true}, nil)
+
+		w := httptest.NewRecorder()
+		wishlistHandler.AddToWishlist(w, newRequest(`{"product_id":12,"color_varian_id":3}`))
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d", w.Code)
+		}
+	})
+
+	t.Run("MissingProduct", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		wishlistHandler.AddToWishlist(w, newRequest(`{}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("AlreadyWishlisted", func(t *testing.T) {
+		mockService.EXPECT().
+			AddItem(uint(7), gomock.Any()).
+			Return(nil, errors.New("product is already in your wishlist"))
+
+		w := httptest.NewRecorder()
+		wishlistHandler.AddToWishlist(w, newRequest(`{"product_id":12}`))
+
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("VariantOfAnotherProduct", func(t *testing.T) {
+		mockService.EXPECT().
+			AddItem(uint(7), gomock.Any()).
+			Return(nil, errors.New("color variant does not belong to the product"))
+
+		w := httptest.NewRecorder()
+		wishlistHandler.AddToWishlist(w, newRequest(`{"product_id":12,"color_varian_id":99}`))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestWishlistHandler_RemoveFromWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWishlistService(ctrl)
+	wishlistHandler := handler.NewWishlistHandler(mockService)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/wishlist/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		return req.WithContext(context.WithValue(ctx, middleware.UserIDContextKey, uint(7)))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().RemoveItem(uint(7), int64(5)).Return(nil)
+
+		w := httptest.NewRecorder()
+		wishlistHandler.RemoveFromWishlist(w, newRequest("5"))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("NotOwned", func(t *testing.T) {
+		mockService.EXPECT().RemoveItem(uint(7), int64(6)).Return(errors.New("wishlist item not found"))
+
+		w := httptest.NewRecorder()
+		wishlistHandler.RemoveFromWishlist(w, newRequest("6"))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+const (
+	APIKeyHeader                = "X-API-Key"
+	APIKeyContextKey contextKey = "api_key"
+)
+
+// APIKeyMiddleware authenticates requests carrying an X-API-Key header.
+// The key must hold the <resource>.<action> scope the route declares, and
+// every call is recorded in the activity log.
+func APIKeyMiddleware(apiKeyService services.APIKeyService, userService services.UserService, resource, action string) func(http.Handler) http.Handler {
+	scope := resource + "." + action
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rawKey := r.Header.Get(APIKeyHeader)
+			if rawKey == "" {
+				sendError(w, http.StatusUnauthorized, "unauthorized", "API key is required")
+				return
+			}
+
+			apiKey, err := apiKeyService.Authenticate(rawKey, r.RemoteAddr)
+			if err != nil {
+				log.Printf("❌ API KEY ERROR: %v", err)
+				sendError(w, http.StatusUnauthorized, "unauthorized", err.Error())
+				return
+			}
+
+			if !apiKey.HasScope(scope) {
+				log.Printf("❌ API KEY ERROR: key %s lacks scope %s", apiKey.Prefix, scope)
+				sendError(w, http.StatusForbidden, "forbidden", "API key is missing scope "+scope)
+				return
+			}
+
+			user, err := userService.GetUserById(apiKey.UserID)
+			if err != nil || user == nil || !user.IsActive {
+				sendError(w, http.StatusUnauthorized, "unauthorized", "API key owner is not active")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserContextKey, user)
+			ctx = context.WithValue(ctx, UserIDContextKey, user.ID)
+			ctx = context.WithValue(ctx, RoleIDContextKey, user.RoleID)
+			ctx = context.WithValue(ctx, APIKeyContextKey, apiKey)
+
+			wrapped := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+			activity := models.ActivityLog{
+				Action:    "api_call",
+				Resource:  resource,
+				Details:   fmt.Sprintf("%s %s via API key %s (status %d)", r.Method, r.URL.Path, apiKey.Prefix, wrapped.statusCode),
+				IPAddress: r.RemoteAddr,
+				UserAgent: r.UserAgent(),
+			}
+			if err := apiKeyService.RecordUsage(apiKey, activity); err != nil {
+				log.Printf("⚠️  WARNING: failed to record API key usage: %v", err)
+			}
+		})
+	}
+}
+
+// AuthOrAPIKeyMiddleware accepts either an X-API-Key header holding the
+// resource.action scope or a bearer token, for routes that back-office
+// integrations are allowed to call.
+func AuthOrAPIKeyMiddleware(userService services.UserService, jwtService *utils.JWTService, apiKeyService services.APIKeyService, resource, action string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		bearer := AuthMiddleware(userService, jwtService)(next)
+		apiKey := APIKeyMiddleware(apiKeyService, userService, resource, action)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(APIKeyHeader) != "" {
+				apiKey.ServeHTTP(w, r)
+				return
+			}
+			bearer.ServeHTTP(w, r)
+		})
+	}
+}
+
+func GetAPIKeyFromContext(r *http.Request) *models.APIKey {
+	apiKey, ok := r.Context().Value(APIKeyContextKey).(*models.APIKey)
+	if !ok {
+		return nil
+	}
+	return apiKey
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"bytes"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// maxAuditBodySize caps how much of a create response is buffered to find
+// the ID of the new resource.
+const maxAuditBodySize = 64 << 10
+
+// auditResponseWriter keeps a copy of the response body next to the status.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rw *auditResponseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *auditResponseWriter) Write(b []byte) (int, error) {
+	if rw.body.Len() < maxAuditBodySize {
+		rw.body.Write(b)
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// AuditMiddleware records every mutating call on a resource in the audit log.
+// resourceType is the table the routes change and keyColumn its primary key;
+// the resource ID is taken from the route's URL parameter, or from the
+// response data for creates. It must run after the auth middleware, inside
+// the group that matches the route so URL parameters are resolved.
+func AuditMiddleware(auditLogService services.AuditLogService, resourceType string, keyColumn string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			resourceID := routeResourceID(r)
+			before := auditLogService.Snapshot(resourceType, keyColumn, resourceID)
+
+			wrapped := &auditResponseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(wrapped, r)
+
+			action := "update"
+			switch {
+			case r.Method == http.MethodDelete:
+				action = "delete"
+			case r.Method == http.MethodPost && resourceID == "":
+				action = "create"
+				if wrapped.statusCode < http.StatusBadRequest {
+					resourceID = createdResourceID(wrapped.body.Bytes(), keyColumn)
+				}
+			}
+
+			var after map[string]interface{}
+			if wrapped.statusCode < http.StatusBadRequest {
+				after = auditLogService.Snapshot(resourceType, keyColumn, resourceID)
+			} else {
+				after = before
+			}
+
+			entry := models.AuditLog{
+				RequestID:    chimiddleware.GetReqID(r.Context()),
+				UserID:       GetUserIDFromContext(r),
+				Action:       action,
+				ResourceType: resourceType,
+				ResourceID:   resourceID,
+				Method:       r.Method,
+				Path:         r.URL.Path,
+				StatusCode:   wrapped.statusCode,
+				IPAddress:    r.RemoteAddr,
+				UserAgent:    r.UserAgent(),
+			}
+			if apiKey := GetAPIKeyFromContext(r); apiKey != nil {
+				entry.APIKeyID = &apiKey.ID
+			}
+
+			if err := auditLogService.Record(entry, before, after); err != nil {
+				log.Printf("⚠️  WARNING: failed to write audit log for %s %s: %v", r.Method, r.URL.Path, err)
+			}
+		})
+	}
+}
+
+// routeResourceID returns the first URL parameter of the matched route,
+// e.g. {id} or {tx_id}.
+func routeResourceID(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return ""
+	}
+
+	for i, key := range rctx.URLParams.Keys {
+		if key != "*" && i < len(rctx.URLParams.Values) {
+			return rctx.URLParams.Values[i]
+		}
+	}
+	return ""
+}
+
+// createdResourceID reads data.<keyColumn> (or data.id) from a
+// utils.WriteJSON response body.
+func createdResourceID(body []byte, keyColumn string) string {
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || response.Data == nil {
+		return ""
+	}
+
+	value, ok := response.Data[keyColumn]
+	if !ok {
+		value, ok = response.Data["id"]
+	}
+	if !ok || value == nil {
+		return ""
+	}
+
+	if number, ok := value.(float64); ok {
+		return fmt.Sprintf("%.0f", number)
+	}
+	return fmt.Sprint(value)
+}
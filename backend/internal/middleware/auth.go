@@ -36,21 +36,32 @@ func AuthMiddleware(userService services.UserService, jwtService *utils.JWTServi
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			log.Printf("🔐 AUTH MIDDLEWARE - Path: %s %s", r.Method, r.URL.Path)
 
+			var tokenString string
+
 			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
+			if authHeader != "" {
+				tokenParts := strings.SplitN(authHeader, " ", 2)
+				if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+					log.Printf("❌ AUTH ERROR: Invalid header format: %s", authHeader)
+					sendError(w, http.StatusUnauthorized, "unauthorized", "Invalid authorization header format")
+					return
+				}
+				tokenString = tokenParts[1]
+			} else if cookie, err := r.Cookie(utils.AccessTokenCookie); err == nil && cookie.Value != "" {
+				// Browsers send cookies on their own, so cookie sessions must
+				// prove the request came from our app with the CSRF token.
+				if isMutatingMethod(r.Method) && !utils.ValidCSRFToken(r) {
+					log.Printf("❌ AUTH ERROR: Missing or invalid CSRF token - Path: %s %s", r.Method, r.URL.Path)
+					sendError(w, http.StatusForbidden, "forbidden", "Invalid CSRF token")
+					return
+				}
+				tokenString = cookie.Value
+			} else {
 				log.Println("❌ AUTH ERROR: No Authorization header")
 				sendError(w, http.StatusUnauthorized, "unauthorized", "Authorization header is required")
 				return
 			}
 
-			tokenParts := strings.SplitN(authHeader, " ", 2)
-			if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-				log.Printf("❌ AUTH ERROR: Invalid header format: %s", authHeader)
-				sendError(w, http.StatusUnauthorized, "unauthorized", "Invalid authorization header format")
-				return
-			}
-
-			tokenString := tokenParts[1]
 			log.Printf("🔑 Validating token (first 20 chars): %s...", tokenString[:min(20, len(tokenString))])
 
 			// Validate token
@@ -110,12 +121,75 @@ func AuthMiddleware(userService services.UserService, jwtService *utils.JWTServi
 			ctx = context.WithValue(ctx, UserIDContextKey, user.ID)
 			ctx = context.WithValue(ctx, RoleIDContextKey, user.RoleID)
 
+			if claims.ImpersonatorID != nil {
+				log.Printf("🎭 AUTH SUCCESS - UserID: %d impersonated by UserID: %d", user.ID, *claims.ImpersonatorID)
+				ctx = context.WithValue(ctx, ImpersonatorIDContextKey, *claims.ImpersonatorID)
+				recordImpersonatedRequest(userService, user, *claims.ImpersonatorID, next, w, r.WithContext(ctx))
+				return
+			}
+
 			log.Printf("✅ AUTH SUCCESS - UserID: %d proceeding to handler", user.ID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// OptionalAuthMiddleware adds the signed-in user to the context of public
+// routes that show extra details to them. It never rejects a request: a
+// missing, invalid or expired token, or a user that cannot be loaded, just
+// leaves the request anonymous.
+func OptionalAuthMiddleware(userService services.UserService, jwtService *utils.JWTService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var tokenString string
+			if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+				tokenParts := strings.SplitN(authHeader, " ", 2)
+				if len(tokenParts) == 2 && tokenParts[0] == "Bearer" {
+					tokenString = tokenParts[1]
+				}
+			} else if cookie, err := r.Cookie(utils.AccessTokenCookie); err == nil {
+				tokenString = cookie.Value
+			}
+
+			if tokenString == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := jwtService.ValidateAccessToken(tokenString)
+			if err != nil || claims.UserID == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := userService.GetUserById(uint(claims.UserID))
+			if err != nil || user == nil || user.ID == 0 || !user.IsActive {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserContextKey, user)
+			ctx = context.WithValue(ctx, UserIDContextKey, user.ID)
+			ctx = context.WithValue(ctx, RoleIDContextKey, user.RoleID)
+			if claims.ImpersonatorID != nil {
+				ctx = context.WithValue(ctx, ImpersonatorIDContextKey, *claims.ImpersonatorID)
+				recordImpersonatedRequest(userService, user, *claims.ImpersonatorID, next, w, r.WithContext(ctx))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
 // Helper functions to get data from context
 func GetUserFromContext(r *http.Request) *models.User {
 	user, ok := r.Context().Value(UserContextKey).(*models.User)
@@ -144,6 +218,23 @@ func GetRoleIDFromContext(r *http.Request) uint {
 	return roleID
 }
 
+// GetActivityContext collects the authenticated user and request metadata
+// needed to write an activity log entry.
+func GetActivityContext(r *http.Request) models.ActivityContext {
+	activity := models.ActivityContext{
+		UserID:    GetUserIDFromContext(r),
+		IPAddress: r.RemoteAddr,
+		UserAgent: r.UserAgent(),
+	}
+
+	if apiKey := GetAPIKeyFromContext(r); apiKey != nil {
+		activity.APIKeyID = &apiKey.ID
+	}
+	activity.ImpersonatorID = GetImpersonatorIDFromContext(r)
+
+	return activity
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+const ImpersonatorIDContextKey contextKey = "impersonator_id"
+
+// GetImpersonatorIDFromContext returns the super admin acting as the current
+// user, or nil for a normal session.
+func GetImpersonatorIDFromContext(r *http.Request) *uint {
+	impersonatorID, ok := r.Context().Value(ImpersonatorIDContextKey).(uint)
+	if !ok {
+		return nil
+	}
+	return &impersonatorID
+}
+
+// ForbidImpersonation blocks actions that must only be done by the account
+// owner, such as changing a password, while impersonating.
+func ForbidImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if impersonatorID := GetImpersonatorIDFromContext(r); impersonatorID != nil {
+			log.Printf("❌ IMPERSONATION ERROR: user %d blocked %s %s", *impersonatorID, r.Method, r.URL.Path)
+			sendError(w, http.StatusForbidden, "forbidden", "This action is not allowed while impersonating")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// recordImpersonatedRequest runs next and writes an activity log entry for
+// the request made on behalf of user.
+func recordImpersonatedRequest(userService services.UserService, user *models.User, impersonatorID uint, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Impersonated-By", fmt.Sprint(impersonatorID))
+
+	wrapped := &responseWriter{
+		ResponseWriter: w,
+		statusCode:     http.StatusOK,
+	}
+
+	next.ServeHTTP(wrapped, r)
+
+	activity := models.ActivityLog{
+		UserID:         user.ID,
+		ImpersonatorID: &impersonatorID,
+		Action:         "impersonated_request",
+		Resource:       "impersonation",
+		Details:        fmt.Sprintf("%s %s by user %d (status %d)", r.Method, r.URL.Path, impersonatorID, wrapped.statusCode),
+		IPAddress:      r.RemoteAddr,
+		UserAgent:      r.UserAgent(),
+	}
+	if err := userService.RecordActivity(activity); err != nil {
+		log.Printf("⚠️  WARNING: failed to record impersonated request: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"go.uber.org/zap"
 )
 
@@ -57,6 +58,10 @@ func Logger(logger *zap.Logger) func(http.Handler) http.Handler {
 				zap.String("user_agent", r.UserAgent()),
 			}
 
+			if requestID := chimiddleware.GetReqID(r.Context()); requestID != "" {
+				fields = append(fields, zap.String("request_id", requestID))
+			}
+
 			if r.URL.RawQuery != "" {
 				fields = append(fields, zap.String("query", r.URL.RawQuery))
 			}
@@ -76,6 +81,17 @@ func Logger(logger *zap.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// RequestIDHeader mengembalikan request ID di response header agar client bisa
+// mencocokkannya dengan log dan audit log
+func RequestIDHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestID := chimiddleware.GetReqID(r.Context()); requestID != "" {
+			w.Header().Set(chimiddleware.RequestIDHeader, requestID)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // Recovery middleware untuk menangkap panic (Zap)
 func Recovery(logger *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
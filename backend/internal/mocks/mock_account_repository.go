@@ -0,0 +1,251 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/account_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/account_repository.go -destination=internal/mocks/mock_account_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockAccountRepository is a mock of AccountRepository interface.
+type MockAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountRepositoryMockRecorder is the mock recorder for MockAccountRepository.
+type MockAccountRepositoryMockRecorder struct {
+	mock *MockAccountRepository
+}
+
+// NewMockAccountRepository creates a new mock instance.
+func NewMockAccountRepository(ctrl *gomock.Controller) *MockAccountRepository {
+	mock := &MockAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Anonymize mocks base method.
+func (m *MockAccountRepository) Anonymize(userID uint, passwordHash string, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", userID, passwordHash, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockAccountRepositoryMockRecorder) Anonymize(userID, passwordHash, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockAccountRepository)(nil).Anonymize), userID, passwordHash, tx)
+}
+
+// CountOpenOrders mocks base method.
+func (m *MockAccountRepository) CountOpenOrders(userID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenOrders", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenOrders indicates an expected call of CountOpenOrders.
+func (mr *MockAccountRepositoryMockRecorder) CountOpenOrders(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenOrders", reflect.TypeOf((*MockAccountRepository)(nil).CountOpenOrders), userID)
+}
+
+// FindActivityLogs mocks base method.
+func (m *MockAccountRepository) FindActivityLogs(userID uint) ([]models.ActivityLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActivityLogs", userID)
+	ret0, _ := ret[0].([]models.ActivityLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActivityLogs indicates an expected call of FindActivityLogs.
+func (mr *MockAccountRepositoryMockRecorder) FindActivityLogs(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActivityLogs", reflect.TypeOf((*MockAccountRepository)(nil).FindActivityLogs), userID)
+}
+
+// FindAddresses mocks base method.
+func (m *MockAccountRepository) FindAddresses(userID uint) ([]models.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAddresses", userID)
+	ret0, _ := ret[0].([]models.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAddresses indicates an expected call of FindAddresses.
+func (mr *MockAccountRepositoryMockRecorder) FindAddresses(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAddresses", reflect.TypeOf((*MockAccountRepository)(nil).FindAddresses), userID)
+}
+
+// FindOrders mocks base method.
+func (m *MockAccountRepository) FindOrders(userID uint) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrders", userID)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrders indicates an expected call of FindOrders.
+func (mr *MockAccountRepositoryMockRecorder) FindOrders(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrders", reflect.TypeOf((*MockAccountRepository)(nil).FindOrders), userID)
+}
+
+// FindPaymentProofs mocks base method.
+func (m *MockAccountRepository) FindPaymentProofs(userID uint) ([]models.PaymentProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaymentProofs", userID)
+	ret0, _ := ret[0].([]models.PaymentProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPaymentProofs indicates an expected call of FindPaymentProofs.
+func (mr *MockAccountRepositoryMockRecorder) FindPaymentProofs(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaymentProofs", reflect.TypeOf((*MockAccountRepository)(nil).FindPaymentProofs), userID)
+}
+
+// FindPayments mocks base method.
+func (m *MockAccountRepository) FindPayments(txIDs []string) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPayments", txIDs)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPayments indicates an expected call of FindPayments.
+func (mr *MockAccountRepositoryMockRecorder) FindPayments(txIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPayments", reflect.TypeOf((*MockAccountRepository)(nil).FindPayments), txIDs)
+}
+
+// FindRefunds mocks base method.
+func (m *MockAccountRepository) FindRefunds(txIDs []string) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefunds", txIDs)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefunds indicates an expected call of FindRefunds.
+func (mr *MockAccountRepositoryMockRecorder) FindRefunds(txIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefunds", reflect.TypeOf((*MockAccountRepository)(nil).FindRefunds), txIDs)
+}
+
+// FindReturns mocks base method.
+func (m *MockAccountRepository) FindReturns(userID uint) ([]models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReturns", userID)
+	ret0, _ := ret[0].([]models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReturns indicates an expected call of FindReturns.
+func (mr *MockAccountRepositoryMockRecorder) FindReturns(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReturns", reflect.TypeOf((*MockAccountRepository)(nil).FindReturns), userID)
+}
+
+// FindShipments mocks base method.
+func (m *MockAccountRepository) FindShipments(txIDs []string) ([]models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShipments", txIDs)
+	ret0, _ := ret[0].([]models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShipments indicates an expected call of FindShipments.
+func (mr *MockAccountRepositoryMockRecorder) FindShipments(txIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShipments", reflect.TypeOf((*MockAccountRepository)(nil).FindShipments), txIDs)
+}
+
+// FindStockAlertNotifications mocks base method.
+func (m *MockAccountRepository) FindStockAlertNotifications(userID uint) ([]models.StockAlertNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStockAlertNotifications", userID)
+	ret0, _ := ret[0].([]models.StockAlertNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStockAlertNotifications indicates an expected call of FindStockAlertNotifications.
+func (mr *MockAccountRepositoryMockRecorder) FindStockAlertNotifications(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStockAlertNotifications", reflect.TypeOf((*MockAccountRepository)(nil).FindStockAlertNotifications), userID)
+}
+
+// FindStockAlerts mocks base method.
+func (m *MockAccountRepository) FindStockAlerts(userID uint) ([]models.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStockAlerts", userID)
+	ret0, _ := ret[0].([]models.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStockAlerts indicates an expected call of FindStockAlerts.
+func (mr *MockAccountRepositoryMockRecorder) FindStockAlerts(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStockAlerts", reflect.TypeOf((*MockAccountRepository)(nil).FindStockAlerts), userID)
+}
+
+// FindTransactions mocks base method.
+func (m *MockAccountRepository) FindTransactions(userID uint) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactions", userID)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactions indicates an expected call of FindTransactions.
+func (mr *MockAccountRepositoryMockRecorder) FindTransactions(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactions", reflect.TypeOf((*MockAccountRepository)(nil).FindTransactions), userID)
+}
+
+// FindWishlists mocks base method.
+func (m *MockAccountRepository) FindWishlists(userID uint) ([]models.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWishlists", userID)
+	ret0, _ := ret[0].([]models.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWishlists indicates an expected call of FindWishlists.
+func (mr *MockAccountRepositoryMockRecorder) FindWishlists(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWishlists", reflect.TypeOf((*MockAccountRepository)(nil).FindWishlists), userID)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/audit_log_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/audit_log_service.go -destination=internal/mocks/mock_audit_log_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditLogService is a mock of AuditLogService interface.
+type MockAuditLogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditLogServiceMockRecorder is the mock recorder for MockAuditLogService.
+type MockAuditLogServiceMockRecorder struct {
+	mock *MockAuditLogService
+}
+
+// NewMockAuditLogService creates a new mock instance.
+func NewMockAuditLogService(ctrl *gomock.Controller) *MockAuditLogService {
+	mock := &MockAuditLogService{ctrl: ctrl}
+	mock.recorder = &MockAuditLogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogService) EXPECT() *MockAuditLogServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockAuditLogService) GetAuditLogs(req *models.AuditLogListRequest) (*models.AuditLogListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", req)
+	ret0, _ := ret[0].(*models.AuditLogListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockAuditLogServiceMockRecorder) GetAuditLogs(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockAuditLogService)(nil).GetAuditLogs), req)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockAuditLogService) PurgeExpired(retention time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", retention)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockAuditLogServiceMockRecorder) PurgeExpired(retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockAuditLogService)(nil).PurgeExpired), retention)
+}
+
+// Record mocks base method.
+func (m *MockAuditLogService) Record(entry models.AuditLog, before, after map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", entry, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditLogServiceMockRecorder) Record(entry, before, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditLogService)(nil).Record), entry, before, after)
+}
+
+// Snapshot mocks base method.
+func (m *MockAuditLogService) Snapshot(resourceType, keyColumn, id string) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", resourceType, keyColumn, id)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockAuditLogServiceMockRecorder) Snapshot(resourceType, keyColumn, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockAuditLogService)(nil).Snapshot), resourceType, keyColumn, id)
+}
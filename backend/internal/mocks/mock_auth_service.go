@@ -70,6 +70,36 @@ func (mr *MockAuthServiceMockRecorder) LoginAdmin(req, ipAddress, userAgent any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginAdmin", reflect.TypeOf((*MockAuthService)(nil).LoginAdmin), req, ipAddress, userAgent)
 }
 
+// OIDCAuthorize mocks base method.
+func (m *MockAuthService) OIDCAuthorize(provider string) (*models.OIDCAuthorizeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCAuthorize", provider)
+	ret0, _ := ret[0].(*models.OIDCAuthorizeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OIDCAuthorize indicates an expected call of OIDCAuthorize.
+func (mr *MockAuthServiceMockRecorder) OIDCAuthorize(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCAuthorize", reflect.TypeOf((*MockAuthService)(nil).OIDCAuthorize), provider)
+}
+
+// OIDCCallback mocks base method.
+func (m *MockAuthService) OIDCCallback(provider string, req models.OIDCCallbackRequest, ipAddress, userAgent string) (*models.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCCallback", provider, req, ipAddress, userAgent)
+	ret0, _ := ret[0].(*models.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OIDCCallback indicates an expected call of OIDCCallback.
+func (mr *MockAuthServiceMockRecorder) OIDCCallback(provider, req, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCCallback", reflect.TypeOf((*MockAuthService)(nil).OIDCCallback), provider, req, ipAddress, userAgent)
+}
+
 // RefreshToken mocks base method.
 func (m *MockAuthService) RefreshToken(req models.RefreshTokenRequest) (*models.TokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -41,6 +41,36 @@ func (m *MockCategoryRepository) EXPECT() *MockCategoryRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountChildren mocks base method.
+func (m *MockCategoryRepository) CountChildren(categoryId int64, tx *gorm.DB) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", categoryId, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockCategoryRepositoryMockRecorder) CountChildren(categoryId, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockCategoryRepository)(nil).CountChildren), categoryId, tx)
+}
+
+// CountProducts mocks base method.
+func (m *MockCategoryRepository) CountProducts(categoryId int64, tx *gorm.DB) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProducts", categoryId, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProducts indicates an expected call of CountProducts.
+func (mr *MockCategoryRepositoryMockRecorder) CountProducts(categoryId, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockCategoryRepository)(nil).CountProducts), categoryId, tx)
+}
+
 // Create mocks base method.
 func (m *MockCategoryRepository) Create(param models.Category, tx *gorm.DB) (models.Category, error) {
 	m.ctrl.T.Helper()
@@ -57,17 +87,17 @@ func (mr *MockCategoryRepositoryMockRecorder) Create(param, tx any) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockCategoryRepository) Delete(param models.Category) error {
+func (m *MockCategoryRepository) Delete(param models.Category, tx *gorm.DB) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", param)
+	ret := m.ctrl.Call(m, "Delete", param, tx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCategoryRepositoryMockRecorder) Delete(param any) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) Delete(param, tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), param)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), param, tx)
 }
 
 // FindAll mocks base method.
@@ -85,6 +115,21 @@ func (mr *MockCategoryRepositoryMockRecorder) FindAll(param any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryRepository)(nil).FindAll), param)
 }
 
+// FindAllTree mocks base method.
+func (m *MockCategoryRepository) FindAllTree() ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllTree")
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllTree indicates an expected call of FindAllTree.
+func (mr *MockCategoryRepositoryMockRecorder) FindAllTree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTree", reflect.TypeOf((*MockCategoryRepository)(nil).FindAllTree))
+}
+
 // FindById mocks base method.
 func (m *MockCategoryRepository) FindById(paramId int64) (models.Category, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +160,50 @@ func (mr *MockCategoryRepositoryMockRecorder) FindByIds(paramId any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockCategoryRepository)(nil).FindByIds), paramId)
 }
 
+// FindBySlug mocks base method.
+func (m *MockCategoryRepository) FindBySlug(slug string) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySlug", slug)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySlug indicates an expected call of FindBySlug.
+func (mr *MockCategoryRepositoryMockRecorder) FindBySlug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySlug", reflect.TypeOf((*MockCategoryRepository)(nil).FindBySlug), slug)
+}
+
+// ReassignProducts mocks base method.
+func (m *MockCategoryRepository) ReassignProducts(fromId, toId int64, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignProducts", fromId, toId, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReassignProducts indicates an expected call of ReassignProducts.
+func (mr *MockCategoryRepositoryMockRecorder) ReassignProducts(fromId, toId, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignProducts", reflect.TypeOf((*MockCategoryRepository)(nil).ReassignProducts), fromId, toId, tx)
+}
+
+// SlugExists mocks base method.
+func (m *MockCategoryRepository) SlugExists(slug string, excludeId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SlugExists", slug, excludeId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SlugExists indicates an expected call of SlugExists.
+func (mr *MockCategoryRepositoryMockRecorder) SlugExists(slug, excludeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SlugExists", reflect.TypeOf((*MockCategoryRepository)(nil).SlugExists), slug, excludeId)
+}
+
 // Update mocks base method.
 func (m *MockCategoryRepository) Update(param models.Category, tx *gorm.DB) (models.Category, error) {
 	m.ctrl.T.Helper()
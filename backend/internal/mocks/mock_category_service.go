@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/category_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/category_service.go -destination=internal/mocks/mock_category_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCategoryService is a mock of CategoryService interface.
+type MockCategoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoryServiceMockRecorder is the mock recorder for MockCategoryService.
+type MockCategoryServiceMockRecorder struct {
+	mock *MockCategoryService
+}
+
+// NewMockCategoryService creates a new mock instance.
+func NewMockCategoryService(ctrl *gomock.Controller) *MockCategoryService {
+	mock := &MockCategoryService{ctrl: ctrl}
+	mock.recorder = &MockCategoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryService) EXPECT() *MockCategoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategoryService) Create(param models.Category, actor models.ActivityContext) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", param, actor)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryServiceMockRecorder) Create(param, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryService)(nil).Create), param, actor)
+}
+
+// Delete mocks base method.
+func (m *MockCategoryService) Delete(id, reassignTo int64, actor models.ActivityContext) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, reassignTo, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryServiceMockRecorder) Delete(id, reassignTo, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryService)(nil).Delete), id, reassignTo, actor)
+}
+
+// GetAll mocks base method.
+func (m *MockCategoryService) GetAll(param models.CategoryListRequest) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", param)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCategoryServiceMockRecorder) GetAll(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCategoryService)(nil).GetAll), param)
+}
+
+// GetById mocks base method.
+func (m *MockCategoryService) GetById(id int64) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", id)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCategoryServiceMockRecorder) GetById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCategoryService)(nil).GetById), id)
+}
+
+// GetByIds mocks base method.
+func (m *MockCategoryService) GetByIds(ids []int64) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ids)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockCategoryServiceMockRecorder) GetByIds(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockCategoryService)(nil).GetByIds), ids)
+}
+
+// GetBySlug mocks base method.
+func (m *MockCategoryService) GetBySlug(slug string) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", slug)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockCategoryServiceMockRecorder) GetBySlug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockCategoryService)(nil).GetBySlug), slug)
+}
+
+// GetTree mocks base method.
+func (m *MockCategoryService) GetTree() ([]models.CategoryTreeNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree")
+	ret0, _ := ret[0].([]models.CategoryTreeNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockCategoryServiceMockRecorder) GetTree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockCategoryService)(nil).GetTree))
+}
+
+// Update mocks base method.
+func (m *MockCategoryService) Update(param models.Category, actor models.ActivityContext) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", param, actor)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryServiceMockRecorder) Update(param, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryService)(nil).Update), param, actor)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/currency_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/currency_service.go -destination=internal/mocks/mock_currency_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCurrencyService is a mock of CurrencyService interface.
+type MockCurrencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCurrencyServiceMockRecorder is the mock recorder for MockCurrencyService.
+type MockCurrencyServiceMockRecorder struct {
+	mock *MockCurrencyService
+}
+
+// NewMockCurrencyService creates a new mock instance.
+func NewMockCurrencyService(ctrl *gomock.Controller) *MockCurrencyService {
+	mock := &MockCurrencyService{ctrl: ctrl}
+	mock.recorder = &MockCurrencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyService) EXPECT() *MockCurrencyServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCurrency mocks base method.
+func (m *MockCurrencyService) CreateCurrency(actor models.ActivityContext, param models.CreateCurrency) (*models.CurrencyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCurrency", actor, param)
+	ret0, _ := ret[0].(*models.CurrencyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCurrency indicates an expected call of CreateCurrency.
+func (mr *MockCurrencyServiceMockRecorder) CreateCurrency(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCurrency", reflect.TypeOf((*MockCurrencyService)(nil).CreateCurrency), actor, param)
+}
+
+// DeleteCurrency mocks base method.
+func (m *MockCurrencyService) DeleteCurrency(actor models.ActivityContext, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCurrency", actor, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCurrency indicates an expected call of DeleteCurrency.
+func (mr *MockCurrencyServiceMockRecorder) DeleteCurrency(actor, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCurrency", reflect.TypeOf((*MockCurrencyService)(nil).DeleteCurrency), actor, code)
+}
+
+// FindAll mocks base method.
+func (m *MockCurrencyService) FindAll(activeOnly bool) ([]models.CurrencyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", activeOnly)
+	ret0, _ := ret[0].([]models.CurrencyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCurrencyServiceMockRecorder) FindAll(activeOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCurrencyService)(nil).FindAll), activeOnly)
+}
+
+// ImportRates mocks base method.
+func (m *MockCurrencyService) ImportRates(actor models.ActivityContext, param models.ImportExchangeRates) (*models.ExchangeRateImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportRates", actor, param)
+	ret0, _ := ret[0].(*models.ExchangeRateImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportRates indicates an expected call of ImportRates.
+func (mr *MockCurrencyServiceMockRecorder) ImportRates(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportRates", reflect.TypeOf((*MockCurrencyService)(nil).ImportRates), actor, param)
+}
+
+// Resolve mocks base method.
+func (m *MockCurrencyService) Resolve(code string) (*models.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", code)
+	ret0, _ := ret[0].(*models.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockCurrencyServiceMockRecorder) Resolve(code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockCurrencyService)(nil).Resolve), code)
+}
+
+// UpdateCurrency mocks base method.
+func (m *MockCurrencyService) UpdateCurrency(actor models.ActivityContext, param models.CreateCurrency) (*models.CurrencyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrency", actor, param)
+	ret0, _ := ret[0].(*models.CurrencyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCurrency indicates an expected call of UpdateCurrency.
+func (mr *MockCurrencyServiceMockRecorder) UpdateCurrency(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrency", reflect.TypeOf((*MockCurrencyService)(nil).UpdateCurrency), actor, param)
+}
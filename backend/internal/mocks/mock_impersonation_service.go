@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/impersonation_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/impersonation_service.go -destination=internal/mocks/mock_impersonation_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImpersonationService is a mock of ImpersonationService interface.
+type MockImpersonationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockImpersonationServiceMockRecorder is the mock recorder for MockImpersonationService.
+type MockImpersonationServiceMockRecorder struct {
+	mock *MockImpersonationService
+}
+
+// NewMockImpersonationService creates a new mock instance.
+func NewMockImpersonationService(ctrl *gomock.Controller) *MockImpersonationService {
+	mock := &MockImpersonationService{ctrl: ctrl}
+	mock.recorder = &MockImpersonationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonationService) EXPECT() *MockImpersonationServiceMockRecorder {
+	return m.recorder
+}
+
+// Impersonate mocks base method.
+func (m *MockImpersonationService) Impersonate(actor models.ActivityContext, req models.ImpersonationRequest) (*models.ImpersonationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Impersonate", actor, req)
+	ret0, _ := ret[0].(*models.ImpersonationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Impersonate indicates an expected call of Impersonate.
+func (mr *MockImpersonationServiceMockRecorder) Impersonate(actor, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Impersonate", reflect.TypeOf((*MockImpersonationService)(nil).Impersonate), actor, req)
+}
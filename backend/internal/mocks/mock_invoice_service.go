@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/invoice_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/invoice_service.go -destination=internal/mocks/mock_invoice_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInvoiceService is a mock of InvoiceService interface.
+type MockInvoiceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoiceServiceMockRecorder is the mock recorder for MockInvoiceService.
+type MockInvoiceServiceMockRecorder struct {
+	mock *MockInvoiceService
+}
+
+// NewMockInvoiceService creates a new mock instance.
+func NewMockInvoiceService(ctrl *gomock.Controller) *MockInvoiceService {
+	mock := &MockInvoiceService{ctrl: ctrl}
+	mock.recorder = &MockInvoiceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceService) EXPECT() *MockInvoiceServiceMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockInvoiceService) Render(document, transactionID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", document, transactionID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockInvoiceServiceMockRecorder) Render(document, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockInvoiceService)(nil).Render), document, transactionID)
+}
+
+// RenderBulk mocks base method.
+func (m *MockInvoiceService) RenderBulk(param models.BulkDocumentRequest) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderBulk", param)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderBulk indicates an expected call of RenderBulk.
+func (mr *MockInvoiceServiceMockRecorder) RenderBulk(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderBulk", reflect.TypeOf((*MockInvoiceService)(nil).RenderBulk), param)
+}
+
+// RenderMine mocks base method.
+func (m *MockInvoiceService) RenderMine(document, transactionID string, userID uint) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderMine", document, transactionID, userID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderMine indicates an expected call of RenderMine.
+func (mr *MockInvoiceServiceMockRecorder) RenderMine(document, transactionID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderMine", reflect.TypeOf((*MockInvoiceService)(nil).RenderMine), document, transactionID, userID)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/payment_proof_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/payment_proof_service.go -destination=internal/mocks/mock_payment_proof_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	multipart "mime/multipart"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentProofService is a mock of PaymentProofService interface.
+type MockPaymentProofService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProofServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentProofServiceMockRecorder is the mock recorder for MockPaymentProofService.
+type MockPaymentProofServiceMockRecorder struct {
+	mock *MockPaymentProofService
+}
+
+// NewMockPaymentProofService creates a new mock instance.
+func NewMockPaymentProofService(ctrl *gomock.Controller) *MockPaymentProofService {
+	mock := &MockPaymentProofService{ctrl: ctrl}
+	mock.recorder = &MockPaymentProofServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentProofService) EXPECT() *MockPaymentProofServiceMockRecorder {
+	return m.recorder
+}
+
+// ApproveProof mocks base method.
+func (m *MockPaymentProofService) ApproveProof(actor models.ActivityContext, id int64) (*models.PaymentProofResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveProof", actor, id)
+	ret0, _ := ret[0].(*models.PaymentProofResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveProof indicates an expected call of ApproveProof.
+func (mr *MockPaymentProofServiceMockRecorder) ApproveProof(actor, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveProof", reflect.TypeOf((*MockPaymentProofService)(nil).ApproveProof), actor, id)
+}
+
+// FindAllProof mocks base method.
+func (m *MockPaymentProofService) FindAllProof(param models.PaymentProofListRequest) (*models.PaymentProofListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllProof", param)
+	ret0, _ := ret[0].(*models.PaymentProofListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllProof indicates an expected call of FindAllProof.
+func (mr *MockPaymentProofServiceMockRecorder) FindAllProof(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllProof", reflect.TypeOf((*MockPaymentProofService)(nil).FindAllProof), param)
+}
+
+// FindById mocks base method.
+func (m *MockPaymentProofService) FindById(id int64) (*models.PaymentProofResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*models.PaymentProofResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPaymentProofServiceMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPaymentProofService)(nil).FindById), id)
+}
+
+// RejectProof mocks base method.
+func (m *MockPaymentProofService) RejectProof(actor models.ActivityContext, param models.RejectPaymentProof) (*models.PaymentProofResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectProof", actor, param)
+	ret0, _ := ret[0].(*models.PaymentProofResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectProof indicates an expected call of RejectProof.
+func (mr *MockPaymentProofServiceMockRecorder) RejectProof(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectProof", reflect.TypeOf((*MockPaymentProofService)(nil).RejectProof), actor, param)
+}
+
+// SubmitProof mocks base method.
+func (m *MockPaymentProofService) SubmitProof(actor models.ActivityContext, param models.CreatePaymentProof, image *multipart.FileHeader) (*models.PaymentProofResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitProof", actor, param, image)
+	ret0, _ := ret[0].(*models.PaymentProofResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitProof indicates an expected call of SubmitProof.
+func (mr *MockPaymentProofServiceMockRecorder) SubmitProof(actor, param, image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitProof", reflect.TypeOf((*MockPaymentProofService)(nil).SubmitProof), actor, param, image)
+}
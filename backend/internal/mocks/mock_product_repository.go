@@ -234,6 +234,21 @@ func (mr *MockProductRepositoryMockRecorder) FindProductById(id, tx any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductById", reflect.TypeOf((*MockProductRepository)(nil).FindProductById), id, tx)
 }
 
+// FindSizeVarianByBarcode mocks base method.
+func (m *MockProductRepository) FindSizeVarianByBarcode(barcode string, tx *gorm.DB) (*models.SizeVarian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSizeVarianByBarcode", barcode, tx)
+	ret0, _ := ret[0].(*models.SizeVarian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSizeVarianByBarcode indicates an expected call of FindSizeVarianByBarcode.
+func (mr *MockProductRepositoryMockRecorder) FindSizeVarianByBarcode(barcode, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSizeVarianByBarcode", reflect.TypeOf((*MockProductRepository)(nil).FindSizeVarianByBarcode), barcode, tx)
+}
+
 // FindSizeVarianByColorVarianId mocks base method.
 func (m *MockProductRepository) FindSizeVarianByColorVarianId(colorVarianId int64, tx *gorm.DB) ([]models.SizeVarian, error) {
 	m.ctrl.T.Helper()
@@ -264,6 +279,21 @@ func (mr *MockProductRepositoryMockRecorder) FindSizeVarianById(id, tx any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSizeVarianById", reflect.TypeOf((*MockProductRepository)(nil).FindSizeVarianById), id, tx)
 }
 
+// FindSizeVarianBySKU mocks base method.
+func (m *MockProductRepository) FindSizeVarianBySKU(sku string, tx *gorm.DB) (*models.SizeVarian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSizeVarianBySKU", sku, tx)
+	ret0, _ := ret[0].(*models.SizeVarian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSizeVarianBySKU indicates an expected call of FindSizeVarianBySKU.
+func (mr *MockProductRepositoryMockRecorder) FindSizeVarianBySKU(sku, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSizeVarianBySKU", reflect.TypeOf((*MockProductRepository)(nil).FindSizeVarianBySKU), sku, tx)
+}
+
 // FindSizeVarianLocked mocks base method.
 func (m *MockProductRepository) FindSizeVarianLocked(tx *gorm.DB, id uint) (*models.SizeVarian, error) {
 	m.ctrl.T.Helper()
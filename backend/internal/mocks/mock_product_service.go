@@ -115,6 +115,36 @@ func (mr *MockProductServiceMockRecorder) FindProductById(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductById", reflect.TypeOf((*MockProductService)(nil).FindProductById), id)
 }
 
+// FindSizeVarianByBarcode mocks base method.
+func (m *MockProductService) FindSizeVarianByBarcode(barcode string) (*models.SizeVarianLookupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSizeVarianByBarcode", barcode)
+	ret0, _ := ret[0].(*models.SizeVarianLookupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSizeVarianByBarcode indicates an expected call of FindSizeVarianByBarcode.
+func (mr *MockProductServiceMockRecorder) FindSizeVarianByBarcode(barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSizeVarianByBarcode", reflect.TypeOf((*MockProductService)(nil).FindSizeVarianByBarcode), barcode)
+}
+
+// FindSizeVarianBySKU mocks base method.
+func (m *MockProductService) FindSizeVarianBySKU(sku string) (*models.SizeVarianLookupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSizeVarianBySKU", sku)
+	ret0, _ := ret[0].(*models.SizeVarianLookupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSizeVarianBySKU indicates an expected call of FindSizeVarianBySKU.
+func (mr *MockProductServiceMockRecorder) FindSizeVarianBySKU(sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSizeVarianBySKU", reflect.TypeOf((*MockProductService)(nil).FindSizeVarianBySKU), sku)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockProductService) UpdateProduct(param models.UpdateProductParam) (*models.ProductDetailResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/rbac_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/rbac_repository.go -destination=internal/mocks/mock_rbac_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRBACRepository is a mock of RBACRepository interface.
+type MockRBACRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRBACRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRBACRepositoryMockRecorder is the mock recorder for MockRBACRepository.
+type MockRBACRepositoryMockRecorder struct {
+	mock *MockRBACRepository
+}
+
+// NewMockRBACRepository creates a new mock instance.
+func NewMockRBACRepository(ctrl *gomock.Controller) *MockRBACRepository {
+	mock := &MockRBACRepository{ctrl: ctrl}
+	mock.recorder = &MockRBACRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRBACRepository) EXPECT() *MockRBACRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserRole mocks base method.
+func (m *MockRBACRepository) GetUserRole(userID uint) (*models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRole", userID)
+	ret0, _ := ret[0].(*models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRole indicates an expected call of GetUserRole.
+func (mr *MockRBACRepositoryMockRecorder) GetUserRole(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRole", reflect.TypeOf((*MockRBACRepository)(nil).GetUserRole), userID)
+}
+
+// HasPermission mocks base method.
+func (m *MockRBACRepository) HasPermission(userID uint, resource, action string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", userID, resource, action)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockRBACRepositoryMockRecorder) HasPermission(userID, resource, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockRBACRepository)(nil).HasPermission), userID, resource, action)
+}
+
+// IsOwner mocks base method.
+func (m *MockRBACRepository) IsOwner(userID uint, resource string, resourceID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsOwner", userID, resource, resourceID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsOwner indicates an expected call of IsOwner.
+func (mr *MockRBACRepositoryMockRecorder) IsOwner(userID, resource, resourceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOwner", reflect.TypeOf((*MockRBACRepository)(nil).IsOwner), userID, resource, resourceID)
+}
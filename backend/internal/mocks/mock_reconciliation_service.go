@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/reconciliation_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/reconciliation_service.go -destination=internal/mocks/mock_reconciliation_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockReconciliationService is a mock of ReconciliationService interface.
+type MockReconciliationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReconciliationServiceMockRecorder is the mock recorder for MockReconciliationService.
+type MockReconciliationServiceMockRecorder struct {
+	mock *MockReconciliationService
+}
+
+// NewMockReconciliationService creates a new mock instance.
+func NewMockReconciliationService(ctrl *gomock.Controller) *MockReconciliationService {
+	mock := &MockReconciliationService{ctrl: ctrl}
+	mock.recorder = &MockReconciliationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationService) EXPECT() *MockReconciliationServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignPayableAmount mocks base method.
+func (m *MockReconciliationService) AssignPayableAmount(tx *gorm.DB, transaction *models.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignPayableAmount", tx, transaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignPayableAmount indicates an expected call of AssignPayableAmount.
+func (mr *MockReconciliationServiceMockRecorder) AssignPayableAmount(tx, transaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignPayableAmount", reflect.TypeOf((*MockReconciliationService)(nil).AssignPayableAmount), tx, transaction)
+}
+
+// FindAllLines mocks base method.
+func (m *MockReconciliationService) FindAllLines(param models.StatementLineListRequest) (*models.StatementLineListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllLines", param)
+	ret0, _ := ret[0].(*models.StatementLineListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllLines indicates an expected call of FindAllLines.
+func (mr *MockReconciliationServiceMockRecorder) FindAllLines(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllLines", reflect.TypeOf((*MockReconciliationService)(nil).FindAllLines), param)
+}
+
+// ImportStatement mocks base method.
+func (m *MockReconciliationService) ImportStatement(actor models.ActivityContext, param models.ImportBankStatement) (*models.BankStatementImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportStatement", actor, param)
+	ret0, _ := ret[0].(*models.BankStatementImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportStatement indicates an expected call of ImportStatement.
+func (mr *MockReconciliationServiceMockRecorder) ImportStatement(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportStatement", reflect.TypeOf((*MockReconciliationService)(nil).ImportStatement), actor, param)
+}
+
+// ResolveLine mocks base method.
+func (m *MockReconciliationService) ResolveLine(actor models.ActivityContext, param models.ResolveStatementLine) (*models.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLine", actor, param)
+	ret0, _ := ret[0].(*models.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLine indicates an expected call of ResolveLine.
+func (mr *MockReconciliationServiceMockRecorder) ResolveLine(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLine", reflect.TypeOf((*MockReconciliationService)(nil).ResolveLine), actor, param)
+}
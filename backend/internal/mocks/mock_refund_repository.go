@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/refund_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/refund_repository.go -destination=internal/mocks/mock_refund_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockRefundRepository is a mock of RefundRepository interface.
+type MockRefundRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRefundRepositoryMockRecorder is the mock recorder for MockRefundRepository.
+type MockRefundRepositoryMockRecorder struct {
+	mock *MockRefundRepository
+}
+
+// NewMockRefundRepository creates a new mock instance.
+func NewMockRefundRepository(ctrl *gomock.Controller) *MockRefundRepository {
+	mock := &MockRefundRepository{ctrl: ctrl}
+	mock.recorder = &MockRefundRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundRepository) EXPECT() *MockRefundRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefundRepository) Create(param models.Refund, tx *gorm.DB) (models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", param, tx)
+	ret0, _ := ret[0].(models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefundRepositoryMockRecorder) Create(param, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefundRepository)(nil).Create), param, tx)
+}
+
+// FindAll mocks base method.
+func (m *MockRefundRepository) FindAll(param models.RefundListRequest) ([]models.Refund, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", param)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRefundRepositoryMockRecorder) FindAll(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRefundRepository)(nil).FindAll), param)
+}
+
+// FindById mocks base method.
+func (m *MockRefundRepository) FindById(id int64) (models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockRefundRepositoryMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockRefundRepository)(nil).FindById), id)
+}
+
+// FindOrdersLocked mocks base method.
+func (m *MockRefundRepository) FindOrdersLocked(tx *gorm.DB, transactionID string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrdersLocked", tx, transactionID)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrdersLocked indicates an expected call of FindOrdersLocked.
+func (mr *MockRefundRepositoryMockRecorder) FindOrdersLocked(tx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrdersLocked", reflect.TypeOf((*MockRefundRepository)(nil).FindOrdersLocked), tx, transactionID)
+}
+
+// FindPaymentLocked mocks base method.
+func (m *MockRefundRepository) FindPaymentLocked(tx *gorm.DB, id int64) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaymentLocked", tx, id)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPaymentLocked indicates an expected call of FindPaymentLocked.
+func (mr *MockRefundRepositoryMockRecorder) FindPaymentLocked(tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaymentLocked", reflect.TypeOf((*MockRefundRepository)(nil).FindPaymentLocked), tx, id)
+}
+
+// RefundedQuantities mocks base method.
+func (m *MockRefundRepository) RefundedQuantities(orderIDs []string, tx *gorm.DB) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundedQuantities", orderIDs, tx)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundedQuantities indicates an expected call of RefundedQuantities.
+func (mr *MockRefundRepositoryMockRecorder) RefundedQuantities(orderIDs, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundedQuantities", reflect.TypeOf((*MockRefundRepository)(nil).RefundedQuantities), orderIDs, tx)
+}
+
+// SumByPayment mocks base method.
+func (m *MockRefundRepository) SumByPayment(paymentID int64, tx *gorm.DB) (models.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByPayment", paymentID, tx)
+	ret0, _ := ret[0].(models.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByPayment indicates an expected call of SumByPayment.
+func (mr *MockRefundRepositoryMockRecorder) SumByPayment(paymentID, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByPayment", reflect.TypeOf((*MockRefundRepository)(nil).SumByPayment), paymentID, tx)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/refund_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/refund_service.go -destination=internal/mocks/mock_refund_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockRefundService is a mock of RefundService interface.
+type MockRefundService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRefundServiceMockRecorder is the mock recorder for MockRefundService.
+type MockRefundServiceMockRecorder struct {
+	mock *MockRefundService
+}
+
+// NewMockRefundService creates a new mock instance.
+func NewMockRefundService(ctrl *gomock.Controller) *MockRefundService {
+	mock := &MockRefundService{ctrl: ctrl}
+	mock.recorder = &MockRefundServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundService) EXPECT() *MockRefundServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRefund mocks base method.
+func (m *MockRefundService) CreateRefund(actor models.ActivityContext, param models.CreateRefund) (*models.PaymentRefundSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefund", actor, param)
+	ret0, _ := ret[0].(*models.PaymentRefundSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefund indicates an expected call of CreateRefund.
+func (mr *MockRefundServiceMockRecorder) CreateRefund(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefund", reflect.TypeOf((*MockRefundService)(nil).CreateRefund), actor, param)
+}
+
+// FindAllRefund mocks base method.
+func (m *MockRefundService) FindAllRefund(param models.RefundListRequest) (*models.RefundListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllRefund", param)
+	ret0, _ := ret[0].(*models.RefundListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllRefund indicates an expected call of FindAllRefund.
+func (mr *MockRefundServiceMockRecorder) FindAllRefund(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllRefund", reflect.TypeOf((*MockRefundService)(nil).FindAllRefund), param)
+}
+
+// FindById mocks base method.
+func (m *MockRefundService) FindById(id int64) (*models.RefundResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*models.RefundResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockRefundServiceMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockRefundService)(nil).FindById), id)
+}
+
+// RefundPayment mocks base method.
+func (m *MockRefundService) RefundPayment(actor models.ActivityContext, param models.CreateRefund, tx *gorm.DB) (*models.PaymentRefundSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", actor, param, tx)
+	ret0, _ := ret[0].(*models.PaymentRefundSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockRefundServiceMockRecorder) RefundPayment(actor, param, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockRefundService)(nil).RefundPayment), actor, param, tx)
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/return_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/return_repository.go -destination=internal/mocks/mock_return_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockReturnRepository is a mock of ReturnRepository interface.
+type MockReturnRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReturnRepositoryMockRecorder is the mock recorder for MockReturnRepository.
+type MockReturnRepositoryMockRecorder struct {
+	mock *MockReturnRepository
+}
+
+// NewMockReturnRepository creates a new mock instance.
+func NewMockReturnRepository(ctrl *gomock.Controller) *MockReturnRepository {
+	mock := &MockReturnRepository{ctrl: ctrl}
+	mock.recorder = &MockReturnRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnRepository) EXPECT() *MockReturnRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReturnRepository) Create(param models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", param, tx)
+	ret0, _ := ret[0].(models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnRepositoryMockRecorder) Create(param, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnRepository)(nil).Create), param, tx)
+}
+
+// FindAll mocks base method.
+func (m *MockReturnRepository) FindAll(param models.ReturnListRequest) ([]models.ReturnRequest, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", param)
+	ret0, _ := ret[0].([]models.ReturnRequest)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockReturnRepositoryMockRecorder) FindAll(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockReturnRepository)(nil).FindAll), param)
+}
+
+// FindById mocks base method.
+func (m *MockReturnRepository) FindById(id int64) (models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockReturnRepositoryMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockReturnRepository)(nil).FindById), id)
+}
+
+// FindByIdLocked mocks base method.
+func (m *MockReturnRepository) FindByIdLocked(tx *gorm.DB, id int64) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdLocked", tx, id)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdLocked indicates an expected call of FindByIdLocked.
+func (mr *MockReturnRepositoryMockRecorder) FindByIdLocked(tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdLocked", reflect.TypeOf((*MockReturnRepository)(nil).FindByIdLocked), tx, id)
+}
+
+// FindOrdersLocked mocks base method.
+func (m *MockReturnRepository) FindOrdersLocked(tx *gorm.DB, orderIDs []string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrdersLocked", tx, orderIDs)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrdersLocked indicates an expected call of FindOrdersLocked.
+func (mr *MockReturnRepositoryMockRecorder) FindOrdersLocked(tx, orderIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrdersLocked", reflect.TypeOf((*MockReturnRepository)(nil).FindOrdersLocked), tx, orderIDs)
+}
+
+// FindPaymentByTransaction mocks base method.
+func (m *MockReturnRepository) FindPaymentByTransaction(transactionID string, tx *gorm.DB) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaymentByTransaction", transactionID, tx)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPaymentByTransaction indicates an expected call of FindPaymentByTransaction.
+func (mr *MockReturnRepositoryMockRecorder) FindPaymentByTransaction(transactionID, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaymentByTransaction", reflect.TypeOf((*MockReturnRepository)(nil).FindPaymentByTransaction), transactionID, tx)
+}
+
+// ReturnedQuantities mocks base method.
+func (m *MockReturnRepository) ReturnedQuantities(orderIDs []string, tx *gorm.DB) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnedQuantities", orderIDs, tx)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnedQuantities indicates an expected call of ReturnedQuantities.
+func (mr *MockReturnRepositoryMockRecorder) ReturnedQuantities(orderIDs, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnedQuantities", reflect.TypeOf((*MockReturnRepository)(nil).ReturnedQuantities), orderIDs, tx)
+}
+
+// Update mocks base method.
+func (m *MockReturnRepository) Update(param models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", param, tx)
+	ret0, _ := ret[0].(models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReturnRepositoryMockRecorder) Update(param, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReturnRepository)(nil).Update), param, tx)
+}
+
+// UpdateItem mocks base method.
+func (m *MockReturnRepository) UpdateItem(param models.ReturnItem, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", param, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockReturnRepositoryMockRecorder) UpdateItem(param, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockReturnRepository)(nil).UpdateItem), param, tx)
+}
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/return_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/return_service.go -destination=internal/mocks/mock_return_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	multipart "mime/multipart"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReturnService is a mock of ReturnService interface.
+type MockReturnService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReturnServiceMockRecorder is the mock recorder for MockReturnService.
+type MockReturnServiceMockRecorder struct {
+	mock *MockReturnService
+}
+
+// NewMockReturnService creates a new mock instance.
+func NewMockReturnService(ctrl *gomock.Controller) *MockReturnService {
+	mock := &MockReturnService{ctrl: ctrl}
+	mock.recorder = &MockReturnServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnService) EXPECT() *MockReturnServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelReturn mocks base method.
+func (m *MockReturnService) CancelReturn(actor models.ActivityContext, id int64) (*models.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReturn", actor, id)
+	ret0, _ := ret[0].(*models.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelReturn indicates an expected call of CancelReturn.
+func (mr *MockReturnServiceMockRecorder) CancelReturn(actor, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReturn", reflect.TypeOf((*MockReturnService)(nil).CancelReturn), actor, id)
+}
+
+// CreateReturn mocks base method.
+func (m *MockReturnService) CreateReturn(actor models.ActivityContext, param models.CreateReturnRequest, photos []*multipart.FileHeader) (*models.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturn", actor, param, photos)
+	ret0, _ := ret[0].(*models.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockReturnServiceMockRecorder) CreateReturn(actor, param, photos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockReturnService)(nil).CreateReturn), actor, param, photos)
+}
+
+// ExchangeReturn mocks base method.
+func (m *MockReturnService) ExchangeReturn(actor models.ActivityContext, param models.ExchangeReturn) (*models.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeReturn", actor, param)
+	ret0, _ := ret[0].(*models.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeReturn indicates an expected call of ExchangeReturn.
+func (mr *MockReturnServiceMockRecorder) ExchangeReturn(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeReturn", reflect.TypeOf((*MockReturnService)(nil).ExchangeReturn), actor, param)
+}
+
+// FindAllReturn mocks base method.
+func (m *MockReturnService) FindAllReturn(param models.ReturnListRequest) (*models.ReturnListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllReturn", param)
+	ret0, _ := ret[0].(*models.ReturnListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllReturn indicates an expected call of FindAllReturn.
+func (mr *MockReturnServiceMockRecorder) FindAllReturn(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllReturn", reflect.TypeOf((*MockReturnService)(nil).FindAllReturn), param)
+}
+
+// FindById mocks base method.
+func (m *MockReturnService) FindById(id int64) (*models.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*models.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockReturnServiceMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockReturnService)(nil).FindById), id)
+}
+
+// InspectReturn mocks base method.
+func (m *MockReturnService) InspectReturn(actor models.ActivityContext, param models.InspectReturn) (*models.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectReturn", actor, param)
+	ret0, _ := ret[0].(*models.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectReturn indicates an expected call of InspectReturn.
+func (mr *MockReturnServiceMockRecorder) InspectReturn(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectReturn", reflect.TypeOf((*MockReturnService)(nil).InspectReturn), actor, param)
+}
+
+// RefundReturn mocks base method.
+func (m *MockReturnService) RefundReturn(actor models.ActivityContext, param models.RefundReturn) (*models.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundReturn", actor, param)
+	ret0, _ := ret[0].(*models.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundReturn indicates an expected call of RefundReturn.
+func (mr *MockReturnServiceMockRecorder) RefundReturn(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundReturn", reflect.TypeOf((*MockReturnService)(nil).RefundReturn), actor, param)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockReturnService) UpdateStatus(actor models.ActivityContext, param models.UpdateReturnStatus) (*models.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", actor, param)
+	ret0, _ := ret[0].(*models.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockReturnServiceMockRecorder) UpdateStatus(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockReturnService)(nil).UpdateStatus), actor, param)
+}
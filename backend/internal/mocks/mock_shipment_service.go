@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/shipment_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/shipment_service.go -destination=internal/mocks/mock_shipment_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShipmentService is a mock of ShipmentService interface.
+type MockShipmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockShipmentServiceMockRecorder is the mock recorder for MockShipmentService.
+type MockShipmentServiceMockRecorder struct {
+	mock *MockShipmentService
+}
+
+// NewMockShipmentService creates a new mock instance.
+func NewMockShipmentService(ctrl *gomock.Controller) *MockShipmentService {
+	mock := &MockShipmentService{ctrl: ctrl}
+	mock.recorder = &MockShipmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentService) EXPECT() *MockShipmentServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEvent mocks base method.
+func (m *MockShipmentService) AddEvent(actor models.ActivityContext, param models.CreateShipmentEvent) (*models.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvent", actor, param)
+	ret0, _ := ret[0].(*models.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEvent indicates an expected call of AddEvent.
+func (mr *MockShipmentServiceMockRecorder) AddEvent(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockShipmentService)(nil).AddEvent), actor, param)
+}
+
+// FindByTransaction mocks base method.
+func (m *MockShipmentService) FindByTransaction(transactionID string) (*models.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTransaction", transactionID)
+	ret0, _ := ret[0].(*models.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTransaction indicates an expected call of FindByTransaction.
+func (mr *MockShipmentServiceMockRecorder) FindByTransaction(transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTransaction", reflect.TypeOf((*MockShipmentService)(nil).FindByTransaction), transactionID)
+}
+
+// FindMine mocks base method.
+func (m *MockShipmentService) FindMine(transactionID string, userID uint) (*models.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMine", transactionID, userID)
+	ret0, _ := ret[0].(*models.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMine indicates an expected call of FindMine.
+func (mr *MockShipmentServiceMockRecorder) FindMine(transactionID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMine", reflect.TypeOf((*MockShipmentService)(nil).FindMine), transactionID, userID)
+}
+
+// HandleCarrierWebhook mocks base method.
+func (m *MockShipmentService) HandleCarrierWebhook(carrier string, param models.CarrierWebhookPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCarrierWebhook", carrier, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleCarrierWebhook indicates an expected call of HandleCarrierWebhook.
+func (mr *MockShipmentServiceMockRecorder) HandleCarrierWebhook(carrier, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCarrierWebhook", reflect.TypeOf((*MockShipmentService)(nil).HandleCarrierWebhook), carrier, param)
+}
+
+// MarkShipped mocks base method.
+func (m *MockShipmentService) MarkShipped(actor models.ActivityContext, param models.CreateShipment) (*models.ShipmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkShipped", actor, param)
+	ret0, _ := ret[0].(*models.ShipmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkShipped indicates an expected call of MarkShipped.
+func (mr *MockShipmentServiceMockRecorder) MarkShipped(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkShipped", reflect.TypeOf((*MockShipmentService)(nil).MarkShipped), actor, param)
+}
+
+// VerifyCarrierWebhook mocks base method.
+func (m *MockShipmentService) VerifyCarrierWebhook(carrier string, body []byte, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCarrierWebhook", carrier, body, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCarrierWebhook indicates an expected call of VerifyCarrierWebhook.
+func (mr *MockShipmentServiceMockRecorder) VerifyCarrierWebhook(carrier, body, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCarrierWebhook", reflect.TypeOf((*MockShipmentService)(nil).VerifyCarrierWebhook), carrier, body, signature)
+}
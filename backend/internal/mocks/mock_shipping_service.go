@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/shipping_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/shipping_service.go -destination=internal/mocks/mock_shipping_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShippingService is a mock of ShippingService interface.
+type MockShippingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockShippingServiceMockRecorder is the mock recorder for MockShippingService.
+type MockShippingServiceMockRecorder struct {
+	mock *MockShippingService
+}
+
+// NewMockShippingService creates a new mock instance.
+func NewMockShippingService(ctrl *gomock.Controller) *MockShippingService {
+	mock := &MockShippingService{ctrl: ctrl}
+	mock.recorder = &MockShippingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShippingService) EXPECT() *MockShippingServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRate mocks base method.
+func (m *MockShippingService) CreateRate(param models.CreateShippingRate) (*models.ShippingZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRate", param)
+	ret0, _ := ret[0].(*models.ShippingZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRate indicates an expected call of CreateRate.
+func (mr *MockShippingServiceMockRecorder) CreateRate(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRate", reflect.TypeOf((*MockShippingService)(nil).CreateRate), param)
+}
+
+// CreateShipping mocks base method.
+func (m *MockShippingService) CreateShipping(param models.CreateShipping) (*models.ShippingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipping", param)
+	ret0, _ := ret[0].(*models.ShippingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipping indicates an expected call of CreateShipping.
+func (mr *MockShippingServiceMockRecorder) CreateShipping(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipping", reflect.TypeOf((*MockShippingService)(nil).CreateShipping), param)
+}
+
+// CreateZone mocks base method.
+func (m *MockShippingService) CreateZone(param models.CreateShippingZone) (*models.ShippingZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZone", param)
+	ret0, _ := ret[0].(*models.ShippingZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZone indicates an expected call of CreateZone.
+func (mr *MockShippingServiceMockRecorder) CreateZone(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZone", reflect.TypeOf((*MockShippingService)(nil).CreateZone), param)
+}
+
+// DeleteRate mocks base method.
+func (m *MockShippingService) DeleteRate(zoneID, rateID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", zoneID, rateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRate indicates an expected call of DeleteRate.
+func (mr *MockShippingServiceMockRecorder) DeleteRate(zoneID, rateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockShippingService)(nil).DeleteRate), zoneID, rateID)
+}
+
+// DeleteShipping mocks base method.
+func (m *MockShippingService) DeleteShipping(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShipping", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShipping indicates an expected call of DeleteShipping.
+func (mr *MockShippingServiceMockRecorder) DeleteShipping(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShipping", reflect.TypeOf((*MockShippingService)(nil).DeleteShipping), id)
+}
+
+// DeleteZone mocks base method.
+func (m *MockShippingService) DeleteZone(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone.
+func (mr *MockShippingServiceMockRecorder) DeleteZone(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockShippingService)(nil).DeleteZone), id)
+}
+
+// FindAllShipping mocks base method.
+func (m *MockShippingService) FindAllShipping(param models.ShippingListRequest) ([]models.ShippingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllShipping", param)
+	ret0, _ := ret[0].([]models.ShippingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllShipping indicates an expected call of FindAllShipping.
+func (mr *MockShippingServiceMockRecorder) FindAllShipping(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllShipping", reflect.TypeOf((*MockShippingService)(nil).FindAllShipping), param)
+}
+
+// FindAllZones mocks base method.
+func (m *MockShippingService) FindAllZones() ([]models.ShippingZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllZones")
+	ret0, _ := ret[0].([]models.ShippingZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllZones indicates an expected call of FindAllZones.
+func (mr *MockShippingServiceMockRecorder) FindAllZones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllZones", reflect.TypeOf((*MockShippingService)(nil).FindAllZones))
+}
+
+// FindByID mocks base method.
+func (m *MockShippingService) FindByID(id int64) (*models.ShippingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.ShippingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockShippingServiceMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockShippingService)(nil).FindByID), id)
+}
+
+// PriceShipping mocks base method.
+func (m *MockShippingService) PriceShipping(shipping models.Shipping, address models.Address, weightGrams int64, subtotal models.Money) (*models.ShippingQuoteOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceShipping", shipping, address, weightGrams, subtotal)
+	ret0, _ := ret[0].(*models.ShippingQuoteOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceShipping indicates an expected call of PriceShipping.
+func (mr *MockShippingServiceMockRecorder) PriceShipping(shipping, address, weightGrams, subtotal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceShipping", reflect.TypeOf((*MockShippingService)(nil).PriceShipping), shipping, address, weightGrams, subtotal)
+}
+
+// Quote mocks base method.
+func (m *MockShippingService) Quote(param models.ShippingQuoteRequest) (*models.ShippingQuoteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", param)
+	ret0, _ := ret[0].(*models.ShippingQuoteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockShippingServiceMockRecorder) Quote(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockShippingService)(nil).Quote), param)
+}
+
+// UpdateShipping mocks base method.
+func (m *MockShippingService) UpdateShipping(param models.UpdateShipping) (*models.ShippingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShipping", param)
+	ret0, _ := ret[0].(*models.ShippingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShipping indicates an expected call of UpdateShipping.
+func (mr *MockShippingServiceMockRecorder) UpdateShipping(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShipping", reflect.TypeOf((*MockShippingService)(nil).UpdateShipping), param)
+}
+
+// UpdateZone mocks base method.
+func (m *MockShippingService) UpdateZone(param models.CreateShippingZone) (*models.ShippingZoneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", param)
+	ret0, _ := ret[0].(*models.ShippingZoneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateZone indicates an expected call of UpdateZone.
+func (mr *MockShippingServiceMockRecorder) UpdateZone(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockShippingService)(nil).UpdateZone), param)
+}
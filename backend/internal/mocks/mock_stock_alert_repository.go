@@ -0,0 +1,221 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/stock_alert_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/stock_alert_repository.go -destination=internal/mocks/mock_stock_alert_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockStockAlertRepository is a mock of StockAlertRepository interface.
+type MockStockAlertRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockAlertRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStockAlertRepositoryMockRecorder is the mock recorder for MockStockAlertRepository.
+type MockStockAlertRepositoryMockRecorder struct {
+	mock *MockStockAlertRepository
+}
+
+// NewMockStockAlertRepository creates a new mock instance.
+func NewMockStockAlertRepository(ctrl *gomock.Controller) *MockStockAlertRepository {
+	mock := &MockStockAlertRepository{ctrl: ctrl}
+	mock.recorder = &MockStockAlertRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockAlertRepository) EXPECT() *MockStockAlertRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockStockAlertRepository) CountUnread(userID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockStockAlertRepositoryMockRecorder) CountUnread(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockStockAlertRepository)(nil).CountUnread), userID)
+}
+
+// Create mocks base method.
+func (m *MockStockAlertRepository) Create(param models.StockAlert, tx *gorm.DB) (models.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", param, tx)
+	ret0, _ := ret[0].(models.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStockAlertRepositoryMockRecorder) Create(param, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStockAlertRepository)(nil).Create), param, tx)
+}
+
+// Delete mocks base method.
+func (m *MockStockAlertRepository) Delete(id int64, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStockAlertRepositoryMockRecorder) Delete(id, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStockAlertRepository)(nil).Delete), id, tx)
+}
+
+// FindActive mocks base method.
+func (m *MockStockAlertRepository) FindActive(userID uint, productID int64, sizeVarianID *int64, alertType models.StockAlertType) (*models.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", userID, productID, sizeVarianID, alertType)
+	ret0, _ := ret[0].(*models.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockStockAlertRepositoryMockRecorder) FindActive(userID, productID, sizeVarianID, alertType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockStockAlertRepository)(nil).FindActive), userID, productID, sizeVarianID, alertType)
+}
+
+// FindActiveByProductLocked mocks base method.
+func (m *MockStockAlertRepository) FindActiveByProductLocked(productID int64, tx *gorm.DB) ([]models.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByProductLocked", productID, tx)
+	ret0, _ := ret[0].([]models.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByProductLocked indicates an expected call of FindActiveByProductLocked.
+func (mr *MockStockAlertRepositoryMockRecorder) FindActiveByProductLocked(productID, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByProductLocked", reflect.TypeOf((*MockStockAlertRepository)(nil).FindActiveByProductLocked), productID, tx)
+}
+
+// FindAllByUser mocks base method.
+func (m *MockStockAlertRepository) FindAllByUser(userID uint) ([]models.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUser", userID)
+	ret0, _ := ret[0].([]models.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUser indicates an expected call of FindAllByUser.
+func (mr *MockStockAlertRepositoryMockRecorder) FindAllByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUser", reflect.TypeOf((*MockStockAlertRepository)(nil).FindAllByUser), userID)
+}
+
+// FindById mocks base method.
+func (m *MockStockAlertRepository) FindById(id int64) (*models.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", id)
+	ret0, _ := ret[0].(*models.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockStockAlertRepositoryMockRecorder) FindById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockStockAlertRepository)(nil).FindById), id)
+}
+
+// FindNotificationById mocks base method.
+func (m *MockStockAlertRepository) FindNotificationById(id int64) (*models.StockAlertNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNotificationById", id)
+	ret0, _ := ret[0].(*models.StockAlertNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNotificationById indicates an expected call of FindNotificationById.
+func (mr *MockStockAlertRepositoryMockRecorder) FindNotificationById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNotificationById", reflect.TypeOf((*MockStockAlertRepository)(nil).FindNotificationById), id)
+}
+
+// FindNotifications mocks base method.
+func (m *MockStockAlertRepository) FindNotifications(param models.StockAlertNotificationListRequest) ([]models.StockAlertNotification, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNotifications", param)
+	ret0, _ := ret[0].([]models.StockAlertNotification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindNotifications indicates an expected call of FindNotifications.
+func (mr *MockStockAlertRepositoryMockRecorder) FindNotifications(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNotifications", reflect.TypeOf((*MockStockAlertRepository)(nil).FindNotifications), param)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockStockAlertRepository) MarkNotificationRead(id int64, readAt time.Time, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", id, readAt, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockStockAlertRepositoryMockRecorder) MarkNotificationRead(id, readAt, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockStockAlertRepository)(nil).MarkNotificationRead), id, readAt, tx)
+}
+
+// MarkNotified mocks base method.
+func (m *MockStockAlertRepository) MarkNotified(id int64, lastPrice models.Money, notifiedAt time.Time, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", id, lastPrice, notifiedAt, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockStockAlertRepositoryMockRecorder) MarkNotified(id, lastPrice, notifiedAt, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockStockAlertRepository)(nil).MarkNotified), id, lastPrice, notifiedAt, tx)
+}
+
+// QueueNotification mocks base method.
+func (m *MockStockAlertRepository) QueueNotification(param models.StockAlertNotification, tx *gorm.DB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueNotification", param, tx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueNotification indicates an expected call of QueueNotification.
+func (mr *MockStockAlertRepositoryMockRecorder) QueueNotification(param, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueNotification", reflect.TypeOf((*MockStockAlertRepository)(nil).QueueNotification), param, tx)
+}
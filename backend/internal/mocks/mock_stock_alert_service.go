@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/stock_alert_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/stock_alert_service.go -destination=internal/mocks/mock_stock_alert_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockStockAlertService is a mock of StockAlertService interface.
+type MockStockAlertService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockAlertServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStockAlertServiceMockRecorder is the mock recorder for MockStockAlertService.
+type MockStockAlertServiceMockRecorder struct {
+	mock *MockStockAlertService
+}
+
+// NewMockStockAlertService creates a new mock instance.
+func NewMockStockAlertService(ctrl *gomock.Controller) *MockStockAlertService {
+	mock := &MockStockAlertService{ctrl: ctrl}
+	mock.recorder = &MockStockAlertServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockAlertService) EXPECT() *MockStockAlertServiceMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockStockAlertService) FindAll(userID uint) ([]models.StockAlertResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", userID)
+	ret0, _ := ret[0].([]models.StockAlertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStockAlertServiceMockRecorder) FindAll(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStockAlertService)(nil).FindAll), userID)
+}
+
+// FindNotifications mocks base method.
+func (m *MockStockAlertService) FindNotifications(param models.StockAlertNotificationListRequest) (*models.StockAlertNotificationListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNotifications", param)
+	ret0, _ := ret[0].(*models.StockAlertNotificationListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNotifications indicates an expected call of FindNotifications.
+func (mr *MockStockAlertServiceMockRecorder) FindNotifications(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNotifications", reflect.TypeOf((*MockStockAlertService)(nil).FindNotifications), param)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockStockAlertService) MarkNotificationRead(userID uint, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockStockAlertServiceMockRecorder) MarkNotificationRead(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockStockAlertService)(nil).MarkNotificationRead), userID, id)
+}
+
+// QueueProductEvents mocks base method.
+func (m *MockStockAlertService) QueueProductEvents(before, after *models.Product, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueProductEvents", before, after, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueProductEvents indicates an expected call of QueueProductEvents.
+func (mr *MockStockAlertServiceMockRecorder) QueueProductEvents(before, after, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueProductEvents", reflect.TypeOf((*MockStockAlertService)(nil).QueueProductEvents), before, after, tx)
+}
+
+// Subscribe mocks base method.
+func (m *MockStockAlertService) Subscribe(userID uint, param models.CreateStockAlert) (*models.StockAlertResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID, param)
+	ret0, _ := ret[0].(*models.StockAlertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockStockAlertServiceMockRecorder) Subscribe(userID, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockStockAlertService)(nil).Subscribe), userID, param)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockStockAlertService) Unsubscribe(userID uint, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockStockAlertServiceMockRecorder) Unsubscribe(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockStockAlertService)(nil).Unsubscribe), userID, id)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/tax_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/tax_service.go -destination=internal/mocks/mock_tax_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxService is a mock of TaxService interface.
+type MockTaxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTaxServiceMockRecorder is the mock recorder for MockTaxService.
+type MockTaxServiceMockRecorder struct {
+	mock *MockTaxService
+}
+
+// NewMockTaxService creates a new mock instance.
+func NewMockTaxService(ctrl *gomock.Controller) *MockTaxService {
+	mock := &MockTaxService{ctrl: ctrl}
+	mock.recorder = &MockTaxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxService) EXPECT() *MockTaxServiceMockRecorder {
+	return m.recorder
+}
+
+// Calculate mocks base method.
+func (m *MockTaxService) Calculate(amount models.Money, class *models.TaxClass) models.Money {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", amount, class)
+	ret0, _ := ret[0].(models.Money)
+	return ret0
+}
+
+// Calculate indicates an expected call of Calculate.
+func (mr *MockTaxServiceMockRecorder) Calculate(amount, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockTaxService)(nil).Calculate), amount, class)
+}
+
+// ClassFor mocks base method.
+func (m *MockTaxService) ClassFor(product models.Product) (*models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClassFor", product)
+	ret0, _ := ret[0].(*models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClassFor indicates an expected call of ClassFor.
+func (mr *MockTaxServiceMockRecorder) ClassFor(product any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClassFor", reflect.TypeOf((*MockTaxService)(nil).ClassFor), product)
+}
+
+// CreateClass mocks base method.
+func (m *MockTaxService) CreateClass(actor models.ActivityContext, param models.CreateTaxClass) (*models.TaxClassResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClass", actor, param)
+	ret0, _ := ret[0].(*models.TaxClassResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClass indicates an expected call of CreateClass.
+func (mr *MockTaxServiceMockRecorder) CreateClass(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClass", reflect.TypeOf((*MockTaxService)(nil).CreateClass), actor, param)
+}
+
+// DeleteClass mocks base method.
+func (m *MockTaxService) DeleteClass(actor models.ActivityContext, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClass", actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClass indicates an expected call of DeleteClass.
+func (mr *MockTaxServiceMockRecorder) DeleteClass(actor, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClass", reflect.TypeOf((*MockTaxService)(nil).DeleteClass), actor, id)
+}
+
+// FindAllClasses mocks base method.
+func (m *MockTaxService) FindAllClasses() ([]models.TaxClassResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllClasses")
+	ret0, _ := ret[0].([]models.TaxClassResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllClasses indicates an expected call of FindAllClasses.
+func (mr *MockTaxServiceMockRecorder) FindAllClasses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllClasses", reflect.TypeOf((*MockTaxService)(nil).FindAllClasses))
+}
+
+// FindClassByID mocks base method.
+func (m *MockTaxService) FindClassByID(id int64) (*models.TaxClassResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClassByID", id)
+	ret0, _ := ret[0].(*models.TaxClassResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClassByID indicates an expected call of FindClassByID.
+func (mr *MockTaxServiceMockRecorder) FindClassByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClassByID", reflect.TypeOf((*MockTaxService)(nil).FindClassByID), id)
+}
+
+// PricesIncludeTax mocks base method.
+func (m *MockTaxService) PricesIncludeTax() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PricesIncludeTax")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// PricesIncludeTax indicates an expected call of PricesIncludeTax.
+func (mr *MockTaxServiceMockRecorder) PricesIncludeTax() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PricesIncludeTax", reflect.TypeOf((*MockTaxService)(nil).PricesIncludeTax))
+}
+
+// UpdateClass mocks base method.
+func (m *MockTaxService) UpdateClass(actor models.ActivityContext, param models.CreateTaxClass) (*models.TaxClassResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClass", actor, param)
+	ret0, _ := ret[0].(*models.TaxClassResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateClass indicates an expected call of UpdateClass.
+func (mr *MockTaxServiceMockRecorder) UpdateClass(actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClass", reflect.TypeOf((*MockTaxService)(nil).UpdateClass), actor, param)
+}
@@ -115,6 +115,21 @@ func (mr *MockTransactionRepositoryMockRecorder) FindByIdLocking(tx, id any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdLocking", reflect.TypeOf((*MockTransactionRepository)(nil).FindByIdLocking), tx, id)
 }
 
+// FindTransactionOwner mocks base method.
+func (m *MockTransactionRepository) FindTransactionOwner(transactionID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionOwner", transactionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionOwner indicates an expected call of FindTransactionOwner.
+func (mr *MockTransactionRepositoryMockRecorder) FindTransactionOwner(transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionOwner", reflect.TypeOf((*MockTransactionRepository)(nil).FindTransactionOwner), transactionID)
+}
+
 // Update mocks base method.
 func (m *MockTransactionRepository) Update(param models.Transaction, tx *gorm.DB) (models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -129,3 +144,18 @@ func (mr *MockTransactionRepositoryMockRecorder) Update(param, tx any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTransactionRepository)(nil).Update), param, tx)
 }
+
+// VirtualAccountExists mocks base method.
+func (m *MockTransactionRepository) VirtualAccountExists(tx *gorm.DB, number string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VirtualAccountExists", tx, number)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VirtualAccountExists indicates an expected call of VirtualAccountExists.
+func (mr *MockTransactionRepositoryMockRecorder) VirtualAccountExists(tx, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VirtualAccountExists", reflect.TypeOf((*MockTransactionRepository)(nil).VirtualAccountExists), tx, number)
+}
@@ -71,6 +71,21 @@ func (mr *MockTransactionServiceMockRecorder) FindAllTransaction(param any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTransaction", reflect.TypeOf((*MockTransactionService)(nil).FindAllTransaction), param)
 }
 
+// FindQRIS mocks base method.
+func (m *MockTransactionService) FindQRIS(txid string, userID uint) (*models.TransactionQRISResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindQRIS", txid, userID)
+	ret0, _ := ret[0].(*models.TransactionQRISResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindQRIS indicates an expected call of FindQRIS.
+func (mr *MockTransactionServiceMockRecorder) FindQRIS(txid, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindQRIS", reflect.TypeOf((*MockTransactionService)(nil).FindQRIS), txid, userID)
+}
+
 // FindTransactionById mocks base method.
 func (m *MockTransactionService) FindTransactionById(txid string) (*models.TransactionResponse, error) {
 	m.ctrl.T.Helper()
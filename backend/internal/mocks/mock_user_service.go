@@ -159,6 +159,20 @@ func (mr *MockUserServiceMockRecorder) GetUsers(req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserService)(nil).GetUsers), req)
 }
 
+// RecordActivity mocks base method.
+func (m *MockUserService) RecordActivity(activity models.ActivityLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordActivity", activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordActivity indicates an expected call of RecordActivity.
+func (mr *MockUserServiceMockRecorder) RecordActivity(activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordActivity", reflect.TypeOf((*MockUserService)(nil).RecordActivity), activity)
+}
+
 // UpdatePassword mocks base method.
 func (m *MockUserService) UpdatePassword(userID uint, req *models.PasswordUpdateInput) error {
 	m.ctrl.T.Helper()
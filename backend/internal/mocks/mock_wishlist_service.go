@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wishlist_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wishlist_service.go -destination=internal/mocks/mock_wishlist_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "e-commerce/backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWishlistService is a mock of WishlistService interface.
+type MockWishlistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWishlistServiceMockRecorder is the mock recorder for MockWishlistService.
+type MockWishlistServiceMockRecorder struct {
+	mock *MockWishlistService
+}
+
+// NewMockWishlistService creates a new mock instance.
+func NewMockWishlistService(ctrl *gomock.Controller) *MockWishlistService {
+	mock := &MockWishlistService{ctrl: ctrl}
+	mock.recorder = &MockWishlistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistService) EXPECT() *MockWishlistServiceMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockWishlistService) AddItem(userID uint, param models.CreateWishlist) (*models.WishlistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", userID, param)
+	ret0, _ := ret[0].(*models.WishlistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockWishlistServiceMockRecorder) AddItem(userID, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockWishlistService)(nil).AddItem), userID, param)
+}
+
+// FindAll mocks base method.
+func (m *MockWishlistService) FindAll(param models.WishlistListRequest) (*models.WishlistListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", param)
+	ret0, _ := ret[0].(*models.WishlistListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockWishlistServiceMockRecorder) FindAll(param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockWishlistService)(nil).FindAll), param)
+}
+
+// RemoveItem mocks base method.
+func (m *MockWishlistService) RemoveItem(userID uint, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockWishlistServiceMockRecorder) RemoveItem(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockWishlistService)(nil).RemoveItem), userID, id)
+}
+
+// RemoveProduct mocks base method.
+func (m *MockWishlistService) RemoveProduct(userID uint, productID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveProduct", userID, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveProduct indicates an expected call of RemoveProduct.
+func (mr *MockWishlistServiceMockRecorder) RemoveProduct(userID, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProduct", reflect.TypeOf((*MockWishlistService)(nil).RemoveProduct), userID, productID)
+}
+
+// UpdateItem mocks base method.
+func (m *MockWishlistService) UpdateItem(userID uint, id int64, param models.CreateWishlist) (*models.WishlistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", userID, id, param)
+	ret0, _ := ret[0].(*models.WishlistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockWishlistServiceMockRecorder) UpdateItem(userID, id, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockWishlistService)(nil).UpdateItem), userID, id, param)
+}
+
+// WishlistedProducts mocks base method.
+func (m *MockWishlistService) WishlistedProducts(userID uint, productIDs []int64) (map[int64]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WishlistedProducts", userID, productIDs)
+	ret0, _ := ret[0].(map[int64]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WishlistedProducts indicates an expected call of WishlistedProducts.
+func (mr *MockWishlistServiceMockRecorder) WishlistedProducts(userID, productIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WishlistedProducts", reflect.TypeOf((*MockWishlistService)(nil).WishlistedProducts), userID, productIDs)
+}
@@ -0,0 +1,108 @@
+package models
+
+import "time"
+
+// AccountDeletionRequest confirms a customer wants to close their account.
+// Accounts without a password leave Password empty and must have signed in
+// with their identity provider shortly before instead.
+type AccountDeletionRequest struct {
+	Password     string `json:"password" validate:"omitempty"`
+	Confirmation string `json:"confirmation" validate:"required,eq=DELETE"`
+}
+
+// UserDataExport is everything stored about a user, returned by the
+// self-service data export.
+type UserDataExport struct {
+	ExportedAt    time.Time                        `json:"exported_at"`
+	Profile       UserResponse                     `json:"profile"`
+	Addresses     []AddressResponse                `json:"addresses"`
+	Transactions  []TransactionExport              `json:"transactions"`
+	Orders        []OrderExport                    `json:"orders"`
+	Payments      []PaymentExport                  `json:"payments"`
+	ActivityLogs  []ActivityLogResponse            `json:"activity_logs"`
+	Wishlist      []WishlistResponse               `json:"wishlist"`
+	StockAlerts   []StockAlertResponse             `json:"stock_alerts"`
+	Notifications []StockAlertNotificationResponse `json:"notifications"`
+	PaymentProofs []PaymentProofResponse           `json:"payment_proofs"`
+	Returns       []ReturnResponse                 `json:"returns"`
+	Refunds       []RefundResponse                 `json:"refunds"`
+	Shipments     []ShipmentResponse               `json:"shipments"`
+}
+
+type TransactionExport struct {
+	TxID            string    `json:"tx_id"`
+	AddressID       int64     `json:"address_id"`
+	ShippingID      int64     `json:"shipping_id"`
+	PaymentMethodID int64     `json:"payment_method_id"`
+	ShippingPrice   Money     `json:"shipping_price"`
+	TotalPrice      Money     `json:"total_price"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type OrderExport struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	ProductID     int64     `json:"product_id"`
+	ProductName   string    `json:"product_name"`
+	ColorVarianID int64     `json:"color_varian_id"`
+	SizeVarianID  int64     `json:"size_varian_id"`
+	UnitPrice     Money     `json:"unit_price"`
+	Subtotal      Money     `json:"subtotal"`
+	Quantity      int64     `json:"quantity"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type PaymentExport struct {
+	ID            int64     `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	TotalPayment  Money     `json:"total_payment"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (tx *Transaction) ToExport() TransactionExport {
+	return TransactionExport{
+		TxID:            tx.TxID,
+		AddressID:       tx.AddressID,
+		ShippingID:      tx.ShippingID,
+		PaymentMethodID: tx.PaymentMethodID,
+		ShippingPrice:   tx.ShippingPrice,
+		TotalPrice:      tx.TotalPrice,
+		Status:          tx.Status,
+		CreatedAt:       tx.CreatedAt,
+		UpdatedAt:       tx.UpdatedAt,
+	}
+}
+
+func (o *Order) ToExport() OrderExport {
+	return OrderExport{
+		ID:            o.ID,
+		TransactionID: o.TransactionID,
+		ProductID:     o.ProductID,
+		ProductName:   o.Product.Name,
+		ColorVarianID: o.ColorVarianID,
+		SizeVarianID:  o.SizeVarianID,
+		UnitPrice:     o.UnitPrice,
+		Subtotal:      o.Subtotal,
+		Quantity:      o.Quantity,
+		Status:        o.Status,
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+	}
+}
+
+func (p *Payment) ToExport() PaymentExport {
+	return PaymentExport{
+		ID:            p.ID,
+		TransactionID: p.TransactionID,
+		TotalPayment:  p.TotalPayment,
+		Status:        p.Status,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
@@ -7,17 +7,20 @@ import (
 )
 
 type ActivityLog struct {
-	ID        uint           `json:"id" gorm:"primarykey"`
-	UserID    uint           `json:"user_id" gorm:"not null;index"`
-	Action    string         `json:"action" gorm:"not null;index" validate:"required,max=100"`
-	Resource  string         `json:"resource" gorm:"not null;index" validate:"required,max=100"`
-	Details   string         `json:"details" gorm:"type:text"`
-	IPAddress string         `json:"ip_address" gorm:"max=45"`
-	UserAgent string         `json:"user_agent" gorm:"type:text"`
-	CreatedAt time.Time      `json:"created_at" gorm:"index"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
-	User      User           `json:"user" gorm:"foreignKey:UserID"`
+	ID             uint           `json:"id" gorm:"primarykey"`
+	UserID         uint           `json:"user_id" gorm:"not null;index"`
+	Action         string         `json:"action" gorm:"not null;index" validate:"required,max=100"`
+	Resource       string         `json:"resource" gorm:"not null;index" validate:"required,max=100"`
+	ResourceID     string         `json:"resource_id,omitempty" gorm:"type:varchar(100);index"`
+	Details        string         `json:"details" gorm:"type:text"`
+	IPAddress      string         `json:"ip_address" gorm:"max=45"`
+	UserAgent      string         `json:"user_agent" gorm:"type:text"`
+	APIKeyID       *uint          `json:"api_key_id" gorm:"index"`
+	ImpersonatorID *uint          `json:"impersonator_id" gorm:"index"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"index"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+	User           User           `json:"user" gorm:"foreignKey:UserID"`
 }
 
 type ActivityLogInput struct {
@@ -29,16 +32,28 @@ type ActivityLogInput struct {
 	UserAgent string `json:"user_agent" validate:"max=500"`
 }
 
+// ActivityContext describes who performed an action and from where, so
+// services can record it in the activity log.
+type ActivityContext struct {
+	UserID         uint
+	APIKeyID       *uint
+	ImpersonatorID *uint
+	IPAddress      string
+	UserAgent      string
+}
+
 type ActivityLogResponse struct {
-	ID        uint      `json:"id"`
-	UserID    uint      `json:"user_id"`
-	Action    string    `json:"action"`
-	Resource  string    `json:"resource"`
-	Details   string    `json:"details"`
-	IPAddress string    `json:"ip_address"`
-	UserAgent string    `json:"user_agent"`
-	CreatedAt time.Time `json:"created_at"`
-	User      struct {
+	ID             uint      `json:"id"`
+	UserID         uint      `json:"user_id"`
+	Action         string    `json:"action"`
+	Resource       string    `json:"resource"`
+	Details        string    `json:"details"`
+	IPAddress      string    `json:"ip_address"`
+	UserAgent      string    `json:"user_agent"`
+	APIKeyID       *uint     `json:"api_key_id"`
+	ImpersonatorID *uint     `json:"impersonator_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	User           struct {
 		ID        uint   `json:"id"`
 		Username  string `json:"username"`
 		FirstName string `json:"first_name"`
@@ -67,14 +82,16 @@ func (ActivityLog) TableName() string {
 
 func (a *ActivityLog) ToResponse() *ActivityLogResponse {
 	response := &ActivityLogResponse{
-		ID:        a.ID,
-		UserID:    a.UserID,
-		Action:    a.Action,
-		Resource:  a.Resource,
-		Details:   a.Details,
-		IPAddress: a.IPAddress,
-		UserAgent: a.UserAgent,
-		CreatedAt: a.CreatedAt,
+		ID:             a.ID,
+		UserID:         a.UserID,
+		Action:         a.Action,
+		Resource:       a.Resource,
+		Details:        a.Details,
+		IPAddress:      a.IPAddress,
+		UserAgent:      a.UserAgent,
+		APIKeyID:       a.APIKeyID,
+		ImpersonatorID: a.ImpersonatorID,
+		CreatedAt:      a.CreatedAt,
 	}
 
 	response.User.ID = a.User.ID
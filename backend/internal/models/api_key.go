@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey lets integrations call the API on behalf of its owner. Only the
+// SHA-256 hash of the key is stored; Prefix identifies the key in logs.
+type APIKey struct {
+	ID         uint           `json:"id" gorm:"primarykey"`
+	UserID     uint           `json:"user_id" gorm:"not null;index"`
+	Name       string         `json:"name" gorm:"type:varchar(100);not null" validate:"required,min=3,max=100"`
+	Prefix     string         `json:"prefix" gorm:"type:varchar(16);uniqueIndex;not null"`
+	KeyHash    string         `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+	Scopes     string         `json:"scopes" gorm:"type:text;not null"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	LastUsedIP string         `json:"last_used_ip" gorm:"type:varchar(45)"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+	User       User           `json:"-" gorm:"foreignKey:UserID"`
+}
+
+type APIKeyInput struct {
+	Name      string     `json:"name" validate:"required,min=3,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP string     `json:"last_used_ip"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyCreatedResponse is returned once on creation and is the only time
+// the plain key is visible.
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && now.After(*k.ExpiresAt)
+}
+
+func (k *APIKey) ToResponse() *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         k.ID,
+		UserID:     k.UserID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.ScopeList(),
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		LastUsedIP: k.LastUsedIP,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records one mutating admin call together with the state of the
+// affected row before and after it. Before, After and Changes hold JSON.
+type AuditLog struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	RequestID    string    `json:"request_id" gorm:"type:varchar(100);index"`
+	UserID       uint      `json:"user_id" gorm:"not null;index"`
+	APIKeyID     *uint     `json:"api_key_id" gorm:"index"`
+	Action       string    `json:"action" gorm:"type:varchar(20);not null;index"`
+	ResourceType string    `json:"resource_type" gorm:"type:varchar(100);not null;index:idx_audit_resource"`
+	ResourceID   string    `json:"resource_id" gorm:"type:varchar(100);index:idx_audit_resource"`
+	Method       string    `json:"method" gorm:"type:varchar(10)"`
+	Path         string    `json:"path" gorm:"type:varchar(255)"`
+	StatusCode   int       `json:"status_code"`
+	Before       string    `json:"before" gorm:"type:text"`
+	After        string    `json:"after" gorm:"type:text"`
+	Changes      string    `json:"changes" gorm:"type:text"`
+	IPAddress    string    `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent    string    `json:"user_agent" gorm:"type:text"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
+	User         User      `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// AuditChange is the old and new value of a single column.
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type AuditLogResponse struct {
+	ID           uint            `json:"id"`
+	RequestID    string          `json:"request_id"`
+	UserID       uint            `json:"user_id"`
+	APIKeyID     *uint           `json:"api_key_id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	StatusCode   int             `json:"status_code"`
+	Before       json.RawMessage `json:"before" swaggertype:"object"`
+	After        json.RawMessage `json:"after" swaggertype:"object"`
+	Changes      json.RawMessage `json:"changes" swaggertype:"object"`
+	IPAddress    string          `json:"ip_address"`
+	UserAgent    string          `json:"user_agent"`
+	CreatedAt    time.Time       `json:"created_at"`
+	User         struct {
+		ID        uint   `json:"id"`
+		Username  string `json:"username"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	} `json:"user"`
+}
+
+type AuditLogListResponse struct {
+	AuditLogs  []AuditLogResponse `json:"audit_logs"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	TotalPages int                `json:"total_pages"`
+}
+
+type AuditLogListRequest struct {
+	UserID       *uint
+	APIKeyID     *uint
+	Action       string
+	ResourceType string
+	ResourceID   string
+	RequestID    string
+	From         *time.Time
+	To           *time.Time
+	Page         int
+	Limit        int
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (a *AuditLog) ToResponse() *AuditLogResponse {
+	response := &AuditLogResponse{
+		ID:           a.ID,
+		RequestID:    a.RequestID,
+		UserID:       a.UserID,
+		APIKeyID:     a.APIKeyID,
+		Action:       a.Action,
+		ResourceType: a.ResourceType,
+		ResourceID:   a.ResourceID,
+		Method:       a.Method,
+		Path:         a.Path,
+		StatusCode:   a.StatusCode,
+		Before:       rawJSON(a.Before),
+		After:        rawJSON(a.After),
+		Changes:      rawJSON(a.Changes),
+		IPAddress:    a.IPAddress,
+		UserAgent:    a.UserAgent,
+		CreatedAt:    a.CreatedAt,
+	}
+
+	response.User.ID = a.User.ID
+	response.User.Username = a.User.Username
+	response.User.FirstName = a.User.FirstName
+	response.User.LastName = a.User.LastName
+
+	return response
+}
+
+func rawJSON(value string) json.RawMessage {
+	if value == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(value)
+}
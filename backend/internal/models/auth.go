@@ -49,6 +49,22 @@ type JWTClaims struct {
 	Email  string `json:"email"`
 	RoleID uint   `json:"role_id"`
 	Type   string `json:"type"` // "access" or "refresh"
+	// ImpersonatorID is set when a super admin is acting as UserID
+	ImpersonatorID *uint `json:"impersonator_id,omitempty"`
+}
+
+type ImpersonationRequest struct {
+	UserID uint   `json:"user_id" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// ImpersonationResponse carries a short-lived access token for the target
+// user. No refresh token is issued, the session ends when it expires.
+type ImpersonationResponse struct {
+	AccessToken    string       `json:"access_token"`
+	ExpiresAt      time.Time    `json:"expires_at"`
+	ImpersonatorID uint         `json:"impersonator_id"`
+	User           UserResponse `json:"user"`
 }
 
 type PasswordResetToken struct {
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// BankStatement is a statement of one of the store's bank accounts imported
+// by an admin to reconcile manual transfers.
+type BankStatement struct {
+	ID              int64               `json:"id" gorm:"primaryKey;autoIncrement"`
+	PaymentMethodID int64               `json:"payment_method_id" gorm:"not null;index"`
+	Filename        string              `json:"filename" gorm:"type:varchar(255);not null"`
+	Format          string              `json:"format" gorm:"type:varchar(10);not null"`
+	ImportedBy      uint                `json:"imported_by" gorm:"index"`
+	CreatedAt       time.Time           `json:"created_at" gorm:"autoCreateTime;index"`
+	Lines           []BankStatementLine `json:"lines,omitempty" gorm:"foreignKey:StatementID"`
+}
+
+// BankStatementLine is a credit booked on a statement. It is matched to the
+// transaction whose payable amount it pays, left unmatched for an admin to
+// resolve by hand, or ignored when it is not a customer payment. The
+// fingerprint keeps a line from being imported twice when statements
+// overlap.
+type BankStatementLine struct {
+	ID              int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	StatementID     int64      `json:"statement_id" gorm:"not null;index"`
+	PaymentMethodID int64      `json:"payment_method_id" gorm:"not null;index"`
+	BookedAt        time.Time  `json:"booked_at" gorm:"not null"`
+	Amount          Money      `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Description     string     `json:"description" gorm:"type:text"`
+	Reference       string     `json:"reference" gorm:"type:varchar(100)"`
+	Fingerprint     string     `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	Status          string     `json:"status" gorm:"type:varchar(20);not null;index"`
+	TransactionID   *string    `json:"transaction_id,omitempty" gorm:"type:varchar(50);index"`
+	PaymentID       *int64     `json:"payment_id,omitempty"`
+	ResolvedBy      *uint      `json:"resolved_by,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	Note            string     `json:"note" gorm:"type:text"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Request untuk mengimpor mutasi rekening. The statement is sent as a
+// multipart file; Format defaults to the file's extension.
+type ImportBankStatement struct {
+	PaymentMethodID int64  `json:"payment_method_id" validate:"required"`
+	Format          string `json:"format" validate:"omitempty,oneof=csv mt940"`
+	Filename        string `json:"-"`
+	Data            []byte `json:"-"`
+}
+
+// ResolveStatementLine settles an unmatched line: matched to a transaction
+// by hand, or ignored with a note.
+type ResolveStatementLine struct {
+	ID            int64  `json:"-"`
+	Action        string `json:"action" validate:"required,oneof=match ignore"`
+	TransactionID string `json:"transaction_id"`
+	Note          string `json:"note"`
+}
+
+type StatementLineListRequest struct {
+	StatementID int64
+	Status      string
+	Limit       int
+	Page        int
+}
+
+type BankStatementImportResponse struct {
+	Statement BankStatement `json:"statement"`
+	Imported  int           `json:"imported"`
+	Matched   int           `json:"matched"`
+	Unmatched int           `json:"unmatched"`
+	// Skipped counts debits and lines imported before
+	Skipped int `json:"skipped"`
+}
+
+type StatementLineListResponse struct {
+	Lines      []BankStatementLine `json:"lines"`
+	Total      int64               `json:"total"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	TotalPages int                 `json:"total_pages"`
+}
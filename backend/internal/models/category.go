@@ -1,19 +1,29 @@
 package models
 
 import (
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// Category Model
+// Category Model. Categories nest to any depth through ParentID, so
+// "Men > Shoes > Sneakers" is three categories; a nil ParentID is a root.
+// On update a ParentID of 0 moves the category to the root and a nil one
+// leaves it where it is. Names are unique among siblings only, so both
+// "Men > Shoes" and "Women > Shoes" can exist.
 type Category struct {
-	ID        int64          `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name      string         `json:"name" validate:"required,min=3,max=100" gorm:"type:varchar(100);unique;not null"`
-	Icon      string         `json:"icon" validate:"omitempty,url" gorm:"type:text"`
-	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	ParentID         *int64         `json:"parent_id,omitempty" gorm:"index;uniqueIndex:idx_categories_parent_name"`
+	Name             string         `json:"name" validate:"required,min=3,max=100" gorm:"type:varchar(100);not null;uniqueIndex:idx_categories_parent_name"`
+	Slug             string         `json:"slug" validate:"omitempty,max=100" gorm:"type:varchar(120);uniqueIndex"`
+	SortOrder        *int           `json:"sort_order,omitempty" gorm:"not null;default:0"`
+	Icon             string         `json:"icon" validate:"omitempty,url" gorm:"type:text"`
+	ReturnWindowDays *int           `json:"return_window_days,omitempty" validate:"omitempty,gte=0,lte=365" gorm:"not null;default:14"`
+	TaxClassID       *int64         `json:"tax_class_id,omitempty" gorm:"index"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type CategoriesParam struct {
@@ -28,11 +38,16 @@ type UpdateCategory struct {
 }
 
 type CategoryResponse struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Icon      string    `json:"icon"`
-	UpdatedAt time.Time `json:"updated_at"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               int64     `json:"id"`
+	ParentID         *int64    `json:"parent_id,omitempty"`
+	Name             string    `json:"name"`
+	Slug             string    `json:"slug"`
+	SortOrder        int       `json:"sort_order"`
+	Icon             string    `json:"icon"`
+	ReturnWindowDays int       `json:"return_window_days"`
+	TaxClassID       *int64    `json:"tax_class_id,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
 type CategoryListRequest struct {
@@ -42,12 +57,173 @@ type CategoryListRequest struct {
 	Search string
 }
 
+// CategoryBreadcrumb is one step of the path from a root category down to
+// a category, as shown above a product.
+type CategoryBreadcrumb struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// CategoryTreeNode is a category with its subcategories, for the category
+// tree endpoint.
+type CategoryTreeNode struct {
+	CategoryResponse
+	Children []CategoryTreeNode `json:"children"`
+}
+
 func (s *Category) ToResponseCategory() *CategoryResponse {
 	return &CategoryResponse{
-		ID:        s.ID,
-		Name:      s.Name,
-		Icon:      s.Icon,
-		UpdatedAt: s.UpdatedAt,
-		CreatedAt: s.CreatedAt,
+		ID:               s.ID,
+		ParentID:         s.ParentID,
+		Name:             s.Name,
+		Slug:             s.Slug,
+		SortOrder:        s.Order(),
+		Icon:             s.Icon,
+		ReturnWindowDays: s.ReturnDays(),
+		TaxClassID:       s.TaxClassID,
+		UpdatedAt:        s.UpdatedAt,
+		CreatedAt:        s.CreatedAt,
+	}
+}
+
+// ReturnDays returns how many days after delivery products of the category
+// may be returned; 0 means they cannot be returned. A nil ReturnWindowDays
+// (not sent on update, or not loaded) falls back to the column default.
+func (s *Category) ReturnDays() int {
+	if s.ReturnWindowDays == nil {
+		return 14
+	}
+	return *s.ReturnWindowDays
+}
+
+// Order returns the position of the category among its siblings; a nil
+// SortOrder (not sent on update, or not loaded) counts as 0.
+func (s *Category) Order() int {
+	if s.SortOrder == nil {
+		return 0
+	}
+	return *s.SortOrder
+}
+
+// CategoryTree indexes a set of categories by parent so the tree can be
+// walked in memory. Categories whose parent is not in the set are treated
+// as roots.
+type CategoryTree struct {
+	byID     map[int64]Category
+	children map[int64][]int64
+	roots    []int64
+}
+
+// NewCategoryTree builds the tree of categories, ordering siblings by sort
+// order and then name.
+func NewCategoryTree(categories []Category) *CategoryTree {
+	t := &CategoryTree{
+		byID:     make(map[int64]Category, len(categories)),
+		children: make(map[int64][]int64),
+	}
+	for _, category := range categories {
+		t.byID[category.ID] = category
+	}
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if _, ok := t.byID[*category.ParentID]; ok && *category.ParentID != category.ID {
+				t.children[*category.ParentID] = append(t.children[*category.ParentID], category.ID)
+				continue
+			}
+		}
+		t.roots = append(t.roots, category.ID)
+	}
+
+	t.sort(t.roots)
+	for _, ids := range t.children {
+		t.sort(ids)
+	}
+	return t
+}
+
+func (t *CategoryTree) sort(ids []int64) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := t.byID[ids[i]], t.byID[ids[j]]
+		if a.Order() != b.Order() {
+			return a.Order() < b.Order()
+		}
+		return a.Name < b.Name
+	})
+}
+
+// Find returns the category with the id, if it is in the tree.
+func (t *CategoryTree) Find(id int64) (Category, bool) {
+	category, ok := t.byID[id]
+	return category, ok
+}
+
+// Breadcrumbs returns the path from the root down to the category, ending
+// with the category itself. It is empty when the category is not in the
+// tree.
+func (t *CategoryTree) Breadcrumbs(id int64) []CategoryBreadcrumb {
+	var path []CategoryBreadcrumb
+	seen := make(map[int64]bool)
+	for {
+		category, ok := t.byID[id]
+		if !ok || seen[id] {
+			break
+		}
+		seen[id] = true
+		path = append(path, CategoryBreadcrumb{ID: category.ID, Name: category.Name, Slug: category.Slug})
+		if category.ParentID == nil {
+			break
+		}
+		id = *category.ParentID
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// DescendantIDs returns the id and the ids of every category below it, so
+// listing a category can include its subcategories.
+func (t *CategoryTree) DescendantIDs(id int64) []int64 {
+	ids := []int64{id}
+	seen := map[int64]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range t.children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// IsDescendant reports whether the category id sits somewhere below
+// ancestor. Moving ancestor under it would make a cycle.
+func (t *CategoryTree) IsDescendant(id, ancestor int64) bool {
+	for _, descendant := range t.DescendantIDs(ancestor)[1:] {
+		if descendant == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Nodes returns the root categories with their subcategories nested below
+// them.
+func (t *CategoryTree) Nodes() []CategoryTreeNode {
+	return t.nodes(t.roots)
+}
+
+func (t *CategoryTree) nodes(ids []int64) []CategoryTreeNode {
+	nodes := make([]CategoryTreeNode, len(ids))
+	for i, id := range ids {
+		category := t.byID[id]
+		nodes[i] = CategoryTreeNode{
+			CategoryResponse: *category.ToResponseCategory(),
+			Children:         t.nodes(t.children[id]),
+		}
 	}
+	return nodes
 }
@@ -0,0 +1,92 @@
+package models_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"reflect"
+	"testing"
+)
+
+// menCategories is Men > Shoes > Sneakers, Men > Shoes > Boots and
+// Men > Shirts, plus a Women root sorted before Men.
+func menCategories() []models.Category {
+	men, shoes := int64(1), int64(2)
+	first, second := 1, 2
+	return []models.Category{
+		{ID: 1, Name: "Men", Slug: "men", SortOrder: &second},
+		{ID: 2, ParentID: &men, Name: "Shoes", Slug: "shoes"},
+		{ID: 3, ParentID: &shoes, Name: "Sneakers", Slug: "sneakers", SortOrder: &second},
+		{ID: 4, ParentID: &shoes, Name: "Boots", Slug: "boots", SortOrder: &first},
+		{ID: 5, ParentID: &men, Name: "Shirts", Slug: "shirts"},
+		{ID: 6, Name: "Women", Slug: "women", SortOrder: &first},
+	}
+}
+
+func TestCategoryTreeBreadcrumbs(t *testing.T) {
+	tree := models.NewCategoryTree(menCategories())
+
+	want := []models.CategoryBreadcrumb{
+		{ID: 1, Name: "Men", Slug: "men"},
+		{ID: 2, Name: "Shoes", Slug: "shoes"},
+		{ID: 3, Name: "Sneakers", Slug: "sneakers"},
+	}
+	if got := tree.Breadcrumbs(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("Breadcrumbs(3) = %+v, want %+v", got, want)
+	}
+	if got := tree.Breadcrumbs(6); len(got) != 1 || got[0].ID != 6 {
+		t.Errorf("Breadcrumbs(6) = %+v, want just Women", got)
+	}
+	if got := tree.Breadcrumbs(99); len(got) != 0 {
+		t.Errorf("Breadcrumbs(99) = %+v, want none", got)
+	}
+}
+
+func TestCategoryTreeDescendants(t *testing.T) {
+	tree := models.NewCategoryTree(menCategories())
+
+	if got, want := tree.DescendantIDs(1), []int64{1, 5, 2, 4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DescendantIDs(1) = %v, want %v", got, want)
+	}
+	if got, want := tree.DescendantIDs(3), []int64{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DescendantIDs(3) = %v, want %v", got, want)
+	}
+	if !tree.IsDescendant(3, 1) {
+		t.Error("Sneakers should be below Men")
+	}
+	if tree.IsDescendant(1, 1) || tree.IsDescendant(1, 3) || tree.IsDescendant(6, 1) {
+		t.Error("IsDescendant should only hold for categories below the ancestor")
+	}
+}
+
+func TestCategoryTreeNodes(t *testing.T) {
+	nodes := models.NewCategoryTree(menCategories()).Nodes()
+
+	if len(nodes) != 2 || nodes[0].Name != "Women" || nodes[1].Name != "Men" {
+		t.Fatalf("Expected roots Women, Men in sort order, got %+v", nodes)
+	}
+	men := nodes[1]
+	if len(men.Children) != 2 || men.Children[0].Name != "Shirts" || men.Children[1].Name != "Shoes" {
+		t.Fatalf("Expected Men's children Shirts, Shoes by name, got %+v", men.Children)
+	}
+	shoes := men.Children[1]
+	if len(shoes.Children) != 2 || shoes.Children[0].Name != "Boots" || shoes.Children[1].SortOrder != 2 {
+		t.Errorf("Expected Shoes' children Boots, Sneakers by sort order, got %+v", shoes.Children)
+	}
+	if nodes[0].Children == nil || len(nodes[0].Children) != 0 {
+		t.Errorf("Expected an empty children list for a leaf, got %v", nodes[0].Children)
+	}
+}
+
+func TestCategoryTreeCycle(t *testing.T) {
+	a, b := int64(1), int64(2)
+	tree := models.NewCategoryTree([]models.Category{
+		{ID: 1, ParentID: &b, Name: "A"},
+		{ID: 2, ParentID: &a, Name: "B"},
+	})
+
+	if got := tree.Breadcrumbs(1); len(got) != 2 {
+		t.Errorf("Expected breadcrumbs to stop at the cycle, got %+v", got)
+	}
+	if got := tree.DescendantIDs(1); len(got) != 2 {
+		t.Errorf("Expected descendants to stop at the cycle, got %v", got)
+	}
+}
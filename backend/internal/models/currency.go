@@ -0,0 +1,118 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Currency is a currency prices can be shown in. Rate is the value of one
+// unit of it in the store currency, e.g. 16250 for USD, as maintained by an
+// admin or imported from a rates file. Amounts are always charged and
+// settled in the store currency; converted prices are for display only.
+//
+// Only currencies with two minor digits, like the store currency, are
+// supported.
+type Currency struct {
+	Code      string    `json:"code" gorm:"type:varchar(3);primaryKey"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null"`
+	Symbol    string    `json:"symbol" gorm:"type:varchar(10);not null;default:''"`
+	Rate      float64   `json:"rate" gorm:"type:decimal(18,6);not null"`
+	IsActive  bool      `json:"is_active" gorm:"not null;default:true"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// StoreCurrencyInfo returns the store currency itself, which is not kept in
+// the currencies table and always has a rate of 1.
+func StoreCurrencyInfo() *Currency {
+	return &Currency{Code: StoreCurrency, Name: "Indonesian Rupiah", Symbol: "Rp", Rate: 1, IsActive: true}
+}
+
+// IsStoreCurrency reports whether c is the store currency.
+func (c *Currency) IsStoreCurrency() bool {
+	return c.Code == StoreCurrency
+}
+
+// Convert returns amount, in the store currency, in this currency, rounded
+// to the nearest minor unit.
+func (c *Currency) Convert(amount Money) Money {
+	if c.IsStoreCurrency() || c.Rate <= 0 {
+		return amount
+	}
+	return Money(math.Round(float64(amount) / c.Rate))
+}
+
+// Request untuk membuat atau mengubah mata uang
+type CreateCurrency struct {
+	Code     string  `json:"code" validate:"required,len=3"`
+	Name     string  `json:"name" validate:"required,max=100"`
+	Symbol   string  `json:"symbol" validate:"max=10"`
+	Rate     float64 `json:"rate" validate:"gt=0"`
+	IsActive *bool   `json:"is_active"`
+}
+
+// ImportExchangeRates is an uploaded rates file. Currencies in it that do
+// not exist yet are created, active.
+type ImportExchangeRates struct {
+	Filename string
+	Data     []byte
+}
+
+type ExchangeRateImportResponse struct {
+	Created   int      `json:"created"`
+	Updated   int      `json:"updated"`
+	Unchanged int      `json:"unchanged"`
+	Codes     []string `json:"codes"`
+}
+
+type CurrencyResponse struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Symbol    string    `json:"symbol"`
+	Rate      float64   `json:"rate"`
+	IsActive  bool      `json:"is_active"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *Currency) ToResponse() *CurrencyResponse {
+	return &CurrencyResponse{
+		Code:      c.Code,
+		Name:      c.Name,
+		Symbol:    c.Symbol,
+		Rate:      c.Rate,
+		IsActive:  c.IsActive,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// InCurrency converts the product's price for display in c. The price in
+// the store currency, which is what checkout charges, is kept in
+// StorePrice.
+func (p *ProductResponse) InCurrency(c *Currency) {
+	if c == nil || c.IsStoreCurrency() {
+		return
+	}
+	p.StorePrice = p.Price
+	p.Price = c.Convert(p.Price)
+	p.Currency = c.Code
+	p.ExchangeRate = c.Rate
+}
+
+// InCurrency converts the product's price and the prices of its sizes for
+// display in c, like ProductResponse.InCurrency.
+func (p *ProductDetailResponse) InCurrency(c *Currency) {
+	if c == nil || c.IsStoreCurrency() {
+		return
+	}
+	p.StorePrice = p.Price
+	p.Price = c.Convert(p.Price)
+	p.Currency = c.Code
+	p.ExchangeRate = c.Rate
+
+	for i := range p.ColorVarian {
+		for j := range p.ColorVarian[i].SizeVarian {
+			size := &p.ColorVarian[i].SizeVarian[j]
+			size.Price = c.Convert(size.Price)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"testing"
+)
+
+func TestCurrencyConvert(t *testing.T) {
+	usd := &models.Currency{Code: "USD", Rate: 16250}
+
+	tests := []struct {
+		amount models.Money
+		want   models.Money
+	}{
+		{models.Rupiah(162500), 1000},
+		{models.Rupiah(150000), 923},
+		{models.Rupiah(8125), 50},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		if got := usd.Convert(tt.amount); got != tt.want {
+			t.Errorf("Convert(%s) = %s, want %s", tt.amount, got, tt.want)
+		}
+	}
+
+	if got := models.StoreCurrencyInfo().Convert(models.Rupiah(150000)); got != models.Rupiah(150000) {
+		t.Errorf("store currency Convert = %s, want unchanged", got)
+	}
+}
+
+func TestTransactionDisplayAmount(t *testing.T) {
+	tx := models.Transaction{PayableAmount: models.Rupiah(325123), DisplayCurrency: "USD", ExchangeRate: 16250}
+	if got := tx.DisplayAmount(); got != 2001 {
+		t.Errorf("DisplayAmount() = %s, want 20.01", got)
+	}
+	if got := tx.SettlementCurrency(); got != models.StoreCurrency {
+		t.Errorf("SettlementCurrency() = %s, want %s", got, models.StoreCurrency)
+	}
+
+	legacy := models.Transaction{TotalPrice: models.Rupiah(100000)}
+	if info := legacy.DisplayCurrencyInfo(); info.Code != models.StoreCurrency || info.Rate != 1 {
+		t.Errorf("DisplayCurrencyInfo() = %+v, want the store currency", info)
+	}
+	if got := legacy.DisplayAmount(); got != models.Rupiah(100000) {
+		t.Errorf("DisplayAmount() = %s, want 100000.00", got)
+	}
+}
@@ -8,24 +8,24 @@ type OrderStatusCount struct {
 }
 
 type OrderAnalytics struct {
-	Date       string  `json:"date"`
-	OrderCount int64   `json:"order_count"`
-	Revenue    float64 `json:"revenue"`
+	Date       string `json:"date"`
+	OrderCount int64  `json:"order_count"`
+	Revenue    Money  `json:"revenue"`
 }
 type TopProduct struct {
-	ProductID   int64   `json:"product_id" gorm:"column:product_id"`
-	ProductName string  `json:"product_name" gorm:"column:product_name"`
-	TotalSold   int64   `json:"total_sold" gorm:"column:total_sold"`
-	Revenue     float64 `json:"revenue" gorm:"column:revenue"`
+	ProductID   int64  `json:"product_id" gorm:"column:product_id"`
+	ProductName string `json:"product_name" gorm:"column:product_name"`
+	TotalSold   int64  `json:"total_sold" gorm:"column:total_sold"`
+	Revenue     Money  `json:"revenue" gorm:"column:revenue"`
 }
 
 type LowStockProduct struct {
-	ProductID   int64   `json:"product_id" gorm:"column:product_id"`
-	ProductName string  `json:"product_name" gorm:"column:product_name"`
-	ColorName   string  `json:"color_name" gorm:"column:color_name"`
-	Size        string  `json:"size" gorm:"column:size"`
-	Stock       int64   `json:"stock" gorm:"column:stock"`
-	Price       float64 `json:"price" gorm:"column:price"`
+	ProductID   int64  `json:"product_id" gorm:"column:product_id"`
+	ProductName string `json:"product_name" gorm:"column:product_name"`
+	ColorName   string `json:"color_name" gorm:"column:color_name"`
+	Size        string `json:"size" gorm:"column:size"`
+	Stock       int64  `json:"stock" gorm:"column:stock"`
+	Price       Money  `json:"price" gorm:"column:price"`
 }
 type UserGrowthAnalytics struct {
 	Date      string `json:"date"`
@@ -44,10 +44,12 @@ type DashboardStatsResponse struct {
 }
 
 type RevenueStatsResponse struct {
-	TotalRevenue     float64 `json:"total_revenue"`
-	RevenueToday     float64 `json:"revenue_today"`
-	RevenueThisMonth float64 `json:"revenue_this_month"`
-	RevenueThisWeek  float64 `json:"revenue_this_week"`
+	TotalRevenue     Money `json:"total_revenue"`
+	RevenueToday     Money `json:"revenue_today"`
+	RevenueThisMonth Money `json:"revenue_this_month"`
+	RevenueThisWeek  Money `json:"revenue_this_week"`
+	TotalTax         Money `json:"total_tax"`
+	TaxThisMonth     Money `json:"tax_this_month"`
 }
 
 type OrderStatsResponse struct {
@@ -66,7 +68,7 @@ type OrderSummary struct {
 	ColorName   string    `json:"color_name"`
 	Size        string    `json:"size"`
 	Quantity    int64     `json:"quantity"`
-	Subtotal    float64   `json:"subtotal"`
+	Subtotal    Money     `json:"subtotal"`
 	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
 }
@@ -85,9 +87,9 @@ type OrderAnalyticsResponse struct {
 }
 
 type AnalyticsSummary struct {
-	TotalOrders  int64   `json:"total_orders"`
-	TotalRevenue float64 `json:"total_revenue"`
-	AverageOrder float64 `json:"average_order"`
+	TotalOrders  int64 `json:"total_orders"`
+	TotalRevenue Money `json:"total_revenue"`
+	AverageOrder Money `json:"average_order"`
 }
 
 type UserGrowthResponse struct {
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Documents that can be generated for a transaction.
+const (
+	InvoiceDocument     = "invoice"
+	PackingSlipDocument = "packing_slip"
+)
+
+// Invoice holds the number issued to a transaction the first time its invoice
+// is generated. Numbers run without gaps per year, e.g. INV/2026/000042.
+type Invoice struct {
+	ID            int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Number        string    `json:"number" gorm:"type:varchar(30);not null;uniqueIndex"`
+	TransactionID string    `json:"transaction_id" gorm:"type:varchar(50);not null;uniqueIndex"`
+	Year          int       `json:"year" gorm:"not null"`
+	Sequence      int64     `json:"sequence" gorm:"not null"`
+	IssuedAt      time.Time `json:"issued_at" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// InvoiceSequence is the last invoice number issued in a year.
+type InvoiceSequence struct {
+	Year       int       `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int64     `gorm:"not null;default:0"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+// Request untuk mengunduh beberapa dokumen sekaligus dalam satu PDF
+type BulkDocumentRequest struct {
+	TransactionIDs []string `json:"transaction_ids" validate:"required,min=1,max=100"`
+	Document       string   `json:"document" validate:"required,oneof=invoice packing_slip"`
+}
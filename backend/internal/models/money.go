@@ -0,0 +1,191 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// StoreCurrency is the ISO 4217 code of the currency every Money amount is
+// in; prices, totals and payments are all kept in it.
+const StoreCurrency = "IDR"
+
+// Money is an amount of the store currency in minor units (sen, 1/100 of a
+// rupiah), so sums and comparisons are exact. It is stored as a decimal
+// column and encoded in JSON as a plain number, as float prices were.
+//
+// Rounding only happens when an amount is derived from something that is
+// not itself money: a float, a percentage or a decimal with more than two
+// places. Those round half away from zero to the nearest sen.
+type Money int64
+
+// NewMoney converts a float amount, such as a form value, to Money.
+func NewMoney(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// Rupiah returns whole rupiah as Money.
+func Rupiah(amount int64) Money {
+	return Money(amount * 100)
+}
+
+// ParseMoney parses a decimal amount such as "150000", "-12.5" or
+// "1250.125" exactly, without going through a float.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty amount")
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	for _, part := range []string{whole, frac} {
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return 0, fmt.Errorf("invalid amount %q", s)
+			}
+		}
+	}
+
+	var units int64
+	if whole != "" {
+		var err error
+		units, err = strconv.ParseInt(whole, 10, 64)
+		if err != nil || units > math.MaxInt64/100-1 {
+			return 0, fmt.Errorf("amount %q is out of range", s)
+		}
+	}
+
+	frac += "000"
+	cents, _ := strconv.ParseInt(frac[:2], 10, 64)
+	amount := units*100 + cents
+	if frac[2] >= '5' {
+		amount++
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+// Mul returns the amount times quantity.
+func (m Money) Mul(quantity int64) Money {
+	return m * Money(quantity)
+}
+
+// Div returns the amount split n ways, rounded to the nearest sen.
+func (m Money) Div(n int64) Money {
+	return Money(math.Round(float64(m) / float64(n)))
+}
+
+// Percent returns rate percent of the amount, rounded to the nearest sen.
+func (m Money) Percent(rate float64) Money {
+	return Money(math.Round(float64(m) * rate / 100))
+}
+
+// RoundRupiah rounds the amount to whole rupiah, half away from zero, for
+// charges that are never asked in sen.
+func (m Money) RoundRupiah() Money {
+	if m < 0 {
+		return -(-m).RoundRupiah()
+	}
+	return (m + 50) / 100 * 100
+}
+
+// String formats the amount as a decimal with two places, e.g. "150000.00".
+func (m Money) String() string {
+	sign := ""
+	units := int64(m)
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, units/100, units%100)
+}
+
+// MarshalJSON writes the amount as a JSON number, without decimals when
+// there are no sen.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m%100 == 0 {
+		return []byte(strconv.FormatInt(int64(m/100), 10)), nil
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or a numeric string. Exponents, which
+// clients may send for large numbers, go through a float.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*m = 0
+		return nil
+	}
+
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid amount %s", data)
+		}
+		*m = NewMoney(f)
+		return nil
+	}
+
+	amount, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// Scan implements sql.Scanner. Decimal columns and sums arrive as text;
+// some drivers hand over floats or integers instead.
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case float64:
+		*m = NewMoney(v)
+	case float32:
+		*m = NewMoney(float64(v))
+	case int64:
+		*m = Rupiah(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+	return nil
+}
+
+func (m *Money) scanString(s string) error {
+	amount, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// Value implements driver.Valuer, writing the amount as decimal text so the
+// database never sees a float.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,115 @@
+package models_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value string
+		want  models.Money
+	}{
+		{"150000", 15000000},
+		{"150000.5", 15000050},
+		{"0.10", 10},
+		{".75", 75},
+		{"-12.5", -1250},
+		{"1250.125", 125013},
+		{"1250.124", 125012},
+		{"-0.005", -1},
+		{"9909.910000", 990991},
+	}
+
+	for _, tt := range tests {
+		got, err := models.ParseMoney(tt.value)
+		if err != nil {
+			t.Errorf("ParseMoney(%q) returned error: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "abc", "1,5", "1.2.3", "-", "99999999999999999999"} {
+		if _, err := models.ParseMoney(value); err == nil {
+			t.Errorf("ParseMoney(%q) expected an error", value)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	// 0.1 + 0.2 is exact in sen.
+	if sum := models.NewMoney(0.1) + models.NewMoney(0.2); sum != models.NewMoney(0.3) {
+		t.Errorf("Expected 0.30, got %s", sum)
+	}
+	if got := models.NewMoney(19.99).Mul(3); got.String() != "59.97" {
+		t.Errorf("Expected 59.97, got %s", got)
+	}
+	if got := models.Rupiah(100000).Percent(2.5); got != models.Rupiah(2500) {
+		t.Errorf("Expected 2500.00, got %s", got)
+	}
+	if got := models.Rupiah(10).Div(3); got.String() != "3.33" {
+		t.Errorf("Expected 3.33, got %s", got)
+	}
+
+	rounding := map[models.Money]models.Money{
+		149:  100,
+		150:  200,
+		-150: -200,
+		-149: -100,
+	}
+	for amount, want := range rounding {
+		if got := amount.RoundRupiah(); got != want {
+			t.Errorf("RoundRupiah(%s) = %s, want %s", amount, got, want)
+		}
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	out, _ := json.Marshal(struct {
+		Whole models.Money `json:"whole"`
+		Cents models.Money `json:"cents"`
+	}{models.Rupiah(150000), models.NewMoney(-9.5)})
+	if string(out) != `{"whole":150000,"cents":-9.50}` {
+		t.Errorf("Unexpected JSON %s", out)
+	}
+
+	var in struct {
+		Number models.Money `json:"number"`
+		Text   models.Money `json:"text"`
+		Exp    models.Money `json:"exp"`
+	}
+	if err := json.Unmarshal([]byte(`{"number":150000.25,"text":"99.9","exp":1.5e3}`), &in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if in.Number != 15000025 || in.Text != 9990 || in.Exp != models.Rupiah(1500) {
+		t.Errorf("Unexpected amounts %+v", in)
+	}
+}
+
+func TestMoney_SQL(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  models.Money
+	}{
+		{[]byte("150000.00"), models.Rupiah(150000)},
+		{"1234.5678", 123457},
+		{float64(12.34), 1234},
+		{int64(7), models.Rupiah(7)},
+		{nil, 0},
+	}
+
+	for _, tt := range tests {
+		var m models.Money
+		if err := m.Scan(tt.value); err != nil || m != tt.want {
+			t.Errorf("Scan(%v) = %d, %v, want %d", tt.value, m, err, tt.want)
+		}
+	}
+
+	if v, _ := models.NewMoney(-0.5).Value(); v != "-0.50" {
+		t.Errorf("Expected -0.50, got %v", v)
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// OIDCState remembers a started social login until the provider redirects
+// back. It is deleted when the login completes so it can only be used once.
+type OIDCState struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	State        string    `json:"-" gorm:"type:varchar(128);not null;uniqueIndex"`
+	Provider     string    `json:"provider" gorm:"type:varchar(50);not null"`
+	Nonce        string    `json:"-" gorm:"type:varchar(128);not null"`
+	CodeVerifier string    `json:"-" gorm:"type:varchar(128);not null"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// UserIdentity links a user to their account at an OIDC provider.
+type UserIdentity struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	Provider  string    `json:"provider" gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string    `json:"subject" gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string    `json:"email" gorm:"type:varchar(255)"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      User      `json:"-" gorm:"foreignKey:UserID"`
+}
+
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+func (OIDCState) TableName() string {
+	return "oidc_states"
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
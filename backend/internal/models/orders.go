@@ -14,10 +14,14 @@ type Order struct {
 	ProductID     int64          `json:"product_id" gorm:"not null;index" validate:"required"`
 	ColorVarianID int64          `json:"color_varian_id" gorm:"not null;index" validate:"required"`
 	SizeVarianID  int64          `json:"size_varian_id" gorm:"not null;index" validate:"required"`
-	UnitPrice     float64        `json:"unit_price" gorm:"type:decimal(10,2);not null" validate:"required,gt=0"`
-	Subtotal      float64        `json:"subtotal" gorm:"type:decimal(10,2);not null" validate:"required,gt=0"`
+	UnitPrice     Money          `json:"unit_price" gorm:"type:decimal(10,2);not null" validate:"required,gt=0"`
+	Subtotal      Money          `json:"subtotal" gorm:"type:decimal(10,2);not null" validate:"required,gt=0"`
 	Quantity      int64          `json:"quantity" gorm:"not null" validate:"required,gt=0"`
+	TaxClassID    *int64         `json:"tax_class_id,omitempty" gorm:"index"`
+	TaxRate       float64        `json:"tax_rate" gorm:"type:decimal(5,2);not null;default:0"`
+	TaxAmount     Money          `json:"tax_amount" gorm:"type:decimal(10,2);not null;default:0"`
 	Status        string         `json:"status" gorm:"type:varchar(20);default:'pending';index" validate:"required,oneof=pending paid shipped completed cancelled"`
+	DeliveredAt   *time.Time     `json:"delivered_at,omitempty"`
 	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
@@ -36,12 +40,12 @@ type UpdateOrder struct {
 
 // Payload untuk Create
 type CreateOrder struct {
-	ProductID     int64   `json:"product_id" validate:"required"`
-	ColorVarianID int64   `json:"color_varian_id" validate:"required"`
-	SizeVarianID  int64   `json:"size_varian_id" validate:"required"`
-	UnitPrice     float64 `json:"unit_price" validate:"required,gt=0"`
-	Subtotal      float64 `json:"subtotal" validate:"required,gt=0"`
-	Quantity      int64   `json:"quantity" validate:"required,gt=0"`
+	ProductID     int64 `json:"product_id" validate:"required"`
+	ColorVarianID int64 `json:"color_varian_id" validate:"required"`
+	SizeVarianID  int64 `json:"size_varian_id" validate:"required"`
+	UnitPrice     Money `json:"unit_price" validate:"required,gt=0"`
+	Subtotal      Money `json:"subtotal" validate:"required,gt=0"`
+	Quantity      int64 `json:"quantity" validate:"required,gt=0"`
 }
 
 // Response Struct
@@ -50,10 +54,13 @@ type OrderResponse struct {
 	TransactionID string               `json:"transaction_id"`
 	Product       ProductOrderResponse `json:"product"`
 	Size          string               `json:"size"`
-	UnitPrice     float64              `json:"unit_price"`
-	Subtotal      float64              `json:"subtotal"`
+	UnitPrice     Money                `json:"unit_price"`
+	Subtotal      Money                `json:"subtotal"`
 	Quantity      int64                `json:"quantity"`
+	TaxRate       float64              `json:"tax_rate"`
+	TaxAmount     Money                `json:"tax_amount"`
 	Status        string               `json:"status"`
+	DeliveredAt   *time.Time           `json:"delivered_at,omitempty"`
 	UpdatedAt     time.Time            `json:"updated_at"`
 	CreatedAt     time.Time            `json:"created_at"`
 }
@@ -65,7 +72,7 @@ type ProductOrderResponse struct {
 	Description string                   `json:"description"`
 	Images      string                   `json:"images"`
 	Rating      float64                  `json:"rating"`
-	Price       float64                  `json:"price"`
+	Price       Money                    `json:"price"`
 	ColorVarian ColorVarianOrderResponse `json:"color_varian"`
 	UpdatedAt   time.Time                `json:"updated_at"`
 	CreatedAt   time.Time                `json:"created_at"`
@@ -120,7 +127,10 @@ func (p *Order) ToOrderResponse() OrderResponse {
 		ID:            p.ID,
 		TransactionID: p.TransactionID,
 		Product:       p.Product.ToResponseProductOrder(&p.ColorVarian),
+		TaxRate:       p.TaxRate,
+		TaxAmount:     p.TaxAmount,
 		Status:        p.Status,
+		DeliveredAt:   p.DeliveredAt,
 		UpdatedAt:     p.UpdatedAt,
 		CreatedAt:     p.CreatedAt,
 	}
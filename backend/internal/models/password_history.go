@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// PasswordHistory keeps hashes of a user's previous passwords so they
+// cannot be reused.
+type PasswordHistory struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	UserID       uint      `json:"user_id" gorm:"not null;index"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
+}
+
+func (PasswordHistory) TableName() string {
+	return "password_histories"
+}
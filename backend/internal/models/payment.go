@@ -9,7 +9,7 @@ import (
 type Payment struct {
 	ID            int64          `json:"id" gorm:"primaryKey;autoIncrement"`
 	TransactionID string         `json:"transaction_id" validate:"required" gorm:"not null;index"`
-	TotalPayment  float64        `json:"total_payment" validate:"required,gt=0" gorm:"type:decimal(12,2);not null"`
+	TotalPayment  Money          `json:"total_payment" validate:"required,gt=0" gorm:"type:decimal(12,2);not null"`
 	Status        string         `json:"status" validate:"required,oneof=pending success failed refunded" gorm:"type:varchar(20);default:'pending';index"`
 	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime"`
@@ -21,8 +21,8 @@ type Payment struct {
 
 // Request untuk membuat pembayaran
 type CreatePayment struct {
-	TransactionID string  `json:"transaction_id" form:"transaction_id" validate:"required"`
-	TotalPayment  float64 `json:"total_payment" form:"total_payment" validate:"required,gt=0"`
+	TransactionID string `json:"transaction_id" form:"transaction_id" validate:"required"`
+	TotalPayment  Money  `json:"total_payment" form:"total_payment" validate:"required,gt=0"`
 }
 
 // Request untuk update status pembayaran
@@ -40,7 +40,8 @@ type PaymentListRequest struct {
 type PaymentResponse struct {
 	ID            int64                `json:"id"`
 	TransactionID string               `json:"transaction_id"`
-	TotalPayment  float64              `json:"total_payment"`
+	TotalPayment  Money                `json:"total_payment"`
+	Currency      string               `json:"currency"`
 	Status        string               `json:"status"`
 	Transaction   *TransactionResponse `json:"transaction,omitempty"`
 	CreatedAt     time.Time            `json:"created_at"`
@@ -52,6 +53,7 @@ func (cl *Payment) ToResponsePayment() *PaymentResponse {
 		ID:            cl.ID,
 		TransactionID: cl.TransactionID,
 		TotalPayment:  cl.TotalPayment,
+		Currency:      StoreCurrency,
 		Status:        cl.Status,
 		Transaction:   cl.Transaction.ToResponseTransaction(),
 		UpdatedAt:     cl.UpdatedAt,
@@ -6,13 +6,89 @@ import (
 	"gorm.io/gorm"
 )
 
-// PaymentMethod Model (DB)
+// Payment types. Bank transfers and e-wallet transfers are sent by hand to
+// the store's account, so they get a unique code; virtual accounts and
+// QRIS carry their own reference; cash on delivery is collected by the
+// courier.
+const (
+	PaymentTypeBankTransfer   = "bank_transfer"
+	PaymentTypeVirtualAccount = "virtual_account"
+	PaymentTypeEWallet        = "ewallet"
+	PaymentTypeQRIS           = "qris"
+	PaymentTypeCOD            = "cod"
+)
+
+// paymentTypeRules tells how a transaction paid with a type is settled.
+type paymentTypeRules struct {
+	// uniqueCode adds a unique code to the payable amount
+	uniqueCode bool
+	// proof lets the customer upload a transfer receipt
+	proof bool
+	// statement means credits show up on an importable bank statement
+	statement bool
+}
+
+var paymentTypes = map[string]paymentTypeRules{
+	PaymentTypeBankTransfer:   {uniqueCode: true, proof: true, statement: true},
+	PaymentTypeVirtualAccount: {statement: true},
+	PaymentTypeEWallet:        {uniqueCode: true, proof: true},
+	PaymentTypeQRIS:           {proof: true},
+	PaymentTypeCOD:            {},
+}
+
+// IsValidPaymentType reports whether t is a supported payment type.
+func IsValidPaymentType(t string) bool {
+	_, ok := paymentTypes[t]
+	return ok
+}
+
+// paymentTypeOf treats methods and transactions from before payment types as
+// bank transfers.
+func paymentTypeOf(t string) string {
+	if t == "" {
+		return PaymentTypeBankTransfer
+	}
+	return t
+}
+
+// PaymentTypeUsesUniqueCode reports whether payments of type t get a unique
+// code added to their amount.
+func PaymentTypeUsesUniqueCode(t string) bool {
+	return paymentTypes[paymentTypeOf(t)].uniqueCode
+}
+
+// PaymentTypeAcceptsProof reports whether customers may upload a transfer
+// receipt for payments of type t.
+func PaymentTypeAcceptsProof(t string) bool {
+	return paymentTypes[paymentTypeOf(t)].proof
+}
+
+// PaymentTypeHasStatement reports whether payments of type t arrive on a bank
+// account whose statements can be imported.
+func PaymentTypeHasStatement(t string) bool {
+	return paymentTypes[paymentTypeOf(t)].statement
+}
+
+// PaymentMethod Model (DB). BankName holds the bank or e-wallet provider,
+// AccountNumber the account or e-wallet phone number. VAPrefix is the
+// company code virtual account numbers start with and QRISPayload the
+// store's static QRIS. A fee of FeeFlat plus FeePercent of the order is
+// charged on top; MinAmount and MaxAmount limit the orders it can pay, zero
+// meaning no limit.
 type PaymentMethod struct {
 	ID            int64          `json:"id" gorm:"primaryKey;autoIncrement"`
-	AccountName   string         `json:"account_name" validate:"required,min=3,max=100" gorm:"type:varchar(100);not null"`
-	AccountNumber string         `json:"account_number" validate:"required,numeric,min=5,max=30" gorm:"type:varchar(30);not null;index"`
-	BankName      string         `json:"bank_name" validate:"required,min=3,max=100" gorm:"type:varchar(100);not null"`
+	Type          string         `json:"type" validate:"required,oneof=bank_transfer virtual_account ewallet qris cod" gorm:"type:varchar(20);not null;default:'bank_transfer';index"`
+	AccountName   string         `json:"account_name" validate:"omitempty,max=100" gorm:"type:varchar(100);not null;default:''"`
+	AccountNumber string         `json:"account_number" validate:"omitempty,max=30" gorm:"type:varchar(30);not null;default:'';index"`
+	BankName      string         `json:"bank_name" validate:"omitempty,max=100" gorm:"type:varchar(100);not null;default:''"`
 	BankImages    string         `json:"bank_images" validate:"omitempty,url" gorm:"type:text"`
+	VAPrefix      string         `json:"va_prefix" gorm:"type:varchar(8);not null;default:''"`
+	QRISPayload   string         `json:"qris_payload" gorm:"type:text"`
+	FeeFlat       Money          `json:"fee_flat" validate:"gte=0" gorm:"type:decimal(12,2);not null;default:0"`
+	FeePercent    float64        `json:"fee_percent" validate:"gte=0,lte=100" gorm:"type:decimal(5,2);not null;default:0"`
+	MinAmount     Money          `json:"min_amount" validate:"gte=0" gorm:"type:decimal(12,2);not null;default:0"`
+	MaxAmount     Money          `json:"max_amount" validate:"gte=0" gorm:"type:decimal(12,2);not null;default:0"`
+	Instructions  string         `json:"instructions" gorm:"type:text"`
 	IsActive      bool           `json:"is_active" gorm:"default:true;index"`
 	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime"`
@@ -21,29 +97,52 @@ type PaymentMethod struct {
 
 // Create Payment Method (Request Payload)
 type CreatePaymentMethod struct {
-	AccountName   string `json:"account_name" form:"account_name" validate:"required,min=3,max=100"`
-	AccountNumber string `json:"account_number" form:"account_number" validate:"required,numeric,min=5,max=30"`
-	BankName      string `json:"bank_name" form:"bank_name" validate:"required,min=3,max=100"`
-	BankImages    string `json:"bank_images" form:"bank_images" validate:"omitempty,url"`
+	Type          string  `json:"type" form:"type" validate:"omitempty,oneof=bank_transfer virtual_account ewallet qris cod"`
+	AccountName   string  `json:"account_name" form:"account_name" validate:"omitempty,max=100"`
+	AccountNumber string  `json:"account_number" form:"account_number" validate:"omitempty,max=30"`
+	BankName      string  `json:"bank_name" form:"bank_name" validate:"omitempty,max=100"`
+	BankImages    string  `json:"bank_images" form:"bank_images" validate:"omitempty,url"`
+	VAPrefix      string  `json:"va_prefix" form:"va_prefix" validate:"omitempty,numeric,min=3,max=8"`
+	QRISPayload   string  `json:"qris_payload" form:"qris_payload"`
+	FeeFlat       Money   `json:"fee_flat" form:"fee_flat" validate:"gte=0"`
+	FeePercent    float64 `json:"fee_percent" form:"fee_percent" validate:"gte=0,lte=100"`
+	MinAmount     Money   `json:"min_amount" form:"min_amount" validate:"gte=0"`
+	MaxAmount     Money   `json:"max_amount" form:"max_amount" validate:"gte=0"`
+	Instructions  string  `json:"instructions" form:"instructions"`
 }
 
-// Update Payment Method (Request Payload)
+// Update Payment Method (Request Payload). Empty strings and nil numbers
+// keep the current value; the type cannot change.
 type UpdatePaymentMethod struct {
-	ID            int64  `json:"id" form:"id" validate:"required,gt=0"`
-	AccountName   string `json:"account_name" form:"account_name" validate:"omitempty,min=3,max=100"`
-	AccountNumber string `json:"account_number" form:"account_number" validate:"omitempty,numeric,min=5,max=30"`
-	BankName      string `json:"bank_name" form:"bank_name" validate:"omitempty,min=3,max=100"`
-	BankImages    string `json:"bank_images" form:"bank_images" validate:"omitempty,url"`
-	IsActive      *bool  `json:"is_active" form:"is_active"`
+	ID            int64    `json:"id" form:"id" validate:"required,gt=0"`
+	AccountName   string   `json:"account_name" form:"account_name" validate:"omitempty,max=100"`
+	AccountNumber string   `json:"account_number" form:"account_number" validate:"omitempty,max=30"`
+	BankName      string   `json:"bank_name" form:"bank_name" validate:"omitempty,max=100"`
+	BankImages    string   `json:"bank_images" form:"bank_images" validate:"omitempty,url"`
+	VAPrefix      string   `json:"va_prefix" form:"va_prefix" validate:"omitempty,numeric,min=3,max=8"`
+	QRISPayload   string   `json:"qris_payload" form:"qris_payload"`
+	FeeFlat       *Money   `json:"fee_flat" form:"fee_flat"`
+	FeePercent    *float64 `json:"fee_percent" form:"fee_percent"`
+	MinAmount     *Money   `json:"min_amount" form:"min_amount"`
+	MaxAmount     *Money   `json:"max_amount" form:"max_amount"`
+	Instructions  *string  `json:"instructions" form:"instructions"`
+	IsActive      *bool    `json:"is_active" form:"is_active"`
 }
 
 // Response Struct (API Output)
 type PaymentMethodResponse struct {
 	ID            int64     `json:"id"`
+	Type          string    `json:"type"`
 	AccountName   string    `json:"account_name"`
 	AccountNumber string    `json:"account_number"`
 	BankName      string    `json:"bank_name"`
 	BankImages    string    `json:"bank_images"`
+	VAPrefix      string    `json:"va_prefix,omitempty"`
+	FeeFlat       Money     `json:"fee_flat"`
+	FeePercent    float64   `json:"fee_percent"`
+	MinAmount     Money     `json:"min_amount"`
+	MaxAmount     Money     `json:"max_amount"`
+	Instructions  string    `json:"instructions,omitempty"`
 	IsActive      bool      `json:"is_active"`
 	UpdatedAt     time.Time `json:"updated_at"`
 	CreatedAt     time.Time `json:"created_at"`
@@ -59,12 +158,99 @@ type PaymentMethodListRequest struct {
 func (s *PaymentMethod) ToResponsePaymentMethod() *PaymentMethodResponse {
 	return &PaymentMethodResponse{
 		ID:            s.ID,
+		Type:          paymentTypeOf(s.Type),
 		AccountName:   s.AccountName,
 		AccountNumber: s.AccountNumber,
 		BankName:      s.BankName,
 		BankImages:    s.BankImages,
+		VAPrefix:      s.VAPrefix,
+		FeeFlat:       s.FeeFlat,
+		FeePercent:    s.FeePercent,
+		MinAmount:     s.MinAmount,
+		MaxAmount:     s.MaxAmount,
+		Instructions:  s.Instructions,
 		IsActive:      s.IsActive,
 		UpdatedAt:     s.UpdatedAt,
 		CreatedAt:     s.CreatedAt,
 	}
 }
+
+// FeeFor is the fee charged for paying amount with the method, rounded to
+// whole rupiah.
+func (s *PaymentMethod) FeeFor(amount Money) Money {
+	return (s.FeeFlat + amount.Percent(s.FeePercent)).RoundRupiah()
+}
+
+// PaymentInstructions tell the customer how to pay a transaction at
+// checkout. Only the fields of the method's type are set.
+type PaymentInstructions struct {
+	Type                 string   `json:"type"`
+	Amount               Money    `json:"amount"`
+	BankName             string   `json:"bank_name,omitempty"`
+	AccountName          string   `json:"account_name,omitempty"`
+	AccountNumber        string   `json:"account_number,omitempty"`
+	VirtualAccountNumber string   `json:"virtual_account_number,omitempty"`
+	QRISPayload          string   `json:"qris_payload,omitempty"`
+	Steps                []string `json:"steps"`
+}
+
+// InstructionsFor returns how to pay tx with the method, followed by the
+// method's own instructions when it has any.
+func (s *PaymentMethod) InstructionsFor(tx *Transaction) *PaymentInstructions {
+	if s == nil {
+		return nil
+	}
+
+	instructions := &PaymentInstructions{
+		Type:   paymentTypeOf(s.Type),
+		Amount: tx.AmountDue(),
+	}
+
+	switch instructions.Type {
+	case PaymentTypeBankTransfer:
+		instructions.BankName = s.BankName
+		instructions.AccountName = s.AccountName
+		instructions.AccountNumber = s.AccountNumber
+		instructions.Steps = []string{
+			"Transfer exactly the amount shown, including the last digits, to the " + s.BankName + " account above.",
+			"Write the transaction ID " + tx.TxID + " in the transfer description.",
+			"Upload the transfer receipt if the payment is not confirmed within 24 hours.",
+		}
+	case PaymentTypeVirtualAccount:
+		instructions.BankName = s.BankName
+		instructions.AccountName = s.AccountName
+		instructions.VirtualAccountNumber = tx.PaymentReference
+		instructions.Steps = []string{
+			"Open the " + s.BankName + " app, internet banking or ATM and choose virtual account payment.",
+			"Enter the virtual account number above and check the amount and the name " + s.AccountName + ".",
+			"Confirm the payment. It is confirmed once it shows up on the store's account.",
+		}
+	case PaymentTypeEWallet:
+		instructions.BankName = s.BankName
+		instructions.AccountName = s.AccountName
+		instructions.AccountNumber = s.AccountNumber
+		instructions.Steps = []string{
+			"Open " + s.BankName + " and send exactly the amount shown to the number above.",
+			"Check that the recipient is " + s.AccountName + " and write the transaction ID " + tx.TxID + " in the note.",
+			"Upload a screenshot of the payment if it is not confirmed within 24 hours.",
+		}
+	case PaymentTypeQRIS:
+		instructions.AccountName = s.AccountName
+		instructions.QRISPayload = tx.PaymentReference
+		instructions.Steps = []string{
+			"Scan the QR code with any banking or e-wallet app that supports QRIS.",
+			"Check that the merchant is " + s.AccountName + " and pay exactly the amount shown.",
+			"Upload a screenshot of the payment if it is not confirmed within 24 hours.",
+		}
+	case PaymentTypeCOD:
+		instructions.Steps = []string{
+			"Pay the courier in cash when your parcel arrives.",
+			"Please have the exact amount ready.",
+		}
+	}
+
+	if s.Instructions != "" {
+		instructions.Steps = append(instructions.Steps, s.Instructions)
+	}
+	return instructions
+}
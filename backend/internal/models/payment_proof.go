@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// PaymentProof is a transfer receipt a customer uploads for a manual bank
+// transfer. It stays pending until an admin approves it, which confirms the
+// payment, or rejects it with a reason, after which the customer may upload
+// a new one.
+type PaymentProof struct {
+	ID                int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	PaymentID         int64      `json:"payment_id" gorm:"not null;index"`
+	TransactionID     string     `json:"transaction_id" gorm:"type:varchar(50);not null;index"`
+	UserID            uint       `json:"user_id" gorm:"not null;index"`
+	ImageURL          string     `json:"image_url" gorm:"type:text;not null"`
+	SenderBankName    string     `json:"sender_bank_name" gorm:"type:varchar(100);not null"`
+	SenderAccountName string     `json:"sender_account_name" gorm:"type:varchar(100);not null"`
+	Status            string     `json:"status" gorm:"type:varchar(20);default:'pending';index"`
+	RejectionReason   string     `json:"rejection_reason" gorm:"type:text"`
+	ReviewedBy        *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+// Request untuk mengunggah bukti transfer. The receipt image is sent as a
+// multipart file.
+type CreatePaymentProof struct {
+	TransactionID     string `json:"transaction_id" validate:"required"`
+	SenderBankName    string `json:"sender_bank_name" validate:"required,max=100"`
+	SenderAccountName string `json:"sender_account_name" validate:"required,max=100"`
+}
+
+// RejectPaymentProof rejects a proof; the reason is shown to the customer.
+type RejectPaymentProof struct {
+	ID     int64  `json:"-"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+type PaymentProofListRequest struct {
+	UserID        uint
+	TransactionID string
+	Status        string
+	Limit         int
+	Page          int
+}
+
+type PaymentProofResponse struct {
+	ID                int64      `json:"id"`
+	PaymentID         int64      `json:"payment_id"`
+	TransactionID     string     `json:"transaction_id"`
+	UserID            uint       `json:"user_id"`
+	ImageURL          string     `json:"image_url"`
+	SenderBankName    string     `json:"sender_bank_name"`
+	SenderAccountName string     `json:"sender_account_name"`
+	Status            string     `json:"status"`
+	RejectionReason   string     `json:"rejection_reason,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+type PaymentProofListResponse struct {
+	Proofs     []PaymentProofResponse `json:"proofs"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	Limit      int                    `json:"limit"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+func (p *PaymentProof) ToResponse() *PaymentProofResponse {
+	return &PaymentProofResponse{
+		ID:                p.ID,
+		PaymentID:         p.PaymentID,
+		TransactionID:     p.TransactionID,
+		UserID:            p.UserID,
+		ImageURL:          p.ImageURL,
+		SenderBankName:    p.SenderBankName,
+		SenderAccountName: p.SenderAccountName,
+		Status:            p.Status,
+		RejectionReason:   p.RejectionReason,
+		ReviewedAt:        p.ReviewedAt,
+		UpdatedAt:         p.UpdatedAt,
+		CreatedAt:         p.CreatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"mime/multipart"
 	"time"
 
@@ -14,7 +15,12 @@ type Product struct {
 	Description string         `json:"description" validate:"max=255" gorm:"type:varchar(255)"`
 	Images      string         `json:"images" validate:"omitempty,url" gorm:"type:text"`
 	Rating      float64        `json:"rating" validate:"gte=0,lte=5" gorm:"type:decimal(2,1);default:0;index"`
-	Price       float64        `json:"price" validate:"required,gt=0" gorm:"type:decimal(10,2);not null;index"`
+	Price       Money          `json:"price" validate:"required,gt=0" gorm:"type:decimal(10,2);not null;index"`
+	TaxClassID  *int64         `json:"tax_class_id,omitempty" gorm:"index"`
+	WeightGrams int64          `json:"weight_grams" validate:"gte=0" gorm:"default:0"`
+	LengthCm    float64        `json:"length_cm" validate:"gte=0" gorm:"type:decimal(8,2);default:0"`
+	WidthCm     float64        `json:"width_cm" validate:"gte=0" gorm:"type:decimal(8,2);default:0"`
+	HeightCm    float64        `json:"height_cm" validate:"gte=0" gorm:"type:decimal(8,2);default:0"`
 	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
@@ -34,11 +40,17 @@ type ColorVarian struct {
 	SizeVarians []SizeVarian `json:"size_varians" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
+// SizeVarian is the unit that is stocked and sold. SKU and Barcode are
+// unique among live variants; deleting a variant releases them. Price, when
+// set, overrides the product price for this size.
 type SizeVarian struct {
 	ID            int64          `json:"id" gorm:"primaryKey;autoIncrement"`
 	ColorVarianID int64          `json:"color_varian_id" validate:"required" gorm:"not null;index"`
 	Size          string         `json:"size" validate:"required" gorm:"type:varchar(10);not null;index"`
 	Stock         int64          `json:"stock" validate:"gte=0" gorm:"default:0"`
+	SKU           *string        `json:"sku,omitempty" gorm:"column:sku;type:varchar(64);uniqueIndex"`
+	Barcode       *string        `json:"barcode,omitempty" gorm:"type:varchar(14);uniqueIndex"`
+	Price         *Money         `json:"price,omitempty" validate:"omitempty,gt=0" gorm:"type:decimal(10,2)"`
 	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
@@ -47,11 +59,22 @@ type CreateProductParam struct {
 	CategoryID  int64                      `json:"category_id" form:"category_id" binding:"required"`
 	Name        string                     `json:"name" form:"name" binding:"required,min=3,max=100"`
 	Description string                     `json:"description" form:"description"`
-	Price       float64                    `json:"price" form:"price" binding:"required,gt=0"`
+	Price       Money                      `json:"price" form:"price" binding:"required,gt=0"`
+	TaxClassID  *int64                     `json:"tax_class_id,omitempty" form:"tax_class_id"`
+	Dimensions  ProductDimensions          `json:"dimensions"`
 	Image       *multipart.FileHeader      `json:"image" form:"image"`
 	ColorVarian []CreateColorVarianRequest `json:"color_varian" form:"color_varian"`
 }
 
+// ProductDimensions is the packed weight and size of one unit, used to
+// price shipping.
+type ProductDimensions struct {
+	WeightGrams int64   `json:"weight_grams" form:"weight_grams" binding:"gte=0"`
+	LengthCm    float64 `json:"length_cm" form:"length_cm" binding:"gte=0"`
+	WidthCm     float64 `json:"width_cm" form:"width_cm" binding:"gte=0"`
+	HeightCm    float64 `json:"height_cm" form:"height_cm" binding:"gte=0"`
+}
+
 type CreateColorVarianRequest struct {
 	Name  string                    `json:"name" form:"name" binding:"required,min=2,max=50"`
 	Color string                    `json:"color" form:"color" binding:"required"`
@@ -60,8 +83,11 @@ type CreateColorVarianRequest struct {
 }
 
 type CreateSizeVarianRequest struct {
-	Size  string `json:"size" form:"size" binding:"required"`
-	Stock int64  `json:"stock" form:"stock" binding:"gte=0"`
+	Size    string  `json:"size" form:"size" binding:"required"`
+	Stock   int64   `json:"stock" form:"stock" binding:"gte=0"`
+	SKU     *string `json:"sku,omitempty" form:"sku"`
+	Barcode *string `json:"barcode,omitempty" form:"barcode"`
+	Price   *Money  `json:"price,omitempty" form:"price"` // kosong berarti pakai harga produk
 }
 
 type UpdateProductParam struct {
@@ -69,8 +95,13 @@ type UpdateProductParam struct {
 	CategoryID  *int64                     `json:"category_id,omitempty" form:"category_id"`
 	Name        *string                    `json:"name,omitempty" form:"name"`
 	Description *string                    `json:"description,omitempty" form:"description"`
-	Price       *float64                   `json:"price,omitempty" form:"price"`
+	Price       *Money                     `json:"price,omitempty" form:"price"`
 	Rating      *float64                   `json:"rating,omitempty" form:"rating"`
+	TaxClassID  *int64                     `json:"tax_class_id,omitempty" form:"tax_class_id"`
+	WeightGrams *int64                     `json:"weight_grams,omitempty" form:"weight_grams"`
+	LengthCm    *float64                   `json:"length_cm,omitempty" form:"length_cm"`
+	WidthCm     *float64                   `json:"width_cm,omitempty" form:"width_cm"`
+	HeightCm    *float64                   `json:"height_cm,omitempty" form:"height_cm"`
 	Image       *multipart.FileHeader      `json:"image,omitempty" form:"image"` // jika ingin ubah gambar utama produk
 	ColorVarian []UpdateColorVarianRequest `json:"color_varian,omitempty" form:"color_varian"`
 }
@@ -83,35 +114,52 @@ type UpdateColorVarianRequest struct {
 	Sizes []UpdateSizeVarianRequest `json:"sizes,omitempty" form:"sizes"`
 }
 
+// UpdateSizeVarianRequest leaves nil fields unchanged. An empty SKU or
+// barcode removes it and a zero price goes back to the product price.
 type UpdateSizeVarianRequest struct {
-	ID    *int64  `json:"id,omitempty" form:"id"` // null berarti size baru
-	Size  *string `json:"size,omitempty" form:"size"`
-	Stock *int64  `json:"stock,omitempty" form:"stock"`
+	ID      *int64  `json:"id,omitempty" form:"id"` // null berarti size baru
+	Size    *string `json:"size,omitempty" form:"size"`
+	Stock   *int64  `json:"stock,omitempty" form:"stock"`
+	SKU     *string `json:"sku,omitempty" form:"sku"`
+	Barcode *string `json:"barcode,omitempty" form:"barcode"`
+	Price   *Money  `json:"price,omitempty" form:"price"`
 }
 
 type ProductResponse struct {
-	ID          int64            `json:"id"`
-	Category    CategoryResponse `json:"category"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Images      string           `json:"images"`
-	Rating      float64          `json:"rating"`
-	Price       float64          `json:"price"`
-	UpdatedAt   time.Time        `json:"updated_at"`
-	CreatedAt   time.Time        `json:"created_at"`
+	ID           int64            `json:"id"`
+	Category     CategoryResponse `json:"category"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Images       string           `json:"images"`
+	Rating       float64          `json:"rating"`
+	Price        Money            `json:"price"`
+	Currency     string           `json:"currency"`
+	StorePrice   Money            `json:"store_price,omitempty"`
+	ExchangeRate float64          `json:"exchange_rate,omitempty"`
+	IsWishlisted bool             `json:"is_wishlisted"`
+	WeightGrams  int64            `json:"weight_grams"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	CreatedAt    time.Time        `json:"created_at"`
 }
 
 type ProductDetailResponse struct {
-	ID          int64                 `json:"id"`
-	Category    CategoryResponse      `json:"category"`
-	Name        string                `json:"name"`
-	Description string                `json:"description"`
-	Images      string                `json:"images"`
-	Rating      float64               `json:"rating"`
-	Price       float64               `json:"price"`
-	ColorVarian []ColorVarianResponse `json:"color_varian"`
-	UpdatedAt   time.Time             `json:"updated_at"`
-	CreatedAt   time.Time             `json:"created_at"`
+	ID           int64                 `json:"id"`
+	Category     CategoryResponse      `json:"category"`
+	Breadcrumbs  []CategoryBreadcrumb  `json:"breadcrumbs"`
+	Name         string                `json:"name"`
+	Description  string                `json:"description"`
+	Images       string                `json:"images"`
+	Rating       float64               `json:"rating"`
+	Price        Money                 `json:"price"`
+	Currency     string                `json:"currency"`
+	StorePrice   Money                 `json:"store_price,omitempty"`
+	ExchangeRate float64               `json:"exchange_rate,omitempty"`
+	IsWishlisted bool                  `json:"is_wishlisted"`
+	TaxClassID   *int64                `json:"tax_class_id,omitempty"`
+	Dimensions   ProductDimensions     `json:"dimensions"`
+	ColorVarian  []ColorVarianResponse `json:"color_varian"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	CreatedAt    time.Time             `json:"created_at"`
 }
 
 type ColorVarianResponse struct {
@@ -125,20 +173,45 @@ type ColorVarianResponse struct {
 	CreatedAt  time.Time            `json:"created_at"`
 }
 
+// SizeVarianResponse carries the price checkout charges for the size;
+// PriceOverride tells whether it differs from the product price.
 type SizeVarianResponse struct {
 	ID            int64     `json:"id"`
 	ColorVarianID int64     `json:"color_varian_id"`
 	Size          string    `json:"size"`
 	Stock         int64     `json:"stock"`
+	SKU           *string   `json:"sku,omitempty"`
+	Barcode       *string   `json:"barcode,omitempty"`
+	Price         Money     `json:"price"`
+	PriceOverride bool      `json:"price_override"`
 	UpdatedAt     time.Time `json:"updated_at"`
 	CreatedAt     time.Time `json:"created_at"`
 }
+
+// SizeVarianLookupResponse is a size variant found by SKU or barcode, with
+// the product and color it belongs to.
+type SizeVarianLookupResponse struct {
+	ProductID     int64              `json:"product_id"`
+	ProductName   string             `json:"product_name"`
+	ColorVarianID int64              `json:"color_varian_id"`
+	ColorName     string             `json:"color_name"`
+	Color         string             `json:"color"`
+	Images        string             `json:"images"`
+	Currency      string             `json:"currency"`
+	SizeVarian    SizeVarianResponse `json:"size_varian"`
+}
+
+// ProductListRequest filters products by CategoryID or CategorySlug; the
+// service expands either into CategoryIDs, the category and everything
+// below it.
 type ProductListRequest struct {
-	Limit      int
-	Page       int
-	SortBy     string
-	Search     string
-	CategoryID int64
+	Limit        int
+	Page         int
+	SortBy       string
+	Search       string
+	CategoryID   int64
+	CategorySlug string
+	CategoryIDs  []int64
 }
 
 type ColorVarianListRequest struct {
@@ -175,7 +248,10 @@ func (p *Product) ToProductResponse(category Category) ProductResponse {
 		ID: p.ID,
 		Category: CategoryResponse{
 			ID:        category.ID,
+			ParentID:  category.ParentID,
 			Name:      category.Name,
+			Slug:      category.Slug,
+			SortOrder: category.Order(),
 			Icon:      category.Icon,
 			CreatedAt: category.CreatedAt,
 			UpdatedAt: category.UpdatedAt,
@@ -185,6 +261,8 @@ func (p *Product) ToProductResponse(category Category) ProductResponse {
 		Images:      p.Images,
 		Rating:      p.Rating,
 		Price:       p.Price,
+		Currency:    StoreCurrency,
+		WeightGrams: p.WeightGrams,
 		UpdatedAt:   p.UpdatedAt,
 		CreatedAt:   p.CreatedAt,
 	}
@@ -195,14 +273,17 @@ func (p *Product) ToProductDetailResponse(category Category) ProductDetailRespon
 	// Map color variants
 	colorVariants := make([]ColorVarianResponse, len(p.ColorVarians))
 	for i, cv := range p.ColorVarians {
-		colorVariants[i] = cv.ToColorVarianResponse()
+		colorVariants[i] = cv.ToColorVarianResponse(p.Price)
 	}
 
 	return ProductDetailResponse{
 		ID: p.ID,
 		Category: CategoryResponse{
 			ID:        category.ID,
+			ParentID:  category.ParentID,
 			Name:      category.Name,
+			Slug:      category.Slug,
+			SortOrder: category.Order(),
 			Icon:      category.Icon,
 			CreatedAt: category.CreatedAt,
 			UpdatedAt: category.UpdatedAt,
@@ -212,17 +293,22 @@ func (p *Product) ToProductDetailResponse(category Category) ProductDetailRespon
 		Images:      p.Images,
 		Rating:      p.Rating,
 		Price:       p.Price,
+		Currency:    StoreCurrency,
+		TaxClassID:  p.TaxClassID,
+		Dimensions:  p.Dimensions(),
 		ColorVarian: colorVariants,
 		UpdatedAt:   p.UpdatedAt,
 		CreatedAt:   p.CreatedAt,
 	}
 }
 
-func (cv *ColorVarian) ToColorVarianResponse() ColorVarianResponse {
+// ToColorVarianResponse converts ColorVarian model to ColorVarianResponse,
+// pricing sizes without an override at productPrice.
+func (cv *ColorVarian) ToColorVarianResponse(productPrice Money) ColorVarianResponse {
 
 	sizeVariants := make([]SizeVarianResponse, len(cv.SizeVarians))
 	for i, sv := range cv.SizeVarians {
-		sizeVariants[i] = sv.ToSizeVarianResponse()
+		sizeVariants[i] = sv.ToSizeVarianResponse(productPrice)
 	}
 
 	return ColorVarianResponse{
@@ -238,17 +324,42 @@ func (cv *ColorVarian) ToColorVarianResponse() ColorVarianResponse {
 }
 
 // ToSizeVarianResponse converts SizeVarian model to SizeVarianResponse
-func (sv *SizeVarian) ToSizeVarianResponse() SizeVarianResponse {
+func (sv *SizeVarian) ToSizeVarianResponse(productPrice Money) SizeVarianResponse {
 	return SizeVarianResponse{
 		ID:            sv.ID,
 		ColorVarianID: sv.ColorVarianID,
 		Size:          sv.Size,
 		Stock:         sv.Stock,
+		SKU:           sv.SKU,
+		Barcode:       sv.Barcode,
+		Price:         sv.UnitPrice(productPrice),
+		PriceOverride: sv.Price != nil,
 		UpdatedAt:     sv.UpdatedAt,
 		CreatedAt:     sv.CreatedAt,
 	}
 }
 
+// UnitPrice is the price checkout charges for one unit of the size: its
+// own price when set, otherwise productPrice.
+func (sv *SizeVarian) UnitPrice(productPrice Money) Money {
+	if sv.Price != nil {
+		return *sv.Price
+	}
+	return productPrice
+}
+
+// FindSizeVarian returns the size of the product, as loaded, with the id.
+func (p *Product) FindSizeVarian(id int64) (*SizeVarian, bool) {
+	for i := range p.ColorVarians {
+		for j := range p.ColorVarians[i].SizeVarians {
+			if p.ColorVarians[i].SizeVarians[j].ID == id {
+				return &p.ColorVarians[i].SizeVarians[j], true
+			}
+		}
+	}
+	return nil, false
+}
+
 // ToProductResponseList converts slice of Product to slice of ProductResponse
 func ToProductResponseList(products []Product, categoryMap map[int64]Category) []ProductResponse {
 	result := make([]ProductResponse, len(products))
@@ -274,3 +385,23 @@ func BuildCategoryMap(categories []Category) map[int64]Category {
 	}
 	return categoryMap
 }
+
+// Dimensions returns the packed weight and size of one unit of the product.
+func (p *Product) Dimensions() ProductDimensions {
+	return ProductDimensions{
+		WeightGrams: p.WeightGrams,
+		LengthCm:    p.LengthCm,
+		WidthCm:     p.WidthCm,
+		HeightCm:    p.HeightCm,
+	}
+}
+
+// ChargeableWeightGrams is the weight carriers bill for one unit: the actual
+// weight or the volumetric weight (L × W × H / 6000 kg), whichever is higher.
+func (d ProductDimensions) ChargeableWeightGrams() int64 {
+	volumetric := int64(math.Ceil(d.LengthCm * d.WidthCm * d.HeightCm / 6))
+	if volumetric > d.WeightGrams {
+		return volumetric
+	}
+	return d.WeightGrams
+}
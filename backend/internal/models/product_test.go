@@ -0,0 +1,42 @@
+package models_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"testing"
+)
+
+func TestProductDetailSizePrices(t *testing.T) {
+	xlPrice := models.Rupiah(120000)
+	product := models.Product{
+		ID:    12,
+		Price: models.Rupiah(100000),
+		ColorVarians: []models.ColorVarian{
+			{ID: 3, SizeVarians: []models.SizeVarian{
+				{ID: 8, Size: "S"},
+				{ID: 9, Size: "XL", Price: &xlPrice},
+			}},
+		},
+	}
+
+	detail := product.ToProductDetailResponse(models.Category{})
+	sizes := detail.ColorVarian[0].SizeVarian
+	if sizes[0].Price != models.Rupiah(100000) || sizes[0].PriceOverride {
+		t.Errorf("expected S at the product price, got %v override=%v", sizes[0].Price, sizes[0].PriceOverride)
+	}
+	if sizes[1].Price != xlPrice || !sizes[1].PriceOverride {
+		t.Errorf("expected XL at its own price, got %v override=%v", sizes[1].Price, sizes[1].PriceOverride)
+	}
+
+	detail.InCurrency(&models.Currency{Code: "USD", Rate: 16000})
+	if sizes[1].Price != 750 {
+		t.Errorf("expected XL converted to 7.50 USD, got %v", sizes[1].Price)
+	}
+
+	size, ok := product.FindSizeVarian(9)
+	if !ok || size.UnitPrice(product.Price) != xlPrice {
+		t.Errorf("FindSizeVarian(9) = %v, %v", size, ok)
+	}
+	if _, ok := product.FindSizeVarian(10); ok {
+		t.Error("expected no size 10")
+	}
+}
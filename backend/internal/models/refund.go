@@ -0,0 +1,117 @@
+package models
+
+import "time"
+
+// Refund records money given back on a payment. A payment may have several
+// partial refunds; together they never exceed the amount paid.
+type Refund struct {
+	ID            int64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	PaymentID     int64        `json:"payment_id" gorm:"not null;index"`
+	TransactionID string       `json:"transaction_id" gorm:"type:varchar(50);not null;index"`
+	Amount        Money        `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Reason        string       `json:"reason" gorm:"type:varchar(255);not null"`
+	Restock       bool         `json:"restock" gorm:"default:false"`
+	CreatedBy     uint         `json:"created_by" gorm:"index"`
+	CreatedAt     time.Time    `json:"created_at" gorm:"autoCreateTime;index"`
+	Items         []RefundItem `json:"items,omitempty" gorm:"foreignKey:RefundID"`
+}
+
+// RefundItem ties part of a refund to an order line.
+type RefundItem struct {
+	ID        int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	RefundID  int64  `json:"refund_id" gorm:"not null;index"`
+	OrderID   string `json:"order_id" gorm:"type:char(36);not null;index"`
+	Quantity  int64  `json:"quantity" gorm:"not null"`
+	Amount    Money  `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Restocked bool   `json:"restocked" gorm:"default:false"`
+}
+
+// Request untuk membuat refund. Amount defaults to the value of the items;
+// without items it must be given.
+type CreateRefund struct {
+	PaymentID int64              `json:"payment_id" validate:"required"`
+	Amount    Money              `json:"amount" validate:"gte=0"`
+	Reason    string             `json:"reason" validate:"required,max=255"`
+	Restock   bool               `json:"restock"`
+	Items     []CreateRefundItem `json:"items" validate:"dive"`
+}
+
+type CreateRefundItem struct {
+	OrderID  string `json:"order_id" validate:"required"`
+	Quantity int64  `json:"quantity" validate:"required,gt=0"`
+}
+
+type RefundListRequest struct {
+	PaymentID int64
+	Limit     int
+	Page      int
+}
+
+type RefundResponse struct {
+	ID            int64                `json:"id"`
+	PaymentID     int64                `json:"payment_id"`
+	TransactionID string               `json:"transaction_id"`
+	Amount        Money                `json:"amount"`
+	Reason        string               `json:"reason"`
+	Restock       bool                 `json:"restock"`
+	CreatedBy     uint                 `json:"created_by"`
+	Items         []RefundItemResponse `json:"items"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+type RefundItemResponse struct {
+	OrderID   string `json:"order_id"`
+	Quantity  int64  `json:"quantity"`
+	Amount    Money  `json:"amount"`
+	Restocked bool   `json:"restocked"`
+}
+
+type RefundListResponse struct {
+	Refunds    []RefundResponse `json:"refunds"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// PaymentRefundSummary is returned after a refund so the caller sees how much
+// of the payment is left.
+type PaymentRefundSummary struct {
+	Refund          RefundResponse `json:"refund"`
+	PaymentStatus   string         `json:"payment_status"`
+	TotalPayment    Money          `json:"total_payment"`
+	RefundedAmount  Money          `json:"refunded_amount"`
+	RemainingAmount Money          `json:"remaining_amount"`
+}
+
+func (Refund) TableName() string {
+	return "refunds"
+}
+
+func (RefundItem) TableName() string {
+	return "refund_items"
+}
+
+func (r *Refund) ToResponse() *RefundResponse {
+	items := make([]RefundItemResponse, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = RefundItemResponse{
+			OrderID:   item.OrderID,
+			Quantity:  item.Quantity,
+			Amount:    item.Amount,
+			Restocked: item.Restocked,
+		}
+	}
+
+	return &RefundResponse{
+		ID:            r.ID,
+		PaymentID:     r.PaymentID,
+		TransactionID: r.TransactionID,
+		Amount:        r.Amount,
+		Reason:        r.Reason,
+		Restock:       r.Restock,
+		CreatedBy:     r.CreatedBy,
+		Items:         items,
+		CreatedAt:     r.CreatedAt,
+	}
+}
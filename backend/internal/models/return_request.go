@@ -0,0 +1,150 @@
+package models
+
+import "time"
+
+// ReturnRequest is a customer's request to send back delivered order lines
+// of one transaction. It moves requested → approved → received → inspected
+// → refunded or exchanged; admins may reject it while it is requested, and
+// the customer may cancel it until the goods are received.
+type ReturnRequest struct {
+	ID            int64         `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        uint          `json:"user_id" gorm:"not null;index"`
+	TransactionID string        `json:"transaction_id" gorm:"type:varchar(50);not null;index"`
+	Status        string        `json:"status" gorm:"type:varchar(20);default:'requested';index"`
+	Reason        string        `json:"reason" gorm:"type:text;not null"`
+	Resolution    string        `json:"resolution" gorm:"type:varchar(20);not null"`
+	AdminNote     string        `json:"admin_note" gorm:"type:text"`
+	RefundID      *int64        `json:"refund_id,omitempty" gorm:"index"`
+	UpdatedAt     time.Time     `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt     time.Time     `json:"created_at" gorm:"autoCreateTime;index"`
+	Items         []ReturnItem  `json:"items,omitempty" gorm:"foreignKey:ReturnID"`
+	Photos        []ReturnPhoto `json:"photos,omitempty" gorm:"foreignKey:ReturnID"`
+}
+
+// ReturnItem is an order line, or part of one, being returned. Restockable
+// is decided at inspection; ReplacementSizeVarianID is set when the item is
+// exchanged.
+type ReturnItem struct {
+	ID                      int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	ReturnID                int64  `json:"return_id" gorm:"not null;index"`
+	OrderID                 string `json:"order_id" gorm:"type:char(36);not null;index"`
+	Quantity                int64  `json:"quantity" gorm:"not null"`
+	Restockable             bool   `json:"restockable" gorm:"default:false"`
+	Restocked               bool   `json:"restocked" gorm:"default:false"`
+	ReplacementSizeVarianID *int64 `json:"replacement_size_varian_id,omitempty"`
+}
+
+// ReturnPhoto is a picture the customer attached to a return request.
+type ReturnPhoto struct {
+	ID       int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	ReturnID int64  `json:"return_id" gorm:"not null;index"`
+	URL      string `json:"url" gorm:"type:text;not null"`
+}
+
+// Request untuk membuka return. Photos are sent as multipart files.
+type CreateReturnRequest struct {
+	Reason     string             `json:"reason" validate:"required"`
+	Resolution string             `json:"resolution" validate:"required,oneof=refund exchange"`
+	Items      []CreateReturnItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type CreateReturnItem struct {
+	OrderID  string `json:"order_id" validate:"required"`
+	Quantity int64  `json:"quantity" validate:"required,gt=0"`
+}
+
+// UpdateReturnStatus moves a return to approved, rejected or received.
+type UpdateReturnStatus struct {
+	ID     int64  `json:"-"`
+	Status string `json:"status" validate:"required,oneof=approved rejected received"`
+	Note   string `json:"note"`
+}
+
+// InspectReturn records which returned items can be sold again. Items not
+// listed are treated as not restockable.
+type InspectReturn struct {
+	ID    int64               `json:"-"`
+	Note  string              `json:"note"`
+	Items []InspectReturnItem `json:"items" validate:"dive"`
+}
+
+type InspectReturnItem struct {
+	ItemID      int64 `json:"item_id" validate:"required"`
+	Restockable bool  `json:"restockable"`
+}
+
+// RefundReturn refunds an inspected return. Amount defaults to the value of
+// the returned items.
+type RefundReturn struct {
+	ID     int64 `json:"-"`
+	Amount Money `json:"amount" validate:"gte=0"`
+}
+
+// ExchangeReturn swaps each returned item for another size or colour of the
+// same product.
+type ExchangeReturn struct {
+	ID    int64                `json:"-"`
+	Items []ExchangeReturnItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type ExchangeReturnItem struct {
+	ItemID       int64 `json:"item_id" validate:"required"`
+	SizeVarianID int64 `json:"size_varian_id" validate:"required"`
+}
+
+type ReturnListRequest struct {
+	UserID uint
+	Status string
+	Limit  int
+	Page   int
+}
+
+type ReturnResponse struct {
+	ID            int64        `json:"id"`
+	UserID        uint         `json:"user_id"`
+	TransactionID string       `json:"transaction_id"`
+	Status        string       `json:"status"`
+	Reason        string       `json:"reason"`
+	Resolution    string       `json:"resolution"`
+	AdminNote     string       `json:"admin_note"`
+	RefundID      *int64       `json:"refund_id,omitempty"`
+	Items         []ReturnItem `json:"items"`
+	Photos        []string     `json:"photos"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type ReturnListResponse struct {
+	Returns    []ReturnResponse `json:"returns"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	TotalPages int              `json:"total_pages"`
+}
+
+func (r *ReturnRequest) ToResponse() *ReturnResponse {
+	items := r.Items
+	if items == nil {
+		items = []ReturnItem{}
+	}
+
+	photos := make([]string, len(r.Photos))
+	for i, photo := range r.Photos {
+		photos[i] = photo.URL
+	}
+
+	return &ReturnResponse{
+		ID:            r.ID,
+		UserID:        r.UserID,
+		TransactionID: r.TransactionID,
+		Status:        r.Status,
+		Reason:        r.Reason,
+		Resolution:    r.Resolution,
+		AdminNote:     r.AdminNote,
+		RefundID:      r.RefundID,
+		Items:         items,
+		Photos:        photos,
+		UpdatedAt:     r.UpdatedAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import "time"
+
+// Shipment is the parcel sent for a transaction. Its status follows the
+// latest tracking event: shipped, in_transit, out_for_delivery, delivered,
+// failed or returned.
+type Shipment struct {
+	ID             int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	TransactionID  string          `json:"transaction_id" gorm:"type:varchar(50);not null;uniqueIndex"`
+	Carrier        string          `json:"carrier" gorm:"type:varchar(50);not null;index:idx_shipment_tracking"`
+	Service        string          `json:"service" gorm:"type:varchar(50)"`
+	TrackingNumber string          `json:"tracking_number" gorm:"type:varchar(100);not null;index:idx_shipment_tracking"`
+	Status         string          `json:"status" gorm:"type:varchar(30);not null;default:'shipped';index"`
+	ShippedAt      time.Time       `json:"shipped_at" gorm:"not null"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	LastEventAt    time.Time       `json:"last_event_at"`
+	CreatedBy      uint            `json:"created_by" gorm:"index"`
+	UpdatedAt      time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	Events         []ShipmentEvent `json:"events,omitempty" gorm:"foreignKey:ShipmentID"`
+}
+
+// ShipmentEvent is one step of a shipment's timeline. The unique index lets
+// carriers retry webhooks without duplicating events.
+type ShipmentEvent struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	ShipmentID  int64     `json:"shipment_id" gorm:"not null;uniqueIndex:idx_shipment_event"`
+	Status      string    `json:"status" gorm:"type:varchar(30);not null;uniqueIndex:idx_shipment_event"`
+	Description string    `json:"description" gorm:"type:text"`
+	Location    string    `json:"location" gorm:"type:varchar(255)"`
+	Source      string    `json:"source" gorm:"type:varchar(20);not null"`
+	OccurredAt  time.Time `json:"occurred_at" gorm:"not null;uniqueIndex:idx_shipment_event"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Request untuk menandai transaksi sebagai dikirim
+type CreateShipment struct {
+	TransactionID  string `json:"transaction_id" validate:"required"`
+	Carrier        string `json:"carrier" validate:"required,max=50"`
+	Service        string `json:"service" validate:"max=50"`
+	TrackingNumber string `json:"tracking_number" validate:"required,max=100"`
+}
+
+// CreateShipmentEvent adds a tracking event by hand or from a carrier
+// webhook. OccurredAt defaults to now.
+type CreateShipmentEvent struct {
+	ShipmentID  int64     `json:"-"`
+	Status      string    `json:"status" validate:"required,oneof=in_transit out_for_delivery delivered failed returned"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// CarrierWebhookPayload is the tracking update a carrier posts, already
+// mapped to our status codes by the carrier integration.
+type CarrierWebhookPayload struct {
+	TrackingNumber string    `json:"tracking_number" validate:"required"`
+	Status         string    `json:"status" validate:"required"`
+	Description    string    `json:"description"`
+	Location       string    `json:"location"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+type ShipmentResponse struct {
+	ID             int64           `json:"id"`
+	TransactionID  string          `json:"transaction_id"`
+	Carrier        string          `json:"carrier"`
+	Service        string          `json:"service"`
+	TrackingNumber string          `json:"tracking_number"`
+	Status         string          `json:"status"`
+	ShippedAt      time.Time       `json:"shipped_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	Events         []ShipmentEvent `json:"events"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func (s *Shipment) ToResponse() *ShipmentResponse {
+	events := s.Events
+	if events == nil {
+		events = []ShipmentEvent{}
+	}
+
+	return &ShipmentResponse{
+		ID:             s.ID,
+		TransactionID:  s.TransactionID,
+		Carrier:        s.Carrier,
+		Service:        s.Service,
+		TrackingNumber: s.TrackingNumber,
+		Status:         s.Status,
+		ShippedAt:      s.ShippedAt,
+		DeliveredAt:    s.DeliveredAt,
+		Events:         events,
+		UpdatedAt:      s.UpdatedAt,
+		CreatedAt:      s.CreatedAt,
+	}
+}
@@ -10,7 +10,7 @@ import (
 type Shipping struct {
 	ID        int64          `json:"id" gorm:"primaryKey;autoIncrement"`
 	Name      string         `json:"name" validate:"required,min=3,max=100" gorm:"type:varchar(100);not null"`
-	Price     float64        `json:"price" validate:"required,gt=0" gorm:"type:decimal(12,2);not null"`
+	Price     Money          `json:"price" validate:"required,gt=0" gorm:"type:decimal(12,2);not null"`
 	State     string         `json:"state" validate:"required,oneof=active inactive" gorm:"type:varchar(20);default:'active';index"`
 	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
@@ -19,24 +19,24 @@ type Shipping struct {
 
 // Create Shipping (Request Payload)
 type CreateShipping struct {
-	Name  string  `json:"name" form:"name" validate:"required,min=3,max=100"`
-	Price float64 `json:"price" form:"price" validate:"required,gt=0"`
-	State string  `json:"state" form:"state" validate:"omitempty,oneof=active inactive"`
+	Name  string `json:"name" form:"name" validate:"required,min=3,max=100"`
+	Price Money  `json:"price" form:"price" validate:"required,gt=0"`
+	State string `json:"state" form:"state" validate:"omitempty,oneof=active inactive"`
 }
 
 // Update Shipping (Request Payload)
 type UpdateShipping struct {
-	ID    int64   `json:"id" form:"id" validate:"required,gt=0"`
-	Name  string  `json:"name" form:"name" validate:"omitempty,min=3,max=100"`
-	Price float64 `json:"price" form:"price" validate:"omitempty,gt=0"`
-	State string  `json:"state" form:"state" validate:"omitempty,oneof=active inactive"`
+	ID    int64  `json:"id" form:"id" validate:"required,gt=0"`
+	Name  string `json:"name" form:"name" validate:"omitempty,min=3,max=100"`
+	Price Money  `json:"price" form:"price" validate:"omitempty,gt=0"`
+	State string `json:"state" form:"state" validate:"omitempty,oneof=active inactive"`
 }
 
 // Response (API Output)
 type ShippingResponse struct {
 	ID        int64     `json:"id"`
 	Name      string    `json:"name"`
-	Price     float64   `json:"price"`
+	Price     Money     `json:"price"`
 	State     string    `json:"state"`
 	UpdatedAt time.Time `json:"updated_at"`
 	CreatedAt time.Time `json:"created_at"`
@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+// ShippingZone groups destinations that share shipping rates. A zone with a
+// city matches only that city, one without a city matches the whole
+// province, and one without a province is the default for everything else.
+// Cash on delivery is only offered in zones with CODAvailable.
+type ShippingZone struct {
+	ID                    int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name                  string         `json:"name" gorm:"type:varchar(100);not null"`
+	Province              string         `json:"province" gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_shipping_zone_area"`
+	City                  string         `json:"city" gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_shipping_zone_area"`
+	FreeShippingThreshold Money          `json:"free_shipping_threshold" gorm:"type:decimal(12,2);not null;default:0"`
+	CODAvailable          bool           `json:"cod_available" gorm:"not null;default:false"`
+	Rates                 []ShippingRate `json:"rates,omitempty" gorm:"foreignKey:ZoneID"`
+	UpdatedAt             time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt             time.Time      `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// ShippingRate is the price of a shipping method in a zone for parcels up to
+// MaxWeightGrams. A MaxWeightGrams of 0 has no upper limit.
+type ShippingRate struct {
+	ID             int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	ZoneID         int64     `json:"zone_id" gorm:"not null;uniqueIndex:idx_shipping_rate_tier"`
+	ShippingID     int64     `json:"shipping_id" gorm:"not null;uniqueIndex:idx_shipping_rate_tier"`
+	MaxWeightGrams int64     `json:"max_weight_grams" gorm:"not null;default:0;uniqueIndex:idx_shipping_rate_tier"`
+	Price          Money     `json:"price" gorm:"type:decimal(12,2);not null"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Request untuk membuat atau mengubah zona pengiriman
+type CreateShippingZone struct {
+	ID                    int64  `json:"-"`
+	Name                  string `json:"name" validate:"required,min=3,max=100"`
+	Province              string `json:"province" validate:"max=100"`
+	City                  string `json:"city" validate:"max=100"`
+	FreeShippingThreshold Money  `json:"free_shipping_threshold" validate:"gte=0"`
+	CODAvailable          bool   `json:"cod_available"`
+}
+
+type CreateShippingRate struct {
+	ZoneID         int64 `json:"-"`
+	ShippingID     int64 `json:"shipping_id" validate:"required,gt=0"`
+	MaxWeightGrams int64 `json:"max_weight_grams" validate:"gte=0"`
+	Price          Money `json:"price" validate:"gte=0"`
+}
+
+// ShippingQuoteRequest asks for the shipping options of a cart delivered to
+// one of the caller's addresses.
+type ShippingQuoteRequest struct {
+	UserID    int64               `json:"-"`
+	AddressID int64               `json:"address_id" validate:"required,gt=0"`
+	Items     []ShippingQuoteItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// ShippingQuoteItem is a product in the cart. With a size the subtotal
+// uses the size's price, like checkout.
+type ShippingQuoteItem struct {
+	ProductID    int64 `json:"product_id" validate:"required,gt=0"`
+	SizeVarianID int64 `json:"size_varian_id,omitempty"`
+	Quantity     int   `json:"quantity" validate:"required,gt=0"`
+}
+
+// ShippingQuoteOption is the price of one shipping method for a parcel.
+// BasePrice is the price before free shipping is applied. CODAvailable tells
+// whether cash on delivery can be chosen for the destination.
+type ShippingQuoteOption struct {
+	ShippingID   int64  `json:"shipping_id"`
+	Name         string `json:"name"`
+	Price        Money  `json:"price"`
+	BasePrice    Money  `json:"base_price"`
+	FreeShipping bool   `json:"free_shipping"`
+	CODAvailable bool   `json:"cod_available"`
+}
+
+type ShippingQuoteResponse struct {
+	Zone        string                `json:"zone"`
+	WeightGrams int64                 `json:"weight_grams"`
+	Subtotal    Money                 `json:"subtotal"`
+	Options     []ShippingQuoteOption `json:"options"`
+}
+
+type ShippingZoneResponse struct {
+	ID                    int64          `json:"id"`
+	Name                  string         `json:"name"`
+	Province              string         `json:"province"`
+	City                  string         `json:"city"`
+	FreeShippingThreshold Money          `json:"free_shipping_threshold"`
+	CODAvailable          bool           `json:"cod_available"`
+	Rates                 []ShippingRate `json:"rates"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	CreatedAt             time.Time      `json:"created_at"`
+}
+
+func (z *ShippingZone) ToResponse() *ShippingZoneResponse {
+	rates := z.Rates
+	if rates == nil {
+		rates = []ShippingRate{}
+	}
+
+	return &ShippingZoneResponse{
+		ID:                    z.ID,
+		Name:                  z.Name,
+		Province:              z.Province,
+		City:                  z.City,
+		FreeShippingThreshold: z.FreeShippingThreshold,
+		CODAvailable:          z.CODAvailable,
+		Rates:                 rates,
+		UpdatedAt:             z.UpdatedAt,
+		CreatedAt:             z.CreatedAt,
+	}
+}
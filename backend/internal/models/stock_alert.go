@@ -0,0 +1,250 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type StockAlertType string
+
+const (
+	StockAlertBackInStock StockAlertType = "back_in_stock"
+	StockAlertPriceDrop   StockAlertType = "price_drop"
+)
+
+func (t StockAlertType) IsValid() bool {
+	return t == StockAlertBackInStock || t == StockAlertPriceDrop
+}
+
+// StockAlert is a customer's subscription to a product, or to one size of
+// it, coming back in stock or getting cheaper. Back-in-stock alerts fire
+// once and are then done; price-drop alerts stay and fire again on every
+// price below the last one the customer was told about.
+type StockAlert struct {
+	ID           int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID       uint           `json:"user_id" gorm:"not null;index"`
+	ProductID    int64          `json:"product_id" gorm:"not null;index"`
+	SizeVarianID *int64         `json:"size_varian_id,omitempty" gorm:"index"`
+	Type         StockAlertType `json:"type" gorm:"type:varchar(20);not null;index"`
+	// LastPrice is the price the customer last saw: the price when they
+	// subscribed, lowered on each price-drop notification.
+	LastPrice  Money      `json:"last_price" gorm:"type:decimal(10,2);not null;default:0"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+
+	Product    *Product    `json:"product,omitempty" gorm:"foreignKey:ProductID;references:ID"`
+	SizeVarian *SizeVarian `json:"size_varian,omitempty" gorm:"foreignKey:SizeVarianID;references:ID"`
+}
+
+// IsActive reports whether the alert can still fire.
+func (a *StockAlert) IsActive() bool {
+	return a.Type == StockAlertPriceDrop || a.NotifiedAt == nil
+}
+
+// StockAlertNotification is a queued message for the subscriber of an
+// alert. EventKey names the event that caused it; together with the alert
+// it is unique, so replaying an update never queues the same event twice.
+type StockAlertNotification struct {
+	ID            int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	StockAlertID  int64          `json:"stock_alert_id" gorm:"not null;uniqueIndex:idx_stock_alert_notifications_event"`
+	EventKey      string         `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_stock_alert_notifications_event"`
+	UserID        uint           `json:"user_id" gorm:"not null;index"`
+	ProductID     int64          `json:"product_id" gorm:"not null;index"`
+	SizeVarianID  *int64         `json:"size_varian_id,omitempty"`
+	Type          StockAlertType `json:"type" gorm:"type:varchar(20);not null"`
+	PreviousPrice Money          `json:"previous_price,omitempty" gorm:"type:decimal(10,2);not null;default:0"`
+	Price         Money          `json:"price" gorm:"type:decimal(10,2);not null;default:0"`
+	Stock         int64          `json:"stock,omitempty" gorm:"not null;default:0"`
+	ReadAt        *time.Time     `json:"read_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
+
+	Product *Product `json:"product,omitempty" gorm:"foreignKey:ProductID;references:ID"`
+}
+
+// BackInStockEventKey names a back-in-stock event. Back-in-stock alerts
+// fire once, so the key only needs to tell the event kinds apart.
+func BackInStockEventKey() string {
+	return string(StockAlertBackInStock)
+}
+
+// PriceDropEventKey names a drop to price. An alert's last price only goes
+// down, so each drop it is told about has a different key.
+func PriceDropEventKey(price Money) string {
+	return fmt.Sprintf("%s:%d", StockAlertPriceDrop, int64(price))
+}
+
+// Request untuk berlangganan notifikasi stok atau harga. A price-drop alert
+// on a size watches that size's unit price, which may override the
+// product price.
+type CreateStockAlert struct {
+	ProductID    int64          `json:"product_id" validate:"required"`
+	SizeVarianID *int64         `json:"size_varian_id,omitempty"`
+	Type         StockAlertType `json:"type" validate:"required"`
+}
+
+type StockAlertNotificationListRequest struct {
+	UserID     uint
+	UnreadOnly bool
+	Limit      int
+	Page       int
+}
+
+type StockAlertResponse struct {
+	ID           int64          `json:"id"`
+	ProductID    int64          `json:"product_id"`
+	ProductName  string         `json:"product_name"`
+	SizeVarianID *int64         `json:"size_varian_id,omitempty"`
+	Size         string         `json:"size,omitempty"`
+	Type         StockAlertType `json:"type"`
+	LastPrice    Money          `json:"last_price"`
+	Active       bool           `json:"active"`
+	NotifiedAt   *time.Time     `json:"notified_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type StockAlertNotificationResponse struct {
+	ID            int64          `json:"id"`
+	StockAlertID  int64          `json:"stock_alert_id"`
+	ProductID     int64          `json:"product_id"`
+	ProductName   string         `json:"product_name"`
+	Images        string         `json:"images"`
+	SizeVarianID  *int64         `json:"size_varian_id,omitempty"`
+	Type          StockAlertType `json:"type"`
+	PreviousPrice Money          `json:"previous_price,omitempty"`
+	Price         Money          `json:"price"`
+	Currency      string         `json:"currency"`
+	Stock         int64          `json:"stock,omitempty"`
+	Read          bool           `json:"read"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type StockAlertNotificationListResponse struct {
+	Items      []StockAlertNotificationResponse `json:"items"`
+	Total      int64                            `json:"total"`
+	Unread     int64                            `json:"unread"`
+	Page       int                              `json:"page"`
+	Limit      int                              `json:"limit"`
+	TotalPages int                              `json:"total_pages"`
+}
+
+func (a *StockAlert) ToResponse() StockAlertResponse {
+	response := StockAlertResponse{
+		ID:           a.ID,
+		ProductID:    a.ProductID,
+		SizeVarianID: a.SizeVarianID,
+		Type:         a.Type,
+		LastPrice:    a.LastPrice,
+		Active:       a.IsActive(),
+		NotifiedAt:   a.NotifiedAt,
+		CreatedAt:    a.CreatedAt,
+	}
+	if a.Product != nil {
+		response.ProductName = a.Product.Name
+	}
+	if a.SizeVarian != nil {
+		response.Size = a.SizeVarian.Size
+	}
+	return response
+}
+
+func (n *StockAlertNotification) ToResponse() StockAlertNotificationResponse {
+	response := StockAlertNotificationResponse{
+		ID:            n.ID,
+		StockAlertID:  n.StockAlertID,
+		ProductID:     n.ProductID,
+		SizeVarianID:  n.SizeVarianID,
+		Type:          n.Type,
+		PreviousPrice: n.PreviousPrice,
+		Price:         n.Price,
+		Currency:      StoreCurrency,
+		Stock:         n.Stock,
+		Read:          n.ReadAt != nil,
+		CreatedAt:     n.CreatedAt,
+	}
+	if n.Product != nil {
+		response.ProductName = n.Product.Name
+		response.Images = n.Product.Images
+	}
+	return response
+}
+
+// TotalStock sums the stock of every size of the product as loaded.
+func (p *Product) TotalStock() int64 {
+	var total int64
+	for _, color := range p.ColorVarians {
+		for _, size := range color.SizeVarians {
+			total += size.Stock
+		}
+	}
+	return total
+}
+
+// SizeStock returns the stock of each size of the product as loaded.
+func (p *Product) SizeStock() map[int64]int64 {
+	stock := make(map[int64]int64)
+	for _, color := range p.ColorVarians {
+		for _, size := range color.SizeVarians {
+			stock[size.ID] = size.Stock
+		}
+	}
+	return stock
+}
+
+// AlertPrice is the price a price-drop alert on the product watches: the
+// unit price of the size for a size alert, otherwise the lowest unit price
+// of any size, or the product price when it has no sizes.
+func (p *Product) AlertPrice(sizeVarianID *int64) Money {
+	if sizeVarianID != nil {
+		if size, ok := p.FindSizeVarian(*sizeVarianID); ok {
+			return size.UnitPrice(p.Price)
+		}
+		return p.Price
+	}
+
+	lowest := p.Price
+	found := false
+	for _, color := range p.ColorVarians {
+		for _, size := range color.SizeVarians {
+			if price := size.UnitPrice(p.Price); !found || price < lowest {
+				lowest = price
+				found = true
+			}
+		}
+	}
+	return lowest
+}
+
+// PriceDropped reports whether the product price, or the unit price of any
+// size, is lower after than before. A new size counts against the product
+// price before.
+func PriceDropped(before, after *Product) bool {
+	if after.Price < before.Price {
+		return true
+	}
+	for _, color := range after.ColorVarians {
+		for _, size := range color.SizeVarians {
+			previous := before.Price
+			if old, ok := before.FindSizeVarian(size.ID); ok {
+				previous = old.UnitPrice(before.Price)
+			}
+			if size.UnitPrice(after.Price) < previous {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RestockedSizes returns the stock of the sizes that were sold out, or did
+// not exist, before and have stock after.
+func RestockedSizes(before, after *Product) map[int64]int64 {
+	previous := before.SizeStock()
+	restocked := make(map[int64]int64)
+	for id, stock := range after.SizeStock() {
+		if stock > 0 && previous[id] <= 0 {
+			restocked[id] = stock
+		}
+	}
+	return restocked
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestRestockedSizes(t *testing.T) {
+	before := &models.Product{ColorVarians: []models.ColorVarian{
+		{ID: 1, SizeVarians: []models.SizeVarian{{ID: 1, Stock: 0}, {ID: 2, Stock: 3}, {ID: 3, Stock: 0}}},
+	}}
+	after := &models.Product{ColorVarians: []models.ColorVarian{
+		{ID: 1, SizeVarians: []models.SizeVarian{{ID: 1, Stock: 4}, {ID: 2, Stock: 5}, {ID: 3, Stock: 0}}},
+		{ID: 2, SizeVarians: []models.SizeVarian{{ID: 4, Stock: 2}}},
+	}}
+
+	restocked := models.RestockedSizes(before, after)
+	if len(restocked) != 2 || restocked[1] != 4 || restocked[4] != 2 {
+		t.Errorf("RestockedSizes() = %v, want sizes 1 and 4", restocked)
+	}
+	if before.TotalStock() != 3 || after.TotalStock() != 11 {
+		t.Errorf("TotalStock() = %d, %d, want 3, 11", before.TotalStock(), after.TotalStock())
+	}
+}
+
+func TestStockAlertIsActive(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		alert models.StockAlert
+		want  bool
+	}{
+		{"new back in stock", models.StockAlert{Type: models.StockAlertBackInStock}, true},
+		{"notified back in stock", models.StockAlert{Type: models.StockAlertBackInStock, NotifiedAt: &now}, false},
+		{"notified price drop", models.StockAlert{Type: models.StockAlertPriceDrop, NotifiedAt: &now}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.alert.IsActive(); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
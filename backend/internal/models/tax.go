@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaxClass is a named tax rate, e.g. PPN 11%. Products use their own class,
+// else their category's, else the default class; without one they are not
+// taxed.
+type TaxClass struct {
+	ID        int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string         `json:"name" gorm:"type:varchar(100);not null"`
+	Code      string         `json:"code" gorm:"type:varchar(30);not null;uniqueIndex"`
+	Rate      float64        `json:"rate" gorm:"type:decimal(5,2);not null"`
+	IsDefault bool           `json:"is_default" gorm:"not null;default:false"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TransactionTaxLine is the tax charged on a transaction for one tax class,
+// as printed on invoices.
+type TransactionTaxLine struct {
+	ID            int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	TransactionID string    `json:"transaction_id" gorm:"type:varchar(50);not null;index"`
+	TaxClassID    int64     `json:"tax_class_id" gorm:"not null;index"`
+	Name          string    `json:"name" gorm:"type:varchar(100);not null"`
+	Rate          float64   `json:"rate" gorm:"type:decimal(5,2);not null"`
+	TaxableAmount Money     `json:"taxable_amount" gorm:"type:decimal(12,2);not null"`
+	TaxAmount     Money     `json:"tax_amount" gorm:"type:decimal(12,2);not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Request untuk membuat atau mengubah kelas pajak
+type CreateTaxClass struct {
+	ID        int64   `json:"-"`
+	Name      string  `json:"name" validate:"required,min=2,max=100"`
+	Code      string  `json:"code" validate:"required,max=30"`
+	Rate      float64 `json:"rate" validate:"gte=0,lte=100"`
+	IsDefault bool    `json:"is_default"`
+}
+
+type TaxClassResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Code      string    `json:"code"`
+	Rate      float64   `json:"rate"`
+	IsDefault bool      `json:"is_default"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c *TaxClass) ToResponse() *TaxClassResponse {
+	return &TaxClassResponse{
+		ID:        c.ID,
+		Name:      c.Name,
+		Code:      c.Code,
+		Rate:      c.Rate,
+		IsDefault: c.IsDefault,
+		UpdatedAt: c.UpdatedAt,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -7,34 +7,45 @@ import (
 )
 
 type Transaction struct {
-	TxID            string         `json:"tx_id" gorm:"primaryKey;type:varchar(50)"`
-	AddressID       int64          `json:"address_id" validate:"required" gorm:"not null;index"`
-	ShippingID      int64          `json:"shipping_id" validate:"required" gorm:"not null;index"`
-	PaymentMethodID int64          `json:"payment_method_id" validate:"required" gorm:"not null;index"`
-	ShippingPrice   float64        `json:"shipping_price" gorm:"type:decimal(12,2);not null"`
-	TotalPrice      float64        `json:"total_price"  gorm:"type:decimal(12,2);not null"`
-	Status          string         `json:"status" gorm:"type:varchar(30);not null;index"`
-	CreatedAt       time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
-	UpdatedAt       time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
-	Address         *Address       `json:"address,omitempty" gorm:"foreignKey:AddressID;references:ID"`
-	Shipping        *Shipping      `json:"shipping,omitempty" gorm:"foreignKey:ShippingID;references:ID"`
-	PaymentMethod   *PaymentMethod `json:"payment_method,omitempty" gorm:"foreignKey:PaymentMethodID;references:ID"`
-	Orders          []Order        `json:"orders,omitempty" gorm:"foreignKey:TransactionID;references:TxID"`
+	TxID             string               `json:"tx_id" gorm:"primaryKey;type:varchar(50)"`
+	AddressID        int64                `json:"address_id" validate:"required" gorm:"not null;index"`
+	ShippingID       int64                `json:"shipping_id" validate:"required" gorm:"not null;index"`
+	PaymentMethodID  int64                `json:"payment_method_id" validate:"required" gorm:"not null;index"`
+	ShippingPrice    Money                `json:"shipping_price" gorm:"type:decimal(12,2);not null"`
+	TotalPrice       Money                `json:"total_price"  gorm:"type:decimal(12,2);not null"`
+	TaxTotal         Money                `json:"tax_total" gorm:"type:decimal(12,2);not null;default:0"`
+	UniqueCode       int64                `json:"unique_code" gorm:"not null;default:0"`
+	PayableAmount    Money                `json:"payable_amount" gorm:"type:decimal(12,2);not null;default:0;index"`
+	PaymentType      string               `json:"payment_type" gorm:"type:varchar(20);not null;default:'bank_transfer'"`
+	PaymentFee       Money                `json:"payment_fee" gorm:"type:decimal(12,2);not null;default:0"`
+	PaymentReference string               `json:"payment_reference" gorm:"type:text"`
+	Currency         string               `json:"currency" gorm:"type:varchar(3);not null;default:'IDR'"`
+	DisplayCurrency  string               `json:"display_currency" gorm:"type:varchar(3);not null;default:'IDR'"`
+	ExchangeRate     float64              `json:"exchange_rate" gorm:"type:decimal(18,6);not null;default:1"`
+	Status           string               `json:"status" gorm:"type:varchar(30);not null;index"`
+	CreatedAt        time.Time            `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt        time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt       `json:"-" gorm:"index"`
+	Address          *Address             `json:"address,omitempty" gorm:"foreignKey:AddressID;references:ID"`
+	Shipping         *Shipping            `json:"shipping,omitempty" gorm:"foreignKey:ShippingID;references:ID"`
+	PaymentMethod    *PaymentMethod       `json:"payment_method,omitempty" gorm:"foreignKey:PaymentMethodID;references:ID"`
+	Orders           []Order              `json:"orders,omitempty" gorm:"foreignKey:TransactionID;references:TxID"`
+	TaxLines         []TransactionTaxLine `json:"tax_lines,omitempty" gorm:"foreignKey:TransactionID;references:TxID"`
 }
 
 type CreateTransaction struct {
 	AddressID       int64         `json:"address_id" form:"address_id" validate:"required"`
 	ShippingID      int64         `json:"shipping_id" form:"shipping_id" validate:"required"`
 	PaymentMethodID int64         `json:"payment_method_id" form:"payment_method_id" validate:"required"`
-	ShippingPrice   float64       `json:"shipping_price" form:"shipping_price" validate:"required,gt=0"`
-	TotalPrice      float64       `json:"total_price" form:"total_price" validate:"required,gt=0"`
+	ShippingPrice   Money         `json:"shipping_price" form:"shipping_price" validate:"omitempty,gte=0"` // ignored, priced from the shipping rates
+	TotalPrice      Money         `json:"total_price" form:"total_price" validate:"required,gt=0"`
 	ProductOrders   []CreateOrder `json:"product_orders" validate:"required,dive"`
+	Currency        string        `json:"currency" form:"currency" validate:"omitempty,len=3"` // currency prices were shown in, snapshotted with its rate
 }
 
 type UpdateTransaction struct {
 	TxID   string `json:"tx_id" form:"tx_id" validate:"required"`
-	Status string `json:"status" form:"status" validate:"required,oneof=pending paid shipped cancelled completed"`
+	Status string `json:"status" form:"status" validate:"required,oneof=pending confirmed paid shipped cancelled completed refunded"`
 }
 
 type TransactionListRequest struct {
@@ -50,12 +61,28 @@ type TransactionResponse struct {
 	Address       AddressResponse       `json:"address"`
 	Shipping      ShippingResponse      `json:"shipping"`
 	PaymentMethod PaymentMethodResponse `json:"payment_method"`
-	ShippingPrice float64               `json:"shipping_price"`
-	TotalPrice    float64               `json:"total_price"`
+	ShippingPrice Money                 `json:"shipping_price"`
+	TotalPrice    Money                 `json:"total_price"`
+	TaxTotal      Money                 `json:"tax_total"`
+	UniqueCode    int64                 `json:"unique_code"`
+	PayableAmount Money                 `json:"payable_amount"`
+	PaymentType   string                `json:"payment_type"`
+	PaymentFee    Money                 `json:"payment_fee"`
+	Currency      string                `json:"currency"`
+	TaxLines      []TransactionTaxLine  `json:"tax_lines"`
 	Status        string                `json:"status"`
 	Orders        []OrderResponse       `json:"orders"`
 	UpdatedAt     time.Time             `json:"updated_at"`
 	CreatedAt     time.Time             `json:"created_at"`
+
+	// PaymentInstructions tell how to pay with the chosen method
+	PaymentInstructions *PaymentInstructions `json:"payment_instructions,omitempty"`
+
+	// The amount due as shown to the customer in the currency they browsed
+	// in, at the rate snapshotted at checkout. Payment is in Currency.
+	DisplayCurrency string  `json:"display_currency"`
+	ExchangeRate    float64 `json:"exchange_rate"`
+	DisplayAmount   Money   `json:"display_amount"`
 }
 
 func (tx *Transaction) ToResponseTransaction() *TransactionResponse {
@@ -71,9 +98,67 @@ func (tx *Transaction) ToResponseTransaction() *TransactionResponse {
 		PaymentMethod: *tx.PaymentMethod.ToResponsePaymentMethod(),
 		ShippingPrice: tx.ShippingPrice,
 		TotalPrice:    tx.TotalPrice,
+		TaxTotal:      tx.TaxTotal,
+		UniqueCode:    tx.UniqueCode,
+		PayableAmount: tx.AmountDue(),
+		PaymentType:   tx.PaymentType,
+		PaymentFee:    tx.PaymentFee,
+		Currency:      tx.SettlementCurrency(),
+		TaxLines:      tx.TaxLines,
 		Status:        tx.Status,
 		Orders:        orderResponse,
 		UpdatedAt:     tx.UpdatedAt,
 		CreatedAt:     tx.CreatedAt,
+
+		PaymentInstructions: tx.PaymentMethod.InstructionsFor(tx),
+
+		DisplayCurrency: tx.DisplayCurrencyInfo().Code,
+		ExchangeRate:    tx.DisplayCurrencyInfo().Rate,
+		DisplayAmount:   tx.DisplayAmount(),
 	}
 }
+
+// AmountDue is what the customer has to pay: the items, shipping, the
+// payment fee and the unique code. Transactions from before unique codes had no payable
+// amount and are due their total price.
+func (tx *Transaction) AmountDue() Money {
+	if tx.PayableAmount > 0 {
+		return tx.PayableAmount
+	}
+	return tx.TotalPrice
+}
+
+// SettlementCurrency is the currency the transaction is charged and paid
+// in. Transactions from before currencies were recorded are in the store
+// currency.
+func (tx *Transaction) SettlementCurrency() string {
+	if tx.Currency == "" {
+		return StoreCurrency
+	}
+	return tx.Currency
+}
+
+// DisplayCurrencyInfo is the currency the customer saw prices in, with the
+// rate snapshotted when they checked out.
+func (tx *Transaction) DisplayCurrencyInfo() *Currency {
+	if tx.DisplayCurrency == "" || tx.DisplayCurrency == tx.SettlementCurrency() || tx.ExchangeRate <= 0 {
+		return StoreCurrencyInfo()
+	}
+	return &Currency{Code: tx.DisplayCurrency, Rate: tx.ExchangeRate}
+}
+
+// DisplayAmount is the amount due converted to the display currency at the
+// snapshotted rate.
+func (tx *Transaction) DisplayAmount() Money {
+	return tx.DisplayCurrencyInfo().Convert(tx.AmountDue())
+}
+
+// TransactionQRISResponse is the dynamic QRIS of a transaction. Payload is
+// what the QR code encodes; Image is the same code as a PNG data URL.
+type TransactionQRISResponse struct {
+	TxID         string `json:"tx_id"`
+	Amount       Money  `json:"amount"`
+	MerchantName string `json:"merchant_name"`
+	Payload      string `json:"payload"`
+	Image        string `json:"image,omitempty"`
+}
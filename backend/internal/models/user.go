@@ -11,6 +11,9 @@ type User struct {
 	Email           string         `json:"email" gorm:"type:varchar(255);uniqueIndex;not null" validate:"required,email"`
 	Username        string         `json:"username" gorm:"type:varchar(255);uniqueIndex;not null" validate:"required,min=3,max=50"`
 	PasswordHash    string         `json:"-" gorm:"not null"`
+	// NoPassword marks accounts created by social login that never set a
+	// password of their own; they confirm by signing in with their provider.
+	NoPassword      bool           `json:"no_password" gorm:"not null;default:false"`
 	FirstName       string         `json:"first_name" gorm:"not null" validate:"required,min=1,max=50"`
 	LastName        string         `json:"last_name" gorm:"not null" validate:"required,min=1,max=50"`
 	RoleID          uint           `json:"role_id" gorm:"not null;index"`
@@ -0,0 +1,124 @@
+package models
+
+import "time"
+
+// Wishlist is a product a customer saved for later, optionally in a
+// specific color and size. Entries of deleted products stay listed as
+// unavailable until the customer removes them.
+type Wishlist struct {
+	ID            int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        uint      `json:"user_id" gorm:"not null;index:idx_wishlists_user_product"`
+	ProductID     int64     `json:"product_id" gorm:"not null;index:idx_wishlists_user_product;index"`
+	ColorVarianID *int64    `json:"color_varian_id,omitempty"`
+	SizeVarianID  *int64    `json:"size_varian_id,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+
+	Product     *Product     `json:"product,omitempty" gorm:"foreignKey:ProductID;references:ID"`
+	ColorVarian *ColorVarian `json:"color_varian,omitempty" gorm:"foreignKey:ColorVarianID;references:ID"`
+	SizeVarian  *SizeVarian  `json:"size_varian,omitempty" gorm:"foreignKey:SizeVarianID;references:ID"`
+}
+
+// Request untuk menyimpan produk ke wishlist. A size without a color takes
+// the size's color.
+type CreateWishlist struct {
+	ProductID     int64  `json:"product_id" validate:"required"`
+	ColorVarianID *int64 `json:"color_varian_id,omitempty"`
+	SizeVarianID  *int64 `json:"size_varian_id,omitempty"`
+}
+
+type WishlistListRequest struct {
+	UserID uint
+	Limit  int
+	Page   int
+}
+
+type WishlistResponse struct {
+	ID            int64     `json:"id"`
+	ProductID     int64     `json:"product_id"`
+	ProductName   string    `json:"product_name"`
+	Images        string    `json:"images"`
+	Price         Money     `json:"price"`
+	Currency      string    `json:"currency"`
+	ColorVarianID *int64    `json:"color_varian_id,omitempty"`
+	ColorName     string    `json:"color_name,omitempty"`
+	SizeVarianID  *int64    `json:"size_varian_id,omitempty"`
+	Size          string    `json:"size,omitempty"`
+	Available     bool      `json:"available"`
+	InStock       bool      `json:"in_stock"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type WishlistListResponse struct {
+	Items      []WishlistResponse `json:"items"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// ToResponse flattens the entry with the product and variants it was
+// loaded with. Without a size the entry is in stock when any size of the
+// chosen color, or of the product, is.
+func (w *Wishlist) ToResponse() WishlistResponse {
+	response := WishlistResponse{
+		ID:            w.ID,
+		ProductID:     w.ProductID,
+		Currency:      StoreCurrency,
+		ColorVarianID: w.ColorVarianID,
+		SizeVarianID:  w.SizeVarianID,
+		CreatedAt:     w.CreatedAt,
+	}
+	if w.Product == nil {
+		return response
+	}
+
+	response.ProductName = w.Product.Name
+	response.Images = w.Product.Images
+	response.Price = w.Product.Price
+	// a chosen color or size that was removed from the catalog can no
+	// longer be bought
+	response.Available = (w.ColorVarianID == nil || w.ColorVarian != nil) && (w.SizeVarianID == nil || w.SizeVarian != nil)
+	if !response.Available {
+		return response
+	}
+
+	if w.ColorVarian != nil {
+		response.ColorName = w.ColorVarian.Name
+		if w.ColorVarian.Images != "" {
+			response.Images = w.ColorVarian.Images
+		}
+	}
+
+	if w.SizeVarian != nil {
+		response.Size = w.SizeVarian.Size
+		response.Price = w.SizeVarian.UnitPrice(w.Product.Price)
+		response.InStock = w.SizeVarian.Stock > 0
+		return response
+	}
+
+	for _, color := range w.Product.ColorVarians {
+		if w.ColorVarianID != nil && color.ID != *w.ColorVarianID {
+			continue
+		}
+		for _, size := range color.SizeVarians {
+			if size.Stock > 0 {
+				response.InStock = true
+			}
+		}
+	}
+	return response
+}
+
+// MostWishedProduct is a product by the number of customers who saved it.
+type MostWishedProduct struct {
+	ProductID     int64  `json:"product_id" gorm:"column:product_id"`
+	ProductName   string `json:"product_name" gorm:"column:product_name"`
+	WishlistCount int64  `json:"wishlist_count" gorm:"column:wishlist_count"`
+	RecentCount   int64  `json:"recent_count" gorm:"column:recent_count"`
+}
+
+type MostWishedProductsResponse struct {
+	Days     int                 `json:"days"`
+	Products []MostWishedProduct `json:"products"`
+}
@@ -0,0 +1,42 @@
+package models_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"testing"
+)
+
+func TestWishlistToResponse(t *testing.T) {
+	colorID, sizeID := int64(3), int64(8)
+	product := &models.Product{
+		ID:     12,
+		Name:   "Kaos Polos",
+		Images: "product.jpg",
+		Price:  models.Rupiah(89000),
+		ColorVarians: []models.ColorVarian{
+			{ID: 3, SizeVarians: []models.SizeVarian{{ID: 8, ColorVarianID: 3, Size: "M", Stock: 0}}},
+			{ID: 4, SizeVarians: []models.SizeVarian{{ID: 9, ColorVarianID: 4, Size: "L", Stock: 2}}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		item          models.Wishlist
+		wantAvailable bool
+		wantInStock   bool
+	}{
+		{"any variant", models.Wishlist{Product: product}, true, true},
+		{"color out of stock", models.Wishlist{Product: product, ColorVarianID: &colorID, ColorVarian: &product.ColorVarians[0]}, true, false},
+		{"size out of stock", models.Wishlist{Product: product, ColorVarianID: &colorID, ColorVarian: &product.ColorVarians[0], SizeVarianID: &sizeID, SizeVarian: &product.ColorVarians[0].SizeVarians[0]}, true, false},
+		{"size removed", models.Wishlist{Product: product, ColorVarianID: &colorID, ColorVarian: &product.ColorVarians[0], SizeVarianID: &sizeID}, false, false},
+		{"product deleted", models.Wishlist{ProductID: 12}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.item.ToResponse()
+			if got.Available != tt.wantAvailable || got.InStock != tt.wantInStock {
+				t.Errorf("ToResponse() available=%v in_stock=%v, want %v %v", got.Available, got.InStock, tt.wantAvailable, tt.wantInStock)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AccountRepository interface {
+	FindAddresses(userID uint) ([]models.Address, error)
+	FindTransactions(userID uint) ([]models.Transaction, error)
+	FindOrders(userID uint) ([]models.Order, error)
+	FindPayments(txIDs []string) ([]models.Payment, error)
+	FindActivityLogs(userID uint) ([]models.ActivityLog, error)
+	FindWishlists(userID uint) ([]models.Wishlist, error)
+	FindStockAlerts(userID uint) ([]models.StockAlert, error)
+	FindStockAlertNotifications(userID uint) ([]models.StockAlertNotification, error)
+	FindPaymentProofs(userID uint) ([]models.PaymentProof, error)
+	FindReturns(userID uint) ([]models.ReturnRequest, error)
+	FindRefunds(txIDs []string) ([]models.Refund, error)
+	FindShipments(txIDs []string) ([]models.Shipment, error)
+	CountOpenOrders(userID uint) (int64, error)
+	Anonymize(userID uint, passwordHash string, tx *gorm.DB) error
+}
+
+type AccountRepositoryImpl struct {
+}
+
+// FindAddresses implements AccountRepository. Deleted addresses are included
+// because past transactions still point at them.
+func (a *AccountRepositoryImpl) FindAddresses(userID uint) ([]models.Address, error) {
+	var addresses []models.Address
+	err := database.DB.Unscoped().
+		Where("user_id = ?", userID).
+		Order("id asc").
+		Find(&addresses).Error
+	return addresses, err
+}
+
+// FindTransactions implements AccountRepository. Transactions belong to a
+// user through the shipping address.
+func (a *AccountRepositoryImpl) FindTransactions(userID uint) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	err := database.DB.
+		Where("address_id IN (?)", database.DB.Unscoped().Model(&models.Address{}).Select("id").Where("user_id = ?", userID)).
+		Order("created_at asc").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// FindOrders implements AccountRepository.
+func (a *AccountRepositoryImpl) FindOrders(userID uint) ([]models.Order, error) {
+	var orders []models.Order
+	err := database.DB.
+		Preload("Product", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Select("id", "name")
+		}).
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&orders).Error
+	return orders, err
+}
+
+// FindPayments implements AccountRepository.
+func (a *AccountRepositoryImpl) FindPayments(txIDs []string) ([]models.Payment, error) {
+	var payments []models.Payment
+	if len(txIDs) == 0 {
+		return payments, nil
+	}
+
+	err := database.DB.
+		Where("transaction_id IN ?", txIDs).
+		Order("created_at asc").
+		Find(&payments).Error
+	return payments, err
+}
+
+// FindActivityLogs implements AccountRepository.
+func (a *AccountRepositoryImpl) FindActivityLogs(userID uint) ([]models.ActivityLog, error) {
+	var activityLogs []models.ActivityLog
+	err := database.DB.
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "first_name", "last_name")
+		}).
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&activityLogs).Error
+	return activityLogs, err
+}
+
+// FindWishlists implements AccountRepository.
+func (a *AccountRepositoryImpl) FindWishlists(userID uint) ([]models.Wishlist, error) {
+	var wishlists []models.Wishlist
+	err := preloadWishlist(database.DB).
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&wishlists).Error
+	return wishlists, err
+}
+
+// FindStockAlerts implements AccountRepository.
+func (a *AccountRepositoryImpl) FindStockAlerts(userID uint) ([]models.StockAlert, error) {
+	var alerts []models.StockAlert
+	err := database.DB.
+		Preload("Product").
+		Preload("SizeVarian").
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// FindStockAlertNotifications implements AccountRepository.
+func (a *AccountRepositoryImpl) FindStockAlertNotifications(userID uint) ([]models.StockAlertNotification, error) {
+	var notifications []models.StockAlertNotification
+	err := database.DB.
+		Preload("Product").
+		Where("user_id = ?", userID).
+		Order("created_at asc, id asc").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// FindPaymentProofs implements AccountRepository.
+func (a *AccountRepositoryImpl) FindPaymentProofs(userID uint) ([]models.PaymentProof, error) {
+	var proofs []models.PaymentProof
+	err := database.DB.
+		Where("user_id = ?", userID).
+		Order("created_at asc, id asc").
+		Find(&proofs).Error
+	return proofs, err
+}
+
+// FindReturns implements AccountRepository.
+func (a *AccountRepositoryImpl) FindReturns(userID uint) ([]models.ReturnRequest, error) {
+	var returns []models.ReturnRequest
+	err := database.DB.
+		Preload("Items").
+		Preload("Photos").
+		Where("user_id = ?", userID).
+		Order("created_at asc, id asc").
+		Find(&returns).Error
+	return returns, err
+}
+
+// FindRefunds implements AccountRepository.
+func (a *AccountRepositoryImpl) FindRefunds(txIDs []string) ([]models.Refund, error) {
+	var refunds []models.Refund
+	if len(txIDs) == 0 {
+		return refunds, nil
+	}
+
+	err := database.DB.
+		Preload("Items").
+		Where("transaction_id IN ?", txIDs).
+		Order("created_at asc, id asc").
+		Find(&refunds).Error
+	return refunds, err
+}
+
+// FindShipments implements AccountRepository.
+func (a *AccountRepositoryImpl) FindShipments(txIDs []string) ([]models.Shipment, error) {
+	var shipments []models.Shipment
+	if len(txIDs) == 0 {
+		return shipments, nil
+	}
+
+	err := database.DB.
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("occurred_at asc, id asc")
+		}).
+		Where("transaction_id IN ?", txIDs).
+		Order("created_at asc").
+		Find(&shipments).Error
+	return shipments, err
+}
+
+// CountOpenOrders implements AccountRepository. Open orders are the ones
+// that have not been completed or cancelled yet.
+func (a *AccountRepositoryImpl) CountOpenOrders(userID uint) (int64, error) {
+	var count int64
+	err := database.DB.Model(&models.Order{}).
+		Where("user_id = ? AND status IN ?", userID, []string{"pending", "paid", "shipped"}).
+		Count(&count).Error
+	return count, err
+}
+
+// Anonymize implements AccountRepository. It scrubs the personal data of a
+// user, soft deletes the account and its addresses and removes the wishlist
+// and stock alerts, including notifications not sent yet. Payment proofs
+// lose the sender's details and the receipt image, return requests their
+// reason and photos; the audit snapshots of these rows are scrubbed the same
+// way. Orders, transactions and payments are kept untouched for bookkeeping.
+func (a *AccountRepositoryImpl) Anonymize(userID uint, passwordHash string, tx *gorm.DB) error {
+	db := database.DB
+	if tx != nil {
+		db = tx
+	}
+
+	now := time.Now()
+
+	placeholders := map[string]interface{}{
+		"email":      fmt.Sprintf("deleted-user-%d@deleted.invalid", userID),
+		"username":   fmt.Sprintf("deleted-user-%d", userID),
+		"first_name": "Deleted",
+		"last_name":  "User",
+	}
+
+	if err := db.Unscoped().Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"email":             placeholders["email"],
+			"username":          placeholders["username"],
+			"first_name":        placeholders["first_name"],
+			"last_name":         placeholders["last_name"],
+			"password_hash":     passwordHash,
+			"is_active":         false,
+			"email_verified_at": nil,
+			"last_login_at":     nil,
+			"deleted_at":        now,
+		}).Error; err != nil {
+		return err
+	}
+
+	if err := scrubAuditSnapshots(db, "users", []string{fmt.Sprint(userID)}, placeholders); err != nil {
+		return err
+	}
+
+	// City, province and postal code stay so tax and shipping reports of past
+	// transactions remain correct.
+	if err := db.Unscoped().Model(&models.Address{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"recipient_name":         "Deleted User",
+			"recipient_phone_number": "",
+			"district":               "",
+			"village":                "",
+			"full_address":           "",
+			"deleted_at":             gorm.Expr("COALESCE(deleted_at, ?)", now),
+		}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Model(&models.ActivityLog{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{"ip_address": "", "user_agent": ""}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Model(&models.AuditLog{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{"ip_address": "", "user_agent": ""}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Model(&models.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error; err != nil {
+		return err
+	}
+
+	if err := anonymizePaymentProofs(db, userID); err != nil {
+		return err
+	}
+
+	if err := anonymizeReturns(db, userID); err != nil {
+		return err
+	}
+
+	if err := db.Where("user_id = ?", userID).Delete(&models.PasswordHistory{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("user_id = ?", userID).Delete(&models.UserIdentity{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("user_id = ?", userID).Delete(&models.Wishlist{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("user_id = ?", userID).Delete(&models.StockAlertNotification{}).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where("user_id = ?", userID).Delete(&models.StockAlert{}).Error; err != nil {
+		return err
+	}
+
+	return db.Unscoped().Where("user_id = ?", userID).Delete(&models.PasswordResetToken{}).Error
+}
+
+// anonymizePaymentProofs scrubs the sender's details and receipt image from
+// the payment proofs of a user and from the audit snapshots of their review.
+func anonymizePaymentProofs(db *gorm.DB, userID uint) error {
+	var proofIDs []string
+	if err := db.Model(&models.PaymentProof{}).Where("user_id = ?", userID).Pluck("id", &proofIDs).Error; err != nil {
+		return err
+	}
+	if len(proofIDs) == 0 {
+		return nil
+	}
+
+	placeholders := map[string]interface{}{
+		"image_url":           "",
+		"sender_bank_name":    "",
+		"sender_account_name": "Deleted User",
+	}
+
+	if err := scrubAuditSnapshots(db, "payment_proofs", proofIDs, placeholders); err != nil {
+		return err
+	}
+
+	return db.Model(&models.PaymentProof{}).
+		Where("user_id = ?", userID).
+		Updates(placeholders).Error
+}
+
+// anonymizeReturns removes the reason and photos of a user's return
+// requests, and the copies of the reason in the activity log, the audit
+// snapshots and the refunds issued for them.
+func anonymizeReturns(db *gorm.DB, userID uint) error {
+	var returns []models.ReturnRequest
+	if err := db.Select("id", "transaction_id", "resolution", "refund_id").Where("user_id = ?", userID).Find(&returns).Error; err != nil {
+		return err
+	}
+	if len(returns) == 0 {
+		return nil
+	}
+
+	returnIDs := make([]int64, len(returns))
+	resourceIDs := make([]string, len(returns))
+	for i, returnRequest := range returns {
+		returnIDs[i] = returnRequest.ID
+		resourceIDs[i] = fmt.Sprint(returnRequest.ID)
+
+		if err := db.Model(&models.ActivityLog{}).
+			Where("resource = ? AND resource_id = ? AND action = ?", "returns", resourceIDs[i], "create").
+			Update("details", fmt.Sprintf("Requested return %d (%s) for transaction %s", returnRequest.ID, returnRequest.Resolution, returnRequest.TransactionID)).Error; err != nil {
+			return err
+		}
+
+		if returnRequest.RefundID != nil {
+			if err := db.Model(&models.Refund{}).
+				Where("id = ?", *returnRequest.RefundID).
+				Update("reason", fmt.Sprintf("Return %d", returnRequest.ID)).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := db.Where("return_id IN ?", returnIDs).Delete(&models.ReturnPhoto{}).Error; err != nil {
+		return err
+	}
+
+	if err := scrubAuditSnapshots(db, "return_requests", resourceIDs, map[string]interface{}{"reason": ""}); err != nil {
+		return err
+	}
+
+	return db.Model(&models.ReturnRequest{}).
+		Where("id IN ?", returnIDs).
+		Update("reason", "").Error
+}
+
+// scrubAuditSnapshots overwrites columns in the audit snapshots of the
+// resourceType rows with the given ids.
+func scrubAuditSnapshots(db *gorm.DB, resourceType string, resourceIDs []string, values map[string]interface{}) error {
+	var entries []models.AuditLog
+	if err := db.Select("id", "before", "after", "changes").
+		Where("resource_type = ? AND resource_id IN ?", resourceType, resourceIDs).
+		Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := utils.ScrubAuditLog(&entry, values); err != nil {
+			return fmt.Errorf("failed to scrub audit log %d: %w", entry.ID, err)
+		}
+
+		if err := db.Model(&models.AuditLog{}).
+			Where("id = ?", entry.ID).
+			Updates(map[string]interface{}{
+				"before":  entry.Before,
+				"after":   entry.After,
+				"changes": entry.Changes,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewAccountRepository() AccountRepository {
+	return &AccountRepositoryImpl{}
+}
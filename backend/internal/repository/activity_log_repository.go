@@ -31,7 +31,7 @@ func (a *ActivityLogRepositoryImpl) Create(param models.ActivityLog, tx *gorm.DB
 	}
 
 	err = db.
-		Select("id", "user_id", "action", "resource", "details", "ip_address", "user_agent", "created_at", "updated_at").
+		Select("id", "user_id", "action", "resource", "details", "ip_address", "user_agent", "api_key_id", "impersonator_id", "created_at", "updated_at").
 		First(&result, param.ID).Error
 	return result, err
 }
@@ -50,7 +50,7 @@ func (a *ActivityLogRepositoryImpl) FindAll(param *models.ActivityLogListRequest
 
 	var activityLogs []models.ActivityLog
 	if err := database.DB.
-		Select("id", "user_id", "action", "resource", "details", "ip_address", "user_agent", "created_at", "updated_at").
+		Select("id", "user_id", "action", "resource", "details", "ip_address", "user_agent", "api_key_id", "impersonator_id", "created_at", "updated_at").
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "username", "email", "first_name", "last_name", "created_at", "updated_at")
 		}).
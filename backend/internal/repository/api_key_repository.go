@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"time"
+)
+
+type APIKeyRepository interface {
+	Create(param models.APIKey) (models.APIKey, error)
+	Update(param *models.APIKey) (models.APIKey, error)
+	FindById(id uint) (models.APIKey, error)
+	FindByPrefix(prefix string) (models.APIKey, error)
+	FindAll() ([]models.APIKey, error)
+	FindAllByUser(userID uint) ([]models.APIKey, error)
+	TouchLastUsed(id uint, usedAt time.Time, ipAddress string) error
+}
+
+type APIKeyRepositoryImpl struct {
+}
+
+// Create implements APIKeyRepository.
+func (a *APIKeyRepositoryImpl) Create(param models.APIKey) (models.APIKey, error) {
+	var result models.APIKey
+
+	if err := database.DB.Create(&param).Error; err != nil {
+		return result, err
+	}
+
+	err := database.DB.First(&result, param.ID).Error
+	return result, err
+}
+
+// Update implements APIKeyRepository.
+func (a *APIKeyRepositoryImpl) Update(param *models.APIKey) (models.APIKey, error) {
+	var result models.APIKey
+
+	if err := database.DB.Save(param).Error; err != nil {
+		return result, err
+	}
+
+	err := database.DB.First(&result, param.ID).Error
+	return result, err
+}
+
+// FindById implements APIKeyRepository.
+func (a *APIKeyRepositoryImpl) FindById(id uint) (models.APIKey, error) {
+	var apiKey models.APIKey
+	err := database.DB.First(&apiKey, id).Error
+	return apiKey, err
+}
+
+// FindByPrefix implements APIKeyRepository.
+func (a *APIKeyRepositoryImpl) FindByPrefix(prefix string) (models.APIKey, error) {
+	var apiKey models.APIKey
+	err := database.DB.Where("prefix = ?", prefix).First(&apiKey).Error
+	return apiKey, err
+}
+
+// FindAll implements APIKeyRepository.
+func (a *APIKeyRepositoryImpl) FindAll() ([]models.APIKey, error) {
+	var apiKeys []models.APIKey
+	err := database.DB.Order("created_at desc").Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+// FindAllByUser implements APIKeyRepository.
+func (a *APIKeyRepositoryImpl) FindAllByUser(userID uint) ([]models.APIKey, error) {
+	var apiKeys []models.APIKey
+	err := database.DB.Where("user_id = ?", userID).Order("created_at desc").Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+// TouchLastUsed implements APIKeyRepository.
+func (a *APIKeyRepositoryImpl) TouchLastUsed(id uint, usedAt time.Time, ipAddress string) error {
+	return database.DB.Model(&models.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"last_used_at": usedAt,
+			"last_used_ip": ipAddress,
+		}).Error
+}
+
+func NewAPIKeyRepository() APIKeyRepository {
+	return &APIKeyRepositoryImpl{}
+}
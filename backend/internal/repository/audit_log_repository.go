@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	Create(param models.AuditLog) (models.AuditLog, error)
+	FindAll(param *models.AuditLogListRequest) (*models.AuditLogListResponse, error)
+	FindRow(table string, keyColumn string, id string) (map[string]interface{}, error)
+	FindChildRows(table string, id string) (map[string][]map[string]interface{}, error)
+	DeleteBefore(cutoff time.Time) (int64, error)
+}
+
+type AuditLogRepositoryImpl struct {
+}
+
+// Create implements AuditLogRepository.
+func (a *AuditLogRepositoryImpl) Create(param models.AuditLog) (models.AuditLog, error) {
+	err := database.DB.Create(&param).Error
+	return param, err
+}
+
+// FindAll implements AuditLogRepository.
+func (a *AuditLogRepositoryImpl) FindAll(param *models.AuditLogListRequest) (*models.AuditLogListResponse, error) {
+	offset := (param.Page - 1) * param.Limit
+
+	query := database.DB.Model(&models.AuditLog{})
+	if param.UserID != nil {
+		query = query.Where("user_id = ?", *param.UserID)
+	}
+	if param.APIKeyID != nil {
+		query = query.Where("api_key_id = ?", *param.APIKeyID)
+	}
+	if param.Action != "" {
+		query = query.Where("action = ?", param.Action)
+	}
+	if param.ResourceType != "" {
+		query = query.Where("resource_type = ?", param.ResourceType)
+	}
+	if param.ResourceID != "" {
+		query = query.Where("resource_id = ?", param.ResourceID)
+	}
+	if param.RequestID != "" {
+		query = query.Where("request_id = ?", param.RequestID)
+	}
+	if param.From != nil {
+		query = query.Where("created_at >= ?", *param.From)
+	}
+	if param.To != nil {
+		query = query.Where("created_at <= ?", *param.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var auditLogs []models.AuditLog
+	if err := query.
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "first_name", "last_name")
+		}).
+		Order("created_at desc").
+		Offset(offset).
+		Limit(param.Limit).
+		Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.AuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		responses[i] = *auditLog.ToResponse()
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(param.Limit)))
+
+	return &models.AuditLogListResponse{
+		AuditLogs:  responses,
+		Total:      total,
+		Page:       param.Page,
+		Limit:      param.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// FindRow implements AuditLogRepository. It reads the raw row so snapshots
+// include every column, including ones the API responses leave out.
+func (a *AuditLogRepositoryImpl) FindRow(table string, keyColumn string, id string) (map[string]interface{}, error) {
+	row := map[string]interface{}{}
+	err := database.DB.Table(table).Where(keyColumn+" = ?", id).Take(&row).Error
+	return row, err
+}
+
+// auditChildQueries select the rows a resource owns and changes through its
+// own routes, by resource table and child name. Sizes are edited through
+// PUT /products/{id} and permissions through POST /roles/{id}/permissions,
+// so without them those changes would leave an empty diff. Soft deleted
+// children are included so removing one shows up as deleted_at being set.
+var auditChildQueries = map[string]map[string]func(db *gorm.DB, id string) *gorm.DB{
+	"products": {
+		"color_varians": func(db *gorm.DB, id string) *gorm.DB {
+			return db.Table("color_varians").Where("product_id = ?", id)
+		},
+		"size_varians": func(db *gorm.DB, id string) *gorm.DB {
+			return db.Table("size_varians").
+				Where("color_varian_id IN (?)", db.Table("color_varians").Select("id").Where("product_id = ?", id))
+		},
+	},
+	"roles": {
+		"permissions": func(db *gorm.DB, id string) *gorm.DB {
+			return db.Table("permissions").
+				Select("permissions.id", "permissions.name").
+				Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+				Where("role_permissions.role_id = ?", id)
+		},
+	},
+}
+
+// FindChildRows implements AuditLogRepository. It returns nil for resources
+// without child rows.
+func (a *AuditLogRepositoryImpl) FindChildRows(table string, id string) (map[string][]map[string]interface{}, error) {
+	queries, ok := auditChildQueries[table]
+	if !ok {
+		return nil, nil
+	}
+
+	children := make(map[string][]map[string]interface{}, len(queries))
+	for name, query := range queries {
+		var rows []map[string]interface{}
+		if err := query(database.DB, id).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		children[name] = rows
+	}
+	return children, nil
+}
+
+// DeleteBefore implements AuditLogRepository.
+func (a *AuditLogRepositoryImpl) DeleteBefore(cutoff time.Time) (int64, error) {
+	result := database.DB.Where("created_at < ?", cutoff).Delete(&models.AuditLog{})
+	return result.RowsAffected, result.Error
+}
+
+func NewAuditLogRepository() AuditLogRepository {
+	return &AuditLogRepositoryImpl{}
+}
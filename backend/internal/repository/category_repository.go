@@ -10,10 +10,16 @@ import (
 type CategoryRepository interface {
 	Create(param models.Category, tx *gorm.DB) (models.Category, error)
 	Update(param models.Category, tx *gorm.DB) (models.Category, error)
-	Delete(param models.Category) error
+	Delete(param models.Category, tx *gorm.DB) error
 	FindById(paramId int64) (models.Category, error)
 	FindByIds(paramId []int64) ([]models.Category, error)
 	FindAll(param models.CategoryListRequest) ([]models.Category, error)
+	CountProducts(categoryId int64, tx *gorm.DB) (int64, error)
+	ReassignProducts(fromId int64, toId int64, tx *gorm.DB) error
+	FindBySlug(slug string) (models.Category, error)
+	SlugExists(slug string, excludeId int64) (bool, error)
+	FindAllTree() ([]models.Category, error)
+	CountChildren(categoryId int64, tx *gorm.DB) (int64, error)
 }
 
 type CategoryRepositoryImpl struct {
@@ -28,7 +34,7 @@ func (a *CategoryRepositoryImpl) FindByIds(paramId []int64) ([]models.Category,
 	}
 
 	err := database.DB.
-		Select("id", "name", "icon", "created_at", "updated_at", "deleted_at").
+		Select("id", "parent_id", "name", "slug", "sort_order", "icon", "return_window_days", "tax_class_id", "created_at", "updated_at", "deleted_at").
 		Where("id IN ? AND deleted_at IS NULL", paramId).
 		Find(&categories).Error
 
@@ -54,14 +60,46 @@ func (a *CategoryRepositoryImpl) Create(param models.Category, tx *gorm.DB) (mod
 	}
 
 	err = db.
-		Select("id", "name", "icon", "created_at", "updated_at", "deleted_at").
+		Select("id", "parent_id", "name", "slug", "sort_order", "icon", "return_window_days", "tax_class_id", "created_at", "updated_at", "deleted_at").
 		First(&result, param.ID).Error
 	return result, err
 }
 
 // Delete implements CategoryRepository.
-func (a *CategoryRepositoryImpl) Delete(param models.Category) error {
-	return database.DB.Delete(&param).Error
+func (a *CategoryRepositoryImpl) Delete(param models.Category, tx *gorm.DB) error {
+	db := database.DB
+	if tx != nil {
+		db = tx
+	}
+
+	return db.Delete(&param).Error
+}
+
+// CountProducts implements CategoryRepository.
+func (a *CategoryRepositoryImpl) CountProducts(categoryId int64, tx *gorm.DB) (int64, error) {
+	db := database.DB
+	if tx != nil {
+		db = tx
+	}
+
+	var total int64
+	err := db.Model(&models.Product{}).
+		Where("category_id = ?", categoryId).
+		Count(&total).Error
+
+	return total, err
+}
+
+// ReassignProducts implements CategoryRepository.
+func (a *CategoryRepositoryImpl) ReassignProducts(fromId int64, toId int64, tx *gorm.DB) error {
+	db := database.DB
+	if tx != nil {
+		db = tx
+	}
+
+	return db.Model(&models.Product{}).
+		Where("category_id = ?", fromId).
+		Update("category_id", toId).Error
 }
 
 // FindAll implements CategoryRepository.
@@ -70,7 +108,7 @@ func (a *CategoryRepositoryImpl) FindAll(param models.CategoryListRequest) ([]mo
 
 	var Categories []models.Category
 	db := database.DB.
-		Select("id", "name", "icon", "created_at", "updated_at", "deleted_at")
+		Select("id", "parent_id", "name", "slug", "sort_order", "icon", "return_window_days", "tax_class_id", "created_at", "updated_at", "deleted_at")
 
 	if param.Search != "" {
 		db = db.Where("name ILIKE ?", "%"+param.Search+"%")
@@ -101,7 +139,7 @@ func (a *CategoryRepositoryImpl) FindAll(param models.CategoryListRequest) ([]mo
 func (a *CategoryRepositoryImpl) FindById(paramId int64) (models.Category, error) {
 	Category := models.Category{}
 	err := database.DB.
-		Select("id", "name", "icon", "created_at", "updated_at", "deleted_at").
+		Select("id", "parent_id", "name", "slug", "sort_order", "icon", "return_window_days", "tax_class_id", "created_at", "updated_at", "deleted_at").
 		First(&Category, "id = ?", paramId).Error
 
 	return Category, err
@@ -116,17 +154,81 @@ func (a *CategoryRepositoryImpl) Update(param models.Category, tx *gorm.DB) (mod
 		db = tx
 	}
 
+	// A parent of 0 moves the category to the root, which Updates would
+	// store as 0 rather than NULL.
+	parentID := param.ParentID
+	param.ParentID = nil
+
 	err := db.Model(&param).Updates(param).Error
 	if err != nil {
 		return result, err
 	}
 
+	if parentID != nil {
+		var parent interface{}
+		if *parentID != 0 {
+			parent = *parentID
+		}
+		err = db.Model(&param).Update("parent_id", parent).Error
+		if err != nil {
+			return result, err
+		}
+	}
+
 	err = db.
-		Select("id", "name", "icon", "created_at", "updated_at", "deleted_at").
+		Select("id", "parent_id", "name", "slug", "sort_order", "icon", "return_window_days", "tax_class_id", "created_at", "updated_at", "deleted_at").
 		First(&result, param.ID).Error
 	return result, err
 }
 
+// FindBySlug implements CategoryRepository.
+func (a *CategoryRepositoryImpl) FindBySlug(slug string) (models.Category, error) {
+	Category := models.Category{}
+	err := database.DB.
+		Select("id", "parent_id", "name", "slug", "sort_order", "icon", "return_window_days", "tax_class_id", "created_at", "updated_at", "deleted_at").
+		First(&Category, "slug = ?", slug).Error
+
+	return Category, err
+}
+
+// SlugExists implements CategoryRepository. Deleted categories keep their
+// slug in the unique index, so they are counted too.
+func (a *CategoryRepositoryImpl) SlugExists(slug string, excludeId int64) (bool, error) {
+	var total int64
+	err := database.DB.Unscoped().Model(&models.Category{}).
+		Where("slug = ? AND id <> ?", slug, excludeId).
+		Count(&total).Error
+
+	return total > 0, err
+}
+
+// FindAllTree implements CategoryRepository. It loads every category in
+// tree order for building the category tree.
+func (a *CategoryRepositoryImpl) FindAllTree() ([]models.Category, error) {
+	var categories []models.Category
+	err := database.DB.
+		Select("id", "parent_id", "name", "slug", "sort_order", "icon", "return_window_days", "tax_class_id", "created_at", "updated_at", "deleted_at").
+		Order("sort_order asc, name asc").
+		Find(&categories).Error
+
+	return categories, err
+}
+
+// CountChildren implements CategoryRepository.
+func (a *CategoryRepositoryImpl) CountChildren(categoryId int64, tx *gorm.DB) (int64, error) {
+	db := database.DB
+	if tx != nil {
+		db = tx
+	}
+
+	var total int64
+	err := db.Model(&models.Category{}).
+		Where("parent_id = ?", categoryId).
+		Count(&total).Error
+
+	return total, err
+}
+
 func NewCategoryRepository() CategoryRepository {
 	return &CategoryRepositoryImpl{}
 }
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CurrencyRepository interface {
+	Create(param models.Currency, tx *gorm.DB) (models.Currency, error)
+	Update(param models.Currency, tx *gorm.DB) (models.Currency, error)
+	Delete(code string, tx *gorm.DB) error
+	FindByCode(code string) (*models.Currency, error)
+	FindAll(activeOnly bool) ([]models.Currency, error)
+}
+
+type CurrencyRepositoryImpl struct {
+}
+
+// Create implements CurrencyRepository.
+func (r *CurrencyRepositoryImpl) Create(param models.Currency, tx *gorm.DB) (models.Currency, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// Update implements CurrencyRepository.
+func (r *CurrencyRepositoryImpl) Update(param models.Currency, tx *gorm.DB) (models.Currency, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("name", "symbol", "rate", "is_active", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// Delete implements CurrencyRepository. Transactions keep the code and rate
+// they were placed with, so currencies can be removed outright.
+func (r *CurrencyRepositoryImpl) Delete(code string, tx *gorm.DB) error {
+	return getDB(tx).Where("code = ?", code).Delete(&models.Currency{}).Error
+}
+
+// FindByCode implements CurrencyRepository.
+func (r *CurrencyRepositoryImpl) FindByCode(code string) (*models.Currency, error) {
+	var currency models.Currency
+	if err := database.DB.Where("code = ?", code).First(&currency).Error; err != nil {
+		return nil, err
+	}
+	return &currency, nil
+}
+
+// FindAll implements CurrencyRepository.
+func (r *CurrencyRepositoryImpl) FindAll(activeOnly bool) ([]models.Currency, error) {
+	var currencies []models.Currency
+	query := database.DB.Order("code ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Find(&currencies).Error
+	return currencies, err
+}
+
+func NewCurrencyRepository() CurrencyRepository {
+	return &CurrencyRepositoryImpl{}
+}
@@ -20,10 +20,11 @@ type DashboardRepository interface {
 	CountTotalRoles(ctx context.Context) (int64, error)
 
 	// Revenue
-	GetTotalRevenue(ctx context.Context) (float64, error)
-	GetRevenueByPeriod(ctx context.Context, startDate, endDate time.Time) (float64, error)
-	GetRevenueToday(ctx context.Context) (float64, error)
-	GetRevenueThisMonth(ctx context.Context) (float64, error)
+	GetTotalRevenue(ctx context.Context) (models.Money, error)
+	GetRevenueByPeriod(ctx context.Context, startDate, endDate time.Time) (models.Money, error)
+	GetRevenueToday(ctx context.Context) (models.Money, error)
+	GetRevenueThisMonth(ctx context.Context) (models.Money, error)
+	GetTaxByPeriod(ctx context.Context, startDate, endDate time.Time) (models.Money, error)
 
 	// Orders
 	GetRecentOrders(ctx context.Context, limit int) ([]models.Order, error)
@@ -33,6 +34,7 @@ type DashboardRepository interface {
 	// Products
 	GetTopSellingProducts(ctx context.Context, limit int) ([]models.TopProduct, error)
 	GetLowStockProducts(ctx context.Context, threshold int, limit int) ([]models.LowStockProduct, error)
+	GetMostWishedProducts(ctx context.Context, since time.Time, limit int) ([]models.MostWishedProduct, error)
 
 	// Users
 	GetNewUsersCount(ctx context.Context, startDate time.Time) (int64, error)
@@ -103,40 +105,86 @@ func (r *dashboardRepository) CountTotalRoles(ctx context.Context) (int64, error
 
 // ==================== Revenue Methods ====================
 
-func (r *dashboardRepository) GetTotalRevenue(ctx context.Context) (float64, error) {
-	var total float64
+// Revenue is net of refunds: the full amount of every refund of a
+// transaction whose orders count as revenue is subtracted in the period it
+// was issued, not the period of the original order. Refunds given as a
+// plain amount, with no item lines, lower revenue the same way.
+func (r *dashboardRepository) GetTotalRevenue(ctx context.Context) (models.Money, error) {
+	var total models.Money
 	err := r.db.WithContext(ctx).
 		Model(&models.Order{}).
 		Where("status IN ?", []string{"completed", "paid"}).
 		Select("COALESCE(SUM(subtotal), 0)").
 		Scan(&total).Error
-	return total, err
+	if err != nil {
+		return 0, err
+	}
+
+	var refunded models.Money
+	err = r.revenueRefunds(ctx).
+		Select("COALESCE(SUM(rf.amount), 0)").
+		Scan(&refunded).Error
+	return total - refunded, err
 }
 
-func (r *dashboardRepository) GetRevenueByPeriod(ctx context.Context, startDate, endDate time.Time) (float64, error) {
-	var total float64
+func (r *dashboardRepository) GetRevenueByPeriod(ctx context.Context, startDate, endDate time.Time) (models.Money, error) {
+	var total models.Money
 	err := r.db.WithContext(ctx).
 		Model(&models.Order{}).
 		Where("status IN ? AND created_at BETWEEN ? AND ?",
 			[]string{"completed", "paid"}, startDate, endDate).
 		Select("COALESCE(SUM(subtotal), 0)").
 		Scan(&total).Error
-	return total, err
+	if err != nil {
+		return 0, err
+	}
+
+	var refunded models.Money
+	err = r.revenueRefunds(ctx).
+		Where("rf.created_at BETWEEN ? AND ?", startDate, endDate).
+		Select("COALESCE(SUM(rf.amount), 0)").
+		Scan(&refunded).Error
+	return total - refunded, err
+}
+
+// revenueRefunds selects the refunds of transactions that have paid or
+// completed orders, the same orders revenue is summed over. A fully refunded
+// transaction keeps its orders paid, so its refunds are still subtracted.
+func (r *dashboardRepository) revenueRefunds(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("refunds rf").
+		Where("EXISTS (SELECT 1 FROM orders o WHERE o.transaction_id = rf.transaction_id AND o.status IN ? AND o.deleted_at IS NULL)",
+			[]string{"completed", "paid"})
 }
 
-func (r *dashboardRepository) GetRevenueToday(ctx context.Context) (float64, error) {
+func (r *dashboardRepository) GetRevenueToday(ctx context.Context) (models.Money, error) {
 	today := time.Now().Truncate(24 * time.Hour)
 	tomorrow := today.Add(24 * time.Hour)
 	return r.GetRevenueByPeriod(ctx, today, tomorrow)
 }
 
-func (r *dashboardRepository) GetRevenueThisMonth(ctx context.Context) (float64, error) {
+func (r *dashboardRepository) GetRevenueThisMonth(ctx context.Context) (models.Money, error) {
 	now := time.Now()
 	firstDay := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	lastDay := firstDay.AddDate(0, 1, 0)
 	return r.GetRevenueByPeriod(ctx, firstDay, lastDay)
 }
 
+// GetTaxByPeriod sums the tax charged on paid and completed orders placed
+// between startDate and endDate. A zero startDate sums all time.
+func (r *dashboardRepository) GetTaxByPeriod(ctx context.Context, startDate, endDate time.Time) (models.Money, error) {
+	var total models.Money
+	db := r.db.WithContext(ctx).
+		Model(&models.Order{}).
+		Where("status IN ?", []string{"completed", "paid"})
+	if !startDate.IsZero() {
+		db = db.Where("created_at BETWEEN ? AND ?", startDate, endDate)
+	}
+
+	err := db.Select("COALESCE(SUM(tax_amount), 0)").Scan(&total).Error
+	return total, err
+}
+
 // ==================== Orders Methods ====================
 
 func (r *dashboardRepository) GetRecentOrders(ctx context.Context, limit int) ([]models.Order, error) {
@@ -173,8 +221,32 @@ func (r *dashboardRepository) GetOrderAnalytics(ctx context.Context, days int) (
 		Group("DATE(created_at)").
 		Order("DATE(created_at)").
 		Scan(&analytics).Error
+	if err != nil {
+		return nil, err
+	}
 
-	return analytics, err
+	var refunds []struct {
+		Date   string
+		Amount models.Money
+	}
+	err = r.revenueRefunds(ctx).
+		Select("DATE(rf.created_at) as date, COALESCE(SUM(rf.amount), 0) as amount").
+		Where("rf.created_at >= ?", startDate).
+		Group("DATE(rf.created_at)").
+		Scan(&refunds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refundsByDate := make(map[string]models.Money, len(refunds))
+	for _, refund := range refunds {
+		refundsByDate[refund.Date] = refund.Amount
+	}
+	for i := range analytics {
+		analytics[i].Revenue -= refundsByDate[analytics[i].Date]
+	}
+
+	return analytics, nil
 }
 
 // ==================== Products Methods ====================
@@ -249,6 +321,37 @@ func (r *dashboardRepository) GetLowStockProducts(
 	return products, nil
 }
 
+// GetMostWishedProducts - Get products by the number of customers who have
+// them in their wishlist, with how many saved them since the given date
+func (r *dashboardRepository) GetMostWishedProducts(ctx context.Context, since time.Time, limit int) ([]models.MostWishedProduct, error) {
+	log.Printf("🗄️  DashboardRepo.GetMostWishedProducts - Since: %s, Limit: %d", since.Format("2006-01-02"), limit)
+
+	var products []models.MostWishedProduct
+
+	err := r.db.WithContext(ctx).
+		Table("wishlists w").
+		Select(`
+			p.id AS product_id,
+			p.name AS product_name,
+			COUNT(DISTINCT w.user_id) AS wishlist_count,
+			COUNT(DISTINCT CASE WHEN w.created_at >= ? THEN w.user_id END) AS recent_count
+		`, since).
+		Joins("JOIN products p ON p.id = w.product_id").
+		Where("p.deleted_at IS NULL").
+		Group("p.id, p.name").
+		Order("wishlist_count DESC, recent_count DESC").
+		Limit(limit).
+		Scan(&products).Error
+
+	if err != nil {
+		log.Printf("❌ GetMostWishedProducts ERROR: %v", err)
+		return nil, err
+	}
+
+	log.Printf("✅ Found %d most wished products", len(products))
+	return products, nil
+}
+
 // ==================== Users Methods ====================
 
 func (r *dashboardRepository) GetNewUsersCount(ctx context.Context, startDate time.Time) (int64, error) {
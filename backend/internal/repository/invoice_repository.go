@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepository interface {
+	Create(param models.Invoice, tx *gorm.DB) (models.Invoice, error)
+	FindByTransaction(transactionID string, tx *gorm.DB) (models.Invoice, error)
+	NextSequence(tx *gorm.DB, year int) (int64, error)
+	FindTransaction(transactionID string) (models.Transaction, error)
+	FindTransactionOwner(transactionID string) (int64, error)
+}
+
+type InvoiceRepositoryImpl struct {
+}
+
+// Create implements InvoiceRepository.
+func (r *InvoiceRepositoryImpl) Create(param models.Invoice, tx *gorm.DB) (models.Invoice, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// FindByTransaction implements InvoiceRepository.
+func (r *InvoiceRepositoryImpl) FindByTransaction(transactionID string, tx *gorm.DB) (models.Invoice, error) {
+	var invoice models.Invoice
+	err := getDB(tx).
+		Where("transaction_id = ?", transactionID).
+		First(&invoice).Error
+	return invoice, err
+}
+
+// NextSequence implements InvoiceRepository. The year's row stays locked
+// until tx ends, so concurrent requests cannot take the same number and a
+// rolled back invoice leaves no gap.
+func (r *InvoiceRepositoryImpl) NextSequence(tx *gorm.DB, year int) (int64, error) {
+	err := tx.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.InvoiceSequence{Year: year}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var sequence models.InvoiceSequence
+	err = tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("year = ?", year).
+		First(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+
+	sequence.LastNumber++
+	err = tx.
+		Model(&sequence).
+		Where("year = ?", year).
+		Update("last_number", sequence.LastNumber).Error
+	return sequence.LastNumber, err
+}
+
+// FindTransaction implements InvoiceRepository. It loads everything printed
+// on invoices and packing slips.
+func (r *InvoiceRepositoryImpl) FindTransaction(transactionID string) (models.Transaction, error) {
+	var transaction models.Transaction
+	err := database.DB.
+		Preload("Address").
+		Preload("Shipping").
+		Preload("PaymentMethod").
+		Preload("TaxLines").
+		Preload("Orders", func(db *gorm.DB) *gorm.DB {
+			return db.
+				Order("created_at ASC, id ASC").
+				Preload("Product", func(db *gorm.DB) *gorm.DB {
+					return db.Select("id", "name", "weight_grams")
+				}).
+				Preload("ColorVarian", func(db *gorm.DB) *gorm.DB {
+					return db.Select("id", "name", "color")
+				}).
+				Preload("SizeVarian", func(db *gorm.DB) *gorm.DB {
+					return db.Select("id", "size")
+				})
+		}).
+		Where("tx_id = ?", transactionID).
+		First(&transaction).Error
+	return transaction, err
+}
+
+// FindTransactionOwner implements InvoiceRepository. A transaction belongs
+// to the user who placed its orders.
+func (r *InvoiceRepositoryImpl) FindTransactionOwner(transactionID string) (int64, error) {
+	var order models.Order
+	err := database.DB.
+		Select("user_id").
+		Where("transaction_id = ?", transactionID).
+		First(&order).Error
+	return order.UserID, err
+}
+
+func NewInvoiceRepository() InvoiceRepository {
+	return &InvoiceRepositoryImpl{}
+}
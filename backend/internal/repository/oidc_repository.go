@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OIDCRepository interface {
+	CreateState(param models.OIDCState) (models.OIDCState, error)
+	ConsumeState(state string) (models.OIDCState, error)
+	FindIdentity(provider string, subject string) (models.UserIdentity, error)
+	CreateIdentity(param models.UserIdentity, tx *gorm.DB) (models.UserIdentity, error)
+}
+
+type OIDCRepositoryImpl struct {
+}
+
+// CreateState implements OIDCRepository. Expired states are cleaned up on
+// the way.
+func (o *OIDCRepositoryImpl) CreateState(param models.OIDCState) (models.OIDCState, error) {
+	if err := database.DB.Where("expires_at < ?", time.Now()).Delete(&models.OIDCState{}).Error; err != nil {
+		return param, err
+	}
+
+	err := database.DB.Create(&param).Error
+	return param, err
+}
+
+// ConsumeState implements OIDCRepository. The state is deleted in the same
+// transaction it is read in, so a replayed callback finds nothing.
+func (o *OIDCRepositoryImpl) ConsumeState(state string) (models.OIDCState, error) {
+	var result models.OIDCState
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state = ?", state).Take(&result).Error; err != nil {
+			return err
+		}
+
+		deleted := tx.Where("id = ?", result.ID).Delete(&models.OIDCState{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		if deleted.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	return result, err
+}
+
+// FindIdentity implements OIDCRepository.
+func (o *OIDCRepositoryImpl) FindIdentity(provider string, subject string) (models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := database.DB.
+		Where("provider = ? AND subject = ?", provider, subject).
+		Take(&identity).Error
+	return identity, err
+}
+
+// CreateIdentity implements OIDCRepository.
+func (o *OIDCRepositoryImpl) CreateIdentity(param models.UserIdentity, tx *gorm.DB) (models.UserIdentity, error) {
+	db := database.DB
+	if tx != nil {
+		db = tx
+	}
+
+	err := db.Create(&param).Error
+	return param, err
+}
+
+func NewOIDCRepository() OIDCRepository {
+	return &OIDCRepositoryImpl{}
+}
@@ -25,7 +25,7 @@ func (a *OrderRepositoryImpl) FindAllByTxId(txId string) ([]models.Order, error)
 	db := database.DB
 
 	if err := db.
-		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "created_at", "updated_at").
+		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "tax_rate", "tax_amount", "created_at", "updated_at").
 		Preload("Product", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "category_id", "name", "description", "created_at", "updated_at")
 		}).
@@ -33,7 +33,7 @@ func (a *OrderRepositoryImpl) FindAllByTxId(txId string) ([]models.Order, error)
 			return db.Select("id", "product_id", "name", "color", "images", "created_at", "updated_at")
 		}).
 		Preload("SizeVarian", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at")
+			return db.Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at")
 		}).
 		Where("transaction_id = ?", txId).
 		Find(&Orders).Error; err != nil {
@@ -58,7 +58,7 @@ func (a *OrderRepositoryImpl) Create(param models.Order, tx *gorm.DB) (models.Or
 	}
 
 	err = db.
-		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "created_at", "updated_at").
+		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "tax_rate", "tax_amount", "created_at", "updated_at").
 		First(&result, "id = ?", param.ID).Error
 
 	return result, err
@@ -75,7 +75,7 @@ func (a *OrderRepositoryImpl) FindAll(param models.OrderListRequest) ([]models.O
 
 	var Orders []models.Order
 	db := database.DB.
-		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "created_at", "updated_at")
+		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "tax_rate", "tax_amount", "created_at", "updated_at")
 
 	if param.SortBy != "" {
 		db = db.Order(param.SortBy)
@@ -97,7 +97,7 @@ func (a *OrderRepositoryImpl) FindAll(param models.OrderListRequest) ([]models.O
 			return db.Select("id", "product_id", "name", "color", "images", "created_at", "updated_at")
 		}).
 		Preload("SizeVarian", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at")
+			return db.Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at")
 		}).
 		Find(&Orders).Error; err != nil {
 		return nil, err
@@ -110,7 +110,7 @@ func (a *OrderRepositoryImpl) FindAll(param models.OrderListRequest) ([]models.O
 func (a *OrderRepositoryImpl) FindById(paramId string) (models.Order, error) {
 	Order := models.Order{}
 	err := database.DB.
-		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "created_at", "updated_at").
+		Select("id", "user_id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "tax_rate", "tax_amount", "status", "delivered_at", "created_at", "updated_at").
 		Preload("Product", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "category_id", "name", "description", "created_at", "updated_at")
 		}).
@@ -118,7 +118,7 @@ func (a *OrderRepositoryImpl) FindById(paramId string) (models.Order, error) {
 			return db.Select("id", "product_id", "name", "color", "images", "created_at", "updated_at")
 		}).
 		Preload("SizeVarian", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at")
+			return db.Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at")
 		}).
 		First(&Order, "id = ?", paramId).Error
 
@@ -140,7 +140,7 @@ func (a *OrderRepositoryImpl) Update(param models.Order, tx *gorm.DB) (models.Or
 	}
 
 	err = db.
-		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "created_at", "updated_at").
+		Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "tax_rate", "tax_amount", "created_at", "updated_at").
 		First(&result, "id = ?", param.ID).Error
 
 	return result, err
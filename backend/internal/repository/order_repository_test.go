@@ -63,8 +63,8 @@ func TestOrderRepository_CRUD(t *testing.T) {
 		AddressID:       address.ID,
 		ShippingID:      shipping.ID,
 		PaymentMethodID: pm.ID,
-		TotalPrice:      100.0,
-		ShippingPrice:   10.0,
+		TotalPrice:      models.Rupiah(100),
+		ShippingPrice:   models.Rupiah(10),
 		Status:          "pending",
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
@@ -79,8 +79,8 @@ func TestOrderRepository_CRUD(t *testing.T) {
 		ColorVarianID: colorParams.ID,
 		SizeVarianID:  sizeParams.ID,
 		Quantity:      1,
-		UnitPrice:     100.0,
-		Subtotal:      100.0,
+		UnitPrice:     models.Rupiah(100),
+		Subtotal:      models.Rupiah(100),
 		Status:        "pending",
 	}
 
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+)
+
+type PasswordHistoryRepository interface {
+	Create(param models.PasswordHistory) (models.PasswordHistory, error)
+	FindRecent(userID uint, limit int) ([]models.PasswordHistory, error)
+	DeleteOlderThanRecent(userID uint, keep int) error
+}
+
+type PasswordHistoryRepositoryImpl struct {
+}
+
+// Create implements PasswordHistoryRepository.
+func (p *PasswordHistoryRepositoryImpl) Create(param models.PasswordHistory) (models.PasswordHistory, error) {
+	err := database.DB.Create(&param).Error
+	return param, err
+}
+
+// FindRecent implements PasswordHistoryRepository.
+func (p *PasswordHistoryRepositoryImpl) FindRecent(userID uint, limit int) ([]models.PasswordHistory, error) {
+	var histories []models.PasswordHistory
+	err := database.DB.
+		Where("user_id = ?", userID).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&histories).Error
+	return histories, err
+}
+
+// DeleteOlderThanRecent implements PasswordHistoryRepository. It keeps the
+// newest keep entries of the user and removes the rest.
+func (p *PasswordHistoryRepositoryImpl) DeleteOlderThanRecent(userID uint, keep int) error {
+	var keepIDs []uint
+	if err := database.DB.
+		Model(&models.PasswordHistory{}).
+		Where("user_id = ?", userID).
+		Order("created_at desc, id desc").
+		Limit(keep).
+		Pluck("id", &keepIDs).Error; err != nil {
+		return err
+	}
+
+	query := database.DB.Where("user_id = ?", userID)
+	if len(keepIDs) > 0 {
+		query = query.Where("id NOT IN ?", keepIDs)
+	}
+	return query.Delete(&models.PasswordHistory{}).Error
+}
+
+func NewPasswordHistoryRepository() PasswordHistoryRepository {
+	return &PasswordHistoryRepositoryImpl{}
+}
@@ -7,6 +7,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// paymentMethodColumns are the columns read whenever a payment method is loaded
+var paymentMethodColumns = []string{"id", "type", "bank_name", "account_number", "account_name", "is_active", "bank_images", "va_prefix", "qris_payload", "fee_flat", "fee_percent", "min_amount", "max_amount", "instructions", "created_at", "updated_at"}
+
 type PaymentMethodRepository interface {
 	Create(param models.PaymentMethod, tx *gorm.DB) (models.PaymentMethod, error)
 	Update(param models.PaymentMethod, tx *gorm.DB) (models.PaymentMethod, error)
@@ -33,7 +36,7 @@ func (a *PaymentMethodRepositoryImpl) Create(param models.PaymentMethod, tx *gor
 	}
 
 	err = db.
-		Select("id", "bank_name", "account_number", "account_name", "is_active", "bank_images", "created_at", "updated_at").
+		Select(paymentMethodColumns).
 		First(&result, param.ID).Error
 	return result, err
 }
@@ -49,7 +52,7 @@ func (a *PaymentMethodRepositoryImpl) FindAll(param models.PaymentMethodListRequ
 
 	var PaymentMethods []models.PaymentMethod
 	db := database.DB.
-		Select("id", "bank_name", "account_number", "account_name", "is_active", "bank_images", "created_at", "updated_at")
+		Select(paymentMethodColumns)
 
 	if param.SortBy != "" {
 		db = db.Order(param.SortBy)
@@ -74,7 +77,7 @@ func (a *PaymentMethodRepositoryImpl) FindAll(param models.PaymentMethodListRequ
 func (a *PaymentMethodRepositoryImpl) FindById(paramId uint) (*models.PaymentMethod, error) {
 	PaymentMethod := models.PaymentMethod{}
 	err := database.DB.
-		Select("id", "bank_name", "account_number", "account_name", "is_active", "bank_images", "created_at", "updated_at").
+		Select(paymentMethodColumns).
 		First(&PaymentMethod, paramId).Error
 
 	if err != nil {
@@ -104,7 +107,7 @@ func (a *PaymentMethodRepositoryImpl) Update(param models.PaymentMethod, tx *gor
 	}
 
 	err = db.
-		Select("id", "bank_name", "account_number", "account_name", "is_active", "bank_images", "created_at", "updated_at").
+		Select(paymentMethodColumns).
 		First(&result, param.ID).Error
 
 	return result, err
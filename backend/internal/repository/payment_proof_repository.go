@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentProofRepository interface {
+	Create(param models.PaymentProof, tx *gorm.DB) (models.PaymentProof, error)
+	Update(param models.PaymentProof, tx *gorm.DB) (models.PaymentProof, error)
+	FindById(id int64) (models.PaymentProof, error)
+	FindByIdLocked(tx *gorm.DB, id int64) (*models.PaymentProof, error)
+	FindAll(param models.PaymentProofListRequest) ([]models.PaymentProof, int64, error)
+	CountPending(transactionID string, tx *gorm.DB) (int64, error)
+	FindOpenPaymentLocked(tx *gorm.DB, transactionID string) (*models.Payment, error)
+	FindPaymentLocked(tx *gorm.DB, id int64) (*models.Payment, error)
+	FindTransactionOwner(transactionID string) (int64, error)
+}
+
+type PaymentProofRepositoryImpl struct {
+}
+
+// Create implements PaymentProofRepository.
+func (r *PaymentProofRepositoryImpl) Create(param models.PaymentProof, tx *gorm.DB) (models.PaymentProof, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// Update implements PaymentProofRepository.
+func (r *PaymentProofRepositoryImpl) Update(param models.PaymentProof, tx *gorm.DB) (models.PaymentProof, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("status", "rejection_reason", "reviewed_by", "reviewed_at", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// FindById implements PaymentProofRepository.
+func (r *PaymentProofRepositoryImpl) FindById(id int64) (models.PaymentProof, error) {
+	var proof models.PaymentProof
+	err := database.DB.First(&proof, id).Error
+	return proof, err
+}
+
+// FindByIdLocked implements PaymentProofRepository. Locking the proof keeps
+// two admins from reviewing it at the same time.
+func (r *PaymentProofRepositoryImpl) FindByIdLocked(tx *gorm.DB, id int64) (*models.PaymentProof, error) {
+	var proof models.PaymentProof
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&proof, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// FindAll implements PaymentProofRepository. Pending proofs are listed
+// oldest first, as a review queue; others newest first.
+func (r *PaymentProofRepositoryImpl) FindAll(param models.PaymentProofListRequest) ([]models.PaymentProof, int64, error) {
+	offset := (param.Page - 1) * param.Limit
+
+	query := database.DB.Model(&models.PaymentProof{})
+	if param.UserID > 0 {
+		query = query.Where("user_id = ?", param.UserID)
+	}
+	if param.TransactionID != "" {
+		query = query.Where("transaction_id = ?", param.TransactionID)
+	}
+	if param.Status != "" {
+		query = query.Where("status = ?", param.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "created_at desc"
+	if param.Status == "pending" {
+		order = "created_at asc"
+	}
+
+	var proofs []models.PaymentProof
+	err := query.
+		Order(order).
+		Offset(offset).
+		Limit(param.Limit).
+		Find(&proofs).Error
+	return proofs, total, err
+}
+
+// CountPending implements PaymentProofRepository.
+func (r *PaymentProofRepositoryImpl) CountPending(transactionID string, tx *gorm.DB) (int64, error) {
+	var count int64
+	err := getDB(tx).
+		Model(&models.PaymentProof{}).
+		Where("transaction_id = ? AND status = ?", transactionID, "pending").
+		Count(&count).Error
+	return count, err
+}
+
+// FindOpenPaymentLocked implements PaymentProofRepository. It returns the
+// latest payment of the transaction still waiting for a proof, i.e. pending
+// or failed after a rejected proof.
+func (r *PaymentProofRepositoryImpl) FindOpenPaymentLocked(tx *gorm.DB, transactionID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.
+		Select("id", "transaction_id", "total_payment", "status", "created_at", "updated_at").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("transaction_id = ? AND status IN ?", transactionID, []string{"pending", "failed"}).
+		Order("id desc").
+		First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindPaymentLocked implements PaymentProofRepository.
+func (r *PaymentProofRepositoryImpl) FindPaymentLocked(tx *gorm.DB, id int64) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.
+		Select("id", "transaction_id", "total_payment", "status", "created_at", "updated_at").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&payment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindTransactionOwner implements PaymentProofRepository. A transaction
+// belongs to the user who placed its orders.
+func (r *PaymentProofRepositoryImpl) FindTransactionOwner(transactionID string) (int64, error) {
+	var order models.Order
+	err := database.DB.
+		Select("user_id").
+		Where("transaction_id = ?", transactionID).
+		First(&order).Error
+	return order.UserID, err
+}
+
+func NewPaymentProofRepository() PaymentProofRepository {
+	return &PaymentProofRepositoryImpl{}
+}
@@ -33,6 +33,8 @@ type ProductRepository interface {
 	FindSizeVarianByColorVarianId(colorVarianId int64, tx *gorm.DB) ([]models.SizeVarian, error)
 	FindByNameAndCategory(name string, categoryID int64, tx *gorm.DB) (*models.Product, error)
 	FindSizeVarianLocked(tx *gorm.DB, id uint) (*models.SizeVarian, error)
+	FindSizeVarianBySKU(sku string, tx *gorm.DB) (*models.SizeVarian, error)
+	FindSizeVarianByBarcode(barcode string, tx *gorm.DB) (*models.SizeVarian, error)
 }
 
 type ProductRepositoryImpl struct{}
@@ -44,7 +46,7 @@ func (r *ProductRepositoryImpl) FindSizeVarianLocked(tx *gorm.DB, id uint) (*mod
 	var sizeVarian models.SizeVarian
 
 	err := db.
-		Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at", "deleted_at").
+		Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at", "deleted_at").
 		Clauses(clause.Locking{Strength: "UPDATE"}).
 		First(&sizeVarian, id).Error
 
@@ -97,6 +99,10 @@ func (r *ProductRepositoryImpl) UpdateProduct(param models.Product, tx *gorm.DB)
 
 func (r *ProductRepositoryImpl) DeleteProduct(param int64, tx *gorm.DB) error {
 	db := getDB(tx)
+	colorIDs := db.Model(&models.ColorVarian{}).Select("id").Where("product_id = ?", param)
+	if err := releaseSizeCodes(db.Where("color_varian_id IN (?)", colorIDs)); err != nil {
+		return err
+	}
 	return db.Delete(&models.Product{}, param).Error
 }
 
@@ -107,7 +113,7 @@ func (r *ProductRepositoryImpl) FindProductById(id int64, tx *gorm.DB) (*models.
 
 	// Use preloads with the chosen db (tx or global)
 	err := db.
-		Select("id", "category_id", "name", "description", "images", "rating", "price", "created_at", "updated_at", "deleted_at").
+		Select("id", "category_id", "name", "description", "images", "rating", "price", "tax_class_id", "weight_grams", "length_cm", "width_cm", "height_cm", "created_at", "updated_at", "deleted_at").
 		Preload("ColorVarians", func(db *gorm.DB) *gorm.DB {
 			return db.
 				Select("id", "product_id", "name", "color", "images", "created_at", "updated_at", "deleted_at").
@@ -116,7 +122,7 @@ func (r *ProductRepositoryImpl) FindProductById(id int64, tx *gorm.DB) (*models.
 		}).
 		Preload("ColorVarians.SizeVarians", func(db *gorm.DB) *gorm.DB {
 			return db.
-				Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at", "deleted_at").
+				Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at", "deleted_at").
 				Where("deleted_at IS NULL").
 				Order("size ASC")
 		}).
@@ -142,7 +148,9 @@ func (r *ProductRepositoryImpl) FindAllProduct(param models.ProductListRequest,
 	query := db.Model(&models.Product{}).Where("deleted_at IS NULL")
 
 	// Filter by category
-	if param.CategoryID != 0 {
+	if len(param.CategoryIDs) > 0 {
+		query = query.Where("category_id IN ?", param.CategoryIDs)
+	} else if param.CategoryID != 0 {
 		query = query.Where("category_id = ?", param.CategoryID)
 	}
 
@@ -163,7 +171,7 @@ func (r *ProductRepositoryImpl) FindAllProduct(param models.ProductListRequest,
 		sortBy = param.SortBy
 	}
 	query = query.
-		Select("id", "category_id", "name", "description", "images", "rating", "price", "created_at", "updated_at", "deleted_at").
+		Select("id", "category_id", "name", "description", "images", "rating", "price", "tax_class_id", "weight_grams", "length_cm", "width_cm", "height_cm", "created_at", "updated_at", "deleted_at").
 		Order(sortBy)
 
 	// Pagination
@@ -199,6 +207,9 @@ func (r *ProductRepositoryImpl) UpdateColorVarian(param models.ColorVarian, tx *
 
 func (r *ProductRepositoryImpl) DeleteColorVarian(param int64, tx *gorm.DB) error {
 	db := getDB(tx)
+	if err := releaseSizeCodes(db.Where("color_varian_id = ?", param)); err != nil {
+		return err
+	}
 	return db.Delete(&models.ColorVarian{}, param).Error
 }
 
@@ -209,7 +220,7 @@ func (r *ProductRepositoryImpl) FindColorVarianById(id int64, tx *gorm.DB) (mode
 		Select("id", "product_id", "name", "color", "images", "created_at", "updated_at", "deleted_at").
 		Preload("SizeVarians", func(db *gorm.DB) *gorm.DB {
 			return db.
-				Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at", "deleted_at").
+				Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at", "deleted_at").
 				Where("deleted_at IS NULL")
 		}).
 		First(&cv, "id = ? AND deleted_at IS NULL", id).Error
@@ -244,7 +255,7 @@ func (r *ProductRepositoryImpl) FindAllColorVarian(param models.ColorVarianListR
 
 	if err := q.Preload("SizeVarians", func(db *gorm.DB) *gorm.DB {
 		return db.
-			Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at", "deleted_at").
+			Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at", "deleted_at").
 			Where("deleted_at IS NULL")
 	}).Find(&list).Error; err != nil {
 		return nil, err
@@ -277,6 +288,9 @@ func (r *ProductRepositoryImpl) UpdateSizeVarian(param models.SizeVarian, tx *go
 
 func (r *ProductRepositoryImpl) DeleteSizeVarian(param int64, tx *gorm.DB) error {
 	db := getDB(tx)
+	if err := releaseSizeCodes(db.Where("id = ?", param)); err != nil {
+		return err
+	}
 	return db.Delete(&models.SizeVarian{}, param).Error
 }
 
@@ -284,7 +298,7 @@ func (r *ProductRepositoryImpl) FindSizeVarianById(id int64, tx *gorm.DB) (model
 	db := getDB(tx)
 	var sv models.SizeVarian
 	err := db.
-		Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at", "deleted_at").
+		Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at", "deleted_at").
 		First(&sv, "id = ? AND deleted_at IS NULL", id).Error
 	return sv, err
 }
@@ -296,7 +310,7 @@ func (r *ProductRepositoryImpl) FindAllSizeVarian(param models.SizeVarianListReq
 
 	var list []models.SizeVarian
 	q := db.Model(&models.SizeVarian{}).
-		Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at", "deleted_at")
+		Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at", "deleted_at")
 
 	if param.ColorVarianID != 0 {
 		q = q.Where("color_varian_id = ?", param.ColorVarianID)
@@ -328,6 +342,41 @@ func (r *ProductRepositoryImpl) FindSizeVarianByColorVarianId(colorVarianId int6
 	return r.FindAllSizeVarian(req, tx)
 }
 
+// FindSizeVarianBySKU finds the live size variant with the SKU, skipping
+// sizes whose color or product was deleted.
+func (r *ProductRepositoryImpl) FindSizeVarianBySKU(sku string, tx *gorm.DB) (*models.SizeVarian, error) {
+	return findLiveSizeVarian(getDB(tx), "size_varians.sku = ?", sku)
+}
+
+// FindSizeVarianByBarcode finds the live size variant with the barcode,
+// like FindSizeVarianBySKU.
+func (r *ProductRepositoryImpl) FindSizeVarianByBarcode(barcode string, tx *gorm.DB) (*models.SizeVarian, error) {
+	return findLiveSizeVarian(getDB(tx), "size_varians.barcode = ?", barcode)
+}
+
+func findLiveSizeVarian(db *gorm.DB, query string, args ...interface{}) (*models.SizeVarian, error) {
+	var sv models.SizeVarian
+	err := db.
+		Select("size_varians.id", "size_varians.color_varian_id", "size_varians.size", "size_varians.stock", "size_varians.sku", "size_varians.barcode", "size_varians.price", "size_varians.created_at", "size_varians.updated_at", "size_varians.deleted_at").
+		Joins("JOIN color_varians ON color_varians.id = size_varians.color_varian_id AND color_varians.deleted_at IS NULL").
+		Joins("JOIN products ON products.id = color_varians.product_id AND products.deleted_at IS NULL").
+		Where(query, args...).
+		First(&sv).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sv, nil
+}
+
+// releaseSizeCodes clears the SKU and barcode of the size variants matched
+// by db before they are soft deleted, so the codes can be given to other
+// variants.
+func releaseSizeCodes(db *gorm.DB) error {
+	return db.Model(&models.SizeVarian{}).
+		Where("sku IS NOT NULL OR barcode IS NOT NULL").
+		Updates(map[string]interface{}{"sku": nil, "barcode": nil}).Error
+}
+
 func NewProductRepository() ProductRepository {
 	return &ProductRepositoryImpl{}
 }
@@ -22,7 +22,7 @@ func TestProductRepository_ProductOperations(t *testing.T) {
 		Name:        "Smartphone X",
 		Description: "Latest smartphone",
 		CategoryID:  category.ID,
-		Price:       999.99,
+		Price:       models.NewMoney(999.99),
 		Images:      "image.jpg",
 		Rating:      5.0,
 	}
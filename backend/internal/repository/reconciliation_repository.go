@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openTransactionStatuses are the statuses of transactions still waiting for
+// their money to arrive.
+var openTransactionStatuses = []string{utils.WaitingPayment, utils.WaitingConfirPayment}
+
+type ReconciliationRepository interface {
+	CreateStatement(param models.BankStatement, tx *gorm.DB) (models.BankStatement, error)
+	CreateLine(param models.BankStatementLine, tx *gorm.DB) (models.BankStatementLine, error)
+	UpdateLine(param models.BankStatementLine, tx *gorm.DB) (models.BankStatementLine, error)
+	FingerprintExists(fingerprint string, tx *gorm.DB) (bool, error)
+	FindLineByIdLocked(tx *gorm.DB, id int64) (*models.BankStatementLine, error)
+	FindAllLines(param models.StatementLineListRequest) ([]models.BankStatementLine, int64, error)
+	FindUsedUniqueCodes(tx *gorm.DB, paymentMethodID int64, base models.Money, max int64) ([]int64, error)
+	FindOpenTransactionsByAmount(tx *gorm.DB, paymentMethodID int64, amount models.Money) ([]models.Transaction, error)
+	FindOpenTransactionByReference(tx *gorm.DB, paymentMethodID int64, references []string) (*models.Transaction, error)
+	ApprovePendingProofs(tx *gorm.DB, transactionID string, reviewer uint) error
+}
+
+type ReconciliationRepositoryImpl struct {
+}
+
+// CreateStatement implements ReconciliationRepository.
+func (r *ReconciliationRepositoryImpl) CreateStatement(param models.BankStatement, tx *gorm.DB) (models.BankStatement, error) {
+	err := getDB(tx).Omit("Lines").Create(&param).Error
+	return param, err
+}
+
+// CreateLine implements ReconciliationRepository.
+func (r *ReconciliationRepositoryImpl) CreateLine(param models.BankStatementLine, tx *gorm.DB) (models.BankStatementLine, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// UpdateLine implements ReconciliationRepository.
+func (r *ReconciliationRepositoryImpl) UpdateLine(param models.BankStatementLine, tx *gorm.DB) (models.BankStatementLine, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("status", "transaction_id", "payment_id", "resolved_by", "resolved_at", "note", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// FingerprintExists implements ReconciliationRepository.
+func (r *ReconciliationRepositoryImpl) FingerprintExists(fingerprint string, tx *gorm.DB) (bool, error) {
+	var count int64
+	err := getDB(tx).
+		Model(&models.BankStatementLine{}).
+		Where("fingerprint = ?", fingerprint).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindLineByIdLocked implements ReconciliationRepository.
+func (r *ReconciliationRepositoryImpl) FindLineByIdLocked(tx *gorm.DB, id int64) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&line, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &line, nil
+}
+
+// FindAllLines implements ReconciliationRepository. Unmatched lines are
+// listed oldest first, as a work queue; others newest first.
+func (r *ReconciliationRepositoryImpl) FindAllLines(param models.StatementLineListRequest) ([]models.BankStatementLine, int64, error) {
+	offset := (param.Page - 1) * param.Limit
+
+	query := database.DB.Model(&models.BankStatementLine{})
+	if param.StatementID > 0 {
+		query = query.Where("statement_id = ?", param.StatementID)
+	}
+	if param.Status != "" {
+		query = query.Where("status = ?", param.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "booked_at desc, id desc"
+	if param.Status == "unmatched" {
+		order = "booked_at asc, id asc"
+	}
+
+	var lines []models.BankStatementLine
+	err := query.
+		Order(order).
+		Offset(offset).
+		Limit(param.Limit).
+		Find(&lines).Error
+	return lines, total, err
+}
+
+// FindUsedUniqueCodes implements ReconciliationRepository. A code is taken
+// when an open transaction to the same account already has base plus that
+// code as its payable amount.
+func (r *ReconciliationRepositoryImpl) FindUsedUniqueCodes(tx *gorm.DB, paymentMethodID int64, base models.Money, max int64) ([]int64, error) {
+	var amounts []models.Money
+	err := getDB(tx).
+		Model(&models.Transaction{}).
+		Where("payment_method_id = ? AND status IN ?", paymentMethodID, openTransactionStatuses).
+		Where("payable_amount > ? AND payable_amount <= ?", base, base+models.Rupiah(max)).
+		Pluck("payable_amount", &amounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]int64, len(amounts))
+	for i, amount := range amounts {
+		codes[i] = int64((amount - base).RoundRupiah() / models.Rupiah(1))
+	}
+	return codes, nil
+}
+
+// FindOpenTransactionsByAmount implements ReconciliationRepository.
+// Transactions from before unique codes are due their total price.
+func (r *ReconciliationRepositoryImpl) FindOpenTransactionsByAmount(tx *gorm.DB, paymentMethodID int64, amount models.Money) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	err := getDB(tx).
+		Select("tx_id", "payment_method_id", "total_price", "shipping_price", "unique_code", "payable_amount", "status", "created_at").
+		Where("payment_method_id = ? AND status IN ?", paymentMethodID, openTransactionStatuses).
+		Where("payable_amount = ? OR (payable_amount = 0 AND total_price = ?)", amount, amount).
+		Order("created_at asc").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// FindOpenTransactionByReference implements ReconciliationRepository. It
+// returns nil when no open transaction has one of the payment references.
+func (r *ReconciliationRepositoryImpl) FindOpenTransactionByReference(tx *gorm.DB, paymentMethodID int64, references []string) (*models.Transaction, error) {
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	var transactions []models.Transaction
+	err := getDB(tx).
+		Select("tx_id").
+		Where("payment_method_id = ? AND status IN ?", paymentMethodID, openTransactionStatuses).
+		Where("payment_reference IN ?", references).
+		Limit(1).
+		Find(&transactions).Error
+	if err != nil || len(transactions) == 0 {
+		return nil, err
+	}
+	return &transactions[0], nil
+}
+
+// ApprovePendingProofs implements ReconciliationRepository. A receipt waiting
+// for review is settled once the money shows up on the statement.
+func (r *ReconciliationRepositoryImpl) ApprovePendingProofs(tx *gorm.DB, transactionID string, reviewer uint) error {
+	return tx.
+		Model(&models.PaymentProof{}).
+		Where("transaction_id = ? AND status = ?", transactionID, "pending").
+		Updates(map[string]interface{}{
+			"status":      "approved",
+			"reviewed_by": reviewer,
+			"reviewed_at": time.Now(),
+		}).Error
+}
+
+func NewReconciliationRepository() ReconciliationRepository {
+	return &ReconciliationRepositoryImpl{}
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundRepository interface {
+	Create(param models.Refund, tx *gorm.DB) (models.Refund, error)
+	FindById(id int64) (models.Refund, error)
+	FindAll(param models.RefundListRequest) ([]models.Refund, int64, error)
+	SumByPayment(paymentID int64, tx *gorm.DB) (models.Money, error)
+	RefundedQuantities(orderIDs []string, tx *gorm.DB) (map[string]int64, error)
+	FindPaymentLocked(tx *gorm.DB, id int64) (*models.Payment, error)
+	FindOrdersLocked(tx *gorm.DB, transactionID string) ([]models.Order, error)
+}
+
+type RefundRepositoryImpl struct {
+}
+
+// Create implements RefundRepository. Items are created together with the
+// refund.
+func (r *RefundRepositoryImpl) Create(param models.Refund, tx *gorm.DB) (models.Refund, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// FindById implements RefundRepository.
+func (r *RefundRepositoryImpl) FindById(id int64) (models.Refund, error) {
+	var refund models.Refund
+	err := database.DB.Preload("Items").First(&refund, id).Error
+	return refund, err
+}
+
+// FindAll implements RefundRepository.
+func (r *RefundRepositoryImpl) FindAll(param models.RefundListRequest) ([]models.Refund, int64, error) {
+	offset := (param.Page - 1) * param.Limit
+
+	query := database.DB.Model(&models.Refund{})
+	if param.PaymentID > 0 {
+		query = query.Where("payment_id = ?", param.PaymentID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var refunds []models.Refund
+	err := query.
+		Preload("Items").
+		Order("created_at desc").
+		Offset(offset).
+		Limit(param.Limit).
+		Find(&refunds).Error
+	return refunds, total, err
+}
+
+// SumByPayment implements RefundRepository.
+func (r *RefundRepositoryImpl) SumByPayment(paymentID int64, tx *gorm.DB) (models.Money, error) {
+	var total models.Money
+	err := getDB(tx).
+		Model(&models.Refund{}).
+		Where("payment_id = ?", paymentID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// RefundedQuantities implements RefundRepository. It returns how many units
+// of each order line were refunded before.
+func (r *RefundRepositoryImpl) RefundedQuantities(orderIDs []string, tx *gorm.DB) (map[string]int64, error) {
+	quantities := map[string]int64{}
+	if len(orderIDs) == 0 {
+		return quantities, nil
+	}
+
+	var rows []struct {
+		OrderID  string
+		Quantity int64
+	}
+	err := getDB(tx).
+		Model(&models.RefundItem{}).
+		Select("order_id, COALESCE(SUM(quantity), 0) AS quantity").
+		Where("order_id IN ?", orderIDs).
+		Group("order_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		quantities[row.OrderID] = row.Quantity
+	}
+	return quantities, nil
+}
+
+// FindPaymentLocked implements RefundRepository. Locking the payment
+// serialises concurrent refunds so they cannot exceed the amount paid.
+func (r *RefundRepositoryImpl) FindPaymentLocked(tx *gorm.DB, id int64) (*models.Payment, error) {
+	var payment models.Payment
+	err := tx.
+		Select("id", "transaction_id", "total_payment", "status", "created_at", "updated_at").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&payment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindOrdersLocked implements RefundRepository. The order lines of the
+// transaction stay locked until tx ends, so the quantities refunded and
+// restocked are checked against rows no one else is changing.
+func (r *RefundRepositoryImpl) FindOrdersLocked(tx *gorm.DB, transactionID string) ([]models.Order, error) {
+	var orders []models.Order
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("transaction_id = ?", transactionID).
+		Order("id").
+		Find(&orders).Error
+	return orders, err
+}
+
+func NewRefundRepository() RefundRepository {
+	return &RefundRepositoryImpl{}
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnRepository interface {
+	Create(param models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error)
+	Update(param models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error)
+	UpdateItem(param models.ReturnItem, tx *gorm.DB) error
+	FindById(id int64) (models.ReturnRequest, error)
+	FindByIdLocked(tx *gorm.DB, id int64) (*models.ReturnRequest, error)
+	FindAll(param models.ReturnListRequest) ([]models.ReturnRequest, int64, error)
+	ReturnedQuantities(orderIDs []string, tx *gorm.DB) (map[string]int64, error)
+	FindPaymentByTransaction(transactionID string, tx *gorm.DB) (*models.Payment, error)
+	FindOrdersLocked(tx *gorm.DB, orderIDs []string) ([]models.Order, error)
+}
+
+type ReturnRepositoryImpl struct {
+}
+
+// Create implements ReturnRepository. Items and photos are created together
+// with the return.
+func (r *ReturnRepositoryImpl) Create(param models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// Update implements ReturnRepository. Only the return itself is saved; items
+// are updated with UpdateItem.
+func (r *ReturnRepositoryImpl) Update(param models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("status", "admin_note", "refund_id", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// UpdateItem implements ReturnRepository.
+func (r *ReturnRepositoryImpl) UpdateItem(param models.ReturnItem, tx *gorm.DB) error {
+	return getDB(tx).
+		Model(&param).
+		Select("restockable", "restocked", "replacement_size_varian_id").
+		Updates(&param).Error
+}
+
+// FindById implements ReturnRepository.
+func (r *ReturnRepositoryImpl) FindById(id int64) (models.ReturnRequest, error) {
+	var returnRequest models.ReturnRequest
+	err := database.DB.
+		Preload("Items").
+		Preload("Photos").
+		First(&returnRequest, id).Error
+	return returnRequest, err
+}
+
+// FindByIdLocked implements ReturnRepository. Locking the return keeps two
+// admins from moving it through the same step twice.
+func (r *ReturnRepositoryImpl) FindByIdLocked(tx *gorm.DB, id int64) (*models.ReturnRequest, error) {
+	var returnRequest models.ReturnRequest
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&returnRequest, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("return_id = ?", id).Order("id").Find(&returnRequest.Items).Error; err != nil {
+		return nil, err
+	}
+	return &returnRequest, nil
+}
+
+// FindAll implements ReturnRepository.
+func (r *ReturnRepositoryImpl) FindAll(param models.ReturnListRequest) ([]models.ReturnRequest, int64, error) {
+	offset := (param.Page - 1) * param.Limit
+
+	query := database.DB.Model(&models.ReturnRequest{})
+	if param.UserID > 0 {
+		query = query.Where("user_id = ?", param.UserID)
+	}
+	if param.Status != "" {
+		query = query.Where("status = ?", param.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var returns []models.ReturnRequest
+	err := query.
+		Preload("Items").
+		Preload("Photos").
+		Order("created_at desc").
+		Offset(offset).
+		Limit(param.Limit).
+		Find(&returns).Error
+	return returns, total, err
+}
+
+// ReturnedQuantities implements ReturnRepository. It returns how many units
+// of each order line are in returns that were not rejected or cancelled.
+func (r *ReturnRepositoryImpl) ReturnedQuantities(orderIDs []string, tx *gorm.DB) (map[string]int64, error) {
+	quantities := map[string]int64{}
+	if len(orderIDs) == 0 {
+		return quantities, nil
+	}
+
+	var rows []struct {
+		OrderID  string
+		Quantity int64
+	}
+	err := getDB(tx).
+		Table("return_items ri").
+		Select("ri.order_id, COALESCE(SUM(ri.quantity), 0) AS quantity").
+		Joins("JOIN return_requests rr ON rr.id = ri.return_id").
+		Where("ri.order_id IN ?", orderIDs).
+		Where("rr.status NOT IN ?", []string{"rejected", "cancelled"}).
+		Group("ri.order_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		quantities[row.OrderID] = row.Quantity
+	}
+	return quantities, nil
+}
+
+// FindPaymentByTransaction implements ReturnRepository. It returns the
+// latest payment of the transaction that was not left pending or failed.
+func (r *ReturnRepositoryImpl) FindPaymentByTransaction(transactionID string, tx *gorm.DB) (*models.Payment, error) {
+	var payment models.Payment
+	err := getDB(tx).
+		Select("id", "transaction_id", "total_payment", "status", "created_at", "updated_at").
+		Where("transaction_id = ?", transactionID).
+		Where("status NOT IN ?", []string{"pending", "failed"}).
+		Order("id desc").
+		First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// FindOrdersLocked implements ReturnRepository. Locking the order lines
+// makes concurrent returns of the same lines wait for each other, so
+// together they cannot return more than was ordered.
+func (r *ReturnRepositoryImpl) FindOrdersLocked(tx *gorm.DB, orderIDs []string) ([]models.Order, error) {
+	var orders []models.Order
+	if len(orderIDs) == 0 {
+		return orders, nil
+	}
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", orderIDs).
+		Order("id").
+		Find(&orders).Error
+	return orders, err
+}
+
+func NewReturnRepository() ReturnRepository {
+	return &ReturnRepositoryImpl{}
+}
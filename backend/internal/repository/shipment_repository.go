@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShipmentRepository interface {
+	Create(param models.Shipment, tx *gorm.DB) (models.Shipment, error)
+	Update(param models.Shipment, tx *gorm.DB) (models.Shipment, error)
+	CreateEvent(param models.ShipmentEvent, tx *gorm.DB) (bool, error)
+	FindByTransaction(transactionID string) (models.Shipment, error)
+	FindByIdLocked(tx *gorm.DB, id int64) (*models.Shipment, error)
+	FindByTrackingLocked(tx *gorm.DB, carrier, trackingNumber string) (*models.Shipment, error)
+	FindTransactionOwner(transactionID string) (int64, error)
+	UpdateOrderStatus(transactionID, status string, deliveredAt *time.Time, tx *gorm.DB) error
+}
+
+type ShipmentRepositoryImpl struct {
+}
+
+// Create implements ShipmentRepository.
+func (r *ShipmentRepositoryImpl) Create(param models.Shipment, tx *gorm.DB) (models.Shipment, error) {
+	err := getDB(tx).Omit("Events").Create(&param).Error
+	return param, err
+}
+
+// Update implements ShipmentRepository.
+func (r *ShipmentRepositoryImpl) Update(param models.Shipment, tx *gorm.DB) (models.Shipment, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("status", "delivered_at", "last_event_at", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// CreateEvent implements ShipmentRepository. It reports false when the same
+// event was recorded before, e.g. a retried webhook.
+func (r *ShipmentRepositoryImpl) CreateEvent(param models.ShipmentEvent, tx *gorm.DB) (bool, error) {
+	result := getDB(tx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&param)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindByTransaction implements ShipmentRepository. Events are returned oldest
+// first, as a timeline.
+func (r *ShipmentRepositoryImpl) FindByTransaction(transactionID string) (models.Shipment, error) {
+	var shipment models.Shipment
+	err := database.DB.
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("occurred_at ASC, id ASC")
+		}).
+		Where("transaction_id = ?", transactionID).
+		First(&shipment).Error
+	return shipment, err
+}
+
+// FindByIdLocked implements ShipmentRepository.
+func (r *ShipmentRepositoryImpl) FindByIdLocked(tx *gorm.DB, id int64) (*models.Shipment, error) {
+	var shipment models.Shipment
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&shipment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// FindByTrackingLocked implements ShipmentRepository.
+func (r *ShipmentRepositoryImpl) FindByTrackingLocked(tx *gorm.DB, carrier, trackingNumber string) (*models.Shipment, error) {
+	var shipment models.Shipment
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("carrier = ? AND tracking_number = ?", carrier, trackingNumber).
+		First(&shipment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// FindTransactionOwner implements ShipmentRepository. A transaction belongs
+// to the user who placed its orders.
+func (r *ShipmentRepositoryImpl) FindTransactionOwner(transactionID string) (int64, error) {
+	var order models.Order
+	err := database.DB.
+		Select("user_id").
+		Where("transaction_id = ?", transactionID).
+		First(&order).Error
+	return order.UserID, err
+}
+
+// UpdateOrderStatus implements ShipmentRepository. Cancelled orders of the
+// transaction are left alone.
+func (r *ShipmentRepositoryImpl) UpdateOrderStatus(transactionID, status string, deliveredAt *time.Time, tx *gorm.DB) error {
+	updates := map[string]interface{}{"status": status}
+	if deliveredAt != nil {
+		updates["delivered_at"] = deliveredAt
+	}
+
+	return getDB(tx).
+		Model(&models.Order{}).
+		Where("transaction_id = ? AND status <> ?", transactionID, "cancelled").
+		Updates(updates).Error
+}
+
+func NewShipmentRepository() ShipmentRepository {
+	return &ShipmentRepositoryImpl{}
+}
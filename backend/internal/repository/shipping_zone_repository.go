@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ShippingZoneRepository interface {
+	Create(param models.ShippingZone, tx *gorm.DB) (models.ShippingZone, error)
+	Update(param models.ShippingZone, tx *gorm.DB) (models.ShippingZone, error)
+	Delete(id int64) error
+	FindById(id int64) (*models.ShippingZone, error)
+	FindAll() ([]models.ShippingZone, error)
+	CreateRate(param models.ShippingRate, tx *gorm.DB) (models.ShippingRate, error)
+	DeleteRate(zoneID, rateID int64) (bool, error)
+}
+
+type ShippingZoneRepositoryImpl struct {
+}
+
+// Create implements ShippingZoneRepository.
+func (r *ShippingZoneRepositoryImpl) Create(param models.ShippingZone, tx *gorm.DB) (models.ShippingZone, error) {
+	err := getDB(tx).Omit("Rates").Create(&param).Error
+	return param, err
+}
+
+// Update implements ShippingZoneRepository.
+func (r *ShippingZoneRepositoryImpl) Update(param models.ShippingZone, tx *gorm.DB) (models.ShippingZone, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("name", "province", "city", "free_shipping_threshold", "cod_available", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// Delete implements ShippingZoneRepository. The zone's rates go with it.
+func (r *ShippingZoneRepositoryImpl) Delete(id int64) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("zone_id = ?", id).Delete(&models.ShippingRate{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ShippingZone{}, id).Error
+	})
+}
+
+// FindById implements ShippingZoneRepository.
+func (r *ShippingZoneRepositoryImpl) FindById(id int64) (*models.ShippingZone, error) {
+	var zone models.ShippingZone
+	err := database.DB.
+		Preload("Rates", orderRates).
+		First(&zone, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// FindAll implements ShippingZoneRepository.
+func (r *ShippingZoneRepositoryImpl) FindAll() ([]models.ShippingZone, error) {
+	var zones []models.ShippingZone
+	err := database.DB.
+		Preload("Rates", orderRates).
+		Order("province ASC, city ASC").
+		Find(&zones).Error
+	return zones, err
+}
+
+// CreateRate implements ShippingZoneRepository.
+func (r *ShippingZoneRepositoryImpl) CreateRate(param models.ShippingRate, tx *gorm.DB) (models.ShippingRate, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// DeleteRate implements ShippingZoneRepository. It reports false when the
+// zone has no such rate.
+func (r *ShippingZoneRepositoryImpl) DeleteRate(zoneID, rateID int64) (bool, error) {
+	result := database.DB.
+		Where("id = ? AND zone_id = ?", rateID, zoneID).
+		Delete(&models.ShippingRate{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// orderRates sorts a zone's rates by method, then from the lightest tier up;
+// unlimited tiers (0) come last.
+func orderRates(db *gorm.DB) *gorm.DB {
+	return db.Order("shipping_id ASC, max_weight_grams = 0 ASC, max_weight_grams ASC")
+}
+
+func NewShippingZoneRepository() ShippingZoneRepository {
+	return &ShippingZoneRepositoryImpl{}
+}
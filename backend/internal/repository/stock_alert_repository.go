@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockAlertRepository interface {
+	Create(param models.StockAlert, tx *gorm.DB) (models.StockAlert, error)
+	Delete(id int64, tx *gorm.DB) error
+	FindById(id int64) (*models.StockAlert, error)
+	FindAllByUser(userID uint) ([]models.StockAlert, error)
+	FindActive(userID uint, productID int64, sizeVarianID *int64, alertType models.StockAlertType) (*models.StockAlert, error)
+	FindActiveByProductLocked(productID int64, tx *gorm.DB) ([]models.StockAlert, error)
+	MarkNotified(id int64, lastPrice models.Money, notifiedAt time.Time, tx *gorm.DB) error
+
+	QueueNotification(param models.StockAlertNotification, tx *gorm.DB) (bool, error)
+	FindNotificationById(id int64) (*models.StockAlertNotification, error)
+	FindNotifications(param models.StockAlertNotificationListRequest) ([]models.StockAlertNotification, int64, error)
+	CountUnread(userID uint) (int64, error)
+	MarkNotificationRead(id int64, readAt time.Time, tx *gorm.DB) error
+}
+
+type StockAlertRepositoryImpl struct {
+}
+
+// Create implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) Create(param models.StockAlert, tx *gorm.DB) (models.StockAlert, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// Delete implements StockAlertRepository. Notifications already queued for
+// the alert are kept.
+func (r *StockAlertRepositoryImpl) Delete(id int64, tx *gorm.DB) error {
+	return getDB(tx).Delete(&models.StockAlert{}, id).Error
+}
+
+// FindById implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) FindById(id int64) (*models.StockAlert, error) {
+	var alert models.StockAlert
+	if err := database.DB.Preload("Product").Preload("SizeVarian").First(&alert, id).Error; err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// FindAllByUser implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) FindAllByUser(userID uint) ([]models.StockAlert, error) {
+	var alerts []models.StockAlert
+	err := database.DB.
+		Preload("Product").
+		Preload("SizeVarian").
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// FindActive implements StockAlertRepository. It finds the user's alert of
+// the type for exactly this product and size that can still fire.
+func (r *StockAlertRepositoryImpl) FindActive(userID uint, productID int64, sizeVarianID *int64, alertType models.StockAlertType) (*models.StockAlert, error) {
+	query := database.DB.Where("user_id = ? AND product_id = ? AND type = ?", userID, productID, alertType)
+	if sizeVarianID != nil {
+		query = query.Where("size_varian_id = ?", *sizeVarianID)
+	} else {
+		query = query.Where("size_varian_id IS NULL")
+	}
+	if alertType == models.StockAlertBackInStock {
+		query = query.Where("notified_at IS NULL")
+	}
+
+	var alert models.StockAlert
+	if err := query.First(&alert).Error; err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// FindActiveByProductLocked implements StockAlertRepository. The rows stay
+// locked until tx ends so concurrent updates of the product queue each
+// event once.
+func (r *StockAlertRepositoryImpl) FindActiveByProductLocked(productID int64, tx *gorm.DB) ([]models.StockAlert, error) {
+	var alerts []models.StockAlert
+	err := getDB(tx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ?", productID).
+		Where("type = ? OR notified_at IS NULL", models.StockAlertPriceDrop).
+		Order("id asc").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// MarkNotified implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) MarkNotified(id int64, lastPrice models.Money, notifiedAt time.Time, tx *gorm.DB) error {
+	return getDB(tx).
+		Model(&models.StockAlert{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_price":  lastPrice,
+			"notified_at": notifiedAt,
+		}).Error
+}
+
+// QueueNotification implements StockAlertRepository. It reports false when
+// the alert was already notified of the event.
+func (r *StockAlertRepositoryImpl) QueueNotification(param models.StockAlertNotification, tx *gorm.DB) (bool, error) {
+	result := getDB(tx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&param)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindNotificationById implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) FindNotificationById(id int64) (*models.StockAlertNotification, error) {
+	var notification models.StockAlertNotification
+	if err := database.DB.First(&notification, id).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// FindNotifications implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) FindNotifications(param models.StockAlertNotificationListRequest) ([]models.StockAlertNotification, int64, error) {
+	offset := (param.Page - 1) * param.Limit
+
+	query := database.DB.Model(&models.StockAlertNotification{}).Where("user_id = ?", param.UserID)
+	if param.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []models.StockAlertNotification
+	err := query.
+		Preload("Product").
+		Order("created_at desc, id desc").
+		Offset(offset).
+		Limit(param.Limit).
+		Find(&notifications).Error
+	return notifications, total, err
+}
+
+// CountUnread implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := database.DB.Model(&models.StockAlertNotification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkNotificationRead implements StockAlertRepository.
+func (r *StockAlertRepositoryImpl) MarkNotificationRead(id int64, readAt time.Time, tx *gorm.DB) error {
+	return getDB(tx).
+		Model(&models.StockAlertNotification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", readAt).Error
+}
+
+func NewStockAlertRepository() StockAlertRepository {
+	return &StockAlertRepositoryImpl{}
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type TaxRepository interface {
+	CreateClass(param models.TaxClass, tx *gorm.DB) (models.TaxClass, error)
+	UpdateClass(param models.TaxClass, tx *gorm.DB) (models.TaxClass, error)
+	DeleteClass(id int64, tx *gorm.DB) error
+	FindClassById(id int64) (*models.TaxClass, error)
+	FindClassByCode(code string) (*models.TaxClass, error)
+	FindAllClasses() ([]models.TaxClass, error)
+	FindDefaultClass() (*models.TaxClass, error)
+	ClearDefault(exceptID int64, tx *gorm.DB) error
+	CountAssignments(id int64) (int64, error)
+	CreateLines(lines []models.TransactionTaxLine, tx *gorm.DB) error
+}
+
+type TaxRepositoryImpl struct {
+}
+
+// CreateClass implements TaxRepository.
+func (r *TaxRepositoryImpl) CreateClass(param models.TaxClass, tx *gorm.DB) (models.TaxClass, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// UpdateClass implements TaxRepository.
+func (r *TaxRepositoryImpl) UpdateClass(param models.TaxClass, tx *gorm.DB) (models.TaxClass, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("name", "code", "rate", "is_default", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// DeleteClass implements TaxRepository.
+func (r *TaxRepositoryImpl) DeleteClass(id int64, tx *gorm.DB) error {
+	return getDB(tx).Delete(&models.TaxClass{}, id).Error
+}
+
+// FindClassById implements TaxRepository.
+func (r *TaxRepositoryImpl) FindClassById(id int64) (*models.TaxClass, error) {
+	var class models.TaxClass
+	if err := database.DB.First(&class, id).Error; err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+// FindClassByCode implements TaxRepository.
+func (r *TaxRepositoryImpl) FindClassByCode(code string) (*models.TaxClass, error) {
+	var class models.TaxClass
+	if err := database.DB.Where("code = ?", code).First(&class).Error; err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+// FindAllClasses implements TaxRepository.
+func (r *TaxRepositoryImpl) FindAllClasses() ([]models.TaxClass, error) {
+	var classes []models.TaxClass
+	err := database.DB.Order("name ASC").Find(&classes).Error
+	return classes, err
+}
+
+// FindDefaultClass implements TaxRepository.
+func (r *TaxRepositoryImpl) FindDefaultClass() (*models.TaxClass, error) {
+	var class models.TaxClass
+	if err := database.DB.Where("is_default = ?", true).First(&class).Error; err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+// ClearDefault implements TaxRepository. It unsets the default flag of every
+// class but exceptID, so only one class is the default.
+func (r *TaxRepositoryImpl) ClearDefault(exceptID int64, tx *gorm.DB) error {
+	return getDB(tx).
+		Model(&models.TaxClass{}).
+		Where("id <> ? AND is_default = ?", exceptID, true).
+		Update("is_default", false).Error
+}
+
+// CountAssignments implements TaxRepository. It counts the categories and
+// products that use the class.
+func (r *TaxRepositoryImpl) CountAssignments(id int64) (int64, error) {
+	var categories, products int64
+	if err := database.DB.Model(&models.Category{}).Where("tax_class_id = ?", id).Count(&categories).Error; err != nil {
+		return 0, err
+	}
+	if err := database.DB.Model(&models.Product{}).Where("tax_class_id = ?", id).Count(&products).Error; err != nil {
+		return 0, err
+	}
+	return categories + products, nil
+}
+
+// CreateLines implements TaxRepository.
+func (r *TaxRepositoryImpl) CreateLines(lines []models.TransactionTaxLine, tx *gorm.DB) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	return getDB(tx).Create(&lines).Error
+}
+
+func NewTaxRepository() TaxRepository {
+	return &TaxRepositoryImpl{}
+}
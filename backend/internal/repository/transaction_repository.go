@@ -15,17 +15,30 @@ type TransactionRepository interface {
 	FindById(paramId string) (models.Transaction, error)
 	FindByIdLocking(tx *gorm.DB, id string) (*models.Transaction, error)
 	FindAll(param models.TransactionListRequest) ([]models.Transaction, error)
+	FindTransactionOwner(transactionID string) (int64, error)
+	VirtualAccountExists(tx *gorm.DB, number string) (bool, error)
 }
 
 type TransactionRepositoryImpl struct {
 }
 
+// VirtualAccountExists implements TransactionRepository. Numbers of old
+// transactions count too, since a late transfer to one must not be matched
+// to a new order.
+func (r *TransactionRepositoryImpl) VirtualAccountExists(tx *gorm.DB, number string) (bool, error) {
+	var total int64
+	err := getDB(tx).Model(&models.Transaction{}).
+		Where("payment_type = ? AND payment_reference = ?", models.PaymentTypeVirtualAccount, number).
+		Count(&total).Error
+	return total > 0, err
+}
+
 // FindByIdLocking implements TransactionRepository.
 func (r *TransactionRepositoryImpl) FindByIdLocking(tx *gorm.DB, id string) (*models.Transaction, error) {
 	var trx models.Transaction
 
 	err := tx.
-		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "status", "created_at", "updated_at").
+		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "tax_total", "unique_code", "payable_amount", "payment_type", "payment_fee", "payment_reference", "currency", "display_currency", "exchange_rate", "status", "created_at", "updated_at").
 		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("tx_id = ?", id).
 		First(&trx).Error
@@ -52,7 +65,7 @@ func (a *TransactionRepositoryImpl) Create(param models.Transaction, tx *gorm.DB
 	}
 
 	err = db.
-		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "status", "created_at", "updated_at").
+		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "tax_total", "unique_code", "payable_amount", "payment_type", "payment_fee", "payment_reference", "currency", "display_currency", "exchange_rate", "status", "created_at", "updated_at").
 		First(&result, "tx_id = ?", param.TxID).Error
 
 	return result, err
@@ -69,7 +82,7 @@ func (a *TransactionRepositoryImpl) FindAll(param models.TransactionListRequest)
 
 	var Transactions []models.Transaction
 	db := database.DB.
-		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "status", "created_at", "updated_at")
+		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "tax_total", "unique_code", "payable_amount", "payment_type", "payment_fee", "payment_reference", "currency", "display_currency", "exchange_rate", "status", "created_at", "updated_at")
 
 	if param.SortBy != "" {
 		db = db.Order(param.SortBy)
@@ -94,7 +107,7 @@ func (a *TransactionRepositoryImpl) FindAll(param models.TransactionListRequest)
 func (a *TransactionRepositoryImpl) FindById(paramId string) (models.Transaction, error) {
 	Transaction := models.Transaction{}
 	err := database.DB.
-		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "status", "created_at", "updated_at").
+		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "tax_total", "unique_code", "payable_amount", "payment_type", "payment_fee", "payment_reference", "currency", "display_currency", "exchange_rate", "status", "created_at", "updated_at").
 		Preload("Address", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "user_id", "recipient_name", "recipient_phone_number", "province", "city", "district", "village", "postal_code", "full_address", "created_at", "updated_at")
 		}).
@@ -102,11 +115,12 @@ func (a *TransactionRepositoryImpl) FindById(paramId string) (models.Transaction
 			return db.Select("id", "name", "price", "state", "created_at", "updated_at")
 		}).
 		Preload("PaymentMethod", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "account_name", "account_number", "bank_name", "bank_images", "is_active", "created_at", "updated_at")
+			return db.Select(paymentMethodColumns)
 		}).
+		Preload("TaxLines").
 		Preload("Orders", func(db *gorm.DB) *gorm.DB {
 			return db.
-				Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "created_at", "updated_at").
+				Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "tax_rate", "tax_amount", "created_at", "updated_at").
 				Preload("Product", func(db *gorm.DB) *gorm.DB {
 					return db.Select("id", "category_id", "name", "description", "created_at", "updated_at")
 				}).
@@ -114,7 +128,7 @@ func (a *TransactionRepositoryImpl) FindById(paramId string) (models.Transaction
 					return db.Select("id", "product_id", "name", "color", "images", "created_at", "updated_at")
 				}).
 				Preload("SizeVarian", func(db *gorm.DB) *gorm.DB {
-					return db.Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at")
+					return db.Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at")
 				})
 		}).
 		First(&Transaction, "tx_id = ?", paramId).Error
@@ -137,7 +151,7 @@ func (a *TransactionRepositoryImpl) Update(param models.Transaction, tx *gorm.DB
 	}
 
 	err = db.
-		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "status", "created_at", "updated_at").
+		Select("tx_id", "address_id", "shipping_id", "payment_method_id", "total_price", "shipping_price", "tax_total", "unique_code", "payable_amount", "payment_type", "payment_fee", "payment_reference", "currency", "display_currency", "exchange_rate", "status", "created_at", "updated_at").
 		Preload("Address", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "user_id", "recipient_name", "recipient_phone_number", "province", "city", "district", "village", "postal_code", "full_address", "created_at", "updated_at")
 		}).
@@ -145,11 +159,12 @@ func (a *TransactionRepositoryImpl) Update(param models.Transaction, tx *gorm.DB
 			return db.Select("id", "name", "price", "state", "created_at", "updated_at")
 		}).
 		Preload("PaymentMethod", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "account_name", "account_number", "bank_name", "bank_images", "is_active", "created_at", "updated_at")
+			return db.Select(paymentMethodColumns)
 		}).
+		Preload("TaxLines").
 		Preload("Orders", func(dbf *gorm.DB) *gorm.DB {
 			return dbf.
-				Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "created_at", "updated_at").
+				Select("id", "transaction_id", "product_id", "color_varian_id", "size_varian_id", "quantity", "unit_price", "subtotal", "tax_rate", "tax_amount", "created_at", "updated_at").
 				Preload("Product", func(db *gorm.DB) *gorm.DB {
 					return db.Select("id", "category_id", "name", "description", "created_at", "updated_at")
 				}).
@@ -157,7 +172,7 @@ func (a *TransactionRepositoryImpl) Update(param models.Transaction, tx *gorm.DB
 					return db.Select("id", "product_id", "name", "color", "images", "created_at", "updated_at")
 				}).
 				Preload("SizeVarian", func(db *gorm.DB) *gorm.DB {
-					return db.Select("id", "color_varian_id", "size", "stock", "created_at", "updated_at")
+					return db.Select("id", "color_varian_id", "size", "stock", "sku", "barcode", "price", "created_at", "updated_at")
 				})
 		}).
 		First(&result, "tx_id = ?", param.TxID).Error
@@ -165,6 +180,17 @@ func (a *TransactionRepositoryImpl) Update(param models.Transaction, tx *gorm.DB
 	return result, err
 }
 
+// FindTransactionOwner implements TransactionRepository. A transaction
+// belongs to the user who placed its orders.
+func (r *TransactionRepositoryImpl) FindTransactionOwner(transactionID string) (int64, error) {
+	var order models.Order
+	err := database.DB.
+		Select("user_id").
+		Where("transaction_id = ?", transactionID).
+		First(&order).Error
+	return order.UserID, err
+}
+
 func NewTransactionRepository() TransactionRepository {
 	return &TransactionRepositoryImpl{}
 }
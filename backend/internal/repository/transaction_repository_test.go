@@ -56,8 +56,8 @@ func TestTransactionRepository_CRUD(t *testing.T) {
 		AddressID:       address.ID,
 		ShippingID:      shipping.ID,
 		PaymentMethodID: pm.ID,
-		TotalPrice:      500000,
-		ShippingPrice:   10000,
+		TotalPrice:      models.Rupiah(500000),
+		ShippingPrice:   models.Rupiah(10000),
 		Status:          "pending",
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
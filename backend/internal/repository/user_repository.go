@@ -31,7 +31,7 @@ func (u *UserRepositoryImpl) FindByEmail(email string) (models.User, error) {
 	user := models.User{}
 
 	err := database.DB.
-		Select("id", "username", "email", "password_hash", "first_name", "last_name", "role_id", "is_active", "created_at", "updated_at").
+		Select("id", "username", "email", "password_hash", "no_password", "first_name", "last_name", "role_id", "is_active", "last_login_at", "created_at", "updated_at").
 		Preload("Role", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "description", "level", "is_system_role", "created_at", "updated_at")
 		}).
@@ -48,7 +48,7 @@ func (u *UserRepositoryImpl) FindByUsernameOrEmail(identifier string) (models.Us
 	user := models.User{}
 
 	err := database.DB.
-		Select("id", "username", "email", "password_hash", "first_name", "last_name", "role_id", "is_active", "created_at", "updated_at").
+		Select("id", "username", "email", "password_hash", "no_password", "first_name", "last_name", "role_id", "is_active", "last_login_at", "created_at", "updated_at").
 		Preload("Role", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "description", "level", "is_system_role", "created_at", "updated_at")
 		}).
@@ -78,7 +78,7 @@ func (u *UserRepositoryImpl) Create(param models.User) (models.User, error) {
 	}
 
 	err := db.
-		Select("id", "username", "email", "password_hash", "first_name", "last_name", "role_id", "is_active", "created_at", "updated_at").
+		Select("id", "username", "email", "password_hash", "no_password", "first_name", "last_name", "role_id", "is_active", "last_login_at", "created_at", "updated_at").
 		Preload("Role", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "description", "level", "is_system_role", "created_at", "updated_at")
 		}).
@@ -160,7 +160,7 @@ func (u *UserRepositoryImpl) FindAll(param models.UserListRequest) (*models.User
 func (u *UserRepositoryImpl) FindById(id uint) (models.User, error) {
 	var user models.User
 	err := database.DB.
-		Select("id", "username", "email", "password_hash", "first_name", "last_name", "role_id", "is_active", "created_at", "updated_at").
+		Select("id", "username", "email", "password_hash", "no_password", "first_name", "last_name", "role_id", "is_active", "last_login_at", "created_at", "updated_at").
 		Preload("Role", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "description", "level", "is_system_role", "created_at", "updated_at")
 		}).
@@ -188,7 +188,7 @@ func (u *UserRepositoryImpl) Update(param *models.User) (models.User, error) {
 	}
 
 	err := db.
-		Select("id", "username", "email", "password_hash", "first_name", "last_name", "role_id", "is_active", "created_at", "updated_at").
+		Select("id", "username", "email", "password_hash", "no_password", "first_name", "last_name", "role_id", "is_active", "last_login_at", "created_at", "updated_at").
 		Preload("Role", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "description", "level", "is_system_role", "created_at", "updated_at")
 		}).
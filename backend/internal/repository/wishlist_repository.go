@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type WishlistRepository interface {
+	Create(param models.Wishlist, tx *gorm.DB) (models.Wishlist, error)
+	Update(param models.Wishlist, tx *gorm.DB) (models.Wishlist, error)
+	Delete(id int64, tx *gorm.DB) error
+	DeleteByProduct(userID uint, productID int64, tx *gorm.DB) (int64, error)
+	FindById(id int64) (*models.Wishlist, error)
+	FindAll(param models.WishlistListRequest) ([]models.Wishlist, int64, error)
+	FindExisting(userID uint, productID int64, colorVarianID, sizeVarianID *int64) (*models.Wishlist, error)
+	FindWishlistedProductIDs(userID uint, productIDs []int64) ([]int64, error)
+}
+
+type WishlistRepositoryImpl struct {
+}
+
+// Create implements WishlistRepository.
+func (r *WishlistRepositoryImpl) Create(param models.Wishlist, tx *gorm.DB) (models.Wishlist, error) {
+	err := getDB(tx).Create(&param).Error
+	return param, err
+}
+
+// Update implements WishlistRepository. Only the chosen variant changes.
+func (r *WishlistRepositoryImpl) Update(param models.Wishlist, tx *gorm.DB) (models.Wishlist, error) {
+	err := getDB(tx).
+		Model(&param).
+		Select("color_varian_id", "size_varian_id", "updated_at").
+		Updates(&param).Error
+	return param, err
+}
+
+// Delete implements WishlistRepository.
+func (r *WishlistRepositoryImpl) Delete(id int64, tx *gorm.DB) error {
+	return getDB(tx).Delete(&models.Wishlist{}, id).Error
+}
+
+// DeleteByProduct implements WishlistRepository. It removes every entry of
+// the product from the user's wishlist, whatever the variant.
+func (r *WishlistRepositoryImpl) DeleteByProduct(userID uint, productID int64, tx *gorm.DB) (int64, error) {
+	result := getDB(tx).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Delete(&models.Wishlist{})
+	return result.RowsAffected, result.Error
+}
+
+// FindById implements WishlistRepository.
+func (r *WishlistRepositoryImpl) FindById(id int64) (*models.Wishlist, error) {
+	var item models.Wishlist
+	if err := preloadWishlist(database.DB).First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindAll implements WishlistRepository.
+func (r *WishlistRepositoryImpl) FindAll(param models.WishlistListRequest) ([]models.Wishlist, int64, error) {
+	offset := (param.Page - 1) * param.Limit
+
+	query := database.DB.Model(&models.Wishlist{}).Where("user_id = ?", param.UserID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []models.Wishlist
+	err := preloadWishlist(query).
+		Order("created_at desc").
+		Offset(offset).
+		Limit(param.Limit).
+		Find(&items).Error
+	return items, total, err
+}
+
+// FindExisting implements WishlistRepository. It finds the user's entry
+// for exactly this product, color and size.
+func (r *WishlistRepositoryImpl) FindExisting(userID uint, productID int64, colorVarianID, sizeVarianID *int64) (*models.Wishlist, error) {
+	query := database.DB.Where("user_id = ? AND product_id = ?", userID, productID)
+	if colorVarianID != nil {
+		query = query.Where("color_varian_id = ?", *colorVarianID)
+	} else {
+		query = query.Where("color_varian_id IS NULL")
+	}
+	if sizeVarianID != nil {
+		query = query.Where("size_varian_id = ?", *sizeVarianID)
+	} else {
+		query = query.Where("size_varian_id IS NULL")
+	}
+
+	var item models.Wishlist
+	if err := query.First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindWishlistedProductIDs implements WishlistRepository. It returns which
+// of productIDs the user has in their wishlist.
+func (r *WishlistRepositoryImpl) FindWishlistedProductIDs(userID uint, productIDs []int64) ([]int64, error) {
+	var ids []int64
+	if len(productIDs) == 0 {
+		return ids, nil
+	}
+
+	err := database.DB.Model(&models.Wishlist{}).
+		Where("user_id = ? AND product_id IN ?", userID, productIDs).
+		Distinct().
+		Pluck("product_id", &ids).Error
+	return ids, err
+}
+
+// preloadWishlist loads the product, with its sizes for the stock check,
+// and the chosen variants. Deleted products and variants are left out.
+func preloadWishlist(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Product").
+		Preload("Product.ColorVarians").
+		Preload("Product.ColorVarians.SizeVarians").
+		Preload("ColorVarian").
+		Preload("SizeVarian")
+}
+
+func NewWishlistRepository() WishlistRepository {
+	return &WishlistRepositoryImpl{}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	mw "e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AccountRoutes are the customer's self-service privacy endpoints.
+func AccountRoutes(r chi.Router, h *handler.AccountHandler, deps Dependencies) {
+	r.Route("/account", func(r chi.Router) {
+		r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
+		r.Use(mw.ForbidImpersonation)
+
+		r.Get("/export", h.ExportData)
+		r.Delete("/", h.DeleteAccount)
+	})
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	mw "e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// APIKeyRoutes sets up routes for integration API key management. These
+// only accept bearer tokens so a key can never mint or revoke other keys.
+func APIKeyRoutes(r chi.Router, h *handler.APIKeyHandler, deps Dependencies) {
+	r.Route("/api-keys", func(r chi.Router) {
+		r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
+		r.Use(mw.ForbidImpersonation)
+		r.Use(mw.RequireAdminArea(deps.RBACService))
+
+		r.Get("/", h.GetAPIKeys)
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "api_keys", "id"))
+			r.Post("/", h.CreateAPIKey)
+			r.Delete("/{id}", h.RevokeAPIKey)
+		})
+	})
+}
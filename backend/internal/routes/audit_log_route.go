@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	mw "e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AuditLogRoutes sets up read-only routes for the admin audit trail
+func AuditLogRoutes(r chi.Router, h *handler.AuditLogHandler, deps Dependencies) {
+	r.Route("/audit-logs", func(r chi.Router) {
+		r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
+		r.Use(mw.RequirePermission(deps.RBACService, "audit_logs", "read"))
+
+		r.Get("/", h.GetAuditLogs)
+	})
+}
@@ -13,13 +13,16 @@ func AuthRoutes(r chi.Router, h *handler.AuthHandler, deps Dependencies) {
 		r.Post("/admin/login", h.AdminLogin)
 		r.Post("/logout", h.SignOut)
 		r.Post("/register", h.SignUp)
+		r.Get("/oidc/{provider}/authorize", h.OIDCAuthorize)
+		r.Post("/oidc/{provider}/callback", h.OIDCCallback)
 		r.With(middleware.AuthMiddleware(deps.UserService, deps.JWTService)).Get("/profile", h.GetProfile)
-		r.With(middleware.AuthMiddleware(deps.UserService, deps.JWTService)).Post("/refresh", h.Refresh)
+		// The refresh token authenticates the call, the access token may have expired
+		r.Post("/refresh", h.Refresh)
 		r.Post("/resend-verification", h.ResendVerification)
 		r.Post("/reset-password", h.ResetPassword)
 		r.Post("/forgot-password", h.ForgotPassword)
 		r.Get("/verify-email", h.VerifyEmail)
-		r.With(middleware.AuthMiddleware(deps.UserService, deps.JWTService)).Put("/change-password", h.ChangePassword)
+		r.With(middleware.AuthMiddleware(deps.UserService, deps.JWTService), middleware.ForbidImpersonation).Put("/change-password", h.ChangePassword)
 
 	})
 }
@@ -2,16 +2,34 @@ package routes
 
 import (
 	"e-commerce/backend/internal/handler"
+	mw "e-commerce/backend/internal/middleware"
 
 	"github.com/go-chi/chi/v5"
 )
 
 func CategoryRoutes(r chi.Router, h *handler.CategoryHandler, deps Dependencies) {
 	r.Route("/categories", func(r chi.Router) {
-		r.Post("/", h.CreateCategory)
 		r.Get("/", h.GetAllCategories)
+		r.Get("/tree", h.GetCategoryTree)
+		r.Get("/slug/{slug}", h.GetCategoryBySlug)
 		r.Get("/{id}", h.GetCategoryById)
-		r.Put("/{id}", h.UpdateCategory)
-		r.Delete("/{id}", h.DeleteCategory)
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "categories", "create"))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "categories", "id"))
+			r.With(mw.RequirePermission(deps.RBACService, "categories", "create")).Post("/", h.CreateCategory)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "categories", "update"))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "categories", "id"))
+			r.With(mw.RequirePermission(deps.RBACService, "categories", "update")).Put("/{id}", h.UpdateCategory)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "categories", "delete"))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "categories", "id"))
+			r.With(mw.RequirePermission(deps.RBACService, "categories", "delete")).Delete("/{id}", h.DeleteCategory)
+		})
 	})
 }
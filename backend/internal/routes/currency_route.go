@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CurrencyRoutes sets up routes for display currencies and exchange rates
+func CurrencyRoutes(r chi.Router, h *handler.CurrencyHandler, deps Dependencies) {
+	r.Route("/currencies", func(r chi.Router) {
+		r.Get("/", h.GetCurrencies)
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "currencies", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "currencies", "read"))
+			r.Get("/all", h.GetAllCurrencies)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "currencies", "create"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "currencies", "create"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "currencies", "code"))
+			r.Post("/", h.CreateCurrency)
+			r.Post("/import", h.ImportRates)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "currencies", "update"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "currencies", "update"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "currencies", "code"))
+			r.Put("/{code}", h.UpdateCurrency)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "currencies", "delete"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "currencies", "delete"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "currencies", "code"))
+			r.Delete("/{code}", h.DeleteCurrency)
+		})
+	})
+}
@@ -9,31 +9,35 @@ import (
 
 func DashboardRoutes(r chi.Router, dashboardHandler *handler.DashboardHandler, deps Dependencies) {
 
-	authMiddleware := middleware.AuthMiddleware(deps.UserService, deps.JWTService)
-
 	r.Route("/dashboard", func(r chi.Router) {
-		r.Use(authMiddleware)
-		r.Use(middleware.RequireAdminArea(deps.RBACService))
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "dashboard", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
 
-		r.Get("/stats", dashboardHandler.GetDashboardStats)
-		r.Get("/revenue", dashboardHandler.GetRevenueStats)
+			r.Get("/stats", dashboardHandler.GetDashboardStats)
+			r.Get("/revenue", dashboardHandler.GetRevenueStats)
 
-		r.Route("/orders", func(r chi.Router) {
-			r.Get("/stats", dashboardHandler.GetOrderStats)
-			r.Get("/recent", dashboardHandler.GetRecentOrders)
-		})
+			r.Get("/orders/stats", dashboardHandler.GetOrderStats)
+			r.Get("/orders/recent", dashboardHandler.GetRecentOrders)
 
-		r.Route("/products", func(r chi.Router) {
-			r.Get("/top", dashboardHandler.GetTopProducts)
-			r.Get("/low-stock", dashboardHandler.GetLowStockProducts)
+			r.Get("/products/top", dashboardHandler.GetTopProducts)
+			r.Get("/products/low-stock", dashboardHandler.GetLowStockProducts)
+			r.Get("/products/most-wished", dashboardHandler.GetMostWishedProducts)
+
+			r.Get("/health", dashboardHandler.GetSystemHealth)
 		})
 
-		r.Route("/analytics", func(r chi.Router) {
-			r.Get("/orders", dashboardHandler.GetOrderAnalytics)
-			r.Get("/users", dashboardHandler.GetUserGrowth)
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "analytics", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+
+			r.Get("/analytics/orders", dashboardHandler.GetOrderAnalytics)
+			r.Get("/analytics/users", dashboardHandler.GetUserGrowth)
 		})
 
-		r.Get("/health", dashboardHandler.GetSystemHealth)
-		r.Get("/activity", dashboardHandler.GetRecentActivity)
+		r.With(
+			authOrAPIKey(deps, "activity_logs", "read"),
+			middleware.RequireAdminArea(deps.RBACService),
+		).Get("/activity", dashboardHandler.GetRecentActivity)
 	})
 }
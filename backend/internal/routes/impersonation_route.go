@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	mw "e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ImpersonationRoutes lets super admins act as another user for support.
+func ImpersonationRoutes(r chi.Router, h *handler.ImpersonationHandler, deps Dependencies) {
+	r.Route("/impersonations", func(r chi.Router) {
+		r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
+		r.Use(mw.ForbidImpersonation)
+		r.Use(mw.RequireRole(deps.RBACService, "super_admin"))
+
+		r.Post("/", h.Impersonate)
+	})
+}
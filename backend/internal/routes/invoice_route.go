@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// InvoiceRoutes sets up routes for invoice and packing slip downloads
+func InvoiceRoutes(r chi.Router, h *handler.InvoiceHandler, deps Dependencies) {
+	r.Route("/documents", func(r chi.Router) {
+		r.With(middleware.AuthMiddleware(deps.UserService, deps.JWTService)).Get("/mine/{tx_id}/{document}", h.GetMyDocument)
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "invoices", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "invoices", "read"))
+			r.Get("/transaction/{tx_id}/{document}", h.GetDocument)
+			r.Post("/bulk", h.GetBulkDocuments)
+		})
+	})
+}
@@ -9,18 +9,26 @@ import (
 
 func OrderRoutes(r chi.Router, h *handler.OrderHandler, deps Dependencies) {
 
-	authMiddleware := middleware.AuthMiddleware(deps.UserService, deps.JWTService)
-
 	r.Route("/orders", func(r chi.Router) {
-		r.Use(authMiddleware)
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "orders", "read"))
+			r.Get("/", h.GetAllOrders)
+			r.Get("/{id}", h.GetOrderByID)
+		})
 
-		r.Get("/", h.GetAllOrders)
-		r.Get("/{id}", h.GetOrderByID)
-		r.Patch("/{id}/cancel", h.CancelOrder)
+		r.With(authOrAPIKey(deps, "orders", "update")).Patch("/{id}/cancel", h.CancelOrder)
 
 		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "orders", "update"))
 			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "orders", "id"))
 			r.Put("/{id}", h.UpdateOrder)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "orders", "delete"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "orders", "id"))
 			r.Delete("/{id}", h.DeleteOrder)
 		})
 
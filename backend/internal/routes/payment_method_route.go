@@ -9,20 +9,31 @@ import (
 
 // PaymentMethodRoutes sets up routes for payment method management
 func PaymentMethodRoutes(r chi.Router, h *handler.PaymentMethodHandler, deps Dependencies) {
-	authMiddleware := mw.AuthMiddleware(deps.UserService, deps.JWTService)
-
 	r.Route("/payment-methods", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
-			r.Use(authMiddleware)
+			r.Use(authOrAPIKey(deps, "payment_methods", "read"))
 			r.Get("/", h.GetAllPaymentMethods)
 			r.Get("/{id}", h.GetPaymentMethodByID)
 		})
 
 		r.Group(func(r chi.Router) {
-			r.Use(authMiddleware)
+			r.Use(authOrAPIKey(deps, "payment_methods", "create"))
 			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "payment_methods", "id"))
 			r.Post("/", h.CreatePaymentMethod)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payment_methods", "update"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "payment_methods", "id"))
 			r.Put("/{id}", h.UpdatePaymentMethod)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payment_methods", "delete"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "payment_methods", "id"))
 			r.Delete("/{id}", h.DeletePaymentMethod)
 		})
 
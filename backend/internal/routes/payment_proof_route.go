@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// PaymentProofRoutes sets up routes for bank transfer receipts and their review
+func PaymentProofRoutes(r chi.Router, h *handler.PaymentProofHandler, deps Dependencies) {
+	r.Route("/payment-proofs", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payment_proofs", "create"))
+			r.Use(middleware.RequirePermission(deps.RBACService, "payment_proofs", "create"))
+			r.Post("/", h.SubmitProof)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payment_proofs", "read_own"))
+			r.Use(middleware.RequirePermission(deps.RBACService, "payment_proofs", "read_own"))
+			r.Get("/mine", h.GetMyProofs)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payment_proofs", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "payment_proofs", "read"))
+			r.Get("/", h.GetAllProofs)
+			r.Get("/{id}", h.GetProofByID)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payment_proofs", "update"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "payment_proofs", "update"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "payment_proofs", "id"))
+			r.Patch("/{id}/approve", h.ApproveProof)
+			r.Patch("/{id}/reject", h.RejectProof)
+		})
+	})
+}
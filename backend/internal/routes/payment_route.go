@@ -9,17 +9,26 @@ import (
 
 // PaymentRoutes sets up routes for payment management
 func PaymentRoutes(r chi.Router, h *handler.PaymentHandler, deps Dependencies) {
-	authMiddleware := middleware.AuthMiddleware(deps.UserService, deps.JWTService)
-
 	r.Route("/payments", func(r chi.Router) {
-		r.Use(authMiddleware)
-		r.Post("/", h.CreatePayment)
-		r.Get("/", h.GetAllPayments)
-		r.Get("/{id}", h.GetPaymentByID)
+		r.With(authOrAPIKey(deps, "payments", "create")).Post("/", h.CreatePayment)
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payments", "read"))
+			r.Get("/", h.GetAllPayments)
+			r.Get("/{id}", h.GetPaymentByID)
+		})
 
 		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payments", "update"))
 			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "payments", "id"))
 			r.Put("/{id}", h.UpdatePayment)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "payments", "delete"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "payments", "id"))
 			r.Delete("/{id}", h.DeletePayment)
 		})
 
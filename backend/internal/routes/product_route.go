@@ -10,15 +10,38 @@ import (
 func ProductRoutes(r chi.Router, h *handler.ProductHandler, deps Dependencies) {
 	r.Route("/products", func(r chi.Router) {
 
-		r.Get("/", h.GetAllProducts)
-		r.Get("/{id}", h.GetProductByID)
+		r.Group(func(r chi.Router) {
+			r.Use(mw.OptionalAuthMiddleware(deps.UserService, deps.JWTService))
+			r.Get("/", h.GetAllProducts)
+			r.Get("/{id}", h.GetProductByID)
+		})
 
 		r.Group(func(r chi.Router) {
-			r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
+			r.Use(authOrAPIKey(deps, "products", "read"))
 			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Get("/sku/{sku}", h.GetSizeVarianBySKU)
+			r.Get("/barcode/{barcode}", h.GetSizeVarianByBarcode)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "products", "create"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "products", "id"))
 			r.Post("/", h.CreateProduct)
 			r.Post("/{id}/color", h.AddColorVariant)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "products", "update"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "products", "id"))
 			r.Put("/{id}", h.UpdateProduct)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "products", "delete"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "products", "id"))
 			r.Delete("/{id}", h.DeleteProduct)
 		})
 	})
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ReconciliationRoutes sets up routes for bank statement imports and the
+// resolution of unmatched transfers
+func ReconciliationRoutes(r chi.Router, h *handler.ReconciliationHandler, deps Dependencies) {
+	r.Route("/bank-statements", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "bank_statements", "create"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "bank_statements", "create"))
+			r.Post("/import", h.ImportStatement)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "bank_statements", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "bank_statements", "read"))
+			r.Get("/lines", h.GetLines)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "bank_statements", "update"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "bank_statements", "update"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "bank_statement_lines", "id"))
+			r.Patch("/lines/{id}/resolve", h.ResolveLine)
+		})
+	})
+}
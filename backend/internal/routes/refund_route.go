@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RefundRoutes sets up routes for payment refunds
+func RefundRoutes(r chi.Router, h *handler.RefundHandler, deps Dependencies) {
+	r.Route("/refunds", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "refunds", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "refunds", "read"))
+			r.Get("/", h.GetAllRefunds)
+			r.Get("/{id}", h.GetRefundByID)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "refunds", "create"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "refunds", "create"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "refunds", "id"))
+			r.Post("/", h.CreateRefund)
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ReturnRoutes sets up routes for customer returns
+func ReturnRoutes(r chi.Router, h *handler.ReturnHandler, deps Dependencies) {
+	r.Route("/returns", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "returns", "create"))
+			r.Use(middleware.RequirePermission(deps.RBACService, "returns", "create"))
+			r.Post("/", h.CreateReturn)
+			r.Patch("/{id}/cancel", h.CancelReturn)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "returns", "read_own"))
+			r.Use(middleware.RequirePermission(deps.RBACService, "returns", "read_own"))
+			r.Get("/mine", h.GetMyReturns)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "returns", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "returns", "read"))
+			r.Get("/", h.GetAllReturns)
+			r.Get("/{id}", h.GetReturnByID)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "returns", "update"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "returns", "update"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "return_requests", "id"))
+			r.Patch("/{id}/status", h.UpdateReturnStatus)
+			r.Patch("/{id}/inspect", h.InspectReturn)
+			r.Post("/{id}/refund", h.RefundReturn)
+			r.Post("/{id}/exchange", h.ExchangeReturn)
+		})
+	})
+}
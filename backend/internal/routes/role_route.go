@@ -23,6 +23,7 @@ func RoleRoutes(r chi.Router, h *handler.RoleHandler, deps Dependencies) {
 
 		r.Group(func(r chi.Router) {
 			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "roles", "id"))
 			r.Post("/", h.CreateRole)
 			r.Put("/{id}", h.UpdateRole)
 			r.Post("/{id}/permissions", h.AssignPermissions)
@@ -18,15 +18,18 @@ import (
 )
 
 type Dependencies struct {
-	RBACService services.RBACService
-	UserService services.UserService
-	JWTService  *utils.JWTService
+	RBACService     services.RBACService
+	UserService     services.UserService
+	APIKeyService   services.APIKeyService
+	AuditLogService services.AuditLogService
+	JWTService      *utils.JWTService
 }
 
 func SetupRoutes(handler *di.Handler, logger *zap.Logger, cfg config.CORSConfig) *chi.Mux {
 	r := chi.NewRouter()
 r.Use(
 	chimiddleware.RequestID,
+	middleware.RequestIDHeader,
 	chimiddleware.RealIP,
 	middleware.Logger(logger),
 	middleware.Recovery(logger),
@@ -45,11 +48,14 @@ r.Use(
 			"Accept",
 			"Authorization",
 			"Content-Type",
+			"X-API-Key",
 			"X-CSRF-Token",
 			"X-Requested-With",
 		},
 		ExposedHeaders: []string{
 			"Link",
+			"X-CSRF-Token",
+			"X-Request-Id",
 		},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -62,6 +68,8 @@ r.Use(
 	r.Get("/health", handler.HealthHandler.HealthCheck)
 	r.Head("/health", handler.HealthHandler.HealthCheck) // T
 
+	r.Get("/.well-known/jwks.json", handler.JWKSHandler.GetJWKS)
+
 	deps := buildDependencies(handler)
 	r.Route("/api/v1", func(api chi.Router) {
 		api.Get("/health", handler.HealthHandler.HealthCheck)
@@ -78,6 +86,20 @@ r.Use(
 		PaymentRoutes(api, handler.PaymentHandler, deps)
 		DashboardRoutes(api, handler.DashboardHandler, deps)
 		CategoryRoutes(api, handler.CategoryHandler, deps)
+		APIKeyRoutes(api, handler.APIKeyHandler, deps)
+		AuditLogRoutes(api, handler.AuditLogHandler, deps)
+		ImpersonationRoutes(api, handler.ImpersonationHandler, deps)
+		AccountRoutes(api, handler.AccountHandler, deps)
+		RefundRoutes(api, handler.RefundHandler, deps)
+		ReturnRoutes(api, handler.ReturnHandler, deps)
+		ShipmentRoutes(api, handler.ShipmentHandler, deps)
+		TaxRoutes(api, handler.TaxHandler, deps)
+		InvoiceRoutes(api, handler.InvoiceHandler, deps)
+		PaymentProofRoutes(api, handler.PaymentProofHandler, deps)
+		ReconciliationRoutes(api, handler.ReconciliationHandler, deps)
+		CurrencyRoutes(api, handler.CurrencyHandler, deps)
+		WishlistRoutes(api, handler.WishlistHandler, deps)
+		StockAlertRoutes(api, handler.StockAlertHandler, deps)
 	})
 
 	r.Get("/swagger/*", httpSwagger.Handler(
@@ -89,8 +111,16 @@ r.Use(
 
 func buildDependencies(handler *di.Handler) Dependencies {
 	return Dependencies{
-		RBACService: handler.RBACService,
-		UserService: handler.UserService,
-		JWTService:  handler.JWTService,
+		RBACService:     handler.RBACService,
+		UserService:     handler.UserService,
+		APIKeyService:   handler.APIKeyService,
+		AuditLogService: handler.AuditLogService,
+		JWTService:      handler.JWTService,
 	}
 }
+
+// authOrAPIKey authenticates a bearer token, or an API key that holds the
+// resource.action scope.
+func authOrAPIKey(deps Dependencies, resource, action string) func(http.Handler) http.Handler {
+	return middleware.AuthOrAPIKeyMiddleware(deps.UserService, deps.JWTService, deps.APIKeyService, resource, action)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ShipmentRoutes sets up routes for shipment tracking and carrier webhooks
+func ShipmentRoutes(r chi.Router, h *handler.ShipmentHandler, deps Dependencies) {
+	r.Route("/shipments", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipments", "read_own"))
+			r.Use(middleware.RequirePermission(deps.RBACService, "shipments", "read_own"))
+			r.Get("/mine/{tx_id}", h.GetMyShipment)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipments", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "shipments", "read"))
+			r.Get("/transaction/{tx_id}", h.GetShipmentByTransaction)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipments", "create"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "shipments", "create"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "shipments", "id"))
+			r.Post("/", h.MarkShipped)
+			r.Post("/{id}/events", h.AddShipmentEvent)
+		})
+	})
+
+	// Carriers authenticate with a signature over the body instead of a token
+	r.Post("/webhooks/carriers/{carrier}", h.CarrierWebhook)
+}
@@ -9,23 +9,67 @@ import (
 
 // ShippingRoutes sets up routes for shipping method management
 func ShippingRoutes(r chi.Router, h *handler.ShippingHandler, deps Dependencies) {
-	authMiddleware := mw.AuthMiddleware(deps.UserService, deps.JWTService)
-
 	r.Route("/shipping", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
-			r.Use(authMiddleware)
+			r.Use(authOrAPIKey(deps, "shipping", "read"))
 			r.Get("/", h.GetAllShipping)
 			r.Get("/{id}", h.GetShippingByID)
+			// A quote only reads rates, whatever its method
+			r.Post("/quote", h.QuoteShipping)
 		})
 
 		// Admin only routes
 		r.Group(func(r chi.Router) {
-			r.Use(authMiddleware)
+			r.Use(authOrAPIKey(deps, "shipping", "create"))
 			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "shippings", "id"))
 			r.Post("/", h.CreateShipping)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipping", "update"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "shippings", "id"))
 			r.Put("/{id}", h.UpdateShipping)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipping", "delete"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "shippings", "id"))
 			r.Delete("/{id}", h.DeleteShipping)
 		})
 
+		// Zones and weight tiers
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipping", "read"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Get("/zones", h.GetAllZones)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipping", "create"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "shipping_zones", "id"))
+			r.Post("/zones", h.CreateZone)
+		})
+
+		// Adding or removing a weight tier changes its zone
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipping", "update"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "shipping_zones", "id"))
+			r.Put("/zones/{id}", h.UpdateZone)
+			r.Post("/zones/{id}/rates", h.CreateRate)
+			r.Delete("/zones/{id}/rates/{rate_id}", h.DeleteRate)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "shipping", "delete"))
+			r.Use(mw.RequireAdminArea(deps.RBACService))
+			r.Use(mw.AuditMiddleware(deps.AuditLogService, "shipping_zones", "id"))
+			r.Delete("/zones/{id}", h.DeleteZone)
+		})
+
 	})
 }
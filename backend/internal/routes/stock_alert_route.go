@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	mw "e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StockAlertRoutes sets up routes for the signed-in customer's back-in-stock
+// and price-drop alerts and their notifications
+func StockAlertRoutes(r chi.Router, h *handler.StockAlertHandler, deps Dependencies) {
+	r.Route("/stock-alerts", func(r chi.Router) {
+		r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
+
+		r.Get("/", h.GetStockAlerts)
+		r.Post("/", h.Subscribe)
+		r.Delete("/{id}", h.Unsubscribe)
+		r.Get("/notifications", h.GetNotifications)
+		r.Put("/notifications/{id}/read", h.MarkNotificationRead)
+	})
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	"e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TaxRoutes sets up routes for tax class management
+func TaxRoutes(r chi.Router, h *handler.TaxHandler, deps Dependencies) {
+	r.Route("/tax-classes", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "tax_classes", "read"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "tax_classes", "read"))
+			r.Get("/", h.GetAllTaxClasses)
+			r.Get("/{id}", h.GetTaxClassByID)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "tax_classes", "create"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "tax_classes", "create"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "tax_classes", "id"))
+			r.Post("/", h.CreateTaxClass)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "tax_classes", "update"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "tax_classes", "update"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "tax_classes", "id"))
+			r.Put("/{id}", h.UpdateTaxClass)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "tax_classes", "delete"))
+			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.RequirePermission(deps.RBACService, "tax_classes", "delete"))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "tax_classes", "id"))
+			r.Delete("/{id}", h.DeleteTaxClass)
+		})
+	})
+}
@@ -9,18 +9,21 @@ import (
 
 // TransactionRoutes sets up routes for transaction management
 func TransactionRoutes(r chi.Router, h *handler.TransactionHandler, deps Dependencies) {
-	authMiddleware := middleware.AuthMiddleware(deps.UserService, deps.JWTService)
-
 	r.Route("/transactions", func(r chi.Router) {
 
-		r.Use(authMiddleware)
+		r.With(authOrAPIKey(deps, "transactions", "create")).Post("/", h.CreateTransaction)
 
-		r.Post("/", h.CreateTransaction)
-		r.Get("/", h.GetAllTransactions)
-		r.Get("/{tx_id}", h.GetTransactionByID)
+		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "transactions", "read"))
+			r.Get("/", h.GetAllTransactions)
+			r.Get("/{tx_id}", h.GetTransactionByID)
+			r.Get("/{tx_id}/qris", h.GetTransactionQRIS)
+		})
 
 		r.Group(func(r chi.Router) {
+			r.Use(authOrAPIKey(deps, "transactions", "update"))
 			r.Use(middleware.RequireAdminArea(deps.RBACService))
+			r.Use(middleware.AuditMiddleware(deps.AuditLogService, "transactions", "tx_id"))
 			r.Put("/{tx_id}", h.UpdateTransaction)
 		})
 	})
@@ -13,9 +13,10 @@ func UserRoutes(r chi.Router, h *handler.UserHandler, deps Dependencies) {
 		r.Group(func(r chi.Router) {
 			r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
 			r.Get("/me", h.GetCurrentUser)
-			r.Put("/me/password", h.UpdateCurrentUserPassword)
+			r.With(mw.ForbidImpersonation).Put("/me/password", h.UpdateCurrentUserPassword)
 			r.Group(func(r chi.Router) {
 				r.Use(mw.RequireAdminArea(deps.RBACService))
+				r.Use(mw.AuditMiddleware(deps.AuditLogService, "users", "id"))
 				r.Get("/", h.GetUsers)
 				r.Get("/{id}", h.GetUserById)
 				r.Post("/", h.CreateUser)
@@ -23,11 +24,12 @@ func UserRoutes(r chi.Router, h *handler.UserHandler, deps Dependencies) {
 				r.Put("/{id}/activate", h.ActivateUser)
 				r.Put("/{id}/deactivate", h.DeactivateUser)
 				r.Post("/bulk", h.BulkUserActions)
-				r.Put("/{id}/password", h.UpdatePassword)
+				r.With(mw.ForbidImpersonation).Put("/{id}/password", h.UpdatePassword)
 			})
 			r.Group(func(r chi.Router) {
 				r.Use(mw.RequirePermissionOrOwn(deps.RBACService, "user", "update"))
-				r.Put("/{userId}", h.UpdateUser)
+				r.Use(mw.AuditMiddleware(deps.AuditLogService, "users", "id"))
+				r.With(mw.ForbidImpersonation).Put("/{userId}", h.UpdateUser)
 			})
 		})
 	})
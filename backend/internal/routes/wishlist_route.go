@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"e-commerce/backend/internal/handler"
+	mw "e-commerce/backend/internal/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WishlistRoutes sets up routes for the signed-in customer's wishlist
+func WishlistRoutes(r chi.Router, h *handler.WishlistHandler, deps Dependencies) {
+	r.Route("/wishlist", func(r chi.Router) {
+		r.Use(mw.AuthMiddleware(deps.UserService, deps.JWTService))
+
+		r.Get("/", h.GetWishlist)
+		r.Post("/", h.AddToWishlist)
+		r.Put("/{id}", h.UpdateWishlistItem)
+		r.Delete("/{id}", h.RemoveFromWishlist)
+		r.Delete("/products/{product_id}", h.RemoveProductFromWishlist)
+	})
+}
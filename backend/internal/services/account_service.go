@@ -0,0 +1,317 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// recentSignInWindow is how long after signing in with their identity
+// provider a user without a password may delete their account.
+const recentSignInWindow = 10 * time.Minute
+
+type AccountService interface {
+	ExportData(userID uint) (*models.UserDataExport, error)
+	ExportArchive(userID uint) ([]byte, error)
+	DeleteAccount(actor models.ActivityContext, req models.AccountDeletionRequest) error
+}
+
+type AccountServiceImpl struct {
+	userRepo        repository.UserRepository
+	accountRepo     repository.AccountRepository
+	activityLogRepo repository.ActivityLogRepository
+}
+
+func NewAccountService(userRepo repository.UserRepository, accountRepo repository.AccountRepository, activityLogRepo repository.ActivityLogRepository) AccountService {
+	return &AccountServiceImpl{
+		userRepo:        userRepo,
+		accountRepo:     accountRepo,
+		activityLogRepo: activityLogRepo,
+	}
+}
+
+// ExportData implements AccountService.
+func (s *AccountServiceImpl) ExportData(userID uint) (*models.UserDataExport, error) {
+	user, err := s.userRepo.FindById(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	addresses, err := s.accountRepo.FindAddresses(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.accountRepo.FindTransactions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.accountRepo.FindOrders(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		txIDs[i] = transaction.TxID
+	}
+
+	payments, err := s.accountRepo.FindPayments(txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	activityLogs, err := s.accountRepo.FindActivityLogs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wishlists, err := s.accountRepo.FindWishlists(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stockAlerts, err := s.accountRepo.FindStockAlerts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.accountRepo.FindStockAlertNotifications(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	proofs, err := s.accountRepo.FindPaymentProofs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	returns, err := s.accountRepo.FindReturns(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := s.accountRepo.FindRefunds(txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments, err := s.accountRepo.FindShipments(txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.UserDataExport{
+		ExportedAt:    time.Now(),
+		Profile:       *user.ToResponse(),
+		Addresses:     make([]models.AddressResponse, len(addresses)),
+		Transactions:  make([]models.TransactionExport, len(transactions)),
+		Orders:        make([]models.OrderExport, len(orders)),
+		Payments:      make([]models.PaymentExport, len(payments)),
+		ActivityLogs:  make([]models.ActivityLogResponse, len(activityLogs)),
+		Wishlist:      make([]models.WishlistResponse, len(wishlists)),
+		StockAlerts:   make([]models.StockAlertResponse, len(stockAlerts)),
+		Notifications: make([]models.StockAlertNotificationResponse, len(notifications)),
+		PaymentProofs: make([]models.PaymentProofResponse, len(proofs)),
+		Returns:       make([]models.ReturnResponse, len(returns)),
+		Refunds:       make([]models.RefundResponse, len(refunds)),
+		Shipments:     make([]models.ShipmentResponse, len(shipments)),
+	}
+	for i := range addresses {
+		export.Addresses[i] = *addresses[i].ToResponseAddress()
+	}
+	for i := range transactions {
+		export.Transactions[i] = transactions[i].ToExport()
+	}
+	for i := range orders {
+		export.Orders[i] = orders[i].ToExport()
+	}
+	for i := range payments {
+		export.Payments[i] = payments[i].ToExport()
+	}
+	for i := range activityLogs {
+		export.ActivityLogs[i] = *activityLogs[i].ToResponse()
+	}
+	for i := range wishlists {
+		export.Wishlist[i] = wishlists[i].ToResponse()
+	}
+	for i := range stockAlerts {
+		export.StockAlerts[i] = stockAlerts[i].ToResponse()
+	}
+	for i := range notifications {
+		export.Notifications[i] = notifications[i].ToResponse()
+	}
+	for i := range proofs {
+		export.PaymentProofs[i] = *proofs[i].ToResponse()
+	}
+	for i := range returns {
+		export.Returns[i] = *returns[i].ToResponse()
+	}
+	for i := range refunds {
+		export.Refunds[i] = *refunds[i].ToResponse()
+	}
+	for i := range shipments {
+		export.Shipments[i] = *shipments[i].ToResponse()
+	}
+
+	return export, nil
+}
+
+// ExportArchive implements AccountService. The archive holds one JSON file
+// per section of the export.
+func (s *AccountServiceImpl) ExportArchive(userID uint) ([]byte, error) {
+	export, err := s.ExportData(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"addresses.json", export.Addresses},
+		{"transactions.json", export.Transactions},
+		{"orders.json", export.Orders},
+		{"payments.json", export.Payments},
+		{"activity_logs.json", export.ActivityLogs},
+		{"wishlist.json", export.Wishlist},
+		{"stock_alerts.json", export.StockAlerts},
+		{"notifications.json", export.Notifications},
+		{"payment_proofs.json", export.PaymentProofs},
+		{"returns.json", export.Returns},
+		{"refunds.json", export.Refunds},
+		{"shipments.json", export.Shipments},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DeleteAccount implements AccountService. Personal data is anonymised
+// instead of removed so orders, transactions and payments stay intact for
+// accounting.
+func (s *AccountServiceImpl) DeleteAccount(actor models.ActivityContext, req models.AccountDeletionRequest) error {
+	if actor.ImpersonatorID != nil {
+		return errors.New("cannot delete an account while impersonating")
+	}
+
+	if req.Confirmation != "DELETE" {
+		return errors.New("confirmation must be DELETE")
+	}
+
+	user, err := s.userRepo.FindById(actor.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if user.NoPassword {
+		if user.LastLoginAt == nil || time.Since(*user.LastLoginAt) > recentSignInWindow {
+			return errors.New("sign in again to delete your account")
+		}
+	} else if err := utils.CheckPassword(req.Password, user.PasswordHash); err != nil {
+		return errors.New("invalid password")
+	}
+
+	openOrders, err := s.accountRepo.CountOpenOrders(user.ID)
+	if err != nil {
+		return err
+	}
+	if openOrders > 0 {
+		return errors.New("account has open orders")
+	}
+
+	// Nobody knows this password, so the account can never be signed in to
+	// again.
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+	passwordHash, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return err
+	}
+
+	proofs, err := s.accountRepo.FindPaymentProofs(user.ID)
+	if err != nil {
+		return err
+	}
+	returns, err := s.accountRepo.FindReturns(user.ID)
+	if err != nil {
+		return err
+	}
+
+	var fileURLs []string
+	for _, proof := range proofs {
+		fileURLs = append(fileURLs, proof.ImageURL)
+	}
+	for _, returnRequest := range returns {
+		for _, photo := range returnRequest.Photos {
+			fileURLs = append(fileURLs, photo.URL)
+		}
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := s.accountRepo.Anonymize(user.ID, passwordHash, tx); err != nil {
+			return err
+		}
+
+		activityLog := models.ActivityLog{
+			UserID:   user.ID,
+			Action:   "delete_account",
+			Resource: "users",
+			Details:  "Account deleted and personal data anonymised",
+		}
+		_, err := s.activityLogRepo.Create(activityLog, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// The uploaded files are removed only once the rows no longer point at
+	// them; a failure leaves orphaned files but the account stays deleted.
+	if err := utils.DeleteMultipleFromSupabase(fileURLs); err != nil {
+		log.Printf("Failed to delete uploaded files of deleted user %d: %v", user.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"e-commerce/backend/internal/utils"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountService_DeleteAccount(t *testing.T) {
+	newService := func(t *testing.T) (services.AccountService, *mocks.MockUserRepository, *mocks.MockAccountRepository) {
+		ctrl := gomock.NewController(t)
+		userRepo := mocks.NewMockUserRepository(ctrl)
+		accountRepo := mocks.NewMockAccountRepository(ctrl)
+		service := services.NewAccountService(userRepo, accountRepo, mocks.NewMockActivityLogRepository(ctrl))
+		return service, userRepo, accountRepo
+	}
+	actor := models.ActivityContext{UserID: 7}
+	req := models.AccountDeletionRequest{Confirmation: "DELETE"}
+
+	t.Run("InvalidPassword", func(t *testing.T) {
+		service, userRepo, _ := newService(t)
+		hash, err := utils.HashPassword("Secret123!")
+		if err != nil {
+			t.Fatalf("HashPassword() error = %v", err)
+		}
+		userRepo.EXPECT().FindById(uint(7)).Return(models.User{ID: 7, PasswordHash: hash}, nil)
+
+		err = service.DeleteAccount(actor, models.AccountDeletionRequest{Password: "wrong", Confirmation: "DELETE"})
+		if err == nil || err.Error() != "invalid password" {
+			t.Fatalf("expected invalid password, got %v", err)
+		}
+	})
+
+	t.Run("SocialLoginSignedInRecently", func(t *testing.T) {
+		service, userRepo, accountRepo := newService(t)
+		signedIn := time.Now().Add(-time.Minute)
+		userRepo.EXPECT().FindById(uint(7)).Return(models.User{ID: 7, PasswordHash: "unknown", NoPassword: true, LastLoginAt: &signedIn}, nil)
+		// getting as far as the open order check means no password was asked for
+		accountRepo.EXPECT().CountOpenOrders(uint(7)).Return(int64(1), nil)
+
+		err := service.DeleteAccount(actor, req)
+		if err == nil || err.Error() != "account has open orders" {
+			t.Fatalf("expected the password check to be skipped, got %v", err)
+		}
+	})
+
+	t.Run("SocialLoginSignedInLongAgo", func(t *testing.T) {
+		service, userRepo, _ := newService(t)
+		signedIn := time.Now().Add(-time.Hour)
+		userRepo.EXPECT().FindById(uint(7)).Return(models.User{ID: 7, PasswordHash: "unknown", NoPassword: true, LastLoginAt: &signedIn}, nil)
+
+		err := service.DeleteAccount(actor, req)
+		if err == nil || err.Error() != "sign in again to delete your account" {
+			t.Fatalf("expected a fresh sign-in to be required, got %v", err)
+		}
+	})
+
+	t.Run("Impersonated", func(t *testing.T) {
+		service, _, _ := newService(t)
+		impersonator := uint(1)
+
+		err := service.DeleteAccount(models.ActivityContext{UserID: 7, ImpersonatorID: &impersonator}, req)
+		if err == nil || err.Error() != "cannot delete an account while impersonating" {
+			t.Fatalf("expected impersonated deletion to be refused, got %v", err)
+		}
+	})
+}
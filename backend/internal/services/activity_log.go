@@ -0,0 +1,28 @@
+package services
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// logActivity records a change to the resource row identified by resourceID
+// made by actor within tx,
+// including the API key or impersonator the actor acted through.
+func logActivity(activityLogRepo repository.ActivityLogRepository, actor models.ActivityContext, resource, resourceID, action, details string, tx *gorm.DB) error {
+	activityLog := models.ActivityLog{
+		UserID:         actor.UserID,
+		APIKeyID:       actor.APIKeyID,
+		ImpersonatorID: actor.ImpersonatorID,
+		Action:         action,
+		Resource:       resource,
+		ResourceID:     resourceID,
+		Details:        details,
+		IPAddress:      actor.IPAddress,
+		UserAgent:      actor.UserAgent,
+	}
+
+	_, err := activityLogRepo.Create(activityLog, tx)
+	return err
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyService interface {
+	CreateAPIKey(actor models.ActivityContext, req models.APIKeyInput) (*models.APIKeyCreatedResponse, error)
+	GetAPIKeys(actor models.ActivityContext) ([]models.APIKeyResponse, error)
+	RevokeAPIKey(id uint, actor models.ActivityContext) error
+	Authenticate(rawKey string, ipAddress string) (*models.APIKey, error)
+	RecordUsage(apiKey *models.APIKey, activity models.ActivityLog) error
+}
+
+type APIKeyServiceImpl struct {
+	apiKeyRepo      repository.APIKeyRepository
+	rbacRepo        repository.RBACRepository
+	activityLogRepo repository.ActivityLogRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, rbacRepo repository.RBACRepository, activityLogRepo repository.ActivityLogRepository) APIKeyService {
+	return &APIKeyServiceImpl{
+		apiKeyRepo:      apiKeyRepo,
+		rbacRepo:        rbacRepo,
+		activityLogRepo: activityLogRepo,
+	}
+}
+
+// CreateAPIKey implements APIKeyService. The key acts as the creating user,
+// so every scope must be a permission that user already holds.
+func (s *APIKeyServiceImpl) CreateAPIKey(actor models.ActivityContext, req models.APIKeyInput) (*models.APIKeyCreatedResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("api key name is required")
+	}
+
+	if len(req.Scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	seen := make(map[string]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scope = strings.TrimSpace(scope)
+		if seen[scope] {
+			continue
+		}
+
+		dot := strings.LastIndex(scope, ".")
+		if dot <= 0 || dot == len(scope)-1 {
+			return nil, fmt.Errorf("invalid scope %q, expected resource.action", scope)
+		}
+
+		ok, err := s.rbacRepo.HasPermission(actor.UserID, scope[:dot], scope[dot+1:])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot grant scope %q you do not have", scope)
+		}
+
+		seen[scope] = true
+		scopes = append(scopes, scope)
+	}
+
+	rawKey, prefix, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, errors.New("failed to generate api key")
+	}
+
+	apiKey, err := s.apiKeyRepo.Create(models.APIKey{
+		UserID:    actor.UserID,
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		KeyHash:   utils.HashAPIKey(rawKey),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logActivity(actor, "create", fmt.Sprint(apiKey.ID), fmt.Sprintf("Created API key %s (%s) with scopes %s", apiKey.Prefix, apiKey.Name, apiKey.Scopes))
+
+	return &models.APIKeyCreatedResponse{
+		APIKeyResponse: *apiKey.ToResponse(),
+		Key:            rawKey,
+	}, nil
+}
+
+// GetAPIKeys implements APIKeyService. Only users allowed to manage API
+// keys see the keys of others.
+func (s *APIKeyServiceImpl) GetAPIKeys(actor models.ActivityContext) ([]models.APIKeyResponse, error) {
+	manage, err := s.canManage(actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiKeys []models.APIKey
+	if manage {
+		apiKeys, err = s.apiKeyRepo.FindAll()
+	} else {
+		apiKeys, err = s.apiKeyRepo.FindAllByUser(actor.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		responses[i] = *apiKey.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// RevokeAPIKey implements APIKeyService. The keys of others are reported
+// as not found unless the actor may manage API keys.
+func (s *APIKeyServiceImpl) RevokeAPIKey(id uint, actor models.ActivityContext) error {
+	apiKey, err := s.apiKeyRepo.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("api key not found")
+		}
+		return err
+	}
+
+	if apiKey.UserID != actor.UserID {
+		manage, err := s.canManage(actor)
+		if err != nil {
+			return err
+		}
+		if !manage {
+			return errors.New("api key not found")
+		}
+	}
+
+	if apiKey.RevokedAt != nil {
+		return errors.New("api key already revoked")
+	}
+
+	now := time.Now()
+	apiKey.RevokedAt = &now
+	if _, err := s.apiKeyRepo.Update(&apiKey); err != nil {
+		return err
+	}
+
+	s.logActivity(actor, "revoke", fmt.Sprint(apiKey.ID), fmt.Sprintf("Revoked API key %s (%s)", apiKey.Prefix, apiKey.Name))
+	return nil
+}
+
+// Authenticate implements APIKeyService.
+func (s *APIKeyServiceImpl) Authenticate(rawKey string, ipAddress string) (*models.APIKey, error) {
+	prefix, ok := utils.ParseAPIKeyPrefix(rawKey)
+	if !ok {
+		return nil, errors.New("invalid api key")
+	}
+
+	apiKey, err := s.apiKeyRepo.FindByPrefix(prefix)
+	if err != nil {
+		return nil, errors.New("invalid api key")
+	}
+
+	if !utils.CheckAPIKeyHash(rawKey, apiKey.KeyHash) {
+		return nil, errors.New("invalid api key")
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil {
+		return nil, errors.New("api key has been revoked")
+	}
+
+	if apiKey.IsExpired(now) {
+		return nil, errors.New("api key has expired")
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(apiKey.ID, now, ipAddress); err != nil {
+		log.Printf("⚠️  WARNING: failed to update last use of API key %s: %v", apiKey.Prefix, err)
+	}
+	apiKey.LastUsedAt = &now
+	apiKey.LastUsedIP = ipAddress
+
+	return &apiKey, nil
+}
+
+// RecordUsage implements APIKeyService.
+func (s *APIKeyServiceImpl) RecordUsage(apiKey *models.APIKey, activity models.ActivityLog) error {
+	activity.UserID = apiKey.UserID
+	activity.APIKeyID = &apiKey.ID
+
+	_, err := s.activityLogRepo.Create(activity, nil)
+	return err
+}
+
+// canManage reports whether actor may see and revoke the API keys of other
+// users.
+func (s *APIKeyServiceImpl) canManage(actor models.ActivityContext) (bool, error) {
+	return s.rbacRepo.HasPermission(actor.UserID, "api_keys", "manage")
+}
+
+func (s *APIKeyServiceImpl) logActivity(actor models.ActivityContext, action, resourceID, details string) {
+	if err := logActivity(s.activityLogRepo, actor, "api_keys", resourceID, action, details, nil); err != nil {
+		log.Printf("⚠️  WARNING: failed to write activity log: %v", err)
+	}
+}
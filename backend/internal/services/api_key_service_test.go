@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyService_GetAPIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	mockRBACRepo := mocks.NewMockRBACRepository(ctrl)
+	service := services.NewAPIKeyService(mockAPIKeyRepo, mockRBACRepo, mocks.NewMockActivityLogRepository(ctrl))
+
+	actor := models.ActivityContext{UserID: 7}
+
+	t.Run("OwnKeys", func(t *testing.T) {
+		mockRBACRepo.EXPECT().HasPermission(uint(7), "api_keys", "manage").Return(false, nil)
+		mockAPIKeyRepo.EXPECT().FindAllByUser(uint(7)).Return([]models.APIKey{{ID: 1, UserID: 7}}, nil)
+
+		apiKeys, err := service.GetAPIKeys(actor)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(apiKeys) != 1 || apiKeys[0].ID != 1 {
+			t.Errorf("expected only the actor's key, got %+v", apiKeys)
+		}
+	})
+
+	t.Run("Manage", func(t *testing.T) {
+		mockRBACRepo.EXPECT().HasPermission(uint(7), "api_keys", "manage").Return(true, nil)
+		mockAPIKeyRepo.EXPECT().FindAll().Return([]models.APIKey{{ID: 1, UserID: 7}, {ID: 2, UserID: 8}}, nil)
+
+		apiKeys, err := service.GetAPIKeys(actor)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(apiKeys) != 2 {
+			t.Errorf("expected every key, got %+v", apiKeys)
+		}
+	})
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	mockRBACRepo := mocks.NewMockRBACRepository(ctrl)
+	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
+	service := services.NewAPIKeyService(mockAPIKeyRepo, mockRBACRepo, mockActivityLogRepo)
+
+	actor := models.ActivityContext{UserID: 7}
+
+	t.Run("OwnKey", func(t *testing.T) {
+		mockAPIKeyRepo.EXPECT().FindById(uint(1)).Return(models.APIKey{ID: 1, UserID: 7}, nil)
+		mockAPIKeyRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(apiKey *models.APIKey) (models.APIKey, error) {
+			if apiKey.RevokedAt == nil {
+				t.Error("expected the key to be revoked")
+			}
+			return *apiKey, nil
+		})
+		mockActivityLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.ActivityLog{}, nil)
+
+		if err := service.RevokeAPIKey(1, actor); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("KeyOfAnotherUser", func(t *testing.T) {
+		mockAPIKeyRepo.EXPECT().FindById(uint(2)).Return(models.APIKey{ID: 2, UserID: 8}, nil)
+		mockRBACRepo.EXPECT().HasPermission(uint(7), "api_keys", "manage").Return(false, nil)
+
+		err := service.RevokeAPIKey(2, actor)
+		if err == nil || err.Error() != "api key not found" {
+			t.Errorf("expected api key not found, got %v", err)
+		}
+	})
+
+	t.Run("ManageKeyOfAnotherUser", func(t *testing.T) {
+		mockAPIKeyRepo.EXPECT().FindById(uint(2)).Return(models.APIKey{ID: 2, UserID: 8}, nil)
+		mockRBACRepo.EXPECT().HasPermission(uint(7), "api_keys", "manage").Return(true, nil)
+		mockAPIKeyRepo.EXPECT().Update(gomock.Any()).Return(models.APIKey{}, nil)
+		mockActivityLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.ActivityLog{}, nil)
+
+		if err := service.RevokeAPIKey(2, actor); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
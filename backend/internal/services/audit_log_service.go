@@ -0,0 +1,115 @@
+package services
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AuditLogService interface {
+	Snapshot(resourceType string, keyColumn string, id string) map[string]interface{}
+	Record(entry models.AuditLog, before, after map[string]interface{}) error
+	GetAuditLogs(req *models.AuditLogListRequest) (*models.AuditLogListResponse, error)
+	PurgeExpired(retention time.Duration) (int64, error)
+}
+
+type AuditLogServiceImpl struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+func NewAuditLogService(auditLogRepo repository.AuditLogRepository) AuditLogService {
+	return &AuditLogServiceImpl{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// Snapshot implements AuditLogService. It returns nil when the row does not
+// exist, e.g. before a create or after a delete. Child rows of the resource,
+// such as the sizes of a product, are added as "<child>.<id>.<column>".
+func (s *AuditLogServiceImpl) Snapshot(resourceType string, keyColumn string, id string) map[string]interface{} {
+	if id == "" {
+		return nil
+	}
+
+	row, err := s.auditLogRepo.FindRow(resourceType, keyColumn, id)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("⚠️  WARNING: failed to snapshot %s %s for audit: %v", resourceType, id, err)
+		}
+		return nil
+	}
+
+	children, err := s.auditLogRepo.FindChildRows(resourceType, id)
+	if err != nil {
+		log.Printf("⚠️  WARNING: failed to snapshot children of %s %s for audit: %v", resourceType, id, err)
+	}
+	for name, rows := range children {
+		for column, value := range utils.FlattenChildRows(name, rows) {
+			row[column] = value
+		}
+	}
+
+	return utils.RedactSnapshot(row)
+}
+
+// Record implements AuditLogService.
+func (s *AuditLogServiceImpl) Record(entry models.AuditLog, before, after map[string]interface{}) error {
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return err
+		}
+		entry.Before = string(data)
+	}
+
+	if after != nil {
+		data, err := json.Marshal(after)
+		if err != nil {
+			return err
+		}
+		entry.After = string(data)
+	}
+
+	if before != nil || after != nil {
+		data, err := json.Marshal(utils.DiffSnapshots(before, after))
+		if err != nil {
+			return err
+		}
+		entry.Changes = string(data)
+	}
+
+	_, err := s.auditLogRepo.Create(entry)
+	return err
+}
+
+// GetAuditLogs implements AuditLogService.
+func (s *AuditLogServiceImpl) GetAuditLogs(req *models.AuditLogListRequest) (*models.AuditLogListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 20
+	}
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	return s.auditLogRepo.FindAll(req)
+}
+
+// PurgeExpired implements AuditLogService. A zero retention keeps audit logs
+// forever.
+func (s *AuditLogServiceImpl) PurgeExpired(retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	return s.auditLogRepo.DeleteBefore(time.Now().Add(-retention))
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"log"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -22,6 +23,8 @@ type AuthService interface {
 	ForgotPassword(req models.ForgotPasswordRequest) (string, error)
 	ResetPassword(req models.ResetPasswordRequest) error
 	RefreshToken(req models.RefreshTokenRequest) (*models.TokenResponse, error)
+	OIDCAuthorize(provider string) (*models.OIDCAuthorizeResponse, error)
+	OIDCCallback(provider string, req models.OIDCCallbackRequest, ipAddress, userAgent string) (*models.TokenResponse, error)
 }
 type AuthServiceImpl struct {
 	jwtService         *utils.JWTService
@@ -29,6 +32,9 @@ type AuthServiceImpl struct {
 	acitvityLogRepo    repository.ActivityLogRepository
 	roleRepo           repository.RoleRepository
 	passwordRepository repository.PasswordResetTokenRepository
+	passwordPolicy     PasswordPolicyService
+	oidcClient         *utils.OIDCClient
+	oidcRepo           repository.OIDCRepository
 }
 
 // loginAdmin implements [AuthService].
@@ -183,17 +189,26 @@ func (a *AuthServiceImpl) ResetPassword(req models.ResetPasswordRequest) error {
 		return errors.New("user not found")
 	}
 
+	if err := a.passwordPolicy.Validate(req.NewPassword, user); err != nil {
+		return err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return err
 	}
 
 	user.PasswordHash = string(hashedPassword)
+	user.NoPassword = false
 	_, err = a.userRepo.Update(&user)
 	if err != nil {
 		return err
 	}
 
+	if err := a.passwordPolicy.Remember(user.ID, user.PasswordHash); err != nil {
+		log.Printf("⚠️  WARNING: failed to record password history for user %d: %v", user.ID, err)
+	}
+
 	err = a.passwordRepository.Delete(&resetToken)
 
 	if err != nil {
@@ -271,6 +286,10 @@ func (a *AuthServiceImpl) SignUp(req models.RegisterRequest) (*models.TokenRespo
 		return nil, errors.New("user with this email already exists")
 	}
 
+	if err := a.passwordPolicy.Validate(req.Password, models.User{Email: req.Email, Username: req.Username}); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, err
@@ -297,15 +316,261 @@ func (a *AuthServiceImpl) SignUp(req models.RegisterRequest) (*models.TokenRespo
 		return nil, err
 	}
 
+	if err := a.passwordPolicy.Remember(userResult.ID, hashedPassword); err != nil {
+		log.Printf("⚠️  WARNING: failed to record password history for user %d: %v", userResult.ID, err)
+	}
+
 	return a.generateTokenResponse(&userResult)
 }
 
-func NewAuthService(jwtService *utils.JWTService, userRepo repository.UserRepository, acitvityLogRepo repository.ActivityLogRepository, roleRepo repository.RoleRepository, passwordRepository repository.PasswordResetTokenRepository) AuthService {
+func NewAuthService(jwtService *utils.JWTService, userRepo repository.UserRepository, acitvityLogRepo repository.ActivityLogRepository, roleRepo repository.RoleRepository, passwordRepository repository.PasswordResetTokenRepository, passwordPolicy PasswordPolicyService, oidcClient *utils.OIDCClient, oidcRepo repository.OIDCRepository) AuthService {
 	return &AuthServiceImpl{
 		jwtService:         jwtService,
 		userRepo:           userRepo,
 		acitvityLogRepo:    acitvityLogRepo,
 		roleRepo:           roleRepo,
 		passwordRepository: passwordRepository,
+		passwordPolicy:     passwordPolicy,
+		oidcClient:         oidcClient,
+		oidcRepo:           oidcRepo,
+	}
+}
+
+// OIDCAuthorize implements AuthService. It starts a social login and returns
+// the provider URL the app should open; the PKCE verifier and nonce stay on
+// the server until the callback.
+func (a *AuthServiceImpl) OIDCAuthorize(provider string) (*models.OIDCAuthorizeResponse, error) {
+	providerConfig, ok := a.oidcClient.Provider(provider)
+	if !ok {
+		return nil, errors.New("unknown identity provider")
+	}
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, codeChallenge, err := utils.GeneratePKCE()
+	if err != nil {
+		return nil, err
+	}
+
+	oidcState, err := a.oidcRepo.CreateState(models.OIDCState{
+		State:        state,
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(a.oidcClient.StateTTL()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OIDCAuthorizeResponse{
+		AuthorizationURL: a.oidcClient.AuthorizationURL(providerConfig, state, nonce, codeChallenge),
+		State:            state,
+		ExpiresAt:        oidcState.ExpiresAt,
+	}, nil
+}
+
+// OIDCCallback implements AuthService. It redeems the authorization code,
+// verifies the ID token and signs in the linked user. A user with the same
+// verified email is linked on first use, otherwise a new customer account is
+// created.
+func (a *AuthServiceImpl) OIDCCallback(provider string, req models.OIDCCallbackRequest, ipAddress, userAgent string) (*models.TokenResponse, error) {
+	providerConfig, ok := a.oidcClient.Provider(provider)
+	if !ok {
+		return nil, errors.New("unknown identity provider")
+	}
+
+	if req.Code == "" || req.State == "" {
+		return nil, errors.New("code and state are required")
+	}
+
+	oidcState, err := a.oidcRepo.ConsumeState(req.State)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired state")
+		}
+		return nil, err
+	}
+	if oidcState.Provider != provider || time.Now().After(oidcState.ExpiresAt) {
+		return nil, errors.New("invalid or expired state")
+	}
+
+	idToken, err := a.oidcClient.ExchangeCode(providerConfig, req.Code, oidcState.CodeVerifier)
+	if err != nil {
+		log.Printf("❌ OIDC ERROR: code exchange with %s failed: %v", provider, err)
+		return nil, errors.New("failed to sign in with identity provider")
+	}
+
+	claims, err := a.oidcClient.VerifyIDToken(providerConfig, idToken, oidcState.Nonce)
+	if err != nil {
+		log.Printf("❌ OIDC ERROR: id token from %s rejected: %v", provider, err)
+		return nil, errors.New("invalid id token")
+	}
+
+	var userResult models.User
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		userResult, err = a.findOrProvisionOIDCUser(provider, claims, tx)
+		if err != nil {
+			return err
+		}
+
+		if !userResult.IsActive {
+			return errors.New("account is deactivated")
+		}
+
+		now := time.Now()
+		userResult.LastLoginAt = &now
+
+		if err := tx.Save(&userResult).Error; err != nil {
+			return err
+		}
+
+		activityLog := models.ActivityLog{
+			UserID:    userResult.ID,
+			Action:    "login",
+			Resource:  "auth",
+			Details:   "User logged in with " + provider,
+			IPAddress: ipAddress,
+			UserAgent: userAgent,
+		}
+
+		if _, err := a.acitvityLogRepo.Create(activityLog, tx); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return a.generateTokenResponse(&userResult)
+}
+
+func (a *AuthServiceImpl) findOrProvisionOIDCUser(provider string, claims *utils.OIDCClaims, tx *gorm.DB) (models.User, error) {
+	identity, err := a.oidcRepo.FindIdentity(provider, claims.Subject)
+	if err == nil {
+		user, err := a.userRepo.FindById(identity.UserID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, errors.New("user not found")
+		}
+		return user, err
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, err
+	}
+
+	// Linking by email is only safe when the provider vouches for it,
+	// otherwise anyone could claim an existing account.
+	if claims.Email == "" || !claims.EmailVerified {
+		return models.User{}, errors.New("identity provider did not return a verified email")
+	}
+
+	user, err := a.userRepo.FindByEmail(claims.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.User{}, err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		user, err = a.provisionOIDCUser(claims, tx)
+		if err != nil {
+			return models.User{}, err
+		}
+	} else if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if _, err := a.oidcRepo.CreateIdentity(models.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}, tx); err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+// provisionOIDCUser creates a customer account for a first time social
+// login. It gets an unusable random password and is marked NoPassword until
+// the user sets one through the forgot password flow.
+func (a *AuthServiceImpl) provisionOIDCUser(claims *utils.OIDCClaims, tx *gorm.DB) (models.User, error) {
+	defaultRole, err := a.roleRepo.FindByName("User")
+	if err != nil {
+		return models.User{}, errors.New("default role not found")
+	}
+
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return models.User{}, err
+	}
+	hashedPassword, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	suffix, err := utils.GenerateRandomToken(3)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	localPart := strings.SplitN(claims.Email, "@", 2)[0]
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" {
+		firstName = claims.Name
+	}
+	if firstName == "" {
+		firstName = localPart
+	}
+
+	now := time.Now()
+	user := models.User{
+		Email:           claims.Email,
+		Username:        oidcUsername(localPart) + "_" + suffix,
+		PasswordHash:    hashedPassword,
+		NoPassword:      true,
+		FirstName:       firstName,
+		LastName:        lastName,
+		RoleID:          defaultRole.ID,
+		IsActive:        true,
+		EmailVerifiedAt: &now,
+	}
+
+	if err := tx.Create(&user).Error; err != nil {
+		return models.User{}, err
+	}
+
+	return user, nil
+}
+
+// oidcUsername keeps the letters, digits, dots and underscores of an email
+// local part, trimmed so the random suffix still fits the 50 character limit.
+func oidcUsername(localPart string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(localPart) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+
+	username := b.String()
+	if len(username) < 3 {
+		username = "user"
+	}
+	if len(username) > 40 {
+		username = username[:40]
 	}
+	return username
 }
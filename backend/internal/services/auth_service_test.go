@@ -21,10 +21,10 @@ func TestAuthService_SignUp(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test", AccessTokenExpiry: time.Hour, RefreshSecret: "refresh", RefreshTokenExpiry: time.Hour}}
+	cfg := &config.Config{JWT: config.JWTConfig{Algorithm: "HS256", Secret: "test", AccessTokenExpiry: time.Hour, RefreshSecret: "refresh", RefreshTokenExpiry: time.Hour}}
 	jwtService := utils.NewJWTService(cfg)
 
-	service := services.NewAuthService(jwtService, mockUserRepo, nil, mockRoleRepo, nil)
+	service := services.NewAuthService(jwtService, mockUserRepo, nil, mockRoleRepo, nil, services.NewPasswordPolicyService(cfg, nil), nil, nil)
 
 	t.Run("Success", func(t *testing.T) {
 		req := models.RegisterRequest{
@@ -82,10 +82,10 @@ func TestAuthService_SignIn(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockActivityRepo := mocks.NewMockActivityLogRepository(ctrl)
 
-	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test", AccessTokenExpiry: time.Hour, RefreshSecret: "refresh", RefreshTokenExpiry: time.Hour}}
+	cfg := &config.Config{JWT: config.JWTConfig{Algorithm: "HS256", Secret: "test", AccessTokenExpiry: time.Hour, RefreshSecret: "refresh", RefreshTokenExpiry: time.Hour}}
 	jwtService := utils.NewJWTService(cfg)
 
-	service := services.NewAuthService(jwtService, mockUserRepo, mockActivityRepo, nil, nil)
+	service := services.NewAuthService(jwtService, mockUserRepo, mockActivityRepo, nil, nil, services.NewPasswordPolicyService(cfg, nil), nil, nil)
 
 	t.Run("Success", func(t *testing.T) {
 		// Insert dependent Role data into the DB to satisfy FK constraints during Save
@@ -1,48 +1,160 @@
 package services
 
 import (
+	"e-commerce/backend/internal/database"
 	"e-commerce/backend/internal/models"
 	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
 	"fmt"
 
 	"gorm.io/gorm"
 )
 
 type CategoryService interface {
-	Create(param models.Category) (models.Category, error)
-	Update(param models.Category) (models.Category, error)
-	Delete(id int64) error
+	Create(param models.Category, actor models.ActivityContext) (models.Category, error)
+	Update(param models.Category, actor models.ActivityContext) (models.Category, error)
+	Delete(id int64, reassignTo int64, actor models.ActivityContext) error
 	GetById(id int64) (models.Category, error)
 	GetByIds(ids []int64) ([]models.Category, error)
 	GetAll(param models.CategoryListRequest) ([]models.Category, error)
+	GetBySlug(slug string) (models.Category, error)
+	GetTree() ([]models.CategoryTreeNode, error)
 }
 
 type CategoryServiceImpl struct {
-	repo repository.CategoryRepository
+	repo            repository.CategoryRepository
+	activityLogRepo repository.ActivityLogRepository
 }
 
-func NewCategoryService(repo repository.CategoryRepository) CategoryService {
+func NewCategoryService(repo repository.CategoryRepository, activityLogRepo repository.ActivityLogRepository) CategoryService {
 	return &CategoryServiceImpl{
-		repo: repo,
+		repo:            repo,
+		activityLogRepo: activityLogRepo,
 	}
 }
 
+// validateReturnWindow checks the return window of a category, if one was
+// given.
+func validateReturnWindow(days *int) error {
+	if days != nil && (*days < 0 || *days > 365) {
+		return fmt.Errorf("return window must be between 0 and 365 days")
+	}
+	return nil
+}
+
+// resolveSlug returns the slug a category is saved with. A slug that was
+// given is normalized and must be free; otherwise one is made from the name,
+// numbered "-2", "-3", ... until it is free.
+func (s *CategoryServiceImpl) resolveSlug(slug, name string, id int64) (string, error) {
+	if slug != "" {
+		normalized := utils.Slugify(slug)
+		if normalized == "" {
+			return "", fmt.Errorf("invalid category slug %q", slug)
+		}
+
+		exists, err := s.repo.SlugExists(normalized, id)
+		if err != nil {
+			return "", fmt.Errorf("failed to check category slug: %w", err)
+		}
+		if exists {
+			return "", fmt.Errorf("category slug is already used")
+		}
+		return normalized, nil
+	}
+
+	base := utils.Slugify(name)
+	if base == "" {
+		base = "category"
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		exists, err := s.repo.SlugExists(candidate, id)
+		if err != nil {
+			return "", fmt.Errorf("failed to check category slug: %w", err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// validateParent checks that parentID names a category that id can be moved
+// below. A nil or 0 parent makes the category a root.
+func (s *CategoryServiceImpl) validateParent(id int64, parentID *int64) error {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+
+	if *parentID == id {
+		return fmt.Errorf("category cannot be its own parent")
+	}
+
+	if _, err := s.repo.FindById(*parentID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("parent category not found")
+		}
+		return fmt.Errorf("failed to check parent category: %w", err)
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	categories, err := s.repo.FindAllTree()
+	if err != nil {
+		return fmt.Errorf("failed to check parent category: %w", err)
+	}
+	if models.NewCategoryTree(categories).IsDescendant(*parentID, id) {
+		return fmt.Errorf("category cannot be moved below its own subcategory")
+	}
+	return nil
+}
+
 // Create implements CategoryService.
-func (s *CategoryServiceImpl) Create(param models.Category) (models.Category, error) {
+func (s *CategoryServiceImpl) Create(param models.Category, actor models.ActivityContext) (models.Category, error) {
 	if param.Name == "" {
 		return models.Category{}, fmt.Errorf("category name cannot be empty")
 	}
 
-	result, err := s.repo.Create(param, nil)
+	if err := validateReturnWindow(param.ReturnWindowDays); err != nil {
+		return models.Category{}, err
+	}
+
+	if err := s.validateParent(0, param.ParentID); err != nil {
+		return models.Category{}, err
+	}
+	if param.ParentID != nil && *param.ParentID == 0 {
+		param.ParentID = nil
+	}
+
+	slug, err := s.resolveSlug(param.Slug, param.Name, 0)
+	if err != nil {
+		return models.Category{}, err
+	}
+	param.Slug = slug
+
+	var result models.Category
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = s.repo.Create(param, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create category: %w", err)
+		}
+
+		details := fmt.Sprintf("Created category %q (ID %d)", result.Name, result.ID)
+		return logActivity(s.activityLogRepo, actor, "categories", fmt.Sprint(result.ID), "create", details, tx)
+	})
 	if err != nil {
-		return models.Category{}, fmt.Errorf("failed to create category: %w", err)
+		return models.Category{}, err
 	}
 
 	return result, nil
 }
 
 // Update implements CategoryService.
-func (s *CategoryServiceImpl) Update(param models.Category) (models.Category, error) {
+func (s *CategoryServiceImpl) Update(param models.Category, actor models.ActivityContext) (models.Category, error) {
 	if param.ID == 0 {
 		return models.Category{}, fmt.Errorf("category id cannot be empty")
 	}
@@ -51,6 +163,10 @@ func (s *CategoryServiceImpl) Update(param models.Category) (models.Category, er
 		return models.Category{}, fmt.Errorf("category name cannot be empty")
 	}
 
+	if err := validateReturnWindow(param.ReturnWindowDays); err != nil {
+		return models.Category{}, err
+	}
+
 	// Check if category exists
 	existing, err := s.repo.FindById(param.ID)
 	if err != nil {
@@ -60,23 +176,53 @@ func (s *CategoryServiceImpl) Update(param models.Category) (models.Category, er
 		return models.Category{}, fmt.Errorf("failed to check category: %w", err)
 	}
 
+	if err := s.validateParent(param.ID, param.ParentID); err != nil {
+		return models.Category{}, err
+	}
+
+	// An empty slug keeps the current one
+	if param.Slug != "" {
+		param.Slug, err = s.resolveSlug(param.Slug, param.Name, param.ID)
+		if err != nil {
+			return models.Category{}, err
+		}
+	}
+
 	// Preserve fields that shouldn't be updated
 	param.CreatedAt = existing.CreatedAt
 
-	result, err := s.repo.Update(param, nil)
+	var result models.Category
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = s.repo.Update(param, tx)
+		if err != nil {
+			return fmt.Errorf("failed to update category: %w", err)
+		}
+
+		details := fmt.Sprintf("Updated category ID %d: name %q -> %q, icon %q -> %q",
+			result.ID, existing.Name, result.Name, existing.Icon, result.Icon)
+		return logActivity(s.activityLogRepo, actor, "categories", fmt.Sprint(result.ID), "update", details, tx)
+	})
 	if err != nil {
-		return models.Category{}, fmt.Errorf("failed to update category: %w", err)
+		return models.Category{}, err
 	}
 
 	return result, nil
 }
 
 // Delete implements CategoryService.
-func (s *CategoryServiceImpl) Delete(id int64) error {
+// Subcategories always block the deletion. Products still referencing the
+// category block it too unless reassignTo names another category to move
+// them to first.
+func (s *CategoryServiceImpl) Delete(id int64, reassignTo int64, actor models.ActivityContext) error {
 	if id == 0 {
 		return fmt.Errorf("category id cannot be empty")
 	}
 
+	if reassignTo == id {
+		return fmt.Errorf("cannot reassign products to the category being deleted")
+	}
+
 	// Check if category exists
 	category, err := s.repo.FindById(id)
 	if err != nil {
@@ -86,12 +232,50 @@ func (s *CategoryServiceImpl) Delete(id int64) error {
 		return fmt.Errorf("failed to check category: %w", err)
 	}
 
-	err = s.repo.Delete(category)
-	if err != nil {
-		return fmt.Errorf("failed to delete category: %w", err)
+	var target models.Category
+	if reassignTo != 0 {
+		target, err = s.repo.FindById(reassignTo)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("target category not found")
+			}
+			return fmt.Errorf("failed to check target category: %w", err)
+		}
 	}
 
-	return nil
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		childCount, err := s.repo.CountChildren(category.ID, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count subcategories: %w", err)
+		}
+		if childCount > 0 {
+			return fmt.Errorf("category still has subcategories")
+		}
+
+		productCount, err := s.repo.CountProducts(category.ID, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count category products: %w", err)
+		}
+
+		details := fmt.Sprintf("Deleted category %q (ID %d)", category.Name, category.ID)
+		if productCount > 0 {
+			if reassignTo == 0 {
+				return fmt.Errorf("category is still used by products")
+			}
+
+			if err := s.repo.ReassignProducts(category.ID, target.ID, tx); err != nil {
+				return fmt.Errorf("failed to reassign products: %w", err)
+			}
+			details = fmt.Sprintf("%s, moved %d products to %q (ID %d)",
+				details, productCount, target.Name, target.ID)
+		}
+
+		if err := s.repo.Delete(category, tx); err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+
+		return logActivity(s.activityLogRepo, actor, "categories", fmt.Sprint(category.ID), "delete", details, tx)
+	})
 }
 
 // GetById implements CategoryService.
@@ -146,3 +330,30 @@ func (s *CategoryServiceImpl) GetAll(param models.CategoryListRequest) ([]models
 
 	return categories, nil
 }
+
+// GetBySlug implements CategoryService.
+func (s *CategoryServiceImpl) GetBySlug(slug string) (models.Category, error) {
+	if slug == "" {
+		return models.Category{}, fmt.Errorf("category slug cannot be empty")
+	}
+
+	category, err := s.repo.FindBySlug(slug)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return models.Category{}, fmt.Errorf("category not found")
+		}
+		return models.Category{}, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+// GetTree implements CategoryService.
+func (s *CategoryServiceImpl) GetTree() ([]models.CategoryTreeNode, error) {
+	categories, err := s.repo.FindAllTree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	return models.NewCategoryTree(categories).Nodes(), nil
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type CurrencyService interface {
+	CreateCurrency(actor models.ActivityContext, param models.CreateCurrency) (*models.CurrencyResponse, error)
+	UpdateCurrency(actor models.ActivityContext, param models.CreateCurrency) (*models.CurrencyResponse, error)
+	DeleteCurrency(actor models.ActivityContext, code string) error
+	FindAll(activeOnly bool) ([]models.CurrencyResponse, error)
+	ImportRates(actor models.ActivityContext, param models.ImportExchangeRates) (*models.ExchangeRateImportResponse, error)
+	Resolve(code string) (*models.Currency, error)
+}
+
+type CurrencyServiceImpl struct {
+	currencyRepo    repository.CurrencyRepository
+	activityLogRepo repository.ActivityLogRepository
+}
+
+func NewCurrencyService(currencyRepo repository.CurrencyRepository, activityLogRepo repository.ActivityLogRepository) CurrencyService {
+	return &CurrencyServiceImpl{
+		currencyRepo:    currencyRepo,
+		activityLogRepo: activityLogRepo,
+	}
+}
+
+// CreateCurrency implements CurrencyService.
+func (s *CurrencyServiceImpl) CreateCurrency(actor models.ActivityContext, param models.CreateCurrency) (*models.CurrencyResponse, error) {
+	currency, err := currencyFromParam(param)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.currencyRepo.FindByCode(currency.Code); err == nil {
+		return nil, errors.New("currency already exists")
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		created, err := s.currencyRepo.Create(currency, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create currency: %w", err)
+		}
+		currency = created
+
+		details := fmt.Sprintf("Created currency %s at %s", currency.Code, formatExchangeRate(currency.Rate))
+		return logActivity(s.activityLogRepo, actor, "currencies", currency.Code, "create", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return currency.ToResponse(), nil
+}
+
+// UpdateCurrency implements CurrencyService. A new rate applies to prices
+// shown from now on; transactions keep the rate they were placed with.
+func (s *CurrencyServiceImpl) UpdateCurrency(actor models.ActivityContext, param models.CreateCurrency) (*models.CurrencyResponse, error) {
+	existing, err := s.currencyRepo.FindByCode(strings.ToUpper(strings.TrimSpace(param.Code)))
+	if err != nil {
+		return nil, errors.New("currency not found")
+	}
+
+	currency, err := currencyFromParam(param)
+	if err != nil {
+		return nil, err
+	}
+	if param.IsActive == nil {
+		currency.IsActive = existing.IsActive
+	}
+	currency.CreatedAt = existing.CreatedAt
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		updated, err := s.currencyRepo.Update(currency, tx)
+		if err != nil {
+			return fmt.Errorf("failed to update currency: %w", err)
+		}
+		currency = updated
+
+		details := fmt.Sprintf("Updated currency %s: rate %s -> %s", currency.Code, formatExchangeRate(existing.Rate), formatExchangeRate(currency.Rate))
+		return logActivity(s.activityLogRepo, actor, "currencies", currency.Code, "update", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return currency.ToResponse(), nil
+}
+
+// DeleteCurrency implements CurrencyService.
+func (s *CurrencyServiceImpl) DeleteCurrency(actor models.ActivityContext, code string) error {
+	currency, err := s.currencyRepo.FindByCode(strings.ToUpper(code))
+	if err != nil {
+		return errors.New("currency not found")
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := s.currencyRepo.Delete(currency.Code, tx); err != nil {
+			return fmt.Errorf("failed to delete currency: %w", err)
+		}
+
+		return logActivity(s.activityLogRepo, actor, "currencies", currency.Code, "delete", fmt.Sprintf("Deleted currency %s", currency.Code), tx)
+	})
+}
+
+// FindAll implements CurrencyService. The store currency comes first.
+func (s *CurrencyServiceImpl) FindAll(activeOnly bool) ([]models.CurrencyResponse, error) {
+	currencies, err := s.currencyRepo.FindAll(activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currencies: %w", err)
+	}
+
+	results := make([]models.CurrencyResponse, 0, len(currencies)+1)
+	results = append(results, *models.StoreCurrencyInfo().ToResponse())
+	for _, currency := range currencies {
+		results = append(results, *currency.ToResponse())
+	}
+	return results, nil
+}
+
+// ImportRates implements CurrencyService. The whole file is applied or,
+// when a line is invalid, none of it. Currencies that are not in the table
+// yet are added.
+func (s *CurrencyServiceImpl) ImportRates(actor models.ActivityContext, param models.ImportExchangeRates) (*models.ExchangeRateImportResponse, error) {
+	rates, err := utils.ParseExchangeRates(param.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ExchangeRateImportResponse{Codes: []string{}}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, rate := range rates {
+			if rate.Code == models.StoreCurrency {
+				return fmt.Errorf("%s is the store currency and has no rate", rate.Code)
+			}
+
+			existing, err := s.currencyRepo.FindByCode(rate.Code)
+			if err != nil {
+				currency := models.Currency{
+					Code:     rate.Code,
+					Name:     rate.Name,
+					Symbol:   rate.Symbol,
+					Rate:     rate.Rate,
+					IsActive: true,
+				}
+				if currency.Name == "" {
+					currency.Name = rate.Code
+				}
+				if _, err := s.currencyRepo.Create(currency, tx); err != nil {
+					return fmt.Errorf("failed to create currency %s: %w", rate.Code, err)
+				}
+				result.Created++
+				result.Codes = append(result.Codes, rate.Code)
+				continue
+			}
+
+			if existing.Rate == rate.Rate && (rate.Name == "" || rate.Name == existing.Name) && (rate.Symbol == "" || rate.Symbol == existing.Symbol) {
+				result.Unchanged++
+				continue
+			}
+
+			existing.Rate = rate.Rate
+			if rate.Name != "" {
+				existing.Name = rate.Name
+			}
+			if rate.Symbol != "" {
+				existing.Symbol = rate.Symbol
+			}
+			if _, err := s.currencyRepo.Update(*existing, tx); err != nil {
+				return fmt.Errorf("failed to update currency %s: %w", rate.Code, err)
+			}
+			result.Updated++
+			result.Codes = append(result.Codes, rate.Code)
+		}
+
+		details := fmt.Sprintf("Imported exchange rates from %s: %d created, %d updated, %d unchanged", param.Filename, result.Created, result.Updated, result.Unchanged)
+		return logActivity(s.activityLogRepo, actor, "currencies", "", "import", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Resolve implements CurrencyService. An empty code means the store
+// currency; other codes must be active currencies.
+func (s *CurrencyServiceImpl) Resolve(code string) (*models.Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || code == models.StoreCurrency {
+		return models.StoreCurrencyInfo(), nil
+	}
+
+	currency, err := s.currencyRepo.FindByCode(code)
+	if err != nil || !currency.IsActive {
+		return nil, fmt.Errorf("currency %s is not available", code)
+	}
+	return currency, nil
+}
+
+func currencyFromParam(param models.CreateCurrency) (models.Currency, error) {
+	currency := models.Currency{
+		Code:     strings.ToUpper(strings.TrimSpace(param.Code)),
+		Name:     strings.TrimSpace(param.Name),
+		Symbol:   strings.TrimSpace(param.Symbol),
+		Rate:     param.Rate,
+		IsActive: param.IsActive == nil || *param.IsActive,
+	}
+
+	if !utils.IsCurrencyCode(currency.Code) {
+		return currency, errors.New("currency code must be 3 letters")
+	}
+	if currency.Code == models.StoreCurrency {
+		return currency, fmt.Errorf("%s is the store currency and has no rate", currency.Code)
+	}
+	if currency.Name == "" {
+		return currency, errors.New("currency name is required")
+	}
+	if currency.Rate <= 0 {
+		return currency, errors.New("exchange rate must be greater than 0")
+	}
+	return currency, nil
+}
+
+// formatExchangeRate prints a rate without trailing zeros, e.g. 16250.5.
+func formatExchangeRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
@@ -15,6 +15,7 @@ type DashboardService interface {
 	GetRecentOrders(ctx context.Context, limit int) (*models.RecentOrdersResponse, error)
 	GetTopProducts(ctx context.Context, limit int) (*models.TopProductsResponse, error)
 	GetLowStockProducts(ctx context.Context, threshold, limit int) (*models.LowStockProductsResponse, error)
+	GetMostWishedProducts(ctx context.Context, days, limit int) (*models.MostWishedProductsResponse, error)
 	GetOrderAnalytics(ctx context.Context, days int) (*models.OrderAnalyticsResponse, error)
 	GetUserGrowth(ctx context.Context, days int) (*models.UserGrowthResponse, error)
 	GetSystemHealth(ctx context.Context) (*models.SystemHealthResponse, error)
@@ -119,11 +120,24 @@ func (s *dashboardService) GetRevenueStats(ctx context.Context) (*models.Revenue
 		return nil, err
 	}
 
+	totalTax, err := s.dashboardRepo.GetTaxByPeriod(ctx, time.Time{}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	taxThisMonth, err := s.dashboardRepo.GetTaxByPeriod(ctx, firstOfMonth, firstOfMonth.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.RevenueStatsResponse{
 		TotalRevenue:     totalRevenue,
 		RevenueToday:     revenueToday,
 		RevenueThisMonth: revenueThisMonth,
 		RevenueThisWeek:  revenueThisWeek,
+		TotalTax:         totalTax,
+		TaxThisMonth:     taxThisMonth,
 	}, nil
 }
 
@@ -212,6 +226,21 @@ func (s *dashboardService) GetLowStockProducts(ctx context.Context, threshold, l
 	}, nil
 }
 
+// GetMostWishedProducts ranks products by how many customers wishlisted
+// them; recent_count counts those who did in the last days.
+func (s *dashboardService) GetMostWishedProducts(ctx context.Context, days, limit int) (*models.MostWishedProductsResponse, error) {
+	since := time.Now().AddDate(0, 0, -days)
+	products, err := s.dashboardRepo.GetMostWishedProducts(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MostWishedProductsResponse{
+		Days:     days,
+		Products: products,
+	}, nil
+}
+
 func (s *dashboardService) GetOrderAnalytics(ctx context.Context, days int) (*models.OrderAnalyticsResponse, error) {
 	analytics, err := s.dashboardRepo.GetOrderAnalytics(ctx, days)
 	if err != nil {
@@ -219,15 +248,15 @@ func (s *dashboardService) GetOrderAnalytics(ctx context.Context, days int) (*mo
 	}
 
 	var totalOrders int64
-	var totalRevenue float64
+	var totalRevenue models.Money
 	for _, a := range analytics {
 		totalOrders += a.OrderCount
 		totalRevenue += a.Revenue
 	}
 
-	averageOrder := float64(0)
+	var averageOrder models.Money
 	if totalOrders > 0 {
-		averageOrder = totalRevenue / float64(totalOrders)
+		averageOrder = totalRevenue.Div(totalOrders)
 	}
 
 	return &models.OrderAnalyticsResponse{
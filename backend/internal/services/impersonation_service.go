@@ -0,0 +1,100 @@
+package services
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type ImpersonationService interface {
+	Impersonate(actor models.ActivityContext, req models.ImpersonationRequest) (*models.ImpersonationResponse, error)
+}
+
+type ImpersonationServiceImpl struct {
+	jwtService      *utils.JWTService
+	userRepo        repository.UserRepository
+	rbacService     RBACService
+	activityLogRepo repository.ActivityLogRepository
+}
+
+func NewImpersonationService(jwtService *utils.JWTService, userRepo repository.UserRepository, rbacService RBACService, activityLogRepo repository.ActivityLogRepository) ImpersonationService {
+	return &ImpersonationServiceImpl{
+		jwtService:      jwtService,
+		userRepo:        userRepo,
+		rbacService:     rbacService,
+		activityLogRepo: activityLogRepo,
+	}
+}
+
+// Impersonate implements ImpersonationService. Only super admins may
+// impersonate, and only users below them in the role hierarchy.
+func (s *ImpersonationServiceImpl) Impersonate(actor models.ActivityContext, req models.ImpersonationRequest) (*models.ImpersonationResponse, error) {
+	if actor.ImpersonatorID != nil {
+		return nil, errors.New("cannot impersonate while impersonating")
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	if req.UserID == actor.UserID {
+		return nil, errors.New("cannot impersonate yourself")
+	}
+
+	isSuperAdmin, err := s.rbacService.HasExactRole(actor.UserID, "super_admin")
+	if err != nil {
+		return nil, err
+	}
+	if !isSuperAdmin {
+		return nil, errors.New("only super admins can impersonate users")
+	}
+
+	target, err := s.userRepo.FindById(req.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	if !target.IsActive {
+		return nil, errors.New("cannot impersonate a deactivated user")
+	}
+
+	canManage, err := s.rbacService.CanManageUser(actor.UserID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("cannot impersonate a user with an equal or higher role")
+	}
+
+	accessToken, expiresAt, err := s.jwtService.GenerateImpersonationToken(&target, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	activityLog := models.ActivityLog{
+		UserID:    actor.UserID,
+		Action:    "impersonate",
+		Resource:  "users",
+		Details:   fmt.Sprintf("Started impersonating user %d (%s) until %s: %s", target.ID, target.Email, expiresAt.Format("2006-01-02 15:04:05"), strings.TrimSpace(req.Reason)),
+		IPAddress: actor.IPAddress,
+		UserAgent: actor.UserAgent,
+	}
+	if _, err := s.activityLogRepo.Create(activityLog, nil); err != nil {
+		return nil, err
+	}
+
+	return &models.ImpersonationResponse{
+		AccessToken:    accessToken,
+		ExpiresAt:      expiresAt,
+		ImpersonatorID: actor.UserID,
+		User:           *target.ToResponse(),
+	}, nil
+}
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// uninvoiceableTransactionStatuses are the statuses of a transaction that
+// was never paid, so it gets neither an invoice nor a packing slip.
+var uninvoiceableTransactionStatuses = map[string]bool{
+	utils.WaitingPayment:       true,
+	utils.WaitingConfirPayment: true,
+	utils.Pending:              true,
+	utils.Cancelled:            true,
+}
+
+type InvoiceService interface {
+	Render(document, transactionID string) ([]byte, error)
+	RenderMine(document, transactionID string, userID uint) ([]byte, error)
+	RenderBulk(param models.BulkDocumentRequest) ([]byte, error)
+}
+
+type InvoiceServiceImpl struct {
+	invoiceRepo repository.InvoiceRepository
+	store       config.StoreConfig
+}
+
+func NewInvoiceService(cfg *config.Config, invoiceRepo repository.InvoiceRepository) InvoiceService {
+	return &InvoiceServiceImpl{
+		invoiceRepo: invoiceRepo,
+		store:       cfg.Store,
+	}
+}
+
+// Render implements InvoiceService. It returns a PDF of the invoice or
+// packing slip of a transaction.
+func (s *InvoiceServiceImpl) Render(document, transactionID string) ([]byte, error) {
+	return s.RenderBulk(models.BulkDocumentRequest{
+		TransactionIDs: []string{transactionID},
+		Document:       document,
+	})
+}
+
+// RenderMine implements InvoiceService. Customers only get documents of
+// their own transactions.
+func (s *InvoiceServiceImpl) RenderMine(document, transactionID string, userID uint) ([]byte, error) {
+	ownerID, err := s.invoiceRepo.FindTransactionOwner(transactionID)
+	if err != nil || ownerID != int64(userID) {
+		return nil, errors.New("transaction not found")
+	}
+
+	return s.Render(document, transactionID)
+}
+
+// RenderBulk implements InvoiceService. Every transaction starts on a new
+// page of a single PDF, in the order requested.
+func (s *InvoiceServiceImpl) RenderBulk(param models.BulkDocumentRequest) ([]byte, error) {
+	if param.Document != models.InvoiceDocument && param.Document != models.PackingSlipDocument {
+		return nil, errors.New("invalid document type")
+	}
+	if len(param.TransactionIDs) == 0 {
+		return nil, errors.New("at least one transaction is required")
+	}
+	if len(param.TransactionIDs) > 100 {
+		return nil, errors.New("at most 100 transactions can be printed at once")
+	}
+
+	doc := utils.NewPDFDocument()
+	for _, transactionID := range param.TransactionIDs {
+		transaction, err := s.invoiceRepo.FindTransaction(strings.TrimSpace(transactionID))
+		if err != nil {
+			return nil, errors.New("transaction not found")
+		}
+
+		if uninvoiceableTransactionStatuses[transaction.Status] {
+			return nil, fmt.Errorf("transaction %s with status %s has no %s", transaction.TxID, transaction.Status, documentLabel(param.Document))
+		}
+
+		if param.Document == models.PackingSlipDocument {
+			s.drawPackingSlip(doc, transaction)
+			continue
+		}
+
+		invoice, err := s.issueInvoice(transaction)
+		if err != nil {
+			return nil, err
+		}
+		s.drawInvoice(doc, transaction, invoice)
+	}
+
+	return doc.Bytes(), nil
+}
+
+// issueInvoice returns the invoice of a transaction, numbering it on first
+// use. Numbers are taken in the order invoices are first generated.
+func (s *InvoiceServiceImpl) issueInvoice(transaction models.Transaction) (models.Invoice, error) {
+	if invoice, err := s.invoiceRepo.FindByTransaction(transaction.TxID, nil); err == nil {
+		return invoice, nil
+	}
+
+	var invoice models.Invoice
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		issuedAt := time.Now()
+		sequence, err := s.invoiceRepo.NextSequence(tx, issuedAt.Year())
+		if err != nil {
+			return fmt.Errorf("failed to issue invoice number: %w", err)
+		}
+
+		// Another request may have issued it while we waited for the lock.
+		if existing, err := s.invoiceRepo.FindByTransaction(transaction.TxID, tx); err == nil {
+			invoice = existing
+			return errInvoiceExists
+		}
+
+		invoice, err = s.invoiceRepo.Create(models.Invoice{
+			Number:        utils.FormatInvoiceNumber(issuedAt.Year(), sequence),
+			TransactionID: transaction.TxID,
+			Year:          issuedAt.Year(),
+			Sequence:      sequence,
+			IssuedAt:      issuedAt,
+		}, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create invoice: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, errInvoiceExists) {
+		return invoice, nil
+	}
+	return invoice, err
+}
+
+// errInvoiceExists rolls back a sequence taken for an invoice that another
+// request issued first.
+var errInvoiceExists = errors.New("invoice already issued")
+
+// Page layout, in points.
+const (
+	docMarginLeft   = 40.0
+	docMarginRight  = utils.PDFPageWidth - 40
+	docBottom       = utils.PDFPageHeight - 60
+	docLineHeight   = 14.0
+	docFontSize     = 9.0
+	docHeadingSize  = 18.0
+	docItemColWidth = 250.0
+)
+
+// drawInvoice adds the pages of one invoice to doc.
+func (s *InvoiceServiceImpl) drawInvoice(doc *utils.PDFDocument, transaction models.Transaction, invoice models.Invoice) {
+	doc.AddPage()
+	y := s.drawHeader(doc, "INVOICE")
+
+	doc.Text(docMarginLeft, y, docFontSize, true, "Bill to")
+	doc.TextRight(docMarginRight-150, y, docFontSize, true, "Invoice no.")
+	doc.TextRight(docMarginRight, y, docFontSize, false, invoice.Number)
+	y += docLineHeight
+
+	details := [][2]string{
+		{"Date", invoice.IssuedAt.Format("02 Jan 2006")},
+		{"Transaction", transaction.TxID},
+		{"Order date", transaction.CreatedAt.Format("02 Jan 2006")},
+		{"Payment", paymentMethodLabel(transaction.PaymentMethod)},
+	}
+	addressLines := addressLabel(transaction.Address)
+	for i := 0; i < len(details) || i < len(addressLines); i++ {
+		if i < len(addressLines) {
+			doc.Text(docMarginLeft, y, docFontSize, false, utils.FitPDFText(addressLines[i], docFontSize, false, 260))
+		}
+		if i < len(details) {
+			doc.TextRight(docMarginRight-150, y, docFontSize, true, details[i][0])
+			doc.TextRight(docMarginRight, y, docFontSize, false, utils.FitPDFText(details[i][1], docFontSize, false, 140))
+		}
+		y += docLineHeight
+	}
+	y += docLineHeight
+
+	columns := []struct {
+		label string
+		right float64
+	}{
+		{"Qty", docMarginLeft + docItemColWidth + 40},
+		{"Unit price", docMarginLeft + docItemColWidth + 120},
+		{"Tax", docMarginLeft + docItemColWidth + 180},
+		{"Amount", docMarginRight},
+	}
+	drawColumns := func() {
+		doc.Text(docMarginLeft, y, docFontSize, true, "Item")
+		for _, column := range columns {
+			doc.TextRight(column.right, y, docFontSize, true, column.label)
+		}
+		doc.Line(docMarginLeft, y+4, docMarginRight, y+4)
+		y += docLineHeight + 2
+	}
+	drawColumns()
+
+	var itemsTotal models.Money
+	for _, order := range transaction.Orders {
+		if y > docBottom {
+			doc.AddPage()
+			y = 60
+			drawColumns()
+		}
+
+		doc.Text(docMarginLeft, y, docFontSize, false, utils.FitPDFText(orderLabel(order), docFontSize, false, docItemColWidth))
+		doc.TextRight(columns[0].right, y, docFontSize, false, fmt.Sprintf("%d", order.Quantity))
+		doc.TextRight(columns[1].right, y, docFontSize, false, utils.FormatRupiah(order.UnitPrice))
+		doc.TextRight(columns[2].right, y, docFontSize, false, formatTaxRate(order.TaxRate))
+		doc.TextRight(columns[3].right, y, docFontSize, false, utils.FormatRupiah(order.Subtotal))
+		itemsTotal += order.Subtotal
+		y += docLineHeight
+	}
+	doc.Line(docMarginLeft, y-8, docMarginRight, y-8)
+	y += 4
+
+	// TotalPrice is the items plus, when prices exclude tax, the tax on top;
+	// so it equals the items total exactly when tax was included.
+	taxIncluded := transaction.TotalPrice == itemsTotal
+
+	totals := [][2]string{{"Subtotal", utils.FormatRupiah(itemsTotal)}}
+	for _, line := range transaction.TaxLines {
+		label := fmt.Sprintf("%s %s", line.Name, formatTaxRate(line.Rate))
+		if taxIncluded {
+			label += " (included)"
+		}
+		totals = append(totals, [2]string{label, utils.FormatRupiah(line.TaxAmount)})
+	}
+	shippingLabel := "Shipping"
+	if transaction.Shipping != nil {
+		shippingLabel = fmt.Sprintf("Shipping (%s)", transaction.Shipping.Name)
+	}
+	totals = append(totals, [2]string{shippingLabel, utils.FormatRupiah(transaction.ShippingPrice)})
+
+	if y+float64(len(totals)+1)*docLineHeight > docBottom {
+		doc.AddPage()
+		y = 60
+	}
+	for _, total := range totals {
+		doc.TextRight(docMarginRight-120, y, docFontSize, false, utils.FitPDFText(total[0], docFontSize, false, 200))
+		doc.TextRight(docMarginRight, y, docFontSize, false, total[1])
+		y += docLineHeight
+	}
+	doc.Line(docMarginRight-250, y-8, docMarginRight, y-8)
+	y += 4
+	doc.TextRight(docMarginRight-120, y, docFontSize+1, true, "Total")
+	doc.TextRight(docMarginRight, y, docFontSize+1, true, utils.FormatRupiah(transaction.TotalPrice+transaction.ShippingPrice))
+}
+
+// drawPackingSlip adds the pages of one packing slip to doc. It has no
+// prices; cancelled orders are left out.
+func (s *InvoiceServiceImpl) drawPackingSlip(doc *utils.PDFDocument, transaction models.Transaction) {
+	doc.AddPage()
+	y := s.drawHeader(doc, "PACKING SLIP")
+
+	doc.Text(docMarginLeft, y, docFontSize, true, "Ship to")
+	doc.TextRight(docMarginRight-150, y, docFontSize, true, "Transaction")
+	doc.TextRight(docMarginRight, y, docFontSize, false, utils.FitPDFText(transaction.TxID, docFontSize, false, 140))
+	y += docLineHeight
+
+	details := [][2]string{{"Order date", transaction.CreatedAt.Format("02 Jan 2006")}}
+	if transaction.Shipping != nil {
+		details = append(details, [2]string{"Shipping", transaction.Shipping.Name})
+	}
+	addressLines := addressLabel(transaction.Address)
+	for i := 0; i < len(details) || i < len(addressLines); i++ {
+		if i < len(addressLines) {
+			doc.Text(docMarginLeft, y, docFontSize, false, utils.FitPDFText(addressLines[i], docFontSize, false, 260))
+		}
+		if i < len(details) {
+			doc.TextRight(docMarginRight-150, y, docFontSize, true, details[i][0])
+			doc.TextRight(docMarginRight, y, docFontSize, false, utils.FitPDFText(details[i][1], docFontSize, false, 140))
+		}
+		y += docLineHeight
+	}
+	y += docLineHeight
+
+	const (
+		colorLeft = docMarginLeft + 230
+		sizeLeft  = docMarginLeft + 350
+		qtyRight  = docMarginLeft + 440
+	)
+	drawColumns := func() {
+		doc.Text(docMarginLeft, y, docFontSize, true, "Product")
+		doc.Text(colorLeft, y, docFontSize, true, "Variant")
+		doc.Text(sizeLeft, y, docFontSize, true, "Size")
+		doc.TextRight(qtyRight, y, docFontSize, true, "Qty")
+		doc.TextRight(docMarginRight, y, docFontSize, true, "Checked")
+		doc.Line(docMarginLeft, y+4, docMarginRight, y+4)
+		y += docLineHeight + 2
+	}
+	drawColumns()
+
+	var items, grams int64
+	for _, order := range transaction.Orders {
+		if order.Status == "cancelled" {
+			continue
+		}
+		if y > docBottom {
+			doc.AddPage()
+			y = 60
+			drawColumns()
+		}
+
+		doc.Text(docMarginLeft, y, docFontSize, false, utils.FitPDFText(productName(order), docFontSize, false, 220))
+		doc.Text(colorLeft, y, docFontSize, false, utils.FitPDFText(order.ColorVarian.Name, docFontSize, false, 110))
+		doc.Text(sizeLeft, y, docFontSize, false, order.SizeVarian.Size)
+		doc.TextRight(qtyRight, y, docFontSize, false, fmt.Sprintf("%d", order.Quantity))
+		doc.Line(docMarginRight-30, y+2, docMarginRight, y+2)
+		items += order.Quantity
+		grams += order.Product.WeightGrams * order.Quantity
+		y += docLineHeight
+	}
+	y += docLineHeight
+
+	doc.Text(docMarginLeft, y, docFontSize, true, fmt.Sprintf("Total items: %d", items))
+	if grams > 0 {
+		doc.TextRight(docMarginRight, y, docFontSize, true, fmt.Sprintf("Total weight: %.2f kg", float64(grams)/1000))
+	}
+}
+
+// drawHeader draws the store details and the document title, returning
+// where the body starts.
+func (s *InvoiceServiceImpl) drawHeader(doc *utils.PDFDocument, title string) float64 {
+	y := 60.0
+	name := s.store.Name
+	if name == "" {
+		name = "Store"
+	}
+	doc.Text(docMarginLeft, y, 14, true, utils.FitPDFText(name, 14, true, 300))
+	doc.TextRight(docMarginRight, y, docHeadingSize, true, title)
+	y += docLineHeight + 4
+
+	for _, line := range []string{s.store.Address, s.store.Phone, s.store.Email} {
+		if line == "" {
+			continue
+		}
+		doc.Text(docMarginLeft, y, docFontSize, false, utils.FitPDFText(line, docFontSize, false, 300))
+		y += docLineHeight
+	}
+	if s.store.TaxID != "" {
+		doc.Text(docMarginLeft, y, docFontSize, false, "NPWP: "+s.store.TaxID)
+		y += docLineHeight
+	}
+
+	y += 4
+	doc.Line(docMarginLeft, y, docMarginRight, y)
+	return y + docLineHeight + 6
+}
+
+func documentLabel(document string) string {
+	if document == models.PackingSlipDocument {
+		return "packing slip"
+	}
+	return "invoice"
+}
+
+func addressLabel(address *models.Address) []string {
+	if address == nil {
+		return nil
+	}
+
+	return []string{
+		address.RecipientName,
+		address.RecipientPhoneNumber,
+		address.FullAddress,
+		strings.Join([]string{address.Village, address.District}, ", "),
+		fmt.Sprintf("%s, %s %s", address.City, address.Province, address.PostalCode),
+	}
+}
+
+func paymentMethodLabel(method *models.PaymentMethod) string {
+	if method == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s %s", method.BankName, method.AccountNumber)
+}
+
+func productName(order models.Order) string {
+	if order.Product.Name == "" {
+		return fmt.Sprintf("Product #%d", order.ProductID)
+	}
+	return order.Product.Name
+}
+
+func orderLabel(order models.Order) string {
+	label := productName(order)
+	var variant []string
+	if order.ColorVarian.Name != "" {
+		variant = append(variant, order.ColorVarian.Name)
+	}
+	if order.SizeVarian.Size != "" {
+		variant = append(variant, order.SizeVarian.Size)
+	}
+	if len(variant) > 0 {
+		label += " - " + strings.Join(variant, " / ")
+	}
+	return label
+}
+
+func formatTaxRate(rate float64) string {
+	if rate == 0 {
+		return "-"
+	}
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", rate), "0"), ".") + "%"
+}
@@ -5,6 +5,7 @@ import (
 	"e-commerce/backend/internal/repository"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type OrderService interface {
@@ -99,6 +100,12 @@ func (o *OrderServiceImpl) UpdateOrder(param models.UpdateOrder) (*models.OrderR
 
 	order.Status = param.Status
 
+	// Completed means delivered; the return window starts here.
+	if order.Status == "completed" && order.DeliveredAt == nil {
+		now := time.Now()
+		order.DeliveredAt = &now
+	}
+
 	updatedOrder, err := o.orderRepo.Update(order, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update order: %w", err)
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"log"
+)
+
+type PasswordPolicyService interface {
+	Validate(password string, user models.User) error
+	Remember(userID uint, passwordHash string) error
+}
+
+type PasswordPolicyServiceImpl struct {
+	policy      *utils.PasswordPolicy
+	historySize int
+	historyRepo repository.PasswordHistoryRepository
+}
+
+func NewPasswordPolicyService(cfg *config.Config, historyRepo repository.PasswordHistoryRepository) PasswordPolicyService {
+	policy, err := utils.NewPasswordPolicy(cfg.Password)
+	if err != nil {
+		log.Printf("⚠️  WARNING: failed to load banned password list %s: %v", cfg.Password.BannedListFile, err)
+	}
+
+	historySize := cfg.Password.HistorySize
+	if historySize < 0 {
+		historySize = 0
+	}
+
+	return &PasswordPolicyServiceImpl{
+		policy:      policy,
+		historySize: historySize,
+		historyRepo: historyRepo,
+	}
+}
+
+// Validate implements PasswordPolicyService. For existing users it also
+// rejects the current password and the last HistorySize passwords.
+func (s *PasswordPolicyServiceImpl) Validate(password string, user models.User) error {
+	if err := s.policy.Validate(password, user.Email, user.Username); err != nil {
+		return err
+	}
+
+	if user.ID == 0 {
+		return nil
+	}
+
+	if user.PasswordHash != "" && utils.CheckPasswordHash(password, user.PasswordHash) {
+		return errors.New("new password must be different from the current password")
+	}
+
+	if s.historySize == 0 {
+		return nil
+	}
+
+	histories, err := s.historyRepo.FindRecent(user.ID, s.historySize)
+	if err != nil {
+		return err
+	}
+
+	for _, history := range histories {
+		if utils.CheckPasswordHash(password, history.PasswordHash) {
+			return errors.New("password was used recently, choose a different one")
+		}
+	}
+
+	return nil
+}
+
+// Remember implements PasswordPolicyService.
+func (s *PasswordPolicyServiceImpl) Remember(userID uint, passwordHash string) error {
+	if s.historySize == 0 {
+		return nil
+	}
+
+	if _, err := s.historyRepo.Create(models.PasswordHistory{UserID: userID, PasswordHash: passwordHash}); err != nil {
+		return err
+	}
+
+	return s.historyRepo.DeleteOlderThanRecent(userID, s.historySize)
+}
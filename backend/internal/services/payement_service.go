@@ -130,7 +130,7 @@ func (p *PaymentServiceImpl) DeletePayment(id int64) error {
 		return errors.New("payment not found")
 	}
 
-	if payment.Status == "completed" || payment.Status == "confirmed" {
+	if payment.Status == "completed" || payment.Status == "confirmed" || payment.Status == "success" {
 		return errors.New("cannot delete payment with completed or confirmed status")
 	}
 
@@ -147,9 +147,11 @@ func (p *PaymentServiceImpl) DeletePayment(id int64) error {
 func (p *PaymentServiceImpl) validateStatusTransition(currentStatus, newStatus string) error {
 	// Define valid status transitions
 	validTransitions := map[string][]string{
-		"pending":   {"confirmed", "rejected", "cancelled"},
+		"pending":   {"confirmed", "rejected", "cancelled", "success", "failed"},
 		"confirmed": {"completed", "refunded"},
 		"rejected":  {"pending"}, // Allow resubmit
+		"success":   {"refunded"},
+		"failed":    {"pending"}, // Allow resubmit
 		"cancelled": {},          // Cannot change from cancelled
 		"completed": {"refunded"},
 		"refunded":  {}, // Final state
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"strings"
 )
 
 type PaymentMethodService interface {
@@ -26,48 +27,112 @@ const bucketFolder = "payment_method" // folder di Supabase
 // -----------------------------------------------------------
 // Validation Helper
 // -----------------------------------------------------------
-func validatePMFields(accountName, accountNumber, bankName string) error {
-	if accountName == "" {
-		return errors.New("account name is required")
+
+// validatePaymentMethod checks the configuration each payment type needs.
+func validatePaymentMethod(pm models.PaymentMethod) error {
+	if !models.IsValidPaymentType(pm.Type) {
+		return errors.New("type must be one of bank_transfer, virtual_account, ewallet, qris or cod")
+	}
+
+	switch pm.Type {
+	case models.PaymentTypeBankTransfer:
+		if pm.BankName == "" || pm.AccountName == "" {
+			return errors.New("bank name and account name are required")
+		}
+		if !isDigits(pm.AccountNumber, 5, 30) {
+			return errors.New("account number must be 5 to 30 digits")
+		}
+	case models.PaymentTypeVirtualAccount:
+		if pm.BankName == "" || pm.AccountName == "" {
+			return errors.New("bank name and account name are required")
+		}
+		if !isDigits(pm.VAPrefix, 3, 8) {
+			return errors.New("virtual account prefix must be 3 to 8 digits")
+		}
+	case models.PaymentTypeEWallet:
+		if pm.BankName == "" || pm.AccountName == "" {
+			return errors.New("e-wallet provider and account name are required")
+		}
+		if !isDigits(strings.TrimPrefix(pm.AccountNumber, "+"), 9, 15) {
+			return errors.New("e-wallet number must be a phone number of 9 to 15 digits")
+		}
+	case models.PaymentTypeQRIS:
+		if pm.AccountName == "" {
+			return errors.New("merchant name is required")
+		}
+		if err := utils.ValidateQRIS(pm.QRISPayload); err != nil {
+			return fmt.Errorf("qris payload must be the content of the store's static QRIS: %w", err)
+		}
 	}
-	if accountNumber == "" {
-		return errors.New("account number is required")
+
+	if pm.FeeFlat < 0 || pm.FeePercent < 0 || pm.FeePercent > 100 {
+		return errors.New("fees must be >= 0 and the fee percentage at most 100")
 	}
-	if bankName == "" {
-		return errors.New("bank name is required")
+	if pm.MinAmount < 0 || pm.MaxAmount < 0 || (pm.MaxAmount > 0 && pm.MaxAmount < pm.MinAmount) {
+		return errors.New("max amount must be 0 or at least the min amount")
 	}
 	return nil
 }
 
+func isDigits(value string, min, max int) bool {
+	if len(value) < min || len(value) > max {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // -----------------------------------------------------------
 // Create
 // -----------------------------------------------------------
+
+// CreatePaymentMethod implements PaymentMethodService. Every type but cash on
+// delivery needs a logo.
 func (pm *PaymentMethodServiceImpl) CreatePaymentMethod(param models.CreatePaymentMethod, file *multipart.FileHeader) (*models.PaymentMethodResponse, error) {
+	newPM := models.PaymentMethod{
+		Type:          strings.TrimSpace(param.Type),
+		AccountName:   strings.TrimSpace(param.AccountName),
+		AccountNumber: strings.TrimSpace(param.AccountNumber),
+		BankName:      strings.TrimSpace(param.BankName),
+		VAPrefix:      strings.TrimSpace(param.VAPrefix),
+		QRISPayload:   strings.TrimSpace(param.QRISPayload),
+		FeeFlat:       param.FeeFlat,
+		FeePercent:    param.FeePercent,
+		MinAmount:     param.MinAmount,
+		MaxAmount:     param.MaxAmount,
+		Instructions:  strings.TrimSpace(param.Instructions),
+		IsActive:      true,
+	}
+	if newPM.Type == "" {
+		newPM.Type = models.PaymentTypeBankTransfer
+	}
 
-	if err := validatePMFields(param.AccountName, param.AccountNumber, param.BankName); err != nil {
+	if err := validatePaymentMethod(newPM); err != nil {
 		return nil, err
 	}
 
-	if file == nil {
+	if file == nil && newPM.Type != models.PaymentTypeCOD {
 		return nil, errors.New("bank image is required")
 	}
 
 	// Upload ke Supabase
-	imageURL, err := utils.UploadToSupabase(file, bucketFolder)
-	if err != nil {
-		return nil, fmt.Errorf("failed upload bank image: %w", err)
-	}
-
-	newPM := models.PaymentMethod{
-		AccountName:   param.AccountName,
-		AccountNumber: param.AccountNumber,
-		BankName:      param.BankName,
-		BankImages:    imageURL,
+	if file != nil {
+		imageURL, err := utils.UploadToSupabase(file, bucketFolder)
+		if err != nil {
+			return nil, fmt.Errorf("failed upload bank image: %w", err)
+		}
+		newPM.BankImages = imageURL
 	}
 
 	created, err := pm.paymentMethodRepo.Create(newPM, nil)
 	if err != nil {
-		utils.DeleteFromSupabase(imageURL) // rollback
+		if newPM.BankImages != "" {
+			utils.DeleteFromSupabase(newPM.BankImages) // rollback
+		}
 		return nil, fmt.Errorf("failed to create payment method: %w", err)
 	}
 
@@ -83,33 +148,61 @@ func (pm *PaymentMethodServiceImpl) UpdatePaymentMethod(param models.UpdatePayme
 	if err != nil {
 		return nil, errors.New("payment method not found")
 	}
-
-	var newImageURL string
-	oldImageURL := existing.BankImages
-
-	// Jika ada file baru → replace
-	if file != nil {
-		newImageURL, err = utils.ReplaceFile(oldImageURL, file, bucketFolder)
-		if err != nil {
-			return nil, fmt.Errorf("failed replacing bank image: %w", err)
-		}
-		existing.BankImages = newImageURL
+	if existing.Type == "" {
+		existing.Type = models.PaymentTypeBankTransfer
 	}
 
 	// Update field lain
 	if param.AccountName != "" {
-		existing.AccountName = param.AccountName
+		existing.AccountName = strings.TrimSpace(param.AccountName)
 	}
 	if param.AccountNumber != "" {
-		existing.AccountNumber = param.AccountNumber
+		existing.AccountNumber = strings.TrimSpace(param.AccountNumber)
 	}
 	if param.BankName != "" {
-		existing.BankName = param.BankName
+		existing.BankName = strings.TrimSpace(param.BankName)
+	}
+	if param.VAPrefix != "" {
+		existing.VAPrefix = strings.TrimSpace(param.VAPrefix)
+	}
+	if param.QRISPayload != "" {
+		existing.QRISPayload = strings.TrimSpace(param.QRISPayload)
+	}
+	if param.FeeFlat != nil {
+		existing.FeeFlat = *param.FeeFlat
+	}
+	if param.FeePercent != nil {
+		existing.FeePercent = *param.FeePercent
+	}
+	if param.MinAmount != nil {
+		existing.MinAmount = *param.MinAmount
+	}
+	if param.MaxAmount != nil {
+		existing.MaxAmount = *param.MaxAmount
+	}
+	if param.Instructions != nil {
+		existing.Instructions = strings.TrimSpace(*param.Instructions)
 	}
 	if param.IsActive != nil {
 		existing.IsActive = *param.IsActive
 	}
 
+	if err := validatePaymentMethod(*existing); err != nil {
+		return nil, err
+	}
+
+	var newImageURL string
+	oldImageURL := existing.BankImages
+
+	// Jika ada file baru → replace
+	if file != nil {
+		newImageURL, err = utils.ReplaceFile(oldImageURL, file, bucketFolder)
+		if err != nil {
+			return nil, fmt.Errorf("failed replacing bank image: %w", err)
+		}
+		existing.BankImages = newImageURL
+	}
+
 	updated, err := pm.paymentMethodRepo.Update(*existing, nil)
 	if err != nil {
 
@@ -176,7 +269,9 @@ func (pm *PaymentMethodServiceImpl) DeletePaymentMethod(id int64) error {
 	}
 
 	// hapus file image dari Supabase
-	utils.DeleteFromSupabase(existing.BankImages)
+	if existing.BankImages != "" {
+		utils.DeleteFromSupabase(existing.BankImages)
+	}
 
 	return nil
 }
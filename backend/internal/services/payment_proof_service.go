@@ -0,0 +1,266 @@
+package services
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PaymentProofService interface {
+	SubmitProof(actor models.ActivityContext, param models.CreatePaymentProof, image *multipart.FileHeader) (*models.PaymentProofResponse, error)
+	FindAllProof(param models.PaymentProofListRequest) (*models.PaymentProofListResponse, error)
+	FindById(id int64) (*models.PaymentProofResponse, error)
+	ApproveProof(actor models.ActivityContext, id int64) (*models.PaymentProofResponse, error)
+	RejectProof(actor models.ActivityContext, param models.RejectPaymentProof) (*models.PaymentProofResponse, error)
+}
+
+type PaymentProofServiceImpl struct {
+	proofRepo       repository.PaymentProofRepository
+	paymentRepo     repository.PaymentRepository
+	transactionRepo repository.TransactionRepository
+	activityLogRepo repository.ActivityLogRepository
+}
+
+func NewPaymentProofService(proofRepo repository.PaymentProofRepository, paymentRepo repository.PaymentRepository, transactionRepo repository.TransactionRepository, activityLogRepo repository.ActivityLogRepository) PaymentProofService {
+	return &PaymentProofServiceImpl{
+		proofRepo:       proofRepo,
+		paymentRepo:     paymentRepo,
+		transactionRepo: transactionRepo,
+		activityLogRepo: activityLogRepo,
+	}
+}
+
+// SubmitProof implements PaymentProofService. The customer's transaction
+// must be waiting for payment; its pending payment is reused, or created
+// when there is none, and the transaction waits for confirmation until an
+// admin reviews the proof.
+func (s *PaymentProofServiceImpl) SubmitProof(actor models.ActivityContext, param models.CreatePaymentProof, image *multipart.FileHeader) (*models.PaymentProofResponse, error) {
+	param.TransactionID = strings.TrimSpace(param.TransactionID)
+	param.SenderBankName = strings.TrimSpace(param.SenderBankName)
+	param.SenderAccountName = strings.TrimSpace(param.SenderAccountName)
+	if param.TransactionID == "" {
+		return nil, errors.New("transaction id is required")
+	}
+	if param.SenderBankName == "" || param.SenderAccountName == "" {
+		return nil, errors.New("sender bank and account name are required")
+	}
+
+	if image == nil {
+		return nil, errors.New("transfer receipt image is required")
+	}
+	if !utils.IsValidImageURL(image.Filename) {
+		return nil, errors.New("transfer receipt must be an image")
+	}
+
+	ownerID, err := s.proofRepo.FindTransactionOwner(param.TransactionID)
+	if err != nil || ownerID != int64(actor.UserID) {
+		return nil, errors.New("transaction not found")
+	}
+
+	imageURL, err := utils.UploadToSupabase(image, fmt.Sprintf("payment-proofs/%d", actor.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload transfer receipt: %w", err)
+	}
+
+	var created models.PaymentProof
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		transaction, err := s.transactionRepo.FindByIdLocking(tx, param.TransactionID)
+		if err != nil {
+			return errors.New("transaction not found")
+		}
+
+		if !models.PaymentTypeAcceptsProof(transaction.PaymentType) {
+			return fmt.Errorf("transfer receipts are not accepted for %s payments", transaction.PaymentType)
+		}
+
+		if transaction.Status != utils.WaitingPayment {
+			return fmt.Errorf("transaction with status %s is not waiting for payment", transaction.Status)
+		}
+
+		pending, err := s.proofRepo.CountPending(transaction.TxID, tx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return errors.New("a payment proof is already waiting for review")
+		}
+
+		payment, err := s.proofRepo.FindOpenPaymentLocked(tx, transaction.TxID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			newPayment, err := s.paymentRepo.Create(models.Payment{
+				TransactionID: transaction.TxID,
+				TotalPayment:  transaction.AmountDue(),
+				Status:        "pending",
+			}, tx)
+			if err != nil {
+				return fmt.Errorf("failed to create payment: %w", err)
+			}
+			payment = &newPayment
+		case err != nil:
+			return err
+		case payment.Status != "pending":
+			payment.Status = "pending"
+			if _, err := s.paymentRepo.Update(*payment, tx); err != nil {
+				return fmt.Errorf("failed to update payment: %w", err)
+			}
+		}
+
+		created, err = s.proofRepo.Create(models.PaymentProof{
+			PaymentID:         payment.ID,
+			TransactionID:     transaction.TxID,
+			UserID:            actor.UserID,
+			ImageURL:          imageURL,
+			SenderBankName:    param.SenderBankName,
+			SenderAccountName: param.SenderAccountName,
+			Status:            "pending",
+		}, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create payment proof: %w", err)
+		}
+
+		transaction.Status = utils.WaitingConfirPayment
+		if _, err := s.transactionRepo.Update(*transaction, tx); err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+
+		details := fmt.Sprintf("Uploaded payment proof %d for transaction %s", created.ID, transaction.TxID)
+		return logActivity(s.activityLogRepo, actor, "payment_proofs", fmt.Sprint(created.ID), "create", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created.ToResponse(), nil
+}
+
+// FindAllProof implements PaymentProofService.
+func (s *PaymentProofServiceImpl) FindAllProof(param models.PaymentProofListRequest) (*models.PaymentProofListResponse, error) {
+	if param.Page < 1 {
+		param.Page = 1
+	}
+	if param.Limit < 1 || param.Limit > 100 {
+		param.Limit = 20
+	}
+
+	proofs, total, err := s.proofRepo.FindAll(param)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment proof list: %w", err)
+	}
+
+	responses := make([]models.PaymentProofResponse, len(proofs))
+	for i := range proofs {
+		responses[i] = *proofs[i].ToResponse()
+	}
+
+	return &models.PaymentProofListResponse{
+		Proofs:     responses,
+		Total:      total,
+		Page:       param.Page,
+		Limit:      param.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(param.Limit))),
+	}, nil
+}
+
+// FindById implements PaymentProofService.
+func (s *PaymentProofServiceImpl) FindById(id int64) (*models.PaymentProofResponse, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid payment proof id")
+	}
+
+	proof, err := s.proofRepo.FindById(id)
+	if err != nil {
+		return nil, errors.New("payment proof not found")
+	}
+
+	return proof.ToResponse(), nil
+}
+
+// ApproveProof implements PaymentProofService. The payment succeeds and the
+// transaction moves on to confirmed, ready to be shipped.
+func (s *PaymentProofServiceImpl) ApproveProof(actor models.ActivityContext, id int64) (*models.PaymentProofResponse, error) {
+	return s.review(actor, id, "approved", "", "success", utils.Confirmed)
+}
+
+// RejectProof implements PaymentProofService. The payment fails and the
+// transaction waits for payment again, so the customer can upload a new
+// proof after reading the reason.
+func (s *PaymentProofServiceImpl) RejectProof(actor models.ActivityContext, param models.RejectPaymentProof) (*models.PaymentProofResponse, error) {
+	param.Reason = strings.TrimSpace(param.Reason)
+	if param.Reason == "" {
+		return nil, errors.New("rejection reason is required")
+	}
+
+	return s.review(actor, param.ID, "rejected", param.Reason, "failed", utils.WaitingPayment)
+}
+
+// review settles a pending proof and moves its payment and transaction to
+// the given statuses, all within one database transaction.
+func (s *PaymentProofServiceImpl) review(actor models.ActivityContext, id int64, status, reason, paymentStatus, transactionStatus string) (*models.PaymentProofResponse, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid payment proof id")
+	}
+
+	var proof *models.PaymentProof
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		proof, err = s.proofRepo.FindByIdLocked(tx, id)
+		if err != nil {
+			return errors.New("payment proof not found")
+		}
+
+		if proof.Status != "pending" {
+			return fmt.Errorf("payment proof has already been %s", proof.Status)
+		}
+
+		payment, err := s.proofRepo.FindPaymentLocked(tx, proof.PaymentID)
+		if err != nil {
+			return errors.New("payment not found")
+		}
+
+		transaction, err := s.transactionRepo.FindByIdLocking(tx, proof.TransactionID)
+		if err != nil {
+			return errors.New("transaction not found")
+		}
+
+		now := time.Now()
+		reviewer := actor.UserID
+		proof.Status = status
+		proof.RejectionReason = reason
+		proof.ReviewedBy = &reviewer
+		proof.ReviewedAt = &now
+		if _, err := s.proofRepo.Update(*proof, tx); err != nil {
+			return fmt.Errorf("failed to update payment proof: %w", err)
+		}
+
+		payment.Status = paymentStatus
+		if _, err := s.paymentRepo.Update(*payment, tx); err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		transaction.Status = transactionStatus
+		if _, err := s.transactionRepo.Update(*transaction, tx); err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+
+		details := fmt.Sprintf("Payment proof %d of transaction %s %s", proof.ID, proof.TransactionID, status)
+		if reason != "" {
+			details += ": " + reason
+		}
+		return logActivity(s.activityLogRepo, actor, "payment_proofs", fmt.Sprint(proof.ID), status, details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof.ToResponse(), nil
+}
@@ -20,11 +20,16 @@ type ProductService interface {
 	DeleteProduct(id int64) error
 	AddColorVarianProduct(productId int64, param models.CreateColorVarianRequest) (*models.ProductDetailResponse, error)
 	UpdateSizeVariants(colorVarianID int64, sizesParam []models.UpdateSizeVarianRequest, tx *gorm.DB) error
+	FindSizeVarianBySKU(sku string) (*models.SizeVarianLookupResponse, error)
+	FindSizeVarianByBarcode(barcode string) (*models.SizeVarianLookupResponse, error)
 }
 
 type ProductServiceImpl struct {
 	categoryRepo repository.CategoryRepository
 	productRepo  repository.ProductRepository
+	// stockAlertService queues back-in-stock and price-drop notifications
+	// for the stock and price changes made here.
+	stockAlertService StockAlertService
 }
 
 // updateSizeVariants implements ProductService.
@@ -57,6 +62,9 @@ func (p *ProductServiceImpl) UpdateSizeVariants(colorVarianID int64, sizesParam
 			if sizeParam.Stock != nil {
 				existingSize.Stock = *sizeParam.Stock
 			}
+			if err := p.applySizeCodes(existingSize, sizeParam.SKU, sizeParam.Barcode, sizeParam.Price, tx); err != nil {
+				return err
+			}
 
 			_, err := p.productRepo.UpdateSizeVarian(*existingSize, tx)
 			if err != nil {
@@ -75,6 +83,9 @@ func (p *ProductServiceImpl) UpdateSizeVariants(colorVarianID int64, sizesParam
 				Size:          *sizeParam.Size,
 				Stock:         *sizeParam.Stock,
 			}
+			if err := p.applySizeCodes(&newSizeVarian, sizeParam.SKU, sizeParam.Barcode, sizeParam.Price, tx); err != nil {
+				return err
+			}
 
 			createdSize, err := p.productRepo.CreateSizeVarian(newSizeVarian, tx)
 			if err != nil {
@@ -103,7 +114,6 @@ func sanitizeFileName(name string) string {
 }
 func (p *ProductServiceImpl) AddColorVarianProduct(productId int64, param models.CreateColorVarianRequest) (*models.ProductDetailResponse, error) {
 	var product models.Product
-	var category models.Category
 
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 
@@ -116,14 +126,13 @@ func (p *ProductServiceImpl) AddColorVarianProduct(productId int64, param models
 		}
 		product = *productResult
 
-		categoryResult, err := p.categoryRepo.FindById((product.CategoryID))
+		_, err = p.categoryRepo.FindById((product.CategoryID))
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return fmt.Errorf("category dengan ID %d tidak ditemukan", product.CategoryID)
 			}
 			return fmt.Errorf("error mengambil category: %w", err)
 		}
-		category = categoryResult
 
 		for _, cv := range product.ColorVarians {
 			if strings.EqualFold(cv.Name, param.Name) {
@@ -170,6 +179,9 @@ func (p *ProductServiceImpl) AddColorVarianProduct(productId int64, param models
 				Size:          sizeParam.Size,
 				Stock:         sizeParam.Stock,
 			}
+			if err := p.applySizeCodes(&sizeVariant, sizeParam.SKU, sizeParam.Barcode, sizeParam.Price, tx); err != nil {
+				return err
+			}
 
 			_, err := p.productRepo.CreateSizeVarian(sizeVariant, tx)
 			if err != nil {
@@ -177,7 +189,7 @@ func (p *ProductServiceImpl) AddColorVarianProduct(productId int64, param models
 			}
 		}
 
-		return nil
+		return p.queueStockAlerts(productResult, tx)
 	})
 
 	if err != nil {
@@ -189,27 +201,24 @@ func (p *ProductServiceImpl) AddColorVarianProduct(productId int64, param models
 		return nil, fmt.Errorf("gagal memuat data product: %w", err)
 	}
 
-	result := productWithRelations.ToProductDetailResponse(category)
-	return &result, nil
+	return p.detailResponse(productWithRelations)
 }
 
 // CreateProduct implements ProductService.
 func (p *ProductServiceImpl) CreateProduct(param models.CreateProductParam) (*models.ProductDetailResponse, error) {
 	var product models.Product
-	var category models.Category
 	var colorVariants []models.ColorVarian
 
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 
 		// VALIDASI CATEGORY (pakai tx)
-		categoryResult, err := p.categoryRepo.FindById(param.CategoryID)
+		_, err := p.categoryRepo.FindById(param.CategoryID)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return fmt.Errorf("category dengan ID %d tidak ditemukan", param.CategoryID)
 			}
 			return fmt.Errorf("error validasi category: %w", err)
 		}
-		category = categoryResult
 
 		var imageURL string
 		if param.Image != nil {
@@ -226,6 +235,11 @@ func (p *ProductServiceImpl) CreateProduct(param models.CreateProductParam) (*mo
 			Name:        param.Name,
 			Description: param.Description,
 			Price:       param.Price,
+			TaxClassID:  param.TaxClassID,
+			WeightGrams: param.Dimensions.WeightGrams,
+			LengthCm:    param.Dimensions.LengthCm,
+			WidthCm:     param.Dimensions.WidthCm,
+			HeightCm:    param.Dimensions.HeightCm,
 			Images:      imageURL,
 		}
 
@@ -262,6 +276,9 @@ func (p *ProductServiceImpl) CreateProduct(param models.CreateProductParam) (*mo
 					Size:          s.Size,
 					Stock:         s.Stock,
 				}
+				if err := p.applySizeCodes(&sizeData, s.SKU, s.Barcode, s.Price, tx); err != nil {
+					return err
+				}
 
 				_, err := p.productRepo.CreateSizeVarian(sizeData, tx)
 				if err != nil {
@@ -280,8 +297,7 @@ func (p *ProductServiceImpl) CreateProduct(param models.CreateProductParam) (*mo
 	}
 
 	product.ColorVarians = colorVariants
-	result := product.ToProductDetailResponse(category)
-	return &result, nil
+	return p.detailResponse(&product)
 }
 
 // DeleteProduct implements ProductService.
@@ -331,6 +347,26 @@ func (p *ProductServiceImpl) DeleteProduct(id int64) error {
 // FindAllProduct implements ProductService.
 func (p *ProductServiceImpl) FindAllProduct(param models.ProductListRequest) (*[]models.ProductResponse, error) {
 
+	if param.CategorySlug != "" {
+		category, err := p.categoryRepo.FindBySlug(param.CategorySlug)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, fmt.Errorf("kategori %s tidak ditemukan", param.CategorySlug)
+			}
+			return nil, fmt.Errorf("error mengambil data kategori: %w", err)
+		}
+		param.CategoryID = category.ID
+	}
+
+	// Produk di subkategori ikut ditampilkan
+	if param.CategoryID != 0 {
+		tree, err := p.categoryRepo.FindAllTree()
+		if err != nil {
+			return nil, fmt.Errorf("error mengambil data kategori: %w", err)
+		}
+		param.CategoryIDs = models.NewCategoryTree(tree).DescendantIDs(param.CategoryID)
+	}
+
 	products, _, err := p.productRepo.FindAllProduct(param, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error mengambil data produk: %w", err)
@@ -367,12 +403,24 @@ func (p *ProductServiceImpl) FindProductById(id int64) (*models.ProductDetailRes
 		return nil, fmt.Errorf("error mengambil produk: %w", err)
 	}
 
-	category, err := p.categoryRepo.FindById(product.CategoryID)
+	return p.detailResponse(product)
+}
+
+// detailResponse builds the detail response of product with its category
+// and the category's breadcrumbs, both taken from the category tree.
+func (p *ProductServiceImpl) detailResponse(product *models.Product) (*models.ProductDetailResponse, error) {
+	categories, err := p.categoryRepo.FindAllTree()
 	if err != nil {
 		return nil, fmt.Errorf("error mengambil kategori: %w", err)
 	}
+	tree := models.NewCategoryTree(categories)
+	category, ok := tree.Find(product.CategoryID)
+	if !ok {
+		return nil, fmt.Errorf("error mengambil kategori: %w", gorm.ErrRecordNotFound)
+	}
 
 	resp := product.ToProductDetailResponse(category)
+	resp.Breadcrumbs = tree.Breadcrumbs(category.ID)
 	return &resp, nil
 }
 
@@ -433,6 +481,21 @@ func (p *ProductServiceImpl) UpdateProduct(param models.UpdateProductParam) (*mo
 		if param.Rating != nil {
 			product.Rating = *param.Rating
 		}
+		if param.TaxClassID != nil {
+			product.TaxClassID = param.TaxClassID
+		}
+		if param.WeightGrams != nil {
+			product.WeightGrams = *param.WeightGrams
+		}
+		if param.LengthCm != nil {
+			product.LengthCm = *param.LengthCm
+		}
+		if param.WidthCm != nil {
+			product.WidthCm = *param.WidthCm
+		}
+		if param.HeightCm != nil {
+			product.HeightCm = *param.HeightCm
+		}
 
 		if param.Image != nil {
 			url, err := utils.ReplaceFile(product.Images, param.Image, fmt.Sprintf("product/%s", sanitizeFileName(product.Name)))
@@ -525,11 +588,16 @@ func (p *ProductServiceImpl) UpdateProduct(param models.UpdateProductParam) (*mo
 					updatedIDs[created.ID] = true
 
 					for _, s := range cv.Sizes {
-						_, err := p.productRepo.CreateSizeVarian(models.SizeVarian{
+						sizeData := models.SizeVarian{
 							ColorVarianID: created.ID,
 							Size:          *s.Size,
 							Stock:         *s.Stock,
-						}, tx)
+						}
+						if err := p.applySizeCodes(&sizeData, s.SKU, s.Barcode, s.Price, tx); err != nil {
+							return err
+						}
+
+						_, err := p.productRepo.CreateSizeVarian(sizeData, tx)
 						if err != nil {
 							return err
 						}
@@ -547,7 +615,7 @@ func (p *ProductServiceImpl) UpdateProduct(param models.UpdateProductParam) (*mo
 			}
 		}
 
-		return nil
+		return p.queueStockAlerts(existing, tx)
 	})
 
 	if err != nil {
@@ -559,11 +627,134 @@ func (p *ProductServiceImpl) UpdateProduct(param models.UpdateProductParam) (*mo
 		return nil, err
 	}
 
-	resp := pd.ToProductDetailResponse(category)
-	return &resp, nil
+	return p.detailResponse(pd)
+}
+
+// FindSizeVarianBySKU implements ProductService.
+func (p *ProductServiceImpl) FindSizeVarianBySKU(sku string) (*models.SizeVarianLookupResponse, error) {
+	code, err := utils.NormalizeSKU(sku)
+	if err != nil {
+		return nil, fmt.Errorf("SKU tidak valid: %w", err)
+	}
+
+	size, err := p.productRepo.FindSizeVarianBySKU(code, nil)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("size variant dengan SKU %s tidak ditemukan", code)
+		}
+		return nil, fmt.Errorf("error mencari size variant: %w", err)
+	}
+	return p.sizeVarianLookup(size)
+}
+
+// FindSizeVarianByBarcode implements ProductService.
+func (p *ProductServiceImpl) FindSizeVarianByBarcode(barcode string) (*models.SizeVarianLookupResponse, error) {
+	code, err := utils.NormalizeBarcode(barcode)
+	if err != nil {
+		return nil, fmt.Errorf("barcode tidak valid: %w", err)
+	}
+
+	size, err := p.productRepo.FindSizeVarianByBarcode(code, nil)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("size variant dengan barcode %s tidak ditemukan", code)
+		}
+		return nil, fmt.Errorf("error mencari size variant: %w", err)
+	}
+	return p.sizeVarianLookup(size)
+}
+
+func (p *ProductServiceImpl) sizeVarianLookup(size *models.SizeVarian) (*models.SizeVarianLookupResponse, error) {
+	color, err := p.productRepo.FindColorVarianById(size.ColorVarianID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error mengambil color varian: %w", err)
+	}
+	product, err := p.productRepo.FindProductById(color.ProductID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error mengambil produk: %w", err)
+	}
+
+	images := color.Images
+	if images == "" {
+		images = product.Images
+	}
+
+	return &models.SizeVarianLookupResponse{
+		ProductID:     product.ID,
+		ProductName:   product.Name,
+		ColorVarianID: color.ID,
+		ColorName:     color.Name,
+		Color:         color.Color,
+		Images:        images,
+		Currency:      models.StoreCurrency,
+		SizeVarian:    size.ToSizeVarianResponse(product.Price),
+	}, nil
+}
+
+// applySizeCodes sets the SKU, barcode and price override of size from a
+// request; nil leaves a field as it is. SKUs and barcodes are normalized
+// and must not be used by another live size variant.
+func (p *ProductServiceImpl) applySizeCodes(size *models.SizeVarian, sku, barcode *string, price *models.Money, tx *gorm.DB) error {
+	if sku != nil {
+		size.SKU = nil
+		if strings.TrimSpace(*sku) != "" {
+			code, err := utils.NormalizeSKU(*sku)
+			if err != nil {
+				return fmt.Errorf("SKU '%s' tidak valid: %w", *sku, err)
+			}
+			existing, err := p.productRepo.FindSizeVarianBySKU(code, tx)
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return fmt.Errorf("error memeriksa SKU: %w", err)
+			}
+			if existing != nil && existing.ID != size.ID {
+				return fmt.Errorf("SKU '%s' sudah dipakai size variant lain", code)
+			}
+			size.SKU = &code
+		}
+	}
+
+	if barcode != nil {
+		size.Barcode = nil
+		if strings.TrimSpace(*barcode) != "" {
+			code, err := utils.NormalizeBarcode(*barcode)
+			if err != nil {
+				return fmt.Errorf("barcode '%s' tidak valid: %w", *barcode, err)
+			}
+			existing, err := p.productRepo.FindSizeVarianByBarcode(code, tx)
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return fmt.Errorf("error memeriksa barcode: %w", err)
+			}
+			if existing != nil && existing.ID != size.ID {
+				return fmt.Errorf("barcode '%s' sudah dipakai size variant lain", code)
+			}
+			size.Barcode = &code
+		}
+	}
+
+	if price != nil {
+		if *price < 0 {
+			return fmt.Errorf("harga size '%s' tidak valid", size.Size)
+		}
+		size.Price = nil
+		if *price > 0 {
+			override := *price
+			size.Price = &override
+		}
+	}
+	return nil
+}
+
+// queueStockAlerts reloads the product inside tx and queues the alerts
+// fired by the changes since before was loaded.
+func (p *ProductServiceImpl) queueStockAlerts(before *models.Product, tx *gorm.DB) error {
+	after, err := p.productRepo.FindProductById(before.ID, tx)
+	if err != nil {
+		return fmt.Errorf("error memuat product: %w", err)
+	}
+	return p.stockAlertService.QueueProductEvents(before, after, tx)
 }
 
 func NewProductService(categoryRepo repository.CategoryRepository,
-	productRepo repository.ProductRepository) ProductService {
-	return &ProductServiceImpl{categoryRepo: categoryRepo, productRepo: productRepo}
+	productRepo repository.ProductRepository, stockAlertService StockAlertService) ProductService {
+	return &ProductServiceImpl{categoryRepo: categoryRepo, productRepo: productRepo, stockAlertService: stockAlertService}
 }
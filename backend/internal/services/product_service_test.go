@@ -21,7 +21,7 @@ func TestProductService_FindProductById(t *testing.T) {
 	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
 
 	// Service
-	service := services.NewProductService(mockCategoryRepo, mockProductRepo)
+	service := services.NewProductService(mockCategoryRepo, mockProductRepo, mocks.NewMockStockAlertService(ctrl))
 
 	// Test Case
 	t.Run("Found", func(t *testing.T) {
@@ -32,7 +32,7 @@ func TestProductService_FindProductById(t *testing.T) {
 			ID:        productID,
 			Name:      "Test Product",
 			Category:  models.CategoryResponse{ID: categoryID, Name: "Test Category"},
-			Price:     100.0,
+			Price:     models.Rupiah(100),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
@@ -44,17 +44,17 @@ func TestProductService_FindProductById(t *testing.T) {
 				ID:         productID,
 				Name:       "Test Product",
 				CategoryID: categoryID,
-				Price:      100.0,
+				Price:      models.Rupiah(100),
 			}, nil)
 
-		// Note: FindProductById service actually calls ToProductDetailResponse which might not call CategoryRepo if category is not needed explicitly or passed?
-		// Checking implementation of FindProductById in service:
-		// It calls productRepo.FindProductById
-		// Then it calls categoryRepo.FindById(product.CategoryID)
-
+		// The category and its breadcrumbs come from the category tree
+		parentID := int64(5)
 		mockCategoryRepo.EXPECT().
-			FindById(categoryID). // Correct method name
-			Return(models.Category{ID: categoryID, Name: "Test Category"}, nil)
+			FindAllTree().
+			Return([]models.Category{
+				{ID: parentID, Name: "Parent", Slug: "parent"},
+				{ID: categoryID, ParentID: &parentID, Name: "Test Category", Slug: "test-category"},
+			}, nil)
 
 		// Execute
 		result, err := service.FindProductById(productID)
@@ -69,6 +69,9 @@ func TestProductService_FindProductById(t *testing.T) {
 		if result.Category.Name != product.Category.Name {
 			t.Errorf("Expected category %s, got %s", product.Category.Name, result.Category.Name)
 		}
+		if len(result.Breadcrumbs) != 2 || result.Breadcrumbs[0].ID != parentID || result.Breadcrumbs[1].ID != categoryID {
+			t.Errorf("Expected breadcrumbs Parent > Test Category, got %+v", result.Breadcrumbs)
+		}
 	})
 }
 
@@ -94,13 +97,13 @@ func TestProductService_CreateProduct(t *testing.T) {
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
 	mockCategoryRepo := mocks.NewMockCategoryRepository(ctrl)
 
-	service := services.NewProductService(mockCategoryRepo, mockProductRepo)
+	service := services.NewProductService(mockCategoryRepo, mockProductRepo, mocks.NewMockStockAlertService(ctrl))
 
 	t.Run("Success", func(t *testing.T) {
 		input := models.CreateProductParam{
 			Name:       "New Product",
 			CategoryID: 1,
-			Price:      200.0,
+			Price:      models.Rupiah(200),
 			ColorVarian: []models.CreateColorVarianRequest{
 				{
 					Name:  "Red",
@@ -147,13 +150,13 @@ func TestProductService_CreateProduct(t *testing.T) {
 				ID:         100,
 				Name:       "New Product",
 				CategoryID: 1,
-				Price:      200.0,
+				Price:      models.Rupiah(200),
 				ColorVarians: []models.ColorVarian{
 					{ID: 200, Name: "Red", SizeVarians: []models.SizeVarian{{ID: 300, Size: "M"}}},
 				},
 			}, nil)
 
-		mockCategoryRepo.EXPECT().FindById(int64(1)).Return(category, nil)
+		mockCategoryRepo.EXPECT().FindAllTree().Return([]models.Category{category}, nil)
 
 		// Execute
 		result, err := service.CreateProduct(input)
@@ -165,5 +168,8 @@ func TestProductService_CreateProduct(t *testing.T) {
 		if result.ID != 100 {
 			t.Errorf("Expected product ID 100, got %d", result.ID)
 		}
+		if len(result.Breadcrumbs) != 1 || result.Breadcrumbs[0].ID != 1 {
+			t.Errorf("Expected breadcrumbs Cat, got %+v", result.Breadcrumbs)
+		}
 	})
 }
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReconciliationService interface {
+	AssignPayableAmount(tx *gorm.DB, transaction *models.Transaction) error
+	ImportStatement(actor models.ActivityContext, param models.ImportBankStatement) (*models.BankStatementImportResponse, error)
+	FindAllLines(param models.StatementLineListRequest) (*models.StatementLineListResponse, error)
+	ResolveLine(actor models.ActivityContext, param models.ResolveStatementLine) (*models.BankStatementLine, error)
+}
+
+type ReconciliationServiceImpl struct {
+	uniqueCodeMax      int64
+	reconciliationRepo repository.ReconciliationRepository
+	proofRepo          repository.PaymentProofRepository
+	paymentRepo        repository.PaymentRepository
+	paymentMethodRepo  repository.PaymentMethodRepository
+	transactionRepo    repository.TransactionRepository
+	activityLogRepo    repository.ActivityLogRepository
+}
+
+func NewReconciliationService(cfg *config.Config, reconciliationRepo repository.ReconciliationRepository, proofRepo repository.PaymentProofRepository, paymentRepo repository.PaymentRepository, paymentMethodRepo repository.PaymentMethodRepository, transactionRepo repository.TransactionRepository, activityLogRepo repository.ActivityLogRepository) ReconciliationService {
+	return &ReconciliationServiceImpl{
+		uniqueCodeMax:      cfg.Payment.UniqueCodeMax,
+		reconciliationRepo: reconciliationRepo,
+		proofRepo:          proofRepo,
+		paymentRepo:        paymentRepo,
+		paymentMethodRepo:  paymentMethodRepo,
+		transactionRepo:    transactionRepo,
+		activityLogRepo:    activityLogRepo,
+	}
+}
+
+// AssignPayableAmount implements ReconciliationService. The customer pays the
+// items, shipping and payment fee. Manual transfers add a code of a few
+// rupiah that no other open transfer to the same account uses, so the credit
+// on the statement points to exactly one transaction. When every code is
+// taken the transaction goes without one and has to be matched by its ID in
+// the transfer description.
+func (s *ReconciliationServiceImpl) AssignPayableAmount(tx *gorm.DB, transaction *models.Transaction) error {
+	base := transaction.TotalPrice + transaction.ShippingPrice + transaction.PaymentFee
+	transaction.UniqueCode = 0
+	transaction.PayableAmount = base
+
+	if s.uniqueCodeMax <= 0 || !models.PaymentTypeUsesUniqueCode(transaction.PaymentType) {
+		return nil
+	}
+
+	codes, err := s.reconciliationRepo.FindUsedUniqueCodes(tx, transaction.PaymentMethodID, base, s.uniqueCodeMax)
+	if err != nil {
+		return fmt.Errorf("find used unique codes: %w", err)
+	}
+
+	used := make(map[int64]bool, len(codes))
+	for _, code := range codes {
+		used[code] = true
+	}
+
+	if code, ok := utils.PickUniqueCode(used, s.uniqueCodeMax); ok {
+		transaction.UniqueCode = code
+		transaction.PayableAmount = base + models.Rupiah(code)
+	}
+	return nil
+}
+
+// ImportStatement implements ReconciliationService. Every credit that was not
+// imported before becomes a statement line. A line is matched to the open
+// transaction whose ID is in its description and whose amount due it pays,
+// otherwise to the only open transaction due exactly its amount; the rest
+// stay unmatched for an admin to resolve.
+func (s *ReconciliationServiceImpl) ImportStatement(actor models.ActivityContext, param models.ImportBankStatement) (*models.BankStatementImportResponse, error) {
+	if param.PaymentMethodID <= 0 {
+		return nil, errors.New("payment method is required")
+	}
+	if len(param.Data) == 0 {
+		return nil, errors.New("statement file is required")
+	}
+
+	paymentMethod, err := s.paymentMethodRepo.FindById(uint(param.PaymentMethodID))
+	if err != nil {
+		return nil, errors.New("payment method not found")
+	}
+	if !models.PaymentTypeHasStatement(paymentMethod.Type) {
+		return nil, errors.New("statements can only be imported for bank transfer and virtual account methods")
+	}
+
+	format := strings.ToLower(strings.TrimSpace(param.Format))
+	if format == "" {
+		format = utils.DetectStatementFormat(param.Filename, param.Data)
+	}
+
+	entries, err := utils.ParseBankStatement(format, param.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid statement: %w", err)
+	}
+
+	result := &models.BankStatementImportResponse{}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		statement, err := s.reconciliationRepo.CreateStatement(models.BankStatement{
+			PaymentMethodID: param.PaymentMethodID,
+			Filename:        param.Filename,
+			Format:          format,
+			ImportedBy:      actor.UserID,
+		}, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create bank statement: %w", err)
+		}
+
+		occurrences := map[string]int{}
+		for _, entry := range entries {
+			if !entry.Credit || entry.Amount <= 0 {
+				result.Skipped++
+				continue
+			}
+
+			key := utils.StatementFingerprint(param.PaymentMethodID, entry, 0)
+			fingerprint := utils.StatementFingerprint(param.PaymentMethodID, entry, occurrences[key])
+			occurrences[key]++
+
+			exists, err := s.reconciliationRepo.FingerprintExists(fingerprint, tx)
+			if err != nil {
+				return err
+			}
+			if exists {
+				result.Skipped++
+				continue
+			}
+
+			line := models.BankStatementLine{
+				StatementID:     statement.ID,
+				PaymentMethodID: param.PaymentMethodID,
+				BookedAt:        entry.BookedAt,
+				Amount:          entry.Amount,
+				Description:     entry.Description,
+				Reference:       entry.Reference,
+				Fingerprint:     fingerprint,
+				Status:          "unmatched",
+			}
+
+			transaction, note, err := s.matchTransaction(tx, line)
+			if err != nil {
+				return err
+			}
+			line.Note = note
+
+			line, err = s.reconciliationRepo.CreateLine(line, tx)
+			if err != nil {
+				return fmt.Errorf("failed to create statement line: %w", err)
+			}
+			result.Imported++
+
+			if transaction == nil {
+				result.Unmatched++
+				continue
+			}
+
+			if err := s.settle(tx, actor, &line, transaction, "matched"); err != nil {
+				return err
+			}
+			result.Matched++
+		}
+
+		result.Statement = statement
+		details := fmt.Sprintf("Imported bank statement %s: %d lines, %d matched, %d unmatched", param.Filename, result.Imported, result.Matched, result.Unmatched)
+		return logActivity(s.activityLogRepo, actor, "bank_statements", fmt.Sprint(statement.ID), "import", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// matchTransaction finds the open transaction a statement line pays, by the
+// transaction ID or virtual account number in its description, or else by
+// its amount. When there is none it returns the reason, kept as the line's
+// note.
+func (s *ReconciliationServiceImpl) matchTransaction(tx *gorm.DB, line models.BankStatementLine) (*models.Transaction, string, error) {
+	txID := utils.TransactionReference(line.Description)
+	if txID == "" {
+		referenced, err := s.reconciliationRepo.FindOpenTransactionByReference(tx, line.PaymentMethodID, virtualAccountNumbers.FindAllString(line.Description+" "+line.Reference, -1))
+		if err != nil {
+			return nil, "", err
+		}
+		if referenced != nil {
+			txID = referenced.TxID
+		}
+	}
+
+	if txID != "" {
+		transaction, err := s.transactionRepo.FindByIdLocking(tx, txID)
+		if err == nil && isOpenTransaction(transaction) && transaction.PaymentMethodID == line.PaymentMethodID && transaction.AmountDue() == line.Amount {
+			return transaction, "", nil
+		}
+	}
+
+	candidates, err := s.reconciliationRepo.FindOpenTransactionsByAmount(tx, line.PaymentMethodID, line.Amount)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, "no open transaction is due this amount", nil
+	case 1:
+		transaction, err := s.transactionRepo.FindByIdLocking(tx, candidates[0].TxID)
+		if err != nil {
+			return nil, "", err
+		}
+		return transaction, "", nil
+	default:
+		return nil, fmt.Sprintf("%d open transactions are due this amount", len(candidates)), nil
+	}
+}
+
+// settle records the line's money as the successful payment of transaction:
+// its open payment succeeds, or a successful one is created, receipts
+// waiting for review are approved and the transaction is confirmed.
+func (s *ReconciliationServiceImpl) settle(tx *gorm.DB, actor models.ActivityContext, line *models.BankStatementLine, transaction *models.Transaction, status string) error {
+	payment, err := s.proofRepo.FindOpenPaymentLocked(tx, transaction.TxID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		newPayment, err := s.paymentRepo.Create(models.Payment{
+			TransactionID: transaction.TxID,
+			TotalPayment:  line.Amount,
+			Status:        "success",
+		}, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create payment: %w", err)
+		}
+		payment = &newPayment
+	case err != nil:
+		return err
+	default:
+		payment.TotalPayment = line.Amount
+		payment.Status = "success"
+		if _, err := s.paymentRepo.Update(*payment, tx); err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+	}
+
+	if err := s.reconciliationRepo.ApprovePendingProofs(tx, transaction.TxID, actor.UserID); err != nil {
+		return fmt.Errorf("failed to approve payment proofs: %w", err)
+	}
+
+	transaction.Status = utils.Confirmed
+	if _, err := s.transactionRepo.Update(*transaction, tx); err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	now := time.Now()
+	resolver := actor.UserID
+	line.Status = status
+	line.TransactionID = &transaction.TxID
+	line.PaymentID = &payment.ID
+	line.ResolvedBy = &resolver
+	line.ResolvedAt = &now
+	if _, err := s.reconciliationRepo.UpdateLine(*line, tx); err != nil {
+		return fmt.Errorf("failed to update statement line: %w", err)
+	}
+	return nil
+}
+
+// FindAllLines implements ReconciliationService.
+func (s *ReconciliationServiceImpl) FindAllLines(param models.StatementLineListRequest) (*models.StatementLineListResponse, error) {
+	if param.Page < 1 {
+		param.Page = 1
+	}
+	if param.Limit < 1 || param.Limit > 100 {
+		param.Limit = 20
+	}
+
+	lines, total, err := s.reconciliationRepo.FindAllLines(param)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement lines: %w", err)
+	}
+
+	return &models.StatementLineListResponse{
+		Lines:      lines,
+		Total:      total,
+		Page:       param.Page,
+		Limit:      param.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(param.Limit))),
+	}, nil
+}
+
+// ResolveLine implements ReconciliationService. An admin matches an
+// unmatched line to the open transaction it pays, whatever its amount, or
+// ignores it when it is not a customer payment.
+func (s *ReconciliationServiceImpl) ResolveLine(actor models.ActivityContext, param models.ResolveStatementLine) (*models.BankStatementLine, error) {
+	if param.ID <= 0 {
+		return nil, errors.New("invalid statement line id")
+	}
+	param.TransactionID = strings.TrimSpace(param.TransactionID)
+	param.Note = strings.TrimSpace(param.Note)
+
+	switch param.Action {
+	case "match":
+		if param.TransactionID == "" {
+			return nil, errors.New("transaction id is required")
+		}
+	case "ignore":
+		if param.Note == "" {
+			return nil, errors.New("a note is required to ignore a statement line")
+		}
+	default:
+		return nil, errors.New("action must be match or ignore")
+	}
+
+	var line *models.BankStatementLine
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		line, err = s.reconciliationRepo.FindLineByIdLocked(tx, param.ID)
+		if err != nil {
+			return errors.New("statement line not found")
+		}
+
+		if line.Status != "unmatched" {
+			return fmt.Errorf("statement line has already been %s", line.Status)
+		}
+		line.Note = param.Note
+
+		if param.Action == "ignore" {
+			now := time.Now()
+			resolver := actor.UserID
+			line.Status = "ignored"
+			line.ResolvedBy = &resolver
+			line.ResolvedAt = &now
+			if _, err := s.reconciliationRepo.UpdateLine(*line, tx); err != nil {
+				return fmt.Errorf("failed to update statement line: %w", err)
+			}
+			return logActivity(s.activityLogRepo, actor, "bank_statements", fmt.Sprint(line.ID), "ignore", fmt.Sprintf("Ignored statement line %d: %s", line.ID, param.Note), tx)
+		}
+
+		transaction, err := s.transactionRepo.FindByIdLocking(tx, param.TransactionID)
+		if err != nil {
+			return errors.New("transaction not found")
+		}
+		if !isOpenTransaction(transaction) {
+			return fmt.Errorf("transaction with status %s is not waiting for payment", transaction.Status)
+		}
+
+		if err := s.settle(tx, actor, line, transaction, "resolved"); err != nil {
+			return err
+		}
+
+		details := fmt.Sprintf("Matched statement line %d of %s to transaction %s", line.ID, utils.FormatRupiah(line.Amount), transaction.TxID)
+		return logActivity(s.activityLogRepo, actor, "bank_statements", fmt.Sprint(line.ID), "resolve", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// virtualAccountNumbers finds the digit runs in a description that may be a
+// virtual account number.
+var virtualAccountNumbers = regexp.MustCompile(`\d{10,}`)
+
+func isOpenTransaction(transaction *models.Transaction) bool {
+	return transaction.Status == utils.WaitingPayment || transaction.Status == utils.WaitingConfirPayment
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// refundablePaymentStatuses are the statuses of a payment whose money was
+// actually received.
+var refundablePaymentStatuses = map[string]bool{
+	"success": true,
+}
+
+type RefundService interface {
+	CreateRefund(actor models.ActivityContext, param models.CreateRefund) (*models.PaymentRefundSummary, error)
+	RefundPayment(actor models.ActivityContext, param models.CreateRefund, tx *gorm.DB) (*models.PaymentRefundSummary, error)
+	FindAllRefund(param models.RefundListRequest) (*models.RefundListResponse, error)
+	FindById(id int64) (*models.RefundResponse, error)
+}
+
+type RefundServiceImpl struct {
+	refundRepo        repository.RefundRepository
+	paymentRepo       repository.PaymentRepository
+	transactionRepo   repository.TransactionRepository
+	productRepo       repository.ProductRepository
+	activityLogRepo   repository.ActivityLogRepository
+	stockAlertService StockAlertService
+}
+
+func NewRefundService(refundRepo repository.RefundRepository, paymentRepo repository.PaymentRepository, transactionRepo repository.TransactionRepository, productRepo repository.ProductRepository, activityLogRepo repository.ActivityLogRepository, stockAlertService StockAlertService) RefundService {
+	return &RefundServiceImpl{
+		refundRepo:        refundRepo,
+		paymentRepo:       paymentRepo,
+		transactionRepo:   transactionRepo,
+		productRepo:       productRepo,
+		activityLogRepo:   activityLogRepo,
+		stockAlertService: stockAlertService,
+	}
+}
+
+// CreateRefund implements RefundService. Refunds may be partial and repeated
+// until the amount paid is used up; the last one marks the payment and its
+// transaction as refunded.
+func (s *RefundServiceImpl) CreateRefund(actor models.ActivityContext, param models.CreateRefund) (*models.PaymentRefundSummary, error) {
+	if param.PaymentID <= 0 {
+		return nil, errors.New("invalid payment id")
+	}
+
+	param.Reason = strings.TrimSpace(param.Reason)
+	if param.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	if param.Amount < 0 {
+		return nil, errors.New("refund amount must be greater than 0")
+	}
+
+	if param.Restock && len(param.Items) == 0 {
+		return nil, errors.New("restock requires refund items")
+	}
+
+	var summary *models.PaymentRefundSummary
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		summary, err = s.RefundPayment(actor, param, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// RefundPayment implements RefundService. It refunds within tx so callers
+// such as returns can record the refund together with their own changes.
+func (s *RefundServiceImpl) RefundPayment(actor models.ActivityContext, param models.CreateRefund, tx *gorm.DB) (*models.PaymentRefundSummary, error) {
+	payment, err := s.refundRepo.FindPaymentLocked(tx, param.PaymentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("payment not found")
+		}
+		return nil, err
+	}
+
+	if payment.Status == "refunded" {
+		return nil, errors.New("payment is already fully refunded")
+	}
+	if !refundablePaymentStatuses[payment.Status] {
+		return nil, errors.New("payment is not refundable")
+	}
+
+	var ordersByID map[string]models.Order
+	if len(param.Items) > 0 {
+		orders, err := s.refundRepo.FindOrdersLocked(tx, payment.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+
+		ordersByID = make(map[string]models.Order, len(orders))
+		for _, order := range orders {
+			ordersByID[order.ID] = order
+		}
+	}
+
+	items, itemsAmount, err := s.buildRefundItems(ordersByID, param.Items, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := param.Amount
+	if amount == 0 {
+		amount = itemsAmount
+	}
+	if amount <= 0 {
+		return nil, errors.New("refund amount must be greater than 0")
+	}
+
+	refunded, err := s.refundRepo.SumByPayment(payment.ID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if refunded+amount > payment.TotalPayment {
+		return nil, errors.New("refund amount exceeds the remaining paid amount")
+	}
+
+	if param.Restock {
+		for i := range items {
+			if err := s.restock(ordersByID[items[i].OrderID], items[i].Quantity, tx); err != nil {
+				return nil, err
+			}
+			items[i].Restocked = true
+		}
+	}
+
+	refund, err := s.refundRepo.Create(models.Refund{
+		PaymentID:     payment.ID,
+		TransactionID: payment.TransactionID,
+		Amount:        amount,
+		Reason:        param.Reason,
+		Restock:       param.Restock,
+		CreatedBy:     actor.UserID,
+		Items:         items,
+	}, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refund: %w", err)
+	}
+
+	refunded += amount
+	fullyRefunded := refunded == payment.TotalPayment
+	if fullyRefunded {
+		payment.Status = "refunded"
+		if _, err := s.paymentRepo.Update(*payment, tx); err != nil {
+			return nil, fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		transaction, err := s.transactionRepo.FindByIdLocking(tx, payment.TransactionID)
+		if err != nil {
+			return nil, errors.New("transaction not found")
+		}
+		transaction.Status = "refunded"
+		if _, err := s.transactionRepo.Update(*transaction, tx); err != nil {
+			return nil, fmt.Errorf("failed to update transaction: %w", err)
+		}
+	}
+
+	details := fmt.Sprintf("Refunded %s of payment %d (transaction %s): %s", utils.FormatRupiah(amount), payment.ID, payment.TransactionID, param.Reason)
+	if err := logActivity(s.activityLogRepo, actor, "payments", fmt.Sprint(payment.ID), "refund", details, tx); err != nil {
+		return nil, err
+	}
+
+	return &models.PaymentRefundSummary{
+		Refund:          *refund.ToResponse(),
+		PaymentStatus:   payment.Status,
+		TotalPayment:    payment.TotalPayment,
+		RefundedAmount:  refunded,
+		RemainingAmount: payment.TotalPayment - refunded,
+	}, nil
+}
+
+// buildRefundItems checks the requested lines against the transaction's
+// locked orders and what was refunded before, and prices them at the unit
+// price paid.
+func (s *RefundServiceImpl) buildRefundItems(ordersByID map[string]models.Order, params []models.CreateRefundItem, tx *gorm.DB) ([]models.RefundItem, models.Money, error) {
+	if len(params) == 0 {
+		return nil, 0, nil
+	}
+
+	orderIDs := make([]string, 0, len(ordersByID))
+	for id := range ordersByID {
+		orderIDs = append(orderIDs, id)
+	}
+
+	refundedQuantities, err := s.refundRepo.RefundedQuantities(orderIDs, tx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]models.RefundItem, 0, len(params))
+	var total models.Money
+	for _, param := range params {
+		order, ok := ordersByID[param.OrderID]
+		if !ok {
+			return nil, 0, fmt.Errorf("order %s does not belong to this payment", param.OrderID)
+		}
+
+		if param.Quantity <= 0 {
+			return nil, 0, errors.New("refund quantity must be greater than 0")
+		}
+
+		refundedQuantities[order.ID] += param.Quantity
+		if refundedQuantities[order.ID] > order.Quantity {
+			return nil, 0, fmt.Errorf("refund quantity exceeds the quantity ordered for order %s", order.ID)
+		}
+
+		amount := order.UnitPrice.Mul(param.Quantity)
+		items = append(items, models.RefundItem{
+			OrderID:  order.ID,
+			Quantity: param.Quantity,
+			Amount:   amount,
+		})
+		total += amount
+	}
+
+	return items, total, nil
+}
+
+// restock puts the refunded units back on the size variant of the order
+// and queues the back-in-stock alerts that fires.
+func (s *RefundServiceImpl) restock(order models.Order, quantity int64, tx *gorm.DB) error {
+	before, err := s.productRepo.FindProductById(order.ProductID, tx)
+	if err != nil {
+		return fmt.Errorf("product of order %s not found", order.ID)
+	}
+
+	sizeVariant, err := s.productRepo.FindSizeVarianLocked(tx, uint(order.SizeVarianID))
+	if err != nil {
+		return fmt.Errorf("size variant of order %s not found", order.ID)
+	}
+
+	sizeVariant.Stock += quantity
+	if _, err := s.productRepo.UpdateSizeVarian(*sizeVariant, tx); err != nil {
+		return fmt.Errorf("failed to restock order %s: %w", order.ID, err)
+	}
+
+	after, err := s.productRepo.FindProductById(order.ProductID, tx)
+	if err != nil {
+		return fmt.Errorf("failed to load product of order %s: %w", order.ID, err)
+	}
+	return s.stockAlertService.QueueProductEvents(before, after, tx)
+}
+
+// FindAllRefund implements RefundService.
+func (s *RefundServiceImpl) FindAllRefund(param models.RefundListRequest) (*models.RefundListResponse, error) {
+	if param.Page < 1 {
+		param.Page = 1
+	}
+	if param.Limit < 1 || param.Limit > 100 {
+		param.Limit = 20
+	}
+
+	refunds, total, err := s.refundRepo.FindAll(param)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refund list: %w", err)
+	}
+
+	responses := make([]models.RefundResponse, len(refunds))
+	for i := range refunds {
+		responses[i] = *refunds[i].ToResponse()
+	}
+
+	return &models.RefundListResponse{
+		Refunds:    responses,
+		Total:      total,
+		Page:       param.Page,
+		Limit:      param.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(param.Limit))),
+	}, nil
+}
+
+// FindById implements RefundService.
+func (s *RefundServiceImpl) FindById(id int64) (*models.RefundResponse, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid refund id")
+	}
+
+	refund, err := s.refundRepo.FindById(id)
+	if err != nil {
+		return nil, errors.New("refund not found")
+	}
+
+	return refund.ToResponse(), nil
+}
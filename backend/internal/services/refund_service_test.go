@@ -0,0 +1,186 @@
+package services_test
+
+import (
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type refundServiceMocks struct {
+	refundRepo      *mocks.MockRefundRepository
+	paymentRepo     *mocks.MockPaymentRepository
+	transactionRepo *mocks.MockTransactionRepository
+	productRepo     *mocks.MockProductRepository
+	activityLogRepo *mocks.MockActivityLogRepository
+	stockAlerts     *mocks.MockStockAlertService
+}
+
+func newRefundService(ctrl *gomock.Controller) (services.RefundService, refundServiceMocks) {
+	m := refundServiceMocks{
+		refundRepo:      mocks.NewMockRefundRepository(ctrl),
+		paymentRepo:     mocks.NewMockPaymentRepository(ctrl),
+		transactionRepo: mocks.NewMockTransactionRepository(ctrl),
+		productRepo:     mocks.NewMockProductRepository(ctrl),
+		activityLogRepo: mocks.NewMockActivityLogRepository(ctrl),
+		stockAlerts:     mocks.NewMockStockAlertService(ctrl),
+	}
+	service := services.NewRefundService(m.refundRepo, m.paymentRepo, m.transactionRepo, m.productRepo, m.activityLogRepo, m.stockAlerts)
+	return service, m
+}
+
+func TestRefundService_RefundPayment(t *testing.T) {
+	actor := models.ActivityContext{UserID: 1}
+
+	t.Run("ExceedsTotalPayment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, m := newRefundService(ctrl)
+
+		m.refundRepo.EXPECT().FindPaymentLocked(gomock.Any(), int64(5)).
+			Return(&models.Payment{ID: 5, TransactionID: "TX1", TotalPayment: models.Rupiah(150000), Status: "success"}, nil)
+		m.refundRepo.EXPECT().SumByPayment(int64(5), gomock.Any()).Return(models.Rupiah(100000), nil)
+
+		_, err := service.RefundPayment(actor, models.CreateRefund{PaymentID: 5, Amount: models.Rupiah(60000), Reason: "damaged"}, nil)
+		if err == nil || err.Error() != "refund amount exceeds the remaining paid amount" {
+			t.Fatalf("expected the total payment cap, got %v", err)
+		}
+	})
+
+	t.Run("PartialThenFull", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, m := newRefundService(ctrl)
+
+		payment := models.Payment{ID: 5, TransactionID: "TX1", TotalPayment: models.Rupiah(150000), Status: "success"}
+
+		// the first refund leaves part of the payment, so nothing changes status
+		m.refundRepo.EXPECT().FindPaymentLocked(gomock.Any(), int64(5)).DoAndReturn(func(tx *gorm.DB, id int64) (*models.Payment, error) {
+			p := payment
+			return &p, nil
+		}).Times(2)
+		m.refundRepo.EXPECT().SumByPayment(int64(5), gomock.Any()).Return(models.Money(0), nil)
+		m.refundRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(r models.Refund, tx *gorm.DB) (models.Refund, error) { return r, nil }).Times(2)
+		m.activityLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.ActivityLog{}, nil).Times(2)
+
+		summary, err := service.RefundPayment(actor, models.CreateRefund{PaymentID: 5, Amount: models.Rupiah(50000), Reason: "late"}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if summary.PaymentStatus != "success" || summary.RemainingAmount != models.Rupiah(100000) {
+			t.Errorf("partial refund summary = %+v", summary)
+		}
+
+		// the second one uses up the rest and marks payment and transaction
+		m.refundRepo.EXPECT().SumByPayment(int64(5), gomock.Any()).Return(models.Rupiah(50000), nil)
+		m.paymentRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(p models.Payment, tx *gorm.DB) (models.Payment, error) {
+				if p.Status != "refunded" {
+					t.Errorf("payment status = %q, want refunded", p.Status)
+				}
+				return p, nil
+			})
+		m.transactionRepo.EXPECT().FindByIdLocking(gomock.Any(), "TX1").Return(&models.Transaction{TxID: "TX1", Status: "success"}, nil)
+		m.transactionRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(tr models.Transaction, tx *gorm.DB) (models.Transaction, error) {
+				if tr.Status != "refunded" {
+					t.Errorf("transaction status = %q, want refunded", tr.Status)
+				}
+				return tr, nil
+			})
+
+		summary, err = service.RefundPayment(actor, models.CreateRefund{PaymentID: 5, Amount: models.Rupiah(100000), Reason: "late"}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if summary.PaymentStatus != "refunded" || summary.RemainingAmount != 0 || summary.RefundedAmount != models.Rupiah(150000) {
+			t.Errorf("full refund summary = %+v", summary)
+		}
+	})
+
+	t.Run("AlreadyRefunded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, m := newRefundService(ctrl)
+
+		m.refundRepo.EXPECT().FindPaymentLocked(gomock.Any(), int64(5)).
+			Return(&models.Payment{ID: 5, TransactionID: "TX1", TotalPayment: models.Rupiah(150000), Status: "refunded"}, nil)
+
+		if _, err := service.RefundPayment(actor, models.CreateRefund{PaymentID: 5, Amount: models.Rupiah(1000), Reason: "again"}, nil); err == nil {
+			t.Fatal("expected error for a fully refunded payment")
+		}
+	})
+
+	t.Run("Restock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, m := newRefundService(ctrl)
+
+		order := models.Order{ID: "TXO1", TransactionID: "TX1", ProductID: 12, SizeVarianID: 8, Quantity: 3, UnitPrice: models.Rupiah(40000)}
+
+		m.refundRepo.EXPECT().FindPaymentLocked(gomock.Any(), int64(5)).
+			Return(&models.Payment{ID: 5, TransactionID: "TX1", TotalPayment: models.Rupiah(150000), Status: "success"}, nil)
+		m.refundRepo.EXPECT().FindOrdersLocked(gomock.Any(), "TX1").Return([]models.Order{order}, nil)
+		m.refundRepo.EXPECT().RefundedQuantities([]string{"TXO1"}, gomock.Any()).Return(map[string]int64{"TXO1": 1}, nil)
+		m.refundRepo.EXPECT().SumByPayment(int64(5), gomock.Any()).Return(models.Rupiah(40000), nil)
+
+		m.productRepo.EXPECT().FindProductById(int64(12), gomock.Any()).Return(&models.Product{ID: 12}, nil).Times(2)
+		m.productRepo.EXPECT().FindSizeVarianLocked(gomock.Any(), uint(8)).Return(&models.SizeVarian{ID: 8, Stock: 1}, nil)
+		m.productRepo.EXPECT().UpdateSizeVarian(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(sv models.SizeVarian, tx *gorm.DB) (models.SizeVarian, error) {
+				if sv.Stock != 3 {
+					t.Errorf("restocked stock = %d, want 3", sv.Stock)
+				}
+				return sv, nil
+			})
+		m.stockAlerts.EXPECT().QueueProductEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		m.refundRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(r models.Refund, tx *gorm.DB) (models.Refund, error) {
+				if r.Amount != models.Rupiah(80000) {
+					t.Errorf("refund amount = %s, want the value of 2 units", r.Amount)
+				}
+				if len(r.Items) != 1 || !r.Items[0].Restocked || r.Items[0].Quantity != 2 {
+					t.Errorf("refund items = %+v", r.Items)
+				}
+				return r, nil
+			})
+		m.activityLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.ActivityLog{}, nil)
+
+		_, err := service.RefundPayment(actor, models.CreateRefund{
+			PaymentID: 5,
+			Reason:    "wrong size",
+			Restock:   true,
+			Items:     []models.CreateRefundItem{{OrderID: "TXO1", Quantity: 2}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("RefundedQuantityExceedsOrdered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, m := newRefundService(ctrl)
+
+		m.refundRepo.EXPECT().FindPaymentLocked(gomock.Any(), int64(5)).
+			Return(&models.Payment{ID: 5, TransactionID: "TX1", TotalPayment: models.Rupiah(150000), Status: "success"}, nil)
+		m.refundRepo.EXPECT().FindOrdersLocked(gomock.Any(), "TX1").
+			Return([]models.Order{{ID: "TXO1", TransactionID: "TX1", Quantity: 3, UnitPrice: models.Rupiah(40000)}}, nil)
+		m.refundRepo.EXPECT().RefundedQuantities([]string{"TXO1"}, gomock.Any()).Return(map[string]int64{"TXO1": 2}, nil)
+
+		_, err := service.RefundPayment(actor, models.CreateRefund{
+			PaymentID: 5,
+			Reason:    "wrong size",
+			Restock:   true,
+			Items:     []models.CreateRefundItem{{OrderID: "TXO1", Quantity: 2}},
+		}, nil)
+		if err == nil {
+			t.Fatal("expected error when refunding more units than were ordered")
+		}
+	})
+}
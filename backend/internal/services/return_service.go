@@ -0,0 +1,501 @@
+package services
+
+import (
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxReturnPhotos limits how many photos a customer may attach to a return.
+const maxReturnPhotos = 5
+
+// returnTransitions lists the statuses a return may move to from each status.
+// An inspected return cannot be rejected any more: its restockable items are
+// already back in stock and a rejected return frees its units to be returned
+// again.
+var returnTransitions = map[string][]string{
+	"requested": {"approved", "rejected", "cancelled"},
+	"approved":  {"received", "cancelled"},
+	"received":  {"inspected"},
+	"inspected": {"refunded", "exchanged"},
+}
+
+type ReturnService interface {
+	CreateReturn(actor models.ActivityContext, param models.CreateReturnRequest, photos []*multipart.FileHeader) (*models.ReturnResponse, error)
+	FindAllReturn(param models.ReturnListRequest) (*models.ReturnListResponse, error)
+	FindById(id int64) (*models.ReturnResponse, error)
+	CancelReturn(actor models.ActivityContext, id int64) (*models.ReturnResponse, error)
+	UpdateStatus(actor models.ActivityContext, param models.UpdateReturnStatus) (*models.ReturnResponse, error)
+	InspectReturn(actor models.ActivityContext, param models.InspectReturn) (*models.ReturnResponse, error)
+	RefundReturn(actor models.ActivityContext, param models.RefundReturn) (*models.ReturnResponse, error)
+	ExchangeReturn(actor models.ActivityContext, param models.ExchangeReturn) (*models.ReturnResponse, error)
+}
+
+type ReturnServiceImpl struct {
+	returnRepo        repository.ReturnRepository
+	orderRepo         repository.OrderRepository
+	categoryRepo      repository.CategoryRepository
+	productRepo       repository.ProductRepository
+	refundService     RefundService
+	activityLogRepo   repository.ActivityLogRepository
+	stockAlertService StockAlertService
+}
+
+func NewReturnService(returnRepo repository.ReturnRepository, orderRepo repository.OrderRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository, refundService RefundService, activityLogRepo repository.ActivityLogRepository, stockAlertService StockAlertService) ReturnService {
+	return &ReturnServiceImpl{
+		returnRepo:        returnRepo,
+		orderRepo:         orderRepo,
+		categoryRepo:      categoryRepo,
+		productRepo:       productRepo,
+		refundService:     refundService,
+		activityLogRepo:   activityLogRepo,
+		stockAlertService: stockAlertService,
+	}
+}
+
+// canTransition reports whether a return in status from may move to to.
+func canTransition(from, to string) bool {
+	for _, next := range returnTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateReturn implements ReturnService. All lines must be delivered orders
+// of the customer from one transaction, still within the return window of
+// their category.
+func (s *ReturnServiceImpl) CreateReturn(actor models.ActivityContext, param models.CreateReturnRequest, photos []*multipart.FileHeader) (*models.ReturnResponse, error) {
+	param.Reason = strings.TrimSpace(param.Reason)
+	if param.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	if param.Resolution != "refund" && param.Resolution != "exchange" {
+		return nil, errors.New("resolution must be refund or exchange")
+	}
+
+	if len(param.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+
+	if len(photos) > maxReturnPhotos {
+		return nil, fmt.Errorf("at most %d photos can be attached", maxReturnPhotos)
+	}
+
+	orders := make(map[string]models.Order, len(param.Items))
+	orderIDs := make([]string, 0, len(param.Items))
+	var transactionID string
+	for _, item := range param.Items {
+		if item.Quantity <= 0 {
+			return nil, errors.New("return quantity must be greater than 0")
+		}
+		if _, ok := orders[item.OrderID]; ok {
+			return nil, fmt.Errorf("order %s is listed more than once", item.OrderID)
+		}
+
+		order, err := s.orderRepo.FindById(item.OrderID)
+		if err != nil || order.UserID != int64(actor.UserID) {
+			return nil, fmt.Errorf("order %s not found", item.OrderID)
+		}
+
+		if order.Status != "completed" {
+			return nil, fmt.Errorf("order %s has not been delivered", order.ID)
+		}
+
+		if transactionID == "" {
+			transactionID = order.TransactionID
+		} else if order.TransactionID != transactionID {
+			return nil, errors.New("all items must come from the same transaction")
+		}
+
+		if err := s.checkReturnWindow(order); err != nil {
+			return nil, err
+		}
+
+		orders[order.ID] = order
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	photoURLs := make([]models.ReturnPhoto, 0, len(photos))
+	for _, photo := range photos {
+		url, err := utils.UploadToSupabase(photo, fmt.Sprintf("returns/%d", actor.UserID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload photo: %w", err)
+		}
+		photoURLs = append(photoURLs, models.ReturnPhoto{URL: url})
+	}
+
+	var created models.ReturnRequest
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		locked, err := s.returnRepo.FindOrdersLocked(tx, orderIDs)
+		if err != nil {
+			return err
+		}
+		for _, order := range locked {
+			orders[order.ID] = order
+		}
+
+		returned, err := s.returnRepo.ReturnedQuantities(orderIDs, tx)
+		if err != nil {
+			return err
+		}
+
+		items := make([]models.ReturnItem, 0, len(param.Items))
+		for _, item := range param.Items {
+			order := orders[item.OrderID]
+			if returned[order.ID]+item.Quantity > order.Quantity {
+				return fmt.Errorf("return quantity exceeds the quantity ordered for order %s", order.ID)
+			}
+			items = append(items, models.ReturnItem{OrderID: order.ID, Quantity: item.Quantity})
+		}
+
+		created, err = s.returnRepo.Create(models.ReturnRequest{
+			UserID:        actor.UserID,
+			TransactionID: transactionID,
+			Status:        "requested",
+			Reason:        param.Reason,
+			Resolution:    param.Resolution,
+			Items:         items,
+			Photos:        photoURLs,
+		}, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create return: %w", err)
+		}
+
+		details := fmt.Sprintf("Requested return %d (%s) for transaction %s: %s", created.ID, created.Resolution, transactionID, param.Reason)
+		return logActivity(s.activityLogRepo, actor, "returns", fmt.Sprint(created.ID), "create", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created.ToResponse(), nil
+}
+
+// checkReturnWindow checks that an order is still within the return window of
+// its product's category. Orders delivered before delivery dates were
+// recorded fall back to their last update.
+func (s *ReturnServiceImpl) checkReturnWindow(order models.Order) error {
+	category, err := s.categoryRepo.FindById(order.Product.CategoryID)
+	if err != nil {
+		return fmt.Errorf("category of order %s not found", order.ID)
+	}
+
+	days := category.ReturnDays()
+	if days == 0 {
+		return fmt.Errorf("products in category %s cannot be returned", category.Name)
+	}
+
+	deliveredAt := order.UpdatedAt
+	if order.DeliveredAt != nil {
+		deliveredAt = *order.DeliveredAt
+	}
+
+	if time.Now().After(deliveredAt.AddDate(0, 0, days)) {
+		return fmt.Errorf("return window for order %s has expired", order.ID)
+	}
+	return nil
+}
+
+// FindAllReturn implements ReturnService.
+func (s *ReturnServiceImpl) FindAllReturn(param models.ReturnListRequest) (*models.ReturnListResponse, error) {
+	if param.Page < 1 {
+		param.Page = 1
+	}
+	if param.Limit < 1 || param.Limit > 100 {
+		param.Limit = 20
+	}
+
+	returns, total, err := s.returnRepo.FindAll(param)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return list: %w", err)
+	}
+
+	responses := make([]models.ReturnResponse, len(returns))
+	for i := range returns {
+		responses[i] = *returns[i].ToResponse()
+	}
+
+	return &models.ReturnListResponse{
+		Returns:    responses,
+		Total:      total,
+		Page:       param.Page,
+		Limit:      param.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(param.Limit))),
+	}, nil
+}
+
+// FindById implements ReturnService.
+func (s *ReturnServiceImpl) FindById(id int64) (*models.ReturnResponse, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid return id")
+	}
+
+	returnRequest, err := s.returnRepo.FindById(id)
+	if err != nil {
+		return nil, errors.New("return not found")
+	}
+
+	return returnRequest.ToResponse(), nil
+}
+
+// transition locks a return, checks that it may move to status, lets apply
+// make the step's changes and saves the new status, all within one database
+// transaction.
+func (s *ReturnServiceImpl) transition(actor models.ActivityContext, id int64, status, note string, apply func(returnRequest *models.ReturnRequest, tx *gorm.DB) error) (*models.ReturnResponse, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid return id")
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		returnRequest, err := s.returnRepo.FindByIdLocked(tx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("return not found")
+			}
+			return err
+		}
+
+		if status == "cancelled" && returnRequest.UserID != actor.UserID {
+			return errors.New("return not found")
+		}
+
+		if !canTransition(returnRequest.Status, status) {
+			return fmt.Errorf("cannot move return from %s to %s", returnRequest.Status, status)
+		}
+
+		if apply != nil {
+			if err := apply(returnRequest, tx); err != nil {
+				return err
+			}
+		}
+
+		previous := returnRequest.Status
+		returnRequest.Status = status
+		if note = strings.TrimSpace(note); note != "" {
+			returnRequest.AdminNote = note
+		}
+
+		if _, err := s.returnRepo.Update(*returnRequest, tx); err != nil {
+			return fmt.Errorf("failed to update return: %w", err)
+		}
+
+		details := fmt.Sprintf("Moved return %d from %s to %s", returnRequest.ID, previous, status)
+		return logActivity(s.activityLogRepo, actor, "returns", fmt.Sprint(returnRequest.ID), status, details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FindById(id)
+}
+
+// CancelReturn implements ReturnService. Customers can cancel their own
+// return until the goods are received.
+func (s *ReturnServiceImpl) CancelReturn(actor models.ActivityContext, id int64) (*models.ReturnResponse, error) {
+	return s.transition(actor, id, "cancelled", "", nil)
+}
+
+// UpdateStatus implements ReturnService.
+func (s *ReturnServiceImpl) UpdateStatus(actor models.ActivityContext, param models.UpdateReturnStatus) (*models.ReturnResponse, error) {
+	switch param.Status {
+	case "approved", "rejected", "received":
+	default:
+		return nil, errors.New("status must be approved, rejected or received")
+	}
+
+	if param.Status == "rejected" && strings.TrimSpace(param.Note) == "" {
+		return nil, errors.New("a note is required when rejecting a return")
+	}
+
+	return s.transition(actor, param.ID, param.Status, param.Note, nil)
+}
+
+// InspectReturn implements ReturnService. Restockable items go back into
+// stock straight away.
+func (s *ReturnServiceImpl) InspectReturn(actor models.ActivityContext, param models.InspectReturn) (*models.ReturnResponse, error) {
+	restockable := make(map[int64]bool, len(param.Items))
+	for _, item := range param.Items {
+		restockable[item.ItemID] = item.Restockable
+	}
+
+	return s.transition(actor, param.ID, "inspected", param.Note, func(returnRequest *models.ReturnRequest, tx *gorm.DB) error {
+		for itemID := range restockable {
+			if !hasReturnItem(returnRequest.Items, itemID) {
+				return fmt.Errorf("item %d does not belong to this return", itemID)
+			}
+		}
+
+		for _, item := range returnRequest.Items {
+			item.Restockable = restockable[item.ID]
+			if item.Restockable {
+				if err := s.adjustStock(item.OrderID, 0, item.Quantity, tx); err != nil {
+					return err
+				}
+				item.Restocked = true
+			}
+
+			if err := s.returnRepo.UpdateItem(item, tx); err != nil {
+				return fmt.Errorf("failed to update return item: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// RefundReturn implements ReturnService. The refund is issued against the
+// transaction's payment for the returned lines; stock was already handled at
+// inspection. Only returns the customer asked to have refunded qualify.
+func (s *ReturnServiceImpl) RefundReturn(actor models.ActivityContext, param models.RefundReturn) (*models.ReturnResponse, error) {
+	if param.Amount < 0 {
+		return nil, errors.New("refund amount must be greater than 0")
+	}
+
+	return s.transition(actor, param.ID, "refunded", "", func(returnRequest *models.ReturnRequest, tx *gorm.DB) error {
+		if err := checkResolution(returnRequest, "refund"); err != nil {
+			return err
+		}
+
+		payment, err := s.returnRepo.FindPaymentByTransaction(returnRequest.TransactionID, tx)
+		if err != nil {
+			return errors.New("payment not found")
+		}
+
+		items := make([]models.CreateRefundItem, len(returnRequest.Items))
+		for i, item := range returnRequest.Items {
+			items[i] = models.CreateRefundItem{OrderID: item.OrderID, Quantity: item.Quantity}
+		}
+
+		summary, err := s.refundService.RefundPayment(actor, models.CreateRefund{
+			PaymentID: payment.ID,
+			Amount:    param.Amount,
+			Reason:    fmt.Sprintf("Return %d: %s", returnRequest.ID, returnRequest.Reason),
+			Items:     items,
+		}, tx)
+		if err != nil {
+			return err
+		}
+
+		returnRequest.RefundID = &summary.Refund.ID
+		return nil
+	})
+}
+
+// ExchangeReturn implements ReturnService. Each item is swapped for another
+// variant of the same product, whose stock is reserved here. Only returns the
+// customer asked to have exchanged qualify.
+func (s *ReturnServiceImpl) ExchangeReturn(actor models.ActivityContext, param models.ExchangeReturn) (*models.ReturnResponse, error) {
+	replacements := make(map[int64]int64, len(param.Items))
+	for _, item := range param.Items {
+		replacements[item.ItemID] = item.SizeVarianID
+	}
+
+	return s.transition(actor, param.ID, "exchanged", "", func(returnRequest *models.ReturnRequest, tx *gorm.DB) error {
+		if err := checkResolution(returnRequest, "exchange"); err != nil {
+			return err
+		}
+
+		if len(replacements) != len(returnRequest.Items) {
+			return errors.New("every returned item needs a replacement")
+		}
+
+		for _, item := range returnRequest.Items {
+			sizeVarianID, ok := replacements[item.ID]
+			if !ok {
+				return fmt.Errorf("item %d needs a replacement", item.ID)
+			}
+
+			if err := s.adjustStock(item.OrderID, sizeVarianID, -item.Quantity, tx); err != nil {
+				return err
+			}
+
+			item.ReplacementSizeVarianID = &sizeVarianID
+			if err := s.returnRepo.UpdateItem(item, tx); err != nil {
+				return fmt.Errorf("failed to update return item: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// adjustStock changes the stock of a size variant by delta. A zero
+// sizeVarianID means the variant of the order itself; any other variant must
+// belong to the same product. Restocking queues the back-in-stock alerts it
+// fires.
+func (s *ReturnServiceImpl) adjustStock(orderID string, sizeVarianID int64, delta int64, tx *gorm.DB) error {
+	order, err := s.orderRepo.FindById(orderID)
+	if err != nil {
+		return fmt.Errorf("order %s not found", orderID)
+	}
+
+	if sizeVarianID == 0 {
+		sizeVarianID = order.SizeVarianID
+	}
+
+	sizeVariant, err := s.productRepo.FindSizeVarianLocked(tx, uint(sizeVarianID))
+	if err != nil {
+		return fmt.Errorf("size variant %d not found", sizeVarianID)
+	}
+
+	if sizeVariant.ID != order.SizeVarianID {
+		colorVariant, err := s.productRepo.FindColorVarianById(sizeVariant.ColorVarianID, tx)
+		if err != nil || colorVariant.ProductID != order.ProductID {
+			return fmt.Errorf("size variant %d is not a variant of the product in order %s", sizeVarianID, orderID)
+		}
+	}
+
+	if sizeVariant.Stock+delta < 0 {
+		return fmt.Errorf("size variant %d is out of stock", sizeVarianID)
+	}
+
+	var before *models.Product
+	if delta > 0 {
+		before, err = s.productRepo.FindProductById(order.ProductID, tx)
+		if err != nil {
+			return fmt.Errorf("product of order %s not found", orderID)
+		}
+	}
+
+	sizeVariant.Stock += delta
+	if _, err := s.productRepo.UpdateSizeVarian(*sizeVariant, tx); err != nil {
+		return fmt.Errorf("failed to update stock of size variant %d: %w", sizeVarianID, err)
+	}
+
+	if before == nil {
+		return nil
+	}
+	after, err := s.productRepo.FindProductById(order.ProductID, tx)
+	if err != nil {
+		return fmt.Errorf("failed to load product of order %s: %w", orderID, err)
+	}
+	return s.stockAlertService.QueueProductEvents(before, after, tx)
+}
+
+// checkResolution makes sure the return is settled the way the customer
+// asked for.
+func checkResolution(returnRequest *models.ReturnRequest, resolution string) error {
+	if returnRequest.Resolution != resolution {
+		return fmt.Errorf("return resolution is %s, not %s", returnRequest.Resolution, resolution)
+	}
+	return nil
+}
+
+func hasReturnItem(items []models.ReturnItem, id int64) bool {
+	for _, item := range items {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
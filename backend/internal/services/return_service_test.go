@@ -0,0 +1,350 @@
+package services_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// noopDriver only begins, commits and rolls back transactions, so services
+// that wrap mocked repositories in database.DB.Transaction can be tested
+// without a database.
+type noopDriver struct{}
+
+type noopConn struct{}
+
+type noopTx struct{}
+
+func (noopDriver) Open(string) (driver.Conn, error) { return noopConn{}, nil }
+
+func (noopConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("noop driver: queries are not supported")
+}
+func (noopConn) Close() error              { return nil }
+func (noopConn) Begin() (driver.Tx, error) { return noopTx{}, nil }
+
+func (noopTx) Commit() error   { return nil }
+func (noopTx) Rollback() error { return nil }
+
+var registerNoopDriver sync.Once
+
+// useNoopDB points database.DB at the noop driver for the rest of the test.
+func useNoopDB(t *testing.T) {
+	registerNoopDriver.Do(func() { sql.Register("services_test_noop", noopDriver{}) })
+
+	sqlDB, err := sql.Open("services_test_noop", "")
+	if err != nil {
+		t.Fatalf("failed to open noop database: %v", err)
+	}
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open noop database: %v", err)
+	}
+
+	original := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = original })
+}
+
+type returnServiceMocks struct {
+	returnRepo    *mocks.MockReturnRepository
+	orderRepo     *mocks.MockOrderRepository
+	categoryRepo  *mocks.MockCategoryRepository
+	productRepo   *mocks.MockProductRepository
+	refundService *mocks.MockRefundService
+	activityLogs  *mocks.MockActivityLogRepository
+	stockAlerts   *mocks.MockStockAlertService
+}
+
+func newReturnService(t *testing.T) (services.ReturnService, returnServiceMocks) {
+	ctrl := gomock.NewController(t)
+	useNoopDB(t)
+
+	m := returnServiceMocks{
+		returnRepo:    mocks.NewMockReturnRepository(ctrl),
+		orderRepo:     mocks.NewMockOrderRepository(ctrl),
+		categoryRepo:  mocks.NewMockCategoryRepository(ctrl),
+		productRepo:   mocks.NewMockProductRepository(ctrl),
+		refundService: mocks.NewMockRefundService(ctrl),
+		activityLogs:  mocks.NewMockActivityLogRepository(ctrl),
+		stockAlerts:   mocks.NewMockStockAlertService(ctrl),
+	}
+	service := services.NewReturnService(m.returnRepo, m.orderRepo, m.categoryRepo, m.productRepo, m.refundService, m.activityLogs, m.stockAlerts)
+	return service, m
+}
+
+// expectLockedReturn makes the return with the given status and resolution
+// the one every transition finds.
+func (m returnServiceMocks) expectLockedReturn(status, resolution string, items ...models.ReturnItem) {
+	m.returnRepo.EXPECT().FindByIdLocked(gomock.Any(), int64(7)).
+		Return(&models.ReturnRequest{ID: 7, UserID: 3, TransactionID: "TX1", Status: status, Resolution: resolution, Reason: "too small", Items: items}, nil)
+}
+
+func (m returnServiceMocks) expectSaved(status string) {
+	m.returnRepo.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(r models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error) {
+			if r.Status != status {
+				return r, errors.New("saved status " + r.Status + ", want " + status)
+			}
+			return r, nil
+		})
+	m.activityLogs.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.ActivityLog{}, nil)
+	m.returnRepo.EXPECT().FindById(int64(7)).Return(models.ReturnRequest{ID: 7, Status: status}, nil)
+}
+
+func TestReturnService_Transitions(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		allowed bool
+	}{
+		{"requested", "approved", true},
+		{"requested", "rejected", true},
+		{"requested", "received", false},
+		{"approved", "received", true},
+		{"approved", "rejected", false},
+		{"inspected", "rejected", false},
+		{"received", "approved", false},
+		{"refunded", "rejected", false},
+		{"rejected", "approved", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			service, m := newReturnService(t)
+			m.expectLockedReturn(tt.from, "refund")
+			if tt.allowed {
+				m.expectSaved(tt.to)
+			}
+
+			_, err := service.UpdateStatus(models.ActivityContext{UserID: 1}, models.UpdateReturnStatus{ID: 7, Status: tt.to, Note: "checked"})
+			if tt.allowed && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.allowed && (err == nil || !strings.HasPrefix(err.Error(), "cannot move return")) {
+				t.Fatalf("expected the move to be refused, got %v", err)
+			}
+		})
+	}
+
+	t.Run("CustomerCancels", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("approved", "refund")
+		m.expectSaved("cancelled")
+
+		if _, err := service.CancelReturn(models.ActivityContext{UserID: 3}, 7); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("CancelAfterReceived", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("received", "refund")
+
+		if _, err := service.CancelReturn(models.ActivityContext{UserID: 3}, 7); err == nil {
+			t.Fatal("expected error once the goods are received")
+		}
+	})
+
+	t.Run("CancelOthersReturn", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("requested", "refund")
+
+		if _, err := service.CancelReturn(models.ActivityContext{UserID: 4}, 7); err == nil || err.Error() != "return not found" {
+			t.Fatalf("expected return not found, got %v", err)
+		}
+	})
+}
+
+func TestReturnService_CheckResolution(t *testing.T) {
+	t.Run("RefundAnExchange", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("inspected", "exchange", models.ReturnItem{ID: 1, OrderID: "TXO1", Quantity: 1})
+
+		_, err := service.RefundReturn(models.ActivityContext{UserID: 1}, models.RefundReturn{ID: 7})
+		if err == nil || err.Error() != "return resolution is exchange, not refund" {
+			t.Fatalf("expected resolution error, got %v", err)
+		}
+	})
+
+	t.Run("ExchangeARefund", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("inspected", "refund", models.ReturnItem{ID: 1, OrderID: "TXO1", Quantity: 1})
+
+		_, err := service.ExchangeReturn(models.ActivityContext{UserID: 1}, models.ExchangeReturn{ID: 7, Items: []models.ExchangeReturnItem{{ItemID: 1, SizeVarianID: 9}}})
+		if err == nil || err.Error() != "return resolution is refund, not exchange" {
+			t.Fatalf("expected resolution error, got %v", err)
+		}
+	})
+
+	t.Run("RefundARefund", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("inspected", "refund", models.ReturnItem{ID: 1, OrderID: "TXO1", Quantity: 2})
+		m.returnRepo.EXPECT().FindPaymentByTransaction("TX1", gomock.Any()).Return(&models.Payment{ID: 5, TransactionID: "TX1"}, nil)
+		m.refundService.EXPECT().RefundPayment(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(actor models.ActivityContext, param models.CreateRefund, tx *gorm.DB) (*models.PaymentRefundSummary, error) {
+				if param.PaymentID != 5 || len(param.Items) != 1 || param.Items[0].Quantity != 2 || param.Restock {
+					t.Errorf("unexpected refund %+v", param)
+				}
+				return &models.PaymentRefundSummary{Refund: models.RefundResponse{ID: 11}}, nil
+			})
+		m.expectSaved("refunded")
+
+		if _, err := service.RefundReturn(models.ActivityContext{UserID: 1}, models.RefundReturn{ID: 7}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestReturnService_CreateReturn(t *testing.T) {
+	customer := models.ActivityContext{UserID: 3}
+	deliveredAt := time.Now().AddDate(0, 0, -2)
+	order := models.Order{
+		ID:            "TXO1",
+		UserID:        3,
+		TransactionID: "TX1",
+		Quantity:      3,
+		Status:        "completed",
+		DeliveredAt:   &deliveredAt,
+		Product:       models.Product{ID: 12, CategoryID: 2},
+	}
+	param := models.CreateReturnRequest{
+		Reason:     "too small",
+		Resolution: "refund",
+		Items:      []models.CreateReturnItem{{OrderID: "TXO1", Quantity: 2}},
+	}
+
+	expectOrder := func(m returnServiceMocks) {
+		m.orderRepo.EXPECT().FindById("TXO1").Return(order, nil)
+		m.categoryRepo.EXPECT().FindById(int64(2)).Return(models.Category{ID: 2, Name: "Gamis"}, nil)
+	}
+
+	t.Run("WithinOrderedQuantity", func(t *testing.T) {
+		service, m := newReturnService(t)
+		expectOrder(m)
+		m.returnRepo.EXPECT().FindOrdersLocked(gomock.Any(), []string{"TXO1"}).Return([]models.Order{order}, nil)
+		m.returnRepo.EXPECT().ReturnedQuantities([]string{"TXO1"}, gomock.Any()).Return(map[string]int64{"TXO1": 1}, nil)
+		m.returnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(r models.ReturnRequest, tx *gorm.DB) (models.ReturnRequest, error) {
+				if r.Status != "requested" || len(r.Items) != 1 || r.Items[0].Quantity != 2 {
+					t.Errorf("unexpected return %+v", r)
+				}
+				r.ID = 7
+				return r, nil
+			})
+		m.activityLogs.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.ActivityLog{}, nil)
+
+		if _, err := service.CreateReturn(customer, param, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ExceedsOrderedQuantity", func(t *testing.T) {
+		service, m := newReturnService(t)
+		expectOrder(m)
+		m.returnRepo.EXPECT().FindOrdersLocked(gomock.Any(), []string{"TXO1"}).Return([]models.Order{order}, nil)
+		// another open return already holds 2 of the 3 units
+		m.returnRepo.EXPECT().ReturnedQuantities([]string{"TXO1"}, gomock.Any()).Return(map[string]int64{"TXO1": 2}, nil)
+
+		_, err := service.CreateReturn(customer, param, nil)
+		if err == nil || !strings.Contains(err.Error(), "exceeds the quantity ordered") {
+			t.Fatalf("expected the returned quantity limit, got %v", err)
+		}
+	})
+}
+
+func TestReturnService_Restock(t *testing.T) {
+	order := models.Order{ID: "TXO1", ProductID: 12, SizeVarianID: 8, Quantity: 3}
+
+	t.Run("InspectRestocksReturnedQuantity", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("received", "refund",
+			models.ReturnItem{ID: 1, OrderID: "TXO1", Quantity: 2},
+			models.ReturnItem{ID: 2, OrderID: "TXO2", Quantity: 1},
+		)
+
+		m.orderRepo.EXPECT().FindById("TXO1").Return(order, nil)
+		m.productRepo.EXPECT().FindSizeVarianLocked(gomock.Any(), uint(8)).Return(&models.SizeVarian{ID: 8, Stock: 4}, nil)
+		m.productRepo.EXPECT().FindProductById(int64(12), gomock.Any()).Return(&models.Product{ID: 12}, nil).Times(2)
+		m.productRepo.EXPECT().UpdateSizeVarian(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(sv models.SizeVarian, tx *gorm.DB) (models.SizeVarian, error) {
+				if sv.Stock != 6 {
+					t.Errorf("stock after restock = %d, want 6", sv.Stock)
+				}
+				return sv, nil
+			})
+		m.stockAlerts.EXPECT().QueueProductEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		var updated []models.ReturnItem
+		m.returnRepo.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(item models.ReturnItem, tx *gorm.DB) error {
+				updated = append(updated, item)
+				return nil
+			}).Times(2)
+		m.expectSaved("inspected")
+
+		_, err := service.InspectReturn(models.ActivityContext{UserID: 1}, models.InspectReturn{
+			ID:    7,
+			Items: []models.InspectReturnItem{{ItemID: 1, Restockable: true}, {ItemID: 2, Restockable: false}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(updated) != 2 || !updated[0].Restocked || updated[1].Restocked {
+			t.Errorf("unexpected item updates %+v", updated)
+		}
+	})
+
+	t.Run("RejectAfterInspection", func(t *testing.T) {
+		service, m := newReturnService(t)
+		// the item is back in stock, so rejecting must not be possible; no
+		// stock or item updates are expected
+		m.expectLockedReturn("inspected", "refund", models.ReturnItem{ID: 1, OrderID: "TXO1", Quantity: 2, Restocked: true})
+
+		_, err := service.UpdateStatus(models.ActivityContext{UserID: 1}, models.UpdateReturnStatus{ID: 7, Status: "rejected", Note: "worn"})
+		if err == nil || err.Error() != "cannot move return from inspected to rejected" {
+			t.Fatalf("expected the rejection to be refused, got %v", err)
+		}
+	})
+
+	t.Run("ExchangeReservesReplacement", func(t *testing.T) {
+		service, m := newReturnService(t)
+		m.expectLockedReturn("inspected", "exchange", models.ReturnItem{ID: 1, OrderID: "TXO1", Quantity: 2})
+
+		m.orderRepo.EXPECT().FindById("TXO1").Return(order, nil)
+		m.productRepo.EXPECT().FindSizeVarianLocked(gomock.Any(), uint(9)).Return(&models.SizeVarian{ID: 9, ColorVarianID: 3, Stock: 5}, nil)
+		m.productRepo.EXPECT().FindColorVarianById(int64(3), gomock.Any()).Return(models.ColorVarian{ID: 3, ProductID: 12}, nil)
+		m.productRepo.EXPECT().UpdateSizeVarian(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(sv models.SizeVarian, tx *gorm.DB) (models.SizeVarian, error) {
+				if sv.Stock != 3 {
+					t.Errorf("replacement stock = %d, want 3", sv.Stock)
+				}
+				return sv, nil
+			})
+		m.returnRepo.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(nil)
+		m.expectSaved("exchanged")
+
+		_, err := service.ExchangeReturn(models.ActivityContext{UserID: 1}, models.ExchangeReturn{
+			ID:    7,
+			Items: []models.ExchangeReturnItem{{ItemID: 1, SizeVarianID: 9}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// shippableTransactionStatuses are the statuses of a paid transaction that
+// has not left the warehouse yet.
+var shippableTransactionStatuses = map[string]bool{
+	"paid":      true,
+	"confirmed": true,
+}
+
+// shipmentEventStatuses are the tracking statuses after the parcel was
+// handed to the carrier.
+var shipmentEventStatuses = map[string]bool{
+	"in_transit":       true,
+	"out_for_delivery": true,
+	"delivered":        true,
+	"failed":           true,
+	"returned":         true,
+}
+
+type ShipmentService interface {
+	MarkShipped(actor models.ActivityContext, param models.CreateShipment) (*models.ShipmentResponse, error)
+	AddEvent(actor models.ActivityContext, param models.CreateShipmentEvent) (*models.ShipmentResponse, error)
+	VerifyCarrierWebhook(carrier string, body []byte, signature string) error
+	HandleCarrierWebhook(carrier string, param models.CarrierWebhookPayload) error
+	FindByTransaction(transactionID string) (*models.ShipmentResponse, error)
+	FindMine(transactionID string, userID uint) (*models.ShipmentResponse, error)
+}
+
+type ShipmentServiceImpl struct {
+	shipmentRepo    repository.ShipmentRepository
+	transactionRepo repository.TransactionRepository
+	paymentRepo     repository.PaymentRepository
+	activityLogRepo repository.ActivityLogRepository
+	webhookSecrets  map[string]string
+	autoComplete    bool
+}
+
+func NewShipmentService(cfg *config.Config, shipmentRepo repository.ShipmentRepository, transactionRepo repository.TransactionRepository, paymentRepo repository.PaymentRepository, activityLogRepo repository.ActivityLogRepository) ShipmentService {
+	return &ShipmentServiceImpl{
+		shipmentRepo:    shipmentRepo,
+		transactionRepo: transactionRepo,
+		paymentRepo:     paymentRepo,
+		activityLogRepo: activityLogRepo,
+		webhookSecrets:  cfg.Shipment.WebhookSecrets,
+		autoComplete:    cfg.Shipment.AutoCompleteOnDelivery,
+	}
+}
+
+// MarkShipped implements ShipmentService. It records the carrier and tracking
+// number and moves the transaction and its orders to shipped.
+func (s *ShipmentServiceImpl) MarkShipped(actor models.ActivityContext, param models.CreateShipment) (*models.ShipmentResponse, error) {
+	param.Carrier = strings.ToLower(strings.TrimSpace(param.Carrier))
+	param.TrackingNumber = strings.TrimSpace(param.TrackingNumber)
+	if param.TransactionID == "" || param.Carrier == "" || param.TrackingNumber == "" {
+		return nil, errors.New("transaction, carrier and tracking number are required")
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		transaction, err := s.transactionRepo.FindByIdLocking(tx, param.TransactionID)
+		if err != nil {
+			return errors.New("transaction not found")
+		}
+
+		if !shippableTransactionStatuses[transaction.Status] {
+			return fmt.Errorf("transaction with status %s cannot be shipped", transaction.Status)
+		}
+
+		if _, err := s.shipmentRepo.FindByTransaction(transaction.TxID); err == nil {
+			return errors.New("transaction has already been shipped")
+		}
+
+		now := time.Now()
+		shipment, err := s.shipmentRepo.Create(models.Shipment{
+			TransactionID:  transaction.TxID,
+			Carrier:        param.Carrier,
+			Service:        strings.TrimSpace(param.Service),
+			TrackingNumber: param.TrackingNumber,
+			Status:         "shipped",
+			ShippedAt:      now,
+			LastEventAt:    now,
+			CreatedBy:      actor.UserID,
+		}, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create shipment: %w", err)
+		}
+
+		if _, err := s.shipmentRepo.CreateEvent(models.ShipmentEvent{
+			ShipmentID:  shipment.ID,
+			Status:      "shipped",
+			Description: fmt.Sprintf("Handed to %s", param.Carrier),
+			Source:      "admin",
+			OccurredAt:  now,
+		}, tx); err != nil {
+			return fmt.Errorf("failed to create shipment event: %w", err)
+		}
+
+		if err := s.setTransactionStatus(transaction, "shipped", nil, tx); err != nil {
+			return err
+		}
+
+		details := fmt.Sprintf("Shipped transaction %s with %s, tracking number %s", transaction.TxID, param.Carrier, param.TrackingNumber)
+		return logActivity(s.activityLogRepo, actor, "shipments", fmt.Sprint(shipment.ID), "ship", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FindByTransaction(param.TransactionID)
+}
+
+// AddEvent implements ShipmentService. Admins use it when a carrier has no
+// webhook, e.g. to mark a parcel delivered.
+func (s *ShipmentServiceImpl) AddEvent(actor models.ActivityContext, param models.CreateShipmentEvent) (*models.ShipmentResponse, error) {
+	if !shipmentEventStatuses[param.Status] {
+		return nil, errors.New("invalid shipment status")
+	}
+
+	var transactionID string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		shipment, err := s.shipmentRepo.FindByIdLocked(tx, param.ShipmentID)
+		if err != nil {
+			return errors.New("shipment not found")
+		}
+		transactionID = shipment.TransactionID
+
+		if err := s.recordEvent(shipment, param, "admin", tx); err != nil {
+			return err
+		}
+
+		details := fmt.Sprintf("Added %s event to shipment %d of transaction %s", param.Status, shipment.ID, shipment.TransactionID)
+		return logActivity(s.activityLogRepo, actor, "shipments", fmt.Sprint(shipment.ID), "track", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FindByTransaction(transactionID)
+}
+
+// VerifyCarrierWebhook implements ShipmentService.
+func (s *ShipmentServiceImpl) VerifyCarrierWebhook(carrier string, body []byte, signature string) error {
+	secret, ok := s.webhookSecrets[strings.ToLower(carrier)]
+	if !ok {
+		return errors.New("unknown carrier")
+	}
+
+	if !utils.ValidWebhookSignature(secret, body, signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// HandleCarrierWebhook implements ShipmentService. The payload must have
+// been verified with VerifyCarrierWebhook; retried deliveries of the same
+// event are ignored.
+func (s *ShipmentServiceImpl) HandleCarrierWebhook(carrier string, param models.CarrierWebhookPayload) error {
+	if !shipmentEventStatuses[param.Status] {
+		return errors.New("invalid shipment status")
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		shipment, err := s.shipmentRepo.FindByTrackingLocked(tx, strings.ToLower(carrier), strings.TrimSpace(param.TrackingNumber))
+		if err != nil {
+			return errors.New("shipment not found")
+		}
+
+		return s.recordEvent(shipment, models.CreateShipmentEvent{
+			ShipmentID:  shipment.ID,
+			Status:      param.Status,
+			Description: param.Description,
+			Location:    param.Location,
+			OccurredAt:  param.OccurredAt,
+		}, "carrier", tx)
+	})
+}
+
+// recordEvent adds an event to the timeline. Only an event newer than the
+// last one changes the shipment status, so late webhooks cannot move a
+// delivered parcel back in transit. Delivery completes the transaction when
+// an admin reports it, or a carrier does and auto-completion is on; for cash
+// on delivery it also records the cash the courier collected as paid.
+func (s *ShipmentServiceImpl) recordEvent(shipment *models.Shipment, param models.CreateShipmentEvent, source string, tx *gorm.DB) error {
+	occurredAt := param.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	created, err := s.shipmentRepo.CreateEvent(models.ShipmentEvent{
+		ShipmentID:  shipment.ID,
+		Status:      param.Status,
+		Description: strings.TrimSpace(param.Description),
+		Location:    strings.TrimSpace(param.Location),
+		Source:      source,
+		OccurredAt:  occurredAt,
+	}, tx)
+	if err != nil {
+		return fmt.Errorf("failed to create shipment event: %w", err)
+	}
+
+	if !created || shipment.Status == "delivered" || occurredAt.Before(shipment.LastEventAt) {
+		return nil
+	}
+
+	shipment.Status = param.Status
+	shipment.LastEventAt = occurredAt
+	if param.Status == "delivered" {
+		shipment.DeliveredAt = &occurredAt
+	}
+
+	if _, err := s.shipmentRepo.Update(*shipment, tx); err != nil {
+		return fmt.Errorf("failed to update shipment: %w", err)
+	}
+
+	if param.Status != "delivered" || (source == "carrier" && !s.autoComplete) {
+		return nil
+	}
+
+	transaction, err := s.transactionRepo.FindByIdLocking(tx, shipment.TransactionID)
+	if err != nil {
+		return errors.New("transaction not found")
+	}
+
+	if transaction.Status != "shipped" {
+		return nil
+	}
+
+	if transaction.PaymentType == models.PaymentTypeCOD {
+		if _, err := s.paymentRepo.Create(models.Payment{
+			TransactionID: transaction.TxID,
+			TotalPayment:  transaction.AmountDue(),
+			Status:        "success",
+		}, tx); err != nil {
+			return fmt.Errorf("failed to record cash on delivery payment: %w", err)
+		}
+	}
+	return s.setTransactionStatus(transaction, "completed", &occurredAt, tx)
+}
+
+// setTransactionStatus moves a transaction and its orders to status.
+func (s *ShipmentServiceImpl) setTransactionStatus(transaction *models.Transaction, status string, deliveredAt *time.Time, tx *gorm.DB) error {
+	transaction.Status = status
+	if _, err := s.transactionRepo.Update(*transaction, tx); err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	if err := s.shipmentRepo.UpdateOrderStatus(transaction.TxID, status, deliveredAt, tx); err != nil {
+		return fmt.Errorf("failed to update orders: %w", err)
+	}
+	return nil
+}
+
+// FindByTransaction implements ShipmentService.
+func (s *ShipmentServiceImpl) FindByTransaction(transactionID string) (*models.ShipmentResponse, error) {
+	shipment, err := s.shipmentRepo.FindByTransaction(transactionID)
+	if err != nil {
+		return nil, errors.New("shipment not found")
+	}
+
+	return shipment.ToResponse(), nil
+}
+
+// FindMine implements ShipmentService. Customers only see shipments of their
+// own transactions.
+func (s *ShipmentServiceImpl) FindMine(transactionID string, userID uint) (*models.ShipmentResponse, error) {
+	ownerID, err := s.shipmentRepo.FindTransactionOwner(transactionID)
+	if err != nil || ownerID != int64(userID) {
+		return nil, errors.New("shipment not found")
+	}
+
+	return s.FindByTransaction(transactionID)
+}
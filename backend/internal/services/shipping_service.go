@@ -5,6 +5,7 @@ import (
 	"e-commerce/backend/internal/repository"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 type ShippingService interface {
@@ -13,20 +14,34 @@ type ShippingService interface {
 	FindAllShipping(param models.ShippingListRequest) ([]models.ShippingResponse, error)
 	FindByID(id int64) (*models.ShippingResponse, error)
 	DeleteShipping(id int64) error
+	CreateZone(param models.CreateShippingZone) (*models.ShippingZoneResponse, error)
+	UpdateZone(param models.CreateShippingZone) (*models.ShippingZoneResponse, error)
+	DeleteZone(id int64) error
+	FindAllZones() ([]models.ShippingZoneResponse, error)
+	CreateRate(param models.CreateShippingRate) (*models.ShippingZoneResponse, error)
+	DeleteRate(zoneID, rateID int64) error
+	Quote(param models.ShippingQuoteRequest) (*models.ShippingQuoteResponse, error)
+	PriceShipping(shipping models.Shipping, address models.Address, weightGrams int64, subtotal models.Money) (*models.ShippingQuoteOption, error)
 }
 
 type ShippingServiceImpl struct {
 	shippingRepo repository.ShippingRepository
+	zoneRepo     repository.ShippingZoneRepository
+	addressRepo  repository.AddressRepository
+	productRepo  repository.ProductRepository
 }
 
-func NewShippingService(shippingRepo repository.ShippingRepository) ShippingService {
+func NewShippingService(shippingRepo repository.ShippingRepository, zoneRepo repository.ShippingZoneRepository, addressRepo repository.AddressRepository, productRepo repository.ProductRepository) ShippingService {
 	return &ShippingServiceImpl{
 		shippingRepo: shippingRepo,
+		zoneRepo:     zoneRepo,
+		addressRepo:  addressRepo,
+		productRepo:  productRepo,
 	}
 }
 
 // --- helpers ---
-func validateShipping(name, state string, price float64) error {
+func validateShipping(name, state string, price models.Money) error {
 	if name == "" {
 		return errors.New("shipping name is required")
 	}
@@ -129,3 +144,315 @@ func (s *ShippingServiceImpl) DeleteShipping(id int64) error {
 
 	return nil
 }
+
+func (s *ShippingServiceImpl) CreateZone(param models.CreateShippingZone) (*models.ShippingZoneResponse, error) {
+	zone, err := s.zoneFromParam(param)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.zoneRepo.Create(zone, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shipping zone: %w", err)
+	}
+
+	return created.ToResponse(), nil
+}
+
+func (s *ShippingServiceImpl) UpdateZone(param models.CreateShippingZone) (*models.ShippingZoneResponse, error) {
+	existing, err := s.zoneRepo.FindById(param.ID)
+	if err != nil {
+		return nil, errors.New("shipping zone not found")
+	}
+
+	zone, err := s.zoneFromParam(param)
+	if err != nil {
+		return nil, err
+	}
+	zone.ID = existing.ID
+	zone.CreatedAt = existing.CreatedAt
+
+	if _, err := s.zoneRepo.Update(zone, nil); err != nil {
+		return nil, fmt.Errorf("failed to update shipping zone: %w", err)
+	}
+
+	updated, err := s.zoneRepo.FindById(zone.ID)
+	if err != nil {
+		return nil, errors.New("shipping zone not found")
+	}
+	return updated.ToResponse(), nil
+}
+
+func (s *ShippingServiceImpl) DeleteZone(id int64) error {
+	if _, err := s.zoneRepo.FindById(id); err != nil {
+		return errors.New("shipping zone not found")
+	}
+
+	if err := s.zoneRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete shipping zone: %w", err)
+	}
+	return nil
+}
+
+func (s *ShippingServiceImpl) FindAllZones() ([]models.ShippingZoneResponse, error) {
+	zones, err := s.zoneRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping zones: %w", err)
+	}
+
+	results := make([]models.ShippingZoneResponse, 0, len(zones))
+	for _, zone := range zones {
+		results = append(results, *zone.ToResponse())
+	}
+	return results, nil
+}
+
+// CreateRate adds a weight tier for a shipping method to a zone. A method
+// has at most one tier per maximum weight in a zone.
+func (s *ShippingServiceImpl) CreateRate(param models.CreateShippingRate) (*models.ShippingZoneResponse, error) {
+	if param.MaxWeightGrams < 0 {
+		return nil, errors.New("max weight must be >= 0")
+	}
+	if param.Price < 0 {
+		return nil, errors.New("shipping price must be >= 0")
+	}
+
+	zone, err := s.zoneRepo.FindById(param.ZoneID)
+	if err != nil {
+		return nil, errors.New("shipping zone not found")
+	}
+
+	if _, err := s.shippingRepo.FindById(uint(param.ShippingID)); err != nil {
+		return nil, errors.New("shipping not found")
+	}
+
+	for _, rate := range zone.Rates {
+		if rate.ShippingID == param.ShippingID && rate.MaxWeightGrams == param.MaxWeightGrams {
+			return nil, errors.New("shipping rate already exists for this weight")
+		}
+	}
+
+	if _, err := s.zoneRepo.CreateRate(models.ShippingRate{
+		ZoneID:         zone.ID,
+		ShippingID:     param.ShippingID,
+		MaxWeightGrams: param.MaxWeightGrams,
+		Price:          param.Price,
+	}, nil); err != nil {
+		return nil, fmt.Errorf("failed to create shipping rate: %w", err)
+	}
+
+	updated, err := s.zoneRepo.FindById(zone.ID)
+	if err != nil {
+		return nil, errors.New("shipping zone not found")
+	}
+	return updated.ToResponse(), nil
+}
+
+func (s *ShippingServiceImpl) DeleteRate(zoneID, rateID int64) error {
+	deleted, err := s.zoneRepo.DeleteRate(zoneID, rateID)
+	if err != nil {
+		return fmt.Errorf("failed to delete shipping rate: %w", err)
+	}
+	if !deleted {
+		return errors.New("shipping rate not found")
+	}
+	return nil
+}
+
+// Quote prices every active shipping method for the items delivered to one
+// of the caller's addresses. Methods without a rate for the destination or
+// the parcel's weight are left out.
+func (s *ShippingServiceImpl) Quote(param models.ShippingQuoteRequest) (*models.ShippingQuoteResponse, error) {
+	if len(param.Items) == 0 {
+		return nil, errors.New("items are required")
+	}
+
+	address, err := s.addressRepo.FindById(uint(param.AddressID))
+	if err != nil || address.UserID != param.UserID {
+		return nil, errors.New("address not found")
+	}
+
+	var weight int64
+	var subtotal models.Money
+	for idx, item := range param.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("invalid quantity at index %d", idx)
+		}
+
+		product, err := s.productRepo.FindProductById(item.ProductID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("product not found at index %d", idx)
+		}
+
+		unitPrice := product.Price
+		if item.SizeVarianID != 0 {
+			size, ok := product.FindSizeVarian(item.SizeVarianID)
+			if !ok {
+				return nil, fmt.Errorf("size variant not found at index %d", idx)
+			}
+			unitPrice = size.UnitPrice(product.Price)
+		}
+
+		weight += product.Dimensions().ChargeableWeightGrams() * int64(item.Quantity)
+		subtotal += unitPrice.Mul(int64(item.Quantity))
+	}
+
+	shippings, err := s.shippingRepo.FindAll(models.ShippingListRequest{SortBy: "price ASC"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping list: %w", err)
+	}
+
+	zone, err := s.matchZone(*address)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ShippingQuoteResponse{
+		WeightGrams: weight,
+		Subtotal:    subtotal,
+		Options:     []models.ShippingQuoteOption{},
+	}
+	if zone != nil {
+		response.Zone = zone.Name
+	}
+
+	for _, shipping := range shippings {
+		if shipping.State != "active" {
+			continue
+		}
+
+		if option, ok := priceInZone(shipping, zone, weight, subtotal); ok {
+			response.Options = append(response.Options, *option)
+		}
+	}
+
+	return response, nil
+}
+
+// PriceShipping prices one shipping method for a parcel delivered to address.
+// Checkout uses it so the shipping price never comes from the client.
+func (s *ShippingServiceImpl) PriceShipping(shipping models.Shipping, address models.Address, weightGrams int64, subtotal models.Money) (*models.ShippingQuoteOption, error) {
+	if shipping.State != "active" {
+		return nil, fmt.Errorf("shipping method %s is not available", shipping.Name)
+	}
+
+	zone, err := s.matchZone(address)
+	if err != nil {
+		return nil, err
+	}
+
+	option, ok := priceInZone(shipping, zone, weightGrams, subtotal)
+	if !ok {
+		return nil, fmt.Errorf("shipping method %s is not available for this address and weight", shipping.Name)
+	}
+	return option, nil
+}
+
+// matchZone finds the most specific zone for address: its city, then its
+// province, then the default zone. It returns nil when none matches.
+func (s *ShippingServiceImpl) matchZone(address models.Address) (*models.ShippingZone, error) {
+	zones, err := s.zoneRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping zones: %w", err)
+	}
+
+	var provinceZone, defaultZone *models.ShippingZone
+	for i := range zones {
+		zone := &zones[i]
+		switch {
+		case zone.Province == "":
+			defaultZone = zone
+		case !strings.EqualFold(zone.Province, strings.TrimSpace(address.Province)):
+		case zone.City == "":
+			provinceZone = zone
+		case strings.EqualFold(zone.City, strings.TrimSpace(address.City)):
+			return zone, nil
+		}
+	}
+
+	if provinceZone != nil {
+		return provinceZone, nil
+	}
+	return defaultZone, nil
+}
+
+// priceInZone picks the lightest tier of the method that fits the parcel.
+// Without a zone the method's flat price applies and cash on delivery is not
+// offered.
+func priceInZone(shipping models.Shipping, zone *models.ShippingZone, weightGrams int64, subtotal models.Money) (*models.ShippingQuoteOption, bool) {
+	option := &models.ShippingQuoteOption{
+		ShippingID: shipping.ID,
+		Name:       shipping.Name,
+		BasePrice:  shipping.Price,
+	}
+
+	if zone != nil {
+		option.CODAvailable = zone.CODAvailable
+
+		var tier *models.ShippingRate
+		for i := range zone.Rates {
+			rate := &zone.Rates[i]
+			if rate.ShippingID != shipping.ID {
+				continue
+			}
+			if rate.MaxWeightGrams != 0 && rate.MaxWeightGrams < weightGrams {
+				continue
+			}
+			if tier == nil || fitsTighter(rate, tier) {
+				tier = rate
+			}
+		}
+		if tier == nil {
+			return nil, false
+		}
+		option.BasePrice = tier.Price
+
+		if zone.FreeShippingThreshold > 0 && subtotal >= zone.FreeShippingThreshold {
+			option.FreeShipping = true
+			return option, true
+		}
+	}
+
+	option.Price = option.BasePrice
+	return option, true
+}
+
+// fitsTighter reports whether rate is a lighter tier than current.
+func fitsTighter(rate, current *models.ShippingRate) bool {
+	if current.MaxWeightGrams == 0 {
+		return rate.MaxWeightGrams != 0
+	}
+	return rate.MaxWeightGrams != 0 && rate.MaxWeightGrams < current.MaxWeightGrams
+}
+
+func (s *ShippingServiceImpl) zoneFromParam(param models.CreateShippingZone) (models.ShippingZone, error) {
+	zone := models.ShippingZone{
+		Name:                  strings.TrimSpace(param.Name),
+		Province:              strings.TrimSpace(param.Province),
+		City:                  strings.TrimSpace(param.City),
+		FreeShippingThreshold: param.FreeShippingThreshold,
+		CODAvailable:          param.CODAvailable,
+	}
+
+	if zone.Name == "" {
+		return zone, errors.New("shipping zone name is required")
+	}
+	if zone.Province == "" && zone.City != "" {
+		return zone, errors.New("province is required when city is set")
+	}
+	if zone.FreeShippingThreshold < 0 {
+		return zone, errors.New("free shipping threshold must be >= 0")
+	}
+
+	zones, err := s.zoneRepo.FindAll()
+	if err != nil {
+		return zone, fmt.Errorf("failed to get shipping zones: %w", err)
+	}
+	for _, other := range zones {
+		if other.ID != param.ID && strings.EqualFold(other.Province, zone.Province) && strings.EqualFold(other.City, zone.City) {
+			return zone, errors.New("shipping zone already exists for this area")
+		}
+	}
+
+	return zone, nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockAlertService interface {
+	Subscribe(userID uint, param models.CreateStockAlert) (*models.StockAlertResponse, error)
+	Unsubscribe(userID uint, id int64) error
+	FindAll(userID uint) ([]models.StockAlertResponse, error)
+	FindNotifications(param models.StockAlertNotificationListRequest) (*models.StockAlertNotificationListResponse, error)
+	MarkNotificationRead(userID uint, id int64) error
+	QueueProductEvents(before, after *models.Product, tx *gorm.DB) error
+}
+
+type StockAlertServiceImpl struct {
+	stockAlertRepo repository.StockAlertRepository
+	productRepo    repository.ProductRepository
+}
+
+func NewStockAlertService(stockAlertRepo repository.StockAlertRepository, productRepo repository.ProductRepository) StockAlertService {
+	return &StockAlertServiceImpl{
+		stockAlertRepo: stockAlertRepo,
+		productRepo:    productRepo,
+	}
+}
+
+// Subscribe implements StockAlertService. Back-in-stock alerts are only
+// taken while the product or size is sold out.
+func (s *StockAlertServiceImpl) Subscribe(userID uint, param models.CreateStockAlert) (*models.StockAlertResponse, error) {
+	if !param.Type.IsValid() {
+		return nil, fmt.Errorf("invalid alert type %q", param.Type)
+	}
+	product, err := s.productRepo.FindProductById(param.ProductID, nil)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	stock := product.TotalStock()
+	if param.SizeVarianID != nil {
+		sizeStock, ok := product.SizeStock()[*param.SizeVarianID]
+		if !ok {
+			return nil, errors.New("size variant not found")
+		}
+		stock = sizeStock
+	}
+	if param.Type == models.StockAlertBackInStock && stock > 0 {
+		return nil, errors.New("product is in stock")
+	}
+
+	if _, err := s.stockAlertRepo.FindActive(userID, product.ID, param.SizeVarianID, param.Type); err == nil {
+		return nil, errors.New("you are already subscribed to this alert")
+	}
+
+	created, err := s.stockAlertRepo.Create(models.StockAlert{
+		UserID:       userID,
+		ProductID:    product.ID,
+		SizeVarianID: param.SizeVarianID,
+		Type:         param.Type,
+		LastPrice:    product.AlertPrice(param.SizeVarianID),
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock alert: %w", err)
+	}
+
+	alert, err := s.stockAlertRepo.FindById(created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock alert: %w", err)
+	}
+	response := alert.ToResponse()
+	return &response, nil
+}
+
+// Unsubscribe implements StockAlertService.
+func (s *StockAlertServiceImpl) Unsubscribe(userID uint, id int64) error {
+	alert, err := s.stockAlertRepo.FindById(id)
+	if err != nil || alert.UserID != userID {
+		return errors.New("stock alert not found")
+	}
+
+	if err := s.stockAlertRepo.Delete(alert.ID, nil); err != nil {
+		return fmt.Errorf("failed to delete stock alert: %w", err)
+	}
+	return nil
+}
+
+// FindAll implements StockAlertService.
+func (s *StockAlertServiceImpl) FindAll(userID uint) ([]models.StockAlertResponse, error) {
+	alerts, err := s.stockAlertRepo.FindAllByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock alerts: %w", err)
+	}
+
+	responses := make([]models.StockAlertResponse, len(alerts))
+	for i := range alerts {
+		responses[i] = alerts[i].ToResponse()
+	}
+	return responses, nil
+}
+
+// FindNotifications implements StockAlertService.
+func (s *StockAlertServiceImpl) FindNotifications(param models.StockAlertNotificationListRequest) (*models.StockAlertNotificationListResponse, error) {
+	if param.Page < 1 {
+		param.Page = 1
+	}
+	if param.Limit < 1 || param.Limit > 100 {
+		param.Limit = 20
+	}
+
+	notifications, total, err := s.stockAlertRepo.FindNotifications(param)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	unread, err := s.stockAlertRepo.CountUnread(param.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	responses := make([]models.StockAlertNotificationResponse, len(notifications))
+	for i := range notifications {
+		responses[i] = notifications[i].ToResponse()
+	}
+
+	return &models.StockAlertNotificationListResponse{
+		Items:      responses,
+		Total:      total,
+		Unread:     unread,
+		Page:       param.Page,
+		Limit:      param.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(param.Limit))),
+	}, nil
+}
+
+// MarkNotificationRead implements StockAlertService.
+func (s *StockAlertServiceImpl) MarkNotificationRead(userID uint, id int64) error {
+	notification, err := s.stockAlertRepo.FindNotificationById(id)
+	if err != nil || notification.UserID != userID {
+		return errors.New("notification not found")
+	}
+
+	if err := s.stockAlertRepo.MarkNotificationRead(notification.ID, time.Now(), nil); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+// QueueProductEvents implements StockAlertService. It compares the product
+// before and after an update or restock, both loaded with their sizes inside tx, and
+// queues a notification for every alert the update fires. Running it in
+// the update's transaction means a rolled back update queues nothing.
+func (s *StockAlertServiceImpl) QueueProductEvents(before, after *models.Product, tx *gorm.DB) error {
+	restocked := models.RestockedSizes(before, after)
+	productRestocked := before.TotalStock() <= 0 && after.TotalStock() > 0
+	priceDropped := models.PriceDropped(before, after)
+	if len(restocked) == 0 && !productRestocked && !priceDropped {
+		return nil
+	}
+
+	alerts, err := s.stockAlertRepo.FindActiveByProductLocked(after.ID, tx)
+	if err != nil {
+		return fmt.Errorf("failed to get stock alerts: %w", err)
+	}
+
+	now := time.Now()
+	for _, alert := range alerts {
+		price := after.AlertPrice(alert.SizeVarianID)
+		notification := models.StockAlertNotification{
+			StockAlertID: alert.ID,
+			UserID:       alert.UserID,
+			ProductID:    alert.ProductID,
+			SizeVarianID: alert.SizeVarianID,
+			Type:         alert.Type,
+			Price:        price,
+		}
+
+		switch alert.Type {
+		case models.StockAlertBackInStock:
+			if alert.SizeVarianID != nil {
+				stock, ok := restocked[*alert.SizeVarianID]
+				if !ok {
+					continue
+				}
+				notification.Stock = stock
+			} else {
+				if !productRestocked {
+					continue
+				}
+				notification.Stock = after.TotalStock()
+			}
+			notification.EventKey = models.BackInStockEventKey()
+		case models.StockAlertPriceDrop:
+			if !priceDropped || price >= before.AlertPrice(alert.SizeVarianID) || price >= alert.LastPrice {
+				continue
+			}
+			notification.PreviousPrice = alert.LastPrice
+			notification.EventKey = models.PriceDropEventKey(price)
+		default:
+			continue
+		}
+
+		if _, err := s.stockAlertRepo.QueueNotification(notification, tx); err != nil {
+			return fmt.Errorf("failed to queue stock alert notification: %w", err)
+		}
+		if err := s.stockAlertRepo.MarkNotified(alert.ID, price, now, tx); err != nil {
+			return fmt.Errorf("failed to update stock alert: %w", err)
+		}
+	}
+	return nil
+}
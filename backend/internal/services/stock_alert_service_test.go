@@ -0,0 +1,237 @@
+package services_test
+
+import (
+	"e-commerce/backend/internal/mocks"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/services"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func stockAlertProduct(price int64, stockM, stockL int64) *models.Product {
+	return &models.Product{
+		ID:    12,
+		Price: models.Rupiah(price),
+		ColorVarians: []models.ColorVarian{
+			{ID: 3, ProductID: 12, SizeVarians: []models.SizeVarian{
+				{ID: 8, ColorVarianID: 3, Size: "M", Stock: stockM},
+				{ID: 9, ColorVarianID: 3, Size: "L", Stock: stockL},
+			}},
+		},
+	}
+}
+
+func TestStockAlertService_QueueProductEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	service := services.NewStockAlertService(mockAlertRepo, mocks.NewMockProductRepository(ctrl))
+
+	sizeM := int64(8)
+	sizeL := int64(9)
+
+	t.Run("BackInStock", func(t *testing.T) {
+		before := stockAlertProduct(100000, 0, 0)
+		after := stockAlertProduct(100000, 5, 0)
+
+		mockAlertRepo.EXPECT().FindActiveByProductLocked(int64(12), gomock.Any()).Return([]models.StockAlert{
+			{ID: 1, UserID: 7, ProductID: 12, Type: models.StockAlertBackInStock},
+			{ID: 2, UserID: 8, ProductID: 12, SizeVarianID: &sizeM, Type: models.StockAlertBackInStock},
+			{ID: 3, UserID: 9, ProductID: 12, SizeVarianID: &sizeL, Type: models.StockAlertBackInStock},
+			{ID: 4, UserID: 10, ProductID: 12, Type: models.StockAlertPriceDrop, LastPrice: models.Rupiah(100000)},
+		}, nil)
+
+		var queued []models.StockAlertNotification
+		mockAlertRepo.EXPECT().QueueNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(n models.StockAlertNotification, tx *gorm.DB) (bool, error) {
+				queued = append(queued, n)
+				return true, nil
+			}).Times(2)
+		mockAlertRepo.EXPECT().MarkNotified(int64(1), models.Rupiah(100000), gomock.Any(), gomock.Any()).Return(nil)
+		mockAlertRepo.EXPECT().MarkNotified(int64(2), models.Rupiah(100000), gomock.Any(), gomock.Any()).Return(nil)
+
+		if err := service.QueueProductEvents(before, after, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(queued) != 2 || queued[0].StockAlertID != 1 || queued[1].StockAlertID != 2 {
+			t.Fatalf("expected notifications for alerts 1 and 2, got %+v", queued)
+		}
+		if queued[0].EventKey != models.BackInStockEventKey() || queued[0].Stock != 5 {
+			t.Errorf("unexpected product notification %+v", queued[0])
+		}
+	})
+
+	t.Run("PriceDrop", func(t *testing.T) {
+		before := stockAlertProduct(100000, 2, 0)
+		after := stockAlertProduct(90000, 2, 0)
+
+		mockAlertRepo.EXPECT().FindActiveByProductLocked(int64(12), gomock.Any()).Return([]models.StockAlert{
+			{ID: 4, UserID: 10, ProductID: 12, Type: models.StockAlertPriceDrop, LastPrice: models.Rupiah(100000)},
+			// told about a lower price already
+			{ID: 5, UserID: 11, ProductID: 12, Type: models.StockAlertPriceDrop, LastPrice: models.Rupiah(85000)},
+		}, nil)
+		mockAlertRepo.EXPECT().QueueNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(n models.StockAlertNotification, tx *gorm.DB) (bool, error) {
+				if n.StockAlertID != 4 || n.PreviousPrice != models.Rupiah(100000) || n.Price != models.Rupiah(90000) {
+					t.Errorf("unexpected notification %+v", n)
+				}
+				if n.EventKey != models.PriceDropEventKey(models.Rupiah(90000)) {
+					t.Errorf("unexpected event key %q", n.EventKey)
+				}
+				return true, nil
+			})
+		mockAlertRepo.EXPECT().MarkNotified(int64(4), models.Rupiah(90000), gomock.Any(), gomock.Any()).Return(nil)
+
+		if err := service.QueueProductEvents(before, after, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("SizePriceDrop", func(t *testing.T) {
+		before := stockAlertProduct(100000, 2, 2)
+		after := stockAlertProduct(100000, 2, 2)
+		override := models.Rupiah(80000)
+		after.ColorVarians[0].SizeVarians[1].Price = &override
+
+		mockAlertRepo.EXPECT().FindActiveByProductLocked(int64(12), gomock.Any()).Return([]models.StockAlert{
+			{ID: 4, UserID: 10, ProductID: 12, Type: models.StockAlertPriceDrop, LastPrice: models.Rupiah(100000)},
+			{ID: 6, UserID: 11, ProductID: 12, SizeVarianID: &sizeM, Type: models.StockAlertPriceDrop, LastPrice: models.Rupiah(100000)},
+			{ID: 7, UserID: 12, ProductID: 12, SizeVarianID: &sizeL, Type: models.StockAlertPriceDrop, LastPrice: models.Rupiah(100000)},
+		}, nil)
+
+		var queued []models.StockAlertNotification
+		mockAlertRepo.EXPECT().QueueNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(n models.StockAlertNotification, tx *gorm.DB) (bool, error) {
+				queued = append(queued, n)
+				return true, nil
+			}).Times(2)
+		mockAlertRepo.EXPECT().MarkNotified(int64(4), override, gomock.Any(), gomock.Any()).Return(nil)
+		mockAlertRepo.EXPECT().MarkNotified(int64(7), override, gomock.Any(), gomock.Any()).Return(nil)
+
+		if err := service.QueueProductEvents(before, after, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(queued) != 2 || queued[0].StockAlertID != 4 || queued[1].StockAlertID != 7 {
+			t.Fatalf("expected notifications for alerts 4 and 7, got %+v", queued)
+		}
+		if queued[1].Price != override || queued[1].EventKey != models.PriceDropEventKey(override) {
+			t.Errorf("unexpected size notification %+v", queued[1])
+		}
+	})
+
+	t.Run("NoEvent", func(t *testing.T) {
+		// stock moving between sizes that were already in stock and a price
+		// rise fire nothing, so the alerts are not even loaded
+		before := stockAlertProduct(100000, 2, 1)
+		after := stockAlertProduct(110000, 1, 3)
+
+		if err := service.QueueProductEvents(before, after, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("QueueFails", func(t *testing.T) {
+		before := stockAlertProduct(100000, 0, 0)
+		after := stockAlertProduct(100000, 1, 0)
+
+		mockAlertRepo.EXPECT().FindActiveByProductLocked(int64(12), gomock.Any()).Return([]models.StockAlert{
+			{ID: 1, UserID: 7, ProductID: 12, Type: models.StockAlertBackInStock},
+		}, nil)
+		mockAlertRepo.EXPECT().QueueNotification(gomock.Any(), gomock.Any()).Return(false, errors.New("db down"))
+
+		if err := service.QueueProductEvents(before, after, nil); err == nil {
+			t.Fatal("expected error so the product update rolls back")
+		}
+	})
+}
+
+func TestStockAlertService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAlertRepo := mocks.NewMockStockAlertRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	service := services.NewStockAlertService(mockAlertRepo, mockProductRepo)
+
+	sizeM := int64(8)
+	sizeL := int64(9)
+
+	t.Run("SoldOutSize", func(t *testing.T) {
+		mockProductRepo.EXPECT().FindProductById(int64(12), gomock.Any()).Return(stockAlertProduct(100000, 0, 4), nil)
+		mockAlertRepo.EXPECT().FindActive(uint(7), int64(12), &sizeM, models.StockAlertBackInStock).Return(nil, gorm.ErrRecordNotFound)
+		mockAlertRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(a models.StockAlert, tx *gorm.DB) (models.StockAlert, error) {
+				if a.LastPrice != models.Rupiah(100000) {
+					t.Errorf("expected the current price to be kept, got %v", a.LastPrice)
+				}
+				a.ID = 1
+				return a, nil
+			})
+		mockAlertRepo.EXPECT().FindById(int64(1)).Return(&models.StockAlert{
+			ID: 1, UserID: 7, ProductID: 12, SizeVarianID: &sizeM, Type: models.StockAlertBackInStock, CreatedAt: time.Now(),
+		}, nil)
+
+		alert, err := service.Subscribe(7, models.CreateStockAlert{ProductID: 12, SizeVarianID: &sizeM, Type: models.StockAlertBackInStock})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !alert.Active {
+			t.Error("expected a new alert to be active")
+		}
+	})
+
+	tests := []struct {
+		name    string
+		param   models.CreateStockAlert
+		wantErr string
+	}{
+		{"InStockSize", models.CreateStockAlert{ProductID: 12, SizeVarianID: &sizeL, Type: models.StockAlertBackInStock}, "product is in stock"},
+		{"SizeOfAnotherProduct", models.CreateStockAlert{ProductID: 12, SizeVarianID: new(int64), Type: models.StockAlertBackInStock}, "size variant not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProductRepo.EXPECT().FindProductById(int64(12), gomock.Any()).Return(stockAlertProduct(100000, 0, 4), nil)
+
+			_, err := service.Subscribe(7, tt.param)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := service.Subscribe(7, models.CreateStockAlert{ProductID: 12, Type: "restock"})
+		if err == nil {
+			t.Error("expected error for an unknown alert type")
+		}
+	})
+
+	t.Run("PriceDropForSize", func(t *testing.T) {
+		product := stockAlertProduct(100000, 2, 4)
+		override := models.Rupiah(120000)
+		product.ColorVarians[0].SizeVarians[1].Price = &override
+
+		mockProductRepo.EXPECT().FindProductById(int64(12), gomock.Any()).Return(product, nil)
+		mockAlertRepo.EXPECT().FindActive(uint(7), int64(12), &sizeL, models.StockAlertPriceDrop).Return(nil, gorm.ErrRecordNotFound)
+		mockAlertRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(a models.StockAlert, tx *gorm.DB) (models.StockAlert, error) {
+				if a.LastPrice != override {
+					t.Errorf("expected the size's own price to be kept, got %v", a.LastPrice)
+				}
+				a.ID = 2
+				return a, nil
+			})
+		mockAlertRepo.EXPECT().FindById(int64(2)).Return(&models.StockAlert{
+			ID: 2, UserID: 7, ProductID: 12, SizeVarianID: &sizeL, Type: models.StockAlertPriceDrop, LastPrice: override, CreatedAt: time.Now(),
+		}, nil)
+
+		if _, err := service.Subscribe(7, models.CreateStockAlert{ProductID: 12, SizeVarianID: &sizeL, Type: models.StockAlertPriceDrop}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
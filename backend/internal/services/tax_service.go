@@ -0,0 +1,224 @@
+package services
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/database"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"e-commerce/backend/internal/utils"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type TaxService interface {
+	CreateClass(actor models.ActivityContext, param models.CreateTaxClass) (*models.TaxClassResponse, error)
+	UpdateClass(actor models.ActivityContext, param models.CreateTaxClass) (*models.TaxClassResponse, error)
+	DeleteClass(actor models.ActivityContext, id int64) error
+	FindAllClasses() ([]models.TaxClassResponse, error)
+	FindClassByID(id int64) (*models.TaxClassResponse, error)
+	ClassFor(product models.Product) (*models.TaxClass, error)
+	Calculate(amount models.Money, class *models.TaxClass) models.Money
+	PricesIncludeTax() bool
+}
+
+type TaxServiceImpl struct {
+	taxRepo          repository.TaxRepository
+	categoryRepo     repository.CategoryRepository
+	activityLogRepo  repository.ActivityLogRepository
+	pricesIncludeTax bool
+}
+
+func NewTaxService(cfg *config.Config, taxRepo repository.TaxRepository, categoryRepo repository.CategoryRepository, activityLogRepo repository.ActivityLogRepository) TaxService {
+	return &TaxServiceImpl{
+		taxRepo:          taxRepo,
+		categoryRepo:     categoryRepo,
+		activityLogRepo:  activityLogRepo,
+		pricesIncludeTax: cfg.Tax.PricesIncludeTax,
+	}
+}
+
+// CreateClass implements TaxService. A new default class takes the default
+// over from the previous one.
+func (s *TaxServiceImpl) CreateClass(actor models.ActivityContext, param models.CreateTaxClass) (*models.TaxClassResponse, error) {
+	class, err := s.classFromParam(param)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		created, err := s.taxRepo.CreateClass(class, tx)
+		if err != nil {
+			return fmt.Errorf("failed to create tax class: %w", err)
+		}
+		class = created
+
+		if class.IsDefault {
+			if err := s.taxRepo.ClearDefault(class.ID, tx); err != nil {
+				return fmt.Errorf("failed to update default tax class: %w", err)
+			}
+		}
+
+		details := fmt.Sprintf("Created tax class %s (%s%%)", class.Code, formatRate(class.Rate))
+		return logActivity(s.activityLogRepo, actor, "tax_classes", fmt.Sprint(class.ID), "create", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return class.ToResponse(), nil
+}
+
+// UpdateClass implements TaxService. Rate changes only apply to new
+// transactions; tax already charged is kept on the orders.
+func (s *TaxServiceImpl) UpdateClass(actor models.ActivityContext, param models.CreateTaxClass) (*models.TaxClassResponse, error) {
+	existing, err := s.taxRepo.FindClassById(param.ID)
+	if err != nil {
+		return nil, errors.New("tax class not found")
+	}
+
+	class, err := s.classFromParam(param)
+	if err != nil {
+		return nil, err
+	}
+	class.ID = existing.ID
+	class.CreatedAt = existing.CreatedAt
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := s.taxRepo.UpdateClass(class, tx); err != nil {
+			return fmt.Errorf("failed to update tax class: %w", err)
+		}
+
+		if class.IsDefault {
+			if err := s.taxRepo.ClearDefault(class.ID, tx); err != nil {
+				return fmt.Errorf("failed to update default tax class: %w", err)
+			}
+		}
+
+		details := fmt.Sprintf("Updated tax class %s: rate %s%% -> %s%%", class.Code, formatRate(existing.Rate), formatRate(class.Rate))
+		return logActivity(s.activityLogRepo, actor, "tax_classes", fmt.Sprint(class.ID), "update", details, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FindClassByID(class.ID)
+}
+
+// DeleteClass implements TaxService. Classes still assigned to categories or
+// products cannot be deleted.
+func (s *TaxServiceImpl) DeleteClass(actor models.ActivityContext, id int64) error {
+	class, err := s.taxRepo.FindClassById(id)
+	if err != nil {
+		return errors.New("tax class not found")
+	}
+
+	count, err := s.taxRepo.CountAssignments(id)
+	if err != nil {
+		return fmt.Errorf("failed to check tax class usage: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("tax class is still assigned to %d categories or products", count)
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := s.taxRepo.DeleteClass(id, tx); err != nil {
+			return fmt.Errorf("failed to delete tax class: %w", err)
+		}
+
+		return logActivity(s.activityLogRepo, actor, "tax_classes", fmt.Sprint(class.ID), "delete", fmt.Sprintf("Deleted tax class %s", class.Code), tx)
+	})
+}
+
+// FindAllClasses implements TaxService.
+func (s *TaxServiceImpl) FindAllClasses() ([]models.TaxClassResponse, error) {
+	classes, err := s.taxRepo.FindAllClasses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax classes: %w", err)
+	}
+
+	results := make([]models.TaxClassResponse, 0, len(classes))
+	for _, class := range classes {
+		results = append(results, *class.ToResponse())
+	}
+	return results, nil
+}
+
+// FindClassByID implements TaxService.
+func (s *TaxServiceImpl) FindClassByID(id int64) (*models.TaxClassResponse, error) {
+	class, err := s.taxRepo.FindClassById(id)
+	if err != nil {
+		return nil, errors.New("tax class not found")
+	}
+	return class.ToResponse(), nil
+}
+
+// ClassFor implements TaxService. It returns the product's own class, else
+// its category's, else the default class. A nil class means the product is
+// not taxed.
+func (s *TaxServiceImpl) ClassFor(product models.Product) (*models.TaxClass, error) {
+	classID := product.TaxClassID
+	if classID == nil {
+		category, err := s.categoryRepo.FindById(product.CategoryID)
+		if err == nil {
+			classID = category.TaxClassID
+		}
+	}
+
+	if classID != nil {
+		if class, err := s.taxRepo.FindClassById(*classID); err == nil {
+			return class, nil
+		}
+	}
+
+	class, err := s.taxRepo.FindDefaultClass()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default tax class: %w", err)
+	}
+	return class, nil
+}
+
+// Calculate implements TaxService. amount is a line total as priced in the
+// catalog.
+func (s *TaxServiceImpl) Calculate(amount models.Money, class *models.TaxClass) models.Money {
+	if class == nil {
+		return 0
+	}
+	return utils.CalculateTax(amount, class.Rate, s.pricesIncludeTax)
+}
+
+// PricesIncludeTax implements TaxService.
+func (s *TaxServiceImpl) PricesIncludeTax() bool {
+	return s.pricesIncludeTax
+}
+
+func (s *TaxServiceImpl) classFromParam(param models.CreateTaxClass) (models.TaxClass, error) {
+	class := models.TaxClass{
+		Name:      strings.TrimSpace(param.Name),
+		Code:      strings.ToUpper(strings.TrimSpace(param.Code)),
+		Rate:      param.Rate,
+		IsDefault: param.IsDefault,
+	}
+
+	if class.Name == "" || class.Code == "" {
+		return class, errors.New("tax class name and code are required")
+	}
+	if class.Rate < 0 || class.Rate > 100 {
+		return class, errors.New("tax rate must be between 0 and 100")
+	}
+
+	if other, err := s.taxRepo.FindClassByCode(class.Code); err == nil && other.ID != param.ID {
+		return class, errors.New("tax class code already exists")
+	}
+	return class, nil
+}
+
+// formatRate prints a percentage without trailing zeros, e.g. 11 or 2.5.
+func formatRate(rate float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", rate), "0"), ".")
+}
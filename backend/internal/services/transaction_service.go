@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
 )
 
 type TransactionService interface {
@@ -18,6 +19,7 @@ type TransactionService interface {
 	UpdateTransaction(param models.UpdateTransaction) (*models.TransactionResponse, error)
 	FindAllTransaction(param models.TransactionListRequest) (*[]models.TransactionResponse, error)
 	FindTransactionById(txid string) (*models.TransactionResponse, error)
+	FindQRIS(txid string, userID uint) (*models.TransactionQRISResponse, error)
 }
 
 type TransactionServiceImpl struct {
@@ -27,6 +29,16 @@ type TransactionServiceImpl struct {
 	orderRepo       repository.OrderRepository
 	paymentRepo     repository.PaymentMethodRepository
 	productRepo     repository.ProductRepository
+	shippingService ShippingService
+	taxService      TaxService
+	taxRepo         repository.TaxRepository
+
+	// reconciliationService gives each transfer a unique payable amount
+	reconciliationService ReconciliationService
+
+	// currencyService resolves the currency prices were shown in, whose rate
+	// is snapshotted on the transaction
+	currencyService CurrencyService
 }
 
 func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param models.CreateTransaction) (*models.TransactionResponse, error) {
@@ -73,6 +85,17 @@ func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param mo
 		return nil, err
 	}
 
+	if !paymentMethod.IsActive {
+		return nil, errors.New("payment method is not available")
+	}
+
+	// Prices are charged in the store currency; the currency the customer
+	// browsed in is kept with today's rate so the order reads as they saw it.
+	displayCurrency, err := t.currencyService.Resolve(param.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	tx := database.DB.WithContext(gctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
@@ -90,7 +113,9 @@ func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param mo
 		AddressID:       param.AddressID,
 		ShippingID:      param.ShippingID,
 		PaymentMethodID: param.PaymentMethodID,
-		ShippingPrice:   param.ShippingPrice,
+		Currency:        models.StoreCurrency,
+		DisplayCurrency: displayCurrency.Code,
+		ExchangeRate:    displayCurrency.Rate,
 		Status:          utils.WaitingPayment,
 	}
 
@@ -100,7 +125,10 @@ func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param mo
 	}
 
 	var orders []models.OrderResponse
-	var total float64
+	var total models.Money
+	var weight int64
+	var taxLines []models.TransactionTaxLine
+	taxLineIndex := map[int64]int{}
 
 	for idx, po := range param.ProductOrders {
 		if err := gctx.Err(); err != nil {
@@ -122,12 +150,23 @@ func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param mo
 			return nil, fmt.Errorf("size variant not found at index %d: %w", idx, err)
 		}
 
+		// the size decides the price, so it must be one of the product's
+		if colorVariant.ProductID != product.ID || sizeVariant.ColorVarianID != colorVariant.ID {
+			return nil, fmt.Errorf("variant does not belong to product %d at index %d", po.ProductID, idx)
+		}
+
 		if sizeVariant.Stock < po.Quantity {
 			return nil, fmt.Errorf("insufficient stock for product %d at index %d", po.ProductID, idx)
 		}
 
-		unitPrice := product.Price
-		subtotal := float64(po.Quantity) * unitPrice
+		unitPrice := sizeVariant.UnitPrice(product.Price)
+		subtotal := unitPrice.Mul(po.Quantity)
+
+		taxClass, err := t.taxService.ClassFor(*product)
+		if err != nil {
+			return nil, err
+		}
+		taxAmount := t.taxService.Calculate(subtotal, taxClass)
 
 		order := &models.Order{
 			ID:            utils.Generate("TXO"),
@@ -138,9 +177,29 @@ func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param mo
 			UnitPrice:     unitPrice,
 			Quantity:      po.Quantity,
 			Subtotal:      subtotal,
+			TaxAmount:     taxAmount,
 			Status:        utils.Pending,
 		}
 
+		if taxClass != nil {
+			order.TaxClassID = &taxClass.ID
+			order.TaxRate = taxClass.Rate
+
+			idx, ok := taxLineIndex[taxClass.ID]
+			if !ok {
+				idx = len(taxLines)
+				taxLineIndex[taxClass.ID] = idx
+				taxLines = append(taxLines, models.TransactionTaxLine{
+					TransactionID: transactionResult.TxID,
+					TaxClassID:    taxClass.ID,
+					Name:          taxClass.Name,
+					Rate:          taxClass.Rate,
+				})
+			}
+			taxLines[idx].TaxableAmount += subtotal
+			taxLines[idx].TaxAmount += taxAmount
+		}
+
 		orderResult, err := t.orderRepo.Create(*order, tx)
 		if err != nil {
 			return nil, fmt.Errorf("create order index %d: %w", idx, err)
@@ -152,10 +211,57 @@ func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param mo
 		}
 
 		total += subtotal
+		weight += product.Dimensions().ChargeableWeightGrams() * int64(po.Quantity)
 		orders = append(orders, orderResult.ToOrderResponse())
 	}
 
+	// The shipping price is priced here from the destination and weight;
+	// the one sent by the client is ignored.
+	shippingOption, err := t.shippingService.PriceShipping(*shipping, *address, weight, total)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tax lines are per class. With exclusive pricing the taxable amount is
+	// the line totals and the tax comes on top; with inclusive pricing the tax
+	// is backed out of them.
+	var taxTotal models.Money
+	for i := range taxLines {
+		if t.taxService.PricesIncludeTax() {
+			taxLines[i].TaxableAmount -= taxLines[i].TaxAmount
+		}
+		taxTotal += taxLines[i].TaxAmount
+	}
+	if err := t.taxRepo.CreateLines(taxLines, tx); err != nil {
+		return nil, fmt.Errorf("create tax lines: %w", err)
+	}
+	if !t.taxService.PricesIncludeTax() {
+		total += taxTotal
+	}
+
 	transactionResult.TotalPrice = total
+	transactionResult.TaxTotal = taxTotal
+	transactionResult.ShippingPrice = shippingOption.Price
+	if err := t.applyPaymentMethod(&transactionResult, paymentMethod, shippingOption); err != nil {
+		return nil, err
+	}
+	if transactionResult.PaymentType == models.PaymentTypeVirtualAccount {
+		number, err := t.newVirtualAccountNumber(tx, paymentMethod.VAPrefix)
+		if err != nil {
+			return nil, err
+		}
+		transactionResult.PaymentReference = number
+	}
+	if err := t.reconciliationService.AssignPayableAmount(tx, &transactionResult); err != nil {
+		return nil, err
+	}
+	if transactionResult.PaymentType == models.PaymentTypeQRIS {
+		payload, err := utils.DynamicQRIS(paymentMethod.QRISPayload, transactionResult.AmountDue(), transactionResult.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("generate qris: %w", err)
+		}
+		transactionResult.PaymentReference = payload
+	}
 	txResult, err := t.transactionRepo.Update(transactionResult, tx)
 	if err != nil {
 		return nil, fmt.Errorf("update transaction total: %w", err)
@@ -173,15 +279,82 @@ func (t *TransactionServiceImpl) CreateTransaction(ctx context.Context, param mo
 		PaymentMethod: *paymentMethod.ToResponsePaymentMethod(),
 		ShippingPrice: txResult.ShippingPrice,
 		TotalPrice:    txResult.TotalPrice,
+		TaxTotal:      txResult.TaxTotal,
+		UniqueCode:    txResult.UniqueCode,
+		PayableAmount: txResult.AmountDue(),
+		PaymentType:   txResult.PaymentType,
+		PaymentFee:    txResult.PaymentFee,
+		Currency:      txResult.SettlementCurrency(),
+		TaxLines:      taxLines,
 		Status:        txResult.Status,
 		Orders:        orders,
 		CreatedAt:     txResult.CreatedAt,
 		UpdatedAt:     txResult.UpdatedAt,
+
+		PaymentInstructions: paymentMethod.InstructionsFor(&txResult),
+
+		DisplayCurrency: txResult.DisplayCurrencyInfo().Code,
+		ExchangeRate:    txResult.DisplayCurrencyInfo().Rate,
+		DisplayAmount:   txResult.DisplayAmount(),
 	}
 
 	return response, nil
 }
 
+// applyPaymentMethod checks the order can be paid with the method and sets
+// the payment fee and how the transaction is paid. Cash on delivery needs a
+// destination where the courier collects cash and skips waiting for payment.
+func (t *TransactionServiceImpl) applyPaymentMethod(transaction *models.Transaction, paymentMethod *models.PaymentMethod, shippingOption *models.ShippingQuoteOption) error {
+	amount := transaction.TotalPrice + transaction.ShippingPrice
+	if amount < paymentMethod.MinAmount {
+		return fmt.Errorf("the minimum order for this payment method is %s", utils.FormatRupiah(paymentMethod.MinAmount))
+	}
+	if paymentMethod.MaxAmount > 0 && amount > paymentMethod.MaxAmount {
+		return fmt.Errorf("the maximum order for this payment method is %s", utils.FormatRupiah(paymentMethod.MaxAmount))
+	}
+
+	transaction.PaymentType = paymentMethod.Type
+	if transaction.PaymentType == "" {
+		transaction.PaymentType = models.PaymentTypeBankTransfer
+	}
+	transaction.PaymentFee = paymentMethod.FeeFor(amount)
+
+	switch transaction.PaymentType {
+	case models.PaymentTypeCOD:
+		if !shippingOption.CODAvailable {
+			return errors.New("cash on delivery is not available for this address")
+		}
+		transaction.Status = utils.Confirmed
+	}
+	return nil
+}
+
+// maxVirtualAccountAttempts bounds the retries for an unused virtual
+// account number.
+const maxVirtualAccountAttempts = 5
+
+// newVirtualAccountNumber draws virtual account numbers until one is not
+// used by any transaction yet. Reconciliation matches transfers by this
+// number, so it must identify a single transaction; the unique index on it
+// catches the rare race between two checkouts drawing the same number.
+func (t *TransactionServiceImpl) newVirtualAccountNumber(tx *gorm.DB, prefix string) (string, error) {
+	for attempt := 0; attempt < maxVirtualAccountAttempts; attempt++ {
+		number, err := utils.VirtualAccountNumber(prefix)
+		if err != nil {
+			return "", fmt.Errorf("generate virtual account number: %w", err)
+		}
+
+		exists, err := t.transactionRepo.VirtualAccountExists(tx, number)
+		if err != nil {
+			return "", fmt.Errorf("check virtual account number: %w", err)
+		}
+		if !exists {
+			return number, nil
+		}
+	}
+	return "", errors.New("could not assign a virtual account number, please try again")
+}
+
 // FindAllTransaction implements [TransactionService].
 func (t *TransactionServiceImpl) FindAllTransaction(param models.TransactionListRequest) (*[]models.TransactionResponse, error) {
 
@@ -197,9 +370,19 @@ func (t *TransactionServiceImpl) FindAllTransaction(param models.TransactionList
 			TxID:          transaction.TxID,
 			ShippingPrice: transaction.ShippingPrice,
 			TotalPrice:    transaction.TotalPrice,
+			TaxTotal:      transaction.TaxTotal,
+			UniqueCode:    transaction.UniqueCode,
+			PayableAmount: transaction.AmountDue(),
+			PaymentType:   transaction.PaymentType,
+			PaymentFee:    transaction.PaymentFee,
+			Currency:      transaction.SettlementCurrency(),
 			Status:        transaction.Status,
 			CreatedAt:     transaction.CreatedAt,
 			UpdatedAt:     transaction.UpdatedAt,
+
+			DisplayCurrency: transaction.DisplayCurrencyInfo().Code,
+			ExchangeRate:    transaction.DisplayCurrencyInfo().Rate,
+			DisplayAmount:   transaction.DisplayAmount(),
 		}
 
 		responses = append(responses, response)
@@ -226,15 +409,69 @@ func (t *TransactionServiceImpl) FindTransactionById(txid string) (*models.Trans
 		PaymentMethod: *transaction.PaymentMethod.ToResponsePaymentMethod(),
 		ShippingPrice: transaction.ShippingPrice,
 		TotalPrice:    transaction.TotalPrice,
+		TaxTotal:      transaction.TaxTotal,
+		UniqueCode:    transaction.UniqueCode,
+		PayableAmount: transaction.AmountDue(),
+		PaymentType:   transaction.PaymentType,
+		PaymentFee:    transaction.PaymentFee,
+		Currency:      transaction.SettlementCurrency(),
+		TaxLines:      transaction.TaxLines,
 		Status:        transaction.Status,
 		Orders:        orderResponse,
 		CreatedAt:     transaction.CreatedAt,
 		UpdatedAt:     transaction.UpdatedAt,
+
+		PaymentInstructions: transaction.PaymentMethod.InstructionsFor(&transaction),
+
+		DisplayCurrency: transaction.DisplayCurrencyInfo().Code,
+		ExchangeRate:    transaction.DisplayCurrencyInfo().Rate,
+		DisplayAmount:   transaction.DisplayAmount(),
 	}
 
 	return response, nil
 }
 
+// FindQRIS implements [TransactionService]. Customers only get the QRIS of
+// their own transactions, and only while it can still be paid. Transactions
+// from before dynamic QRIS get one generated from the merchant's static QRIS.
+func (t *TransactionServiceImpl) FindQRIS(txid string, userID uint) (*models.TransactionQRISResponse, error) {
+	ownerID, err := t.transactionRepo.FindTransactionOwner(txid)
+	if err != nil || ownerID != int64(userID) {
+		return nil, errors.New("transaction not found")
+	}
+
+	transaction, err := t.transactionRepo.FindById(txid)
+	if err != nil {
+		return nil, errors.New("transaction not found")
+	}
+	if transaction.PaymentType != models.PaymentTypeQRIS {
+		return nil, errors.New("transaction is not paid with qris")
+	}
+	if transaction.Status != utils.WaitingPayment {
+		return nil, fmt.Errorf("transaction with status %s is not waiting for payment", transaction.Status)
+	}
+
+	payload := transaction.PaymentReference
+	if payload == "" {
+		payload, err = utils.DynamicQRIS(transaction.PaymentMethod.QRISPayload, transaction.AmountDue(), transaction.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate qris: %w", err)
+		}
+	}
+
+	merchantName := utils.QRISMerchantName(payload)
+	if merchantName == "" {
+		merchantName = transaction.PaymentMethod.AccountName
+	}
+
+	return &models.TransactionQRISResponse{
+		TxID:         transaction.TxID,
+		Amount:       transaction.AmountDue(),
+		MerchantName: merchantName,
+		Payload:      payload,
+	}, nil
+}
+
 // UpdateTransaction implements [TransactionService].
 func (t *TransactionServiceImpl) UpdateTransaction(param models.UpdateTransaction) (*models.TransactionResponse, error) {
 	tx := database.DB.Begin()
@@ -283,10 +520,23 @@ func (t *TransactionServiceImpl) UpdateTransaction(param models.UpdateTransactio
 		PaymentMethod: *transaction.PaymentMethod.ToResponsePaymentMethod(),
 		ShippingPrice: transaction.ShippingPrice,
 		TotalPrice:    transaction.TotalPrice,
+		TaxTotal:      transaction.TaxTotal,
+		UniqueCode:    transaction.UniqueCode,
+		PayableAmount: transaction.AmountDue(),
+		PaymentType:   transaction.PaymentType,
+		PaymentFee:    transaction.PaymentFee,
+		Currency:      transaction.SettlementCurrency(),
+		TaxLines:      transaction.TaxLines,
 		Status:        transaction.Status,
 		Orders:        orderResponse,
 		CreatedAt:     transaction.CreatedAt,
 		UpdatedAt:     transaction.UpdatedAt,
+
+		PaymentInstructions: transaction.PaymentMethod.InstructionsFor(transaction),
+
+		DisplayCurrency: transaction.DisplayCurrencyInfo().Code,
+		ExchangeRate:    transaction.DisplayCurrencyInfo().Rate,
+		DisplayAmount:   transaction.DisplayAmount(),
 	}
 
 	return response, nil
@@ -297,7 +547,12 @@ func NewTransactionService(TransactionRepo repository.TransactionRepository,
 	AddressRepo repository.AddressRepository,
 	PaymentRepo repository.PaymentMethodRepository,
 	OrderRepo repository.OrderRepository,
-	ProductRepo repository.ProductRepository) TransactionService {
+	ProductRepo repository.ProductRepository,
+	ShippingService ShippingService,
+	TaxService TaxService,
+	TaxRepo repository.TaxRepository,
+	ReconciliationService ReconciliationService,
+	CurrencyService CurrencyService) TransactionService {
 	return &TransactionServiceImpl{
 		transactionRepo: TransactionRepo,
 		shippingRepo:    ShippingRepo,
@@ -305,5 +560,11 @@ func NewTransactionService(TransactionRepo repository.TransactionRepository,
 		orderRepo:       OrderRepo,
 		paymentRepo:     PaymentRepo,
 		productRepo:     ProductRepo,
+		shippingService: ShippingService,
+		taxService:      TaxService,
+		taxRepo:         TaxRepo,
+
+		reconciliationService: ReconciliationService,
+		currencyService:       CurrencyService,
 	}
 }
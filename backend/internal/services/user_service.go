@@ -6,6 +6,7 @@ import (
 	"e-commerce/backend/internal/repository"
 	"e-commerce/backend/internal/utils"
 	"errors"
+	"log"
 
 	"gorm.io/gorm"
 )
@@ -21,6 +22,7 @@ type UserService interface {
 	GetUserActivityLogs(req *models.ActivityLogListRequest) (*models.ActivityLogListResponse, error)
 	BulkUserActions(req *BulkActionRequest) error
 	UpdatePassword(userID uint, req *models.PasswordUpdateInput) error
+	RecordActivity(activity models.ActivityLog) error
 }
 
 type BulkActionRequest struct {
@@ -32,6 +34,7 @@ type UserServiceImpl struct {
 	userRepo        repository.UserRepository
 	acitvityLogRepo repository.ActivityLogRepository
 	roleRepo        repository.RoleRepository
+	passwordPolicy  PasswordPolicyService
 }
 
 func (u *UserServiceImpl) ActivateUser(id uint) (*models.User, error) {
@@ -82,6 +85,10 @@ func (u *UserServiceImpl) CreateUser(req *models.UserInput) (*models.User, error
 		return nil, errors.New("role not found")
 	}
 
+	if err := u.passwordPolicy.Validate(req.Password, models.User{Email: req.Email, Username: req.Username}); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, err
@@ -102,6 +109,10 @@ func (u *UserServiceImpl) CreateUser(req *models.UserInput) (*models.User, error
 		return nil, err
 	}
 
+	if err := u.passwordPolicy.Remember(userResult.ID, hashedPassword); err != nil {
+		log.Printf("⚠️  WARNING: failed to record password history for user %d: %v", userResult.ID, err)
+	}
+
 	return &userResult, nil
 }
 
@@ -184,6 +195,10 @@ func (u *UserServiceImpl) UpdatePassword(userID uint, req *models.PasswordUpdate
 		return errors.New("current password is incorrect")
 	}
 
+	if err := u.passwordPolicy.Validate(req.NewPassword, user); err != nil {
+		return err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
 		return errors.New("failed to hash new password")
@@ -194,6 +209,10 @@ func (u *UserServiceImpl) UpdatePassword(userID uint, req *models.PasswordUpdate
 		return err
 	}
 
+	if err := u.passwordPolicy.Remember(user.ID, hashedPassword); err != nil {
+		log.Printf("⚠️  WARNING: failed to record password history for user %d: %v", user.ID, err)
+	}
+
 	return nil
 }
 
@@ -248,9 +267,15 @@ func (u *UserServiceImpl) UpdateUser(id uint, req *models.UserUpdateInput) (*mod
 	return &updatedUser, nil
 }
 
+// RecordActivity implements UserService.
+func (u *UserServiceImpl) RecordActivity(activity models.ActivityLog) error {
+	_, err := u.acitvityLogRepo.Create(activity, nil)
+	return err
+}
+
 func NewUserService(userRepo repository.UserRepository,
-	acitvityLogRepo repository.ActivityLogRepository, roleRepo repository.RoleRepository) UserService {
+	acitvityLogRepo repository.ActivityLogRepository, roleRepo repository.RoleRepository, passwordPolicy PasswordPolicyService) UserService {
 	return &UserServiceImpl{
-		userRepo: userRepo, acitvityLogRepo: acitvityLogRepo, roleRepo: roleRepo,
+		userRepo: userRepo, acitvityLogRepo: acitvityLogRepo, roleRepo: roleRepo, passwordPolicy: passwordPolicy,
 	}
 }
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"e-commerce/backend/internal/config"
 	"e-commerce/backend/internal/mocks"
 	"e-commerce/backend/internal/models"
 	"e-commerce/backend/internal/services"
@@ -19,7 +20,7 @@ func TestUserService_GetUserById(t *testing.T) {
 	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo)
+	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo, services.NewPasswordPolicyService(&config.Config{}, nil))
 
 	tests := []struct {
 		name    string
@@ -108,7 +109,7 @@ func TestUserService_CreateUser(t *testing.T) {
 	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo)
+	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo, services.NewPasswordPolicyService(&config.Config{}, nil))
 
 	tests := []struct {
 		name    string
@@ -238,7 +239,7 @@ func TestUserService_UpdateUser(t *testing.T) {
 	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo)
+	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo, services.NewPasswordPolicyService(&config.Config{}, nil))
 
 	tests := []struct {
 		name    string
@@ -335,7 +336,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo)
+	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo, services.NewPasswordPolicyService(&config.Config{}, nil))
 
 	tests := []struct {
 		name    string
@@ -405,7 +406,7 @@ func TestUserService_ActivateUser(t *testing.T) {
 	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo)
+	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo, services.NewPasswordPolicyService(&config.Config{}, nil))
 
 	tests := []struct {
 		name    string
@@ -468,7 +469,7 @@ func TestUserService_DeactivateUser(t *testing.T) {
 	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo)
+	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo, services.NewPasswordPolicyService(&config.Config{}, nil))
 
 	tests := []struct {
 		name    string
@@ -531,7 +532,7 @@ func TestUserService_GetUsers(t *testing.T) {
 	mockActivityLogRepo := mocks.NewMockActivityLogRepository(ctrl)
 	mockRoleRepo := mocks.NewMockRoleRepository(ctrl)
 
-	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo)
+	service := services.NewUserService(mockUserRepo, mockActivityLogRepo, mockRoleRepo, services.NewPasswordPolicyService(&config.Config{}, nil))
 
 	tests := []struct {
 		name    string
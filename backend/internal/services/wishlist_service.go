@@ -0,0 +1,199 @@
+package services
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/repository"
+	"errors"
+	"fmt"
+	"math"
+)
+
+type WishlistService interface {
+	AddItem(userID uint, param models.CreateWishlist) (*models.WishlistResponse, error)
+	UpdateItem(userID uint, id int64, param models.CreateWishlist) (*models.WishlistResponse, error)
+	RemoveItem(userID uint, id int64) error
+	RemoveProduct(userID uint, productID int64) error
+	FindAll(param models.WishlistListRequest) (*models.WishlistListResponse, error)
+	WishlistedProducts(userID uint, productIDs []int64) (map[int64]bool, error)
+}
+
+type WishlistServiceImpl struct {
+	wishlistRepo repository.WishlistRepository
+	productRepo  repository.ProductRepository
+}
+
+func NewWishlistService(wishlistRepo repository.WishlistRepository, productRepo repository.ProductRepository) WishlistService {
+	return &WishlistServiceImpl{
+		wishlistRepo: wishlistRepo,
+		productRepo:  productRepo,
+	}
+}
+
+// AddItem implements WishlistService. The same product may be saved in
+// several variants, but each variant only once.
+func (s *WishlistServiceImpl) AddItem(userID uint, param models.CreateWishlist) (*models.WishlistResponse, error) {
+	item := models.Wishlist{
+		UserID:    userID,
+		ProductID: param.ProductID,
+	}
+	if err := s.applyVariant(&item, param); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.wishlistRepo.FindExisting(userID, item.ProductID, item.ColorVarianID, item.SizeVarianID); err == nil {
+		return nil, errors.New("product is already in your wishlist")
+	}
+
+	created, err := s.wishlistRepo.Create(item, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add to wishlist: %w", err)
+	}
+
+	return s.findResponse(created.ID)
+}
+
+// UpdateItem implements WishlistService. Only the color and size of an
+// entry can change; the product stays the same.
+func (s *WishlistServiceImpl) UpdateItem(userID uint, id int64, param models.CreateWishlist) (*models.WishlistResponse, error) {
+	item, err := s.findOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	param.ProductID = item.ProductID
+	if err := s.applyVariant(item, param); err != nil {
+		return nil, err
+	}
+
+	if other, err := s.wishlistRepo.FindExisting(userID, item.ProductID, item.ColorVarianID, item.SizeVarianID); err == nil && other.ID != item.ID {
+		return nil, errors.New("product is already in your wishlist")
+	}
+
+	if _, err := s.wishlistRepo.Update(*item, nil); err != nil {
+		return nil, fmt.Errorf("failed to update wishlist: %w", err)
+	}
+
+	return s.findResponse(item.ID)
+}
+
+// RemoveItem implements WishlistService.
+func (s *WishlistServiceImpl) RemoveItem(userID uint, id int64) error {
+	item, err := s.findOwned(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.wishlistRepo.Delete(item.ID, nil); err != nil {
+		return fmt.Errorf("failed to remove from wishlist: %w", err)
+	}
+	return nil
+}
+
+// RemoveProduct implements WishlistService. It removes the product in any
+// variant, for the heart toggle on product pages.
+func (s *WishlistServiceImpl) RemoveProduct(userID uint, productID int64) error {
+	removed, err := s.wishlistRepo.DeleteByProduct(userID, productID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove from wishlist: %w", err)
+	}
+	if removed == 0 {
+		return errors.New("wishlist item not found")
+	}
+	return nil
+}
+
+// FindAll implements WishlistService.
+func (s *WishlistServiceImpl) FindAll(param models.WishlistListRequest) (*models.WishlistListResponse, error) {
+	if param.Page < 1 {
+		param.Page = 1
+	}
+	if param.Limit < 1 || param.Limit > 100 {
+		param.Limit = 20
+	}
+
+	items, total, err := s.wishlistRepo.FindAll(param)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist: %w", err)
+	}
+
+	responses := make([]models.WishlistResponse, len(items))
+	for i := range items {
+		responses[i] = items[i].ToResponse()
+	}
+
+	return &models.WishlistListResponse{
+		Items:      responses,
+		Total:      total,
+		Page:       param.Page,
+		Limit:      param.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(param.Limit))),
+	}, nil
+}
+
+// WishlistedProducts implements WishlistService.
+func (s *WishlistServiceImpl) WishlistedProducts(userID uint, productIDs []int64) (map[int64]bool, error) {
+	ids, err := s.wishlistRepo.FindWishlistedProductIDs(userID, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist: %w", err)
+	}
+
+	wishlisted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wishlisted[id] = true
+	}
+	return wishlisted, nil
+}
+
+// applyVariant checks the product and the chosen color and size belong
+// together and sets them on item. A size without a color takes the size's
+// color.
+func (s *WishlistServiceImpl) applyVariant(item *models.Wishlist, param models.CreateWishlist) error {
+	if _, err := s.productRepo.FindProductById(param.ProductID, nil); err != nil {
+		return errors.New("product not found")
+	}
+
+	item.ColorVarianID = param.ColorVarianID
+	item.SizeVarianID = param.SizeVarianID
+
+	if param.SizeVarianID != nil {
+		size, err := s.productRepo.FindSizeVarianById(*param.SizeVarianID, nil)
+		if err != nil {
+			return errors.New("size variant not found")
+		}
+		if param.ColorVarianID != nil && size.ColorVarianID != *param.ColorVarianID {
+			return errors.New("size variant does not belong to the color variant")
+		}
+		colorID := size.ColorVarianID
+		item.ColorVarianID = &colorID
+	}
+
+	if item.ColorVarianID != nil {
+		color, err := s.productRepo.FindColorVarianById(*item.ColorVarianID, nil)
+		if err != nil {
+			return errors.New("color variant not found")
+		}
+		if color.ProductID != param.ProductID {
+			return errors.New("color variant does not belong to the product")
+		}
+	}
+	return nil
+}
+
+// findOwned returns the entry if it is the user's; other users' entries
+// are reported as not found.
+func (s *WishlistServiceImpl) findOwned(userID uint, id int64) (*models.Wishlist, error) {
+	item, err := s.wishlistRepo.FindById(id)
+	if err != nil || item.UserID != userID {
+		return nil, errors.New("wishlist item not found")
+	}
+	return item, nil
+}
+
+func (s *WishlistServiceImpl) findResponse(id int64) (*models.WishlistResponse, error) {
+	item, err := s.wishlistRepo.FindById(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wishlist item: %w", err)
+	}
+	response := item.ToResponse()
+	return &response, nil
+}
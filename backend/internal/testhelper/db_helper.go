@@ -45,9 +45,31 @@ func CleanupTestDB(db *gorm.DB) {
 
 	tables := []interface{}{
 		&models.ActivityLog{},
+		&models.AuditLog{},
+		&models.APIKey{},
 		&models.Order{},
 		&models.Transaction{},
 		&models.Payment{},
+		&models.Refund{},
+		&models.RefundItem{},
+		&models.ReturnRequest{},
+		&models.ReturnItem{},
+		&models.ReturnPhoto{},
+		&models.Shipment{},
+		&models.ShipmentEvent{},
+		&models.ShippingZone{},
+		&models.ShippingRate{},
+		&models.TaxClass{},
+		&models.TransactionTaxLine{},
+		&models.Currency{},
+		&models.Wishlist{},
+		&models.StockAlert{},
+		&models.StockAlertNotification{},
+		&models.Invoice{},
+		&models.InvoiceSequence{},
+		&models.PaymentProof{},
+		&models.BankStatement{},
+		&models.BankStatementLine{},
 		&models.PaymentMethod{},
 		&models.Address{},
 		&models.ColorVarian{},
@@ -55,6 +77,9 @@ func CleanupTestDB(db *gorm.DB) {
 		&models.Product{},
 		&models.Category{},
 		&models.PasswordResetToken{},
+		&models.PasswordHistory{},
+		&models.OIDCState{},
+		&models.UserIdentity{},
 		&models.User{},
 		&models.Permission{},
 		&models.Role{},
@@ -75,6 +100,9 @@ func MigrateTestDB(db *gorm.DB) error {
 		&models.Permission{},
 		&models.User{},
 		&models.PasswordResetToken{},
+		&models.PasswordHistory{},
+		&models.OIDCState{},
+		&models.UserIdentity{},
 		&models.Category{},
 		&models.Product{},
 		&models.ColorVarian{},
@@ -82,9 +110,31 @@ func MigrateTestDB(db *gorm.DB) error {
 		&models.Address{},
 		&models.PaymentMethod{},
 		&models.Payment{},
+		&models.Refund{},
+		&models.RefundItem{},
+		&models.ReturnRequest{},
+		&models.ReturnItem{},
+		&models.ReturnPhoto{},
+		&models.Shipment{},
+		&models.ShipmentEvent{},
+		&models.ShippingZone{},
+		&models.ShippingRate{},
+		&models.TaxClass{},
+		&models.TransactionTaxLine{},
+		&models.Currency{},
+		&models.Wishlist{},
+		&models.StockAlert{},
+		&models.StockAlertNotification{},
+		&models.Invoice{},
+		&models.InvoiceSequence{},
+		&models.PaymentProof{},
+		&models.BankStatement{},
+		&models.BankStatementLine{},
 		&models.Transaction{},
 		&models.Order{},
 		&models.ActivityLog{},
+		&models.APIKey{},
+		&models.AuditLog{},
 		&models.SeedTracker{},
 	)
 }
@@ -173,7 +223,7 @@ func CreateTestProduct(db *gorm.DB, name string, categoryID int64, price float64
 	product := &models.Product{
 		Name:        name,
 		Description: "Test product: " + name,
-		Price:       price,
+		Price:       models.NewMoney(price),
 		CategoryID:  categoryID,
 		Images:      "https://example.com/product.png",
 		Rating:      4.5,
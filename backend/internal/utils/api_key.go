@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	apiKeyIdentifier = "ek_"
+	apiKeyPrefixLen  = len(apiKeyIdentifier) + 8
+)
+
+// GenerateAPIKey returns a new key in the form ek_<8 hex>_<64 hex> and its
+// public prefix (ek_<8 hex>) used to look the key up.
+func GenerateAPIKey() (string, string, error) {
+	id, err := GenerateRandomToken(4)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret, err := GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix := apiKeyIdentifier + id
+	return prefix + "_" + secret, prefix, nil
+}
+
+// ParseAPIKeyPrefix extracts the lookup prefix from a raw key.
+func ParseAPIKeyPrefix(rawKey string) (string, bool) {
+	if !strings.HasPrefix(rawKey, apiKeyIdentifier) || len(rawKey) <= apiKeyPrefixLen+1 {
+		return "", false
+	}
+
+	if rawKey[apiKeyPrefixLen] != '_' {
+		return "", false
+	}
+
+	return rawKey[:apiKeyPrefixLen], true
+}
+
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func CheckAPIKeyHash(rawKey, hashedKey string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(rawKey)), []byte(hashedKey)) == 1
+}
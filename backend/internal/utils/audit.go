@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"e-commerce/backend/internal/models"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+// auditIgnoredColumns change on every write and would only add noise to diffs.
+var auditIgnoredColumns = map[string]bool{
+	"updated_at": true,
+}
+
+// RedactSnapshot masks columns that must never be copied into the audit
+// trail, such as password hashes, tokens and API key hashes.
+func RedactSnapshot(row map[string]interface{}) map[string]interface{} {
+	if row == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		if isSensitiveColumn(column) {
+			redacted[column] = redactedValue
+			continue
+		}
+		redacted[column] = normalizeAuditValue(value)
+	}
+
+	return redacted
+}
+
+// DiffSnapshots returns the columns whose values differ between before and
+// after. A missing snapshot (create or delete) counts as every column changing.
+func DiffSnapshots(before, after map[string]interface{}) map[string]models.AuditChange {
+	changes := make(map[string]models.AuditChange)
+
+	for column, from := range before {
+		if auditIgnoredColumns[column] {
+			continue
+		}
+		to, ok := after[column]
+		if !ok || !reflect.DeepEqual(from, to) {
+			changes[column] = models.AuditChange{From: from, To: to}
+		}
+	}
+
+	for column, to := range after {
+		if auditIgnoredColumns[column] {
+			continue
+		}
+		if _, ok := before[column]; !ok {
+			changes[column] = models.AuditChange{From: nil, To: to}
+		}
+	}
+
+	return changes
+}
+
+// FlattenChildRows turns the child rows of a resource into snapshot columns
+// named "<name>.<id>.<column>", so DiffSnapshots reports a changed stock of
+// size 12 as "size_varians.12.stock".
+func FlattenChildRows(name string, rows []map[string]interface{}) map[string]interface{} {
+	columns := make(map[string]interface{})
+	for _, row := range rows {
+		id := fmt.Sprint(normalizeAuditValue(row["id"]))
+		for column, value := range row {
+			if column == "id" || auditIgnoredColumns[column] {
+				continue
+			}
+			columns[name+"."+id+"."+column] = value
+		}
+	}
+	return columns
+}
+
+// ScrubAuditLog overwrites columns of the before, after and changes
+// snapshots of entry with values, e.g. the placeholders of an anonymized
+// account. Columns a snapshot does not hold are not added.
+func ScrubAuditLog(entry *models.AuditLog, values map[string]interface{}) error {
+	for _, snapshot := range []*string{&entry.Before, &entry.After} {
+		if *snapshot == "" {
+			continue
+		}
+
+		var row map[string]interface{}
+		if err := json.Unmarshal([]byte(*snapshot), &row); err != nil {
+			return err
+		}
+		for column, value := range values {
+			if _, ok := row[column]; ok {
+				row[column] = value
+			}
+		}
+
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		*snapshot = string(data)
+	}
+
+	if entry.Changes == "" {
+		return nil
+	}
+
+	var changes map[string]models.AuditChange
+	if err := json.Unmarshal([]byte(entry.Changes), &changes); err != nil {
+		return err
+	}
+	for column, value := range values {
+		change, ok := changes[column]
+		if !ok {
+			continue
+		}
+		if change.From != nil {
+			change.From = value
+		}
+		if change.To != nil {
+			change.To = value
+		}
+		changes[column] = change
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	entry.Changes = string(data)
+	return nil
+}
+
+func isSensitiveColumn(column string) bool {
+	column = strings.ToLower(column)
+	for _, marker := range []string{"password", "token", "secret", "hash"} {
+		if strings.Contains(column, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAuditValue turns driver values into JSON friendly ones so that
+// snapshots read at different times compare equal when nothing changed.
+func normalizeAuditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,130 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedactSnapshot(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	row := map[string]interface{}{
+		"id":         uint(1),
+		"email":      "admin@example.com",
+		"password":   "$2a$10$hash",
+		"key_hash":   "abc",
+		"name":       []byte("Admin"),
+		"created_at": createdAt,
+	}
+
+	redacted := utils.RedactSnapshot(row)
+
+	if redacted["password"] != "[REDACTED]" || redacted["key_hash"] != "[REDACTED]" {
+		t.Errorf("Expected sensitive columns to be redacted, got %+v", redacted)
+	}
+	if redacted["email"] != "admin@example.com" {
+		t.Errorf("Expected email to be kept, got %v", redacted["email"])
+	}
+	if redacted["name"] != "Admin" {
+		t.Errorf("Expected bytes to become a string, got %v", redacted["name"])
+	}
+	if redacted["created_at"] != createdAt.String() {
+		t.Errorf("Expected time to become a string, got %v", redacted["created_at"])
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	before := map[string]interface{}{"id": 1, "price": 100, "stock": 5, "updated_at": "a"}
+
+	t.Run("Update", func(t *testing.T) {
+		after := map[string]interface{}{"id": 1, "price": 120, "stock": 5, "updated_at": "b"}
+
+		changes := utils.DiffSnapshots(before, after)
+		if len(changes) != 1 {
+			t.Fatalf("Expected only price to change, got %+v", changes)
+		}
+		if changes["price"].From != 100 || changes["price"].To != 120 {
+			t.Errorf("Unexpected price change %+v", changes["price"])
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		changes := utils.DiffSnapshots(before, nil)
+		if len(changes) != 3 {
+			t.Fatalf("Expected every column except updated_at, got %+v", changes)
+		}
+		if changes["stock"].From != 5 || changes["stock"].To != nil {
+			t.Errorf("Unexpected stock change %+v", changes["stock"])
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		changes := utils.DiffSnapshots(nil, before)
+		if changes["price"].From != nil || changes["price"].To != 100 {
+			t.Errorf("Unexpected price change %+v", changes["price"])
+		}
+	})
+}
+
+func TestFlattenChildRows(t *testing.T) {
+	before := utils.FlattenChildRows("size_varians", []map[string]interface{}{
+		{"id": int64(12), "size": "M", "stock": int64(5), "sku": "TS-M", "updated_at": "a"},
+		{"id": int64(13), "size": "L", "stock": int64(2), "sku": "TS-L", "updated_at": "a"},
+	})
+	after := utils.FlattenChildRows("size_varians", []map[string]interface{}{
+		{"id": int64(12), "size": "M", "stock": int64(9), "sku": "TS-M", "updated_at": "b"},
+		{"id": int64(13), "size": "L", "stock": int64(2), "sku": "TS-L2", "updated_at": "b"},
+	})
+
+	if _, ok := before["size_varians.12.updated_at"]; ok {
+		t.Error("Expected updated_at of child rows to be left out")
+	}
+	if before["size_varians.12.stock"] != int64(5) {
+		t.Errorf("Expected size_varians.12.stock to be 5, got %v", before["size_varians.12.stock"])
+	}
+
+	changes := utils.DiffSnapshots(before, after)
+	if len(changes) != 2 {
+		t.Fatalf("Expected the stock of 12 and the SKU of 13 to change, got %+v", changes)
+	}
+	if changes["size_varians.12.stock"].From != int64(5) || changes["size_varians.12.stock"].To != int64(9) {
+		t.Errorf("Unexpected stock change %+v", changes["size_varians.12.stock"])
+	}
+	if changes["size_varians.13.sku"].To != "TS-L2" {
+		t.Errorf("Unexpected SKU change %+v", changes["size_varians.13.sku"])
+	}
+}
+
+func TestScrubAuditLog(t *testing.T) {
+	entry := models.AuditLog{
+		Before:  `{"id":7,"email":"jane@example.com","first_name":"Jane","is_active":true}`,
+		After:   `{"id":7,"email":"jane.doe@example.com","first_name":"Jane","is_active":true}`,
+		Changes: `{"email":{"from":"jane@example.com","to":"jane.doe@example.com"}}`,
+	}
+
+	err := utils.ScrubAuditLog(&entry, map[string]interface{}{
+		"email":      "deleted-user-7@deleted.invalid",
+		"first_name": "Deleted",
+		"username":   "deleted-user-7",
+	})
+	if err != nil {
+		t.Fatalf("ScrubAuditLog() error = %v", err)
+	}
+
+	for _, snapshot := range []string{entry.Before, entry.After, entry.Changes} {
+		if strings.Contains(snapshot, "jane") || strings.Contains(snapshot, "Jane") {
+			t.Errorf("Expected personal data to be scrubbed, got %s", snapshot)
+		}
+	}
+	if strings.Contains(entry.Before, "username") {
+		t.Errorf("Expected missing columns not to be added, got %s", entry.Before)
+	}
+	if !strings.Contains(entry.After, `"is_active":true`) {
+		t.Errorf("Expected other columns to be kept, got %s", entry.After)
+	}
+	if entry.Changes != `{"email":{"from":"deleted-user-7@deleted.invalid","to":"deleted-user-7@deleted.invalid"}}` {
+		t.Errorf("Unexpected changes %s", entry.Changes)
+	}
+}
@@ -0,0 +1,334 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"e-commerce/backend/internal/models"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// StatementEntry is one booking read from a bank statement. Amount is
+// always positive; Credit tells incoming from outgoing money.
+type StatementEntry struct {
+	BookedAt    time.Time
+	Amount      models.Money
+	Credit      bool
+	Description string
+	Reference   string
+}
+
+// Bank statement formats accepted by ParseBankStatement.
+const (
+	StatementFormatCSV   = "csv"
+	StatementFormatMT940 = "mt940"
+)
+
+// ParseBankStatement reads the entries of a CSV or MT940 bank statement.
+func ParseBankStatement(format string, data []byte) ([]StatementEntry, error) {
+	switch strings.ToLower(format) {
+	case StatementFormatCSV:
+		return parseCSVStatement(data)
+	case StatementFormatMT940:
+		return parseMT940Statement(data)
+	default:
+		return nil, fmt.Errorf("unsupported statement format %q", format)
+	}
+}
+
+// DetectStatementFormat guesses the format from the file name, falling back
+// to the content: MT940 files start with SWIFT tags such as :20:.
+func DetectStatementFormat(filename string, data []byte) string {
+	lower := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return StatementFormatCSV
+	case strings.HasSuffix(lower, ".sta"), strings.HasSuffix(lower, ".mt940"), strings.HasSuffix(lower, ".940"):
+		return StatementFormatMT940
+	}
+
+	if bytes.Contains(data, []byte(":61:")) {
+		return StatementFormatMT940
+	}
+	return StatementFormatCSV
+}
+
+// csvColumns maps the header names banks use, in English and Indonesian, to
+// the columns we need.
+var csvColumns = map[string]string{
+	"date":         "date",
+	"tanggal":      "date",
+	"booking date": "date",
+	"description":  "description",
+	"keterangan":   "description",
+	"remark":       "description",
+	"amount":       "amount",
+	"jumlah":       "amount",
+	"nominal":      "amount",
+	"credit":       "credit",
+	"kredit":       "credit",
+	"debit":        "debit",
+	"type":         "type",
+	"db/cr":        "type",
+	"reference":    "reference",
+	"referensi":    "reference",
+	"ref":          "reference",
+}
+
+// parseCSVStatement reads a CSV statement with a header row naming at least
+// a date column and either an amount column, signed or with a CR/DB type
+// column, or separate credit and debit columns.
+func parseCSVStatement(data []byte) ([]StatementEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := bufio.NewReader(bytes.NewReader(data)).ReadLine(); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("statement is empty")
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		if column, ok := csvColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[column] = i
+		}
+	}
+	if _, ok := columns["date"]; !ok {
+		return nil, errors.New("statement has no date column")
+	}
+	_, hasAmount := columns["amount"]
+	_, hasCredit := columns["credit"]
+	if !hasAmount && !hasCredit {
+		return nil, errors.New("statement has no amount or credit column")
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var entries []StatementEntry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		bookedAt, err := parseStatementDate(field(record, "date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		entry := StatementEntry{
+			BookedAt:    bookedAt,
+			Description: field(record, "description"),
+			Reference:   field(record, "reference"),
+		}
+
+		if hasCredit && field(record, "credit") != "" {
+			entry.Amount, _, err = ParseStatementAmount(field(record, "credit"))
+			entry.Credit = true
+		} else if hasCredit && !hasAmount {
+			entry.Amount, _, err = ParseStatementAmount(field(record, "debit"))
+		} else {
+			var negative bool
+			entry.Amount, negative, err = ParseStatementAmount(field(record, "amount"))
+			kind := strings.ToUpper(field(record, "type"))
+			entry.Credit = !negative && !strings.HasPrefix(kind, "D")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+var statementDateLayouts = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"02-01-2006",
+	"02/01/06",
+	"2006/01/02",
+	"02 Jan 2006",
+}
+
+func parseStatementDate(value string) (time.Time, error) {
+	for _, layout := range statementDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// ParseStatementAmount parses an amount as banks print it, e.g.
+// "1.250.123,00", "1,250,123.00", "Rp 1.250.123", "-15000" or
+// "150.000 DB". It reports whether the amount was marked as negative or a
+// debit.
+func ParseStatementAmount(value string) (models.Money, bool, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	negative := false
+	switch {
+	case strings.HasSuffix(s, "DB"), strings.HasSuffix(s, "D"):
+		negative = true
+		s = strings.TrimRight(s, "DB ")
+	case strings.HasSuffix(s, "CR"):
+		s = strings.TrimSuffix(s, "CR")
+	}
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = strings.Trim(s, "()")
+	}
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "RP"))
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	}
+	s = strings.ReplaceAll(s, " ", "")
+
+	// The last separator is the decimal one when it is followed by one or
+	// two digits; every other separator groups thousands.
+	decimal := strings.LastIndexAny(s, ".,")
+	if decimal >= 0 && len(s)-decimal-1 <= 2 {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s[:decimal]) + "." + s[decimal+1:]
+	} else {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s)
+	}
+
+	amount, err := models.ParseMoney(s)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, negative, nil
+}
+
+// mt940Booking matches the :61: statement line: value date, optional entry
+// date, debit/credit mark, optional funds code, amount, transaction type,
+// customer reference and optional bank reference.
+var mt940Booking = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d{0,2})([NFS][A-Z0-9]{3})([^/]*)(?://(.*))?`)
+
+// parseMT940Statement reads the :61: bookings of an MT940 statement with the
+// :86: information that follows each of them as description.
+func parseMT940Statement(data []byte) ([]StatementEntry, error) {
+	var entries []StatementEntry
+	var current *StatementEntry
+	inDescription := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		switch {
+		case strings.HasPrefix(line, ":61:"):
+			inDescription = false
+			match := mt940Booking.FindStringSubmatch(strings.TrimPrefix(line, ":61:"))
+			if match == nil {
+				return nil, fmt.Errorf("invalid statement line %q", line)
+			}
+
+			bookedAt, err := time.Parse("060102", match[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid value date in %q", line)
+			}
+			amount, err := models.ParseMoney(strings.Replace(match[5], ",", ".", 1))
+			if err != nil {
+				return nil, fmt.Errorf("invalid amount in %q", line)
+			}
+
+			entries = append(entries, StatementEntry{
+				BookedAt:  bookedAt,
+				Amount:    amount,
+				Credit:    match[3] == "C" || match[3] == "RD",
+				Reference: strings.TrimSpace(firstNonEmpty(match[8], match[7])),
+			})
+			current = &entries[len(entries)-1]
+		case strings.HasPrefix(line, ":86:") && current != nil:
+			inDescription = true
+			current.Description = strings.TrimSpace(strings.TrimPrefix(line, ":86:"))
+		case strings.HasPrefix(line, ":"), strings.HasPrefix(line, "-}"):
+			inDescription = false
+		case inDescription:
+			current.Description = strings.TrimSpace(current.Description + " " + strings.TrimSpace(line))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		return nil, errors.New("statement has no bookings")
+	}
+	return entries, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" && strings.TrimSpace(v) != "NONREF" {
+			return v
+		}
+	}
+	return ""
+}
+
+// PickUniqueCode returns a code from 1 to max that is not in used, starting
+// from a random one so that concurrent checkouts rarely pick the same code.
+// It reports false when every code is taken.
+func PickUniqueCode(used map[int64]bool, max int64) (int64, bool) {
+	if max <= 0 {
+		return 0, false
+	}
+
+	start := rand.Int63n(max)
+	for i := int64(0); i < max; i++ {
+		code := (start+i)%max + 1
+		if !used[code] {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+var transactionReference = regexp.MustCompile(`(?i)TRX-?([A-Z0-9]{10})`)
+
+// TransactionReference returns the transaction ID a customer wrote in the
+// transfer's description, or "" when there is none. Banks often drop the dash
+// or change the case, so both are normalised.
+func TransactionReference(description string) string {
+	match := transactionReference.FindStringSubmatch(description)
+	if match == nil {
+		return ""
+	}
+	return "TRX-" + strings.ToUpper(match[1])
+}
+
+// StatementFingerprint identifies a booking across overlapping statements of
+// the same account. Occurrence tells apart identical bookings on one
+// statement, such as two customers transferring the same amount.
+func StatementFingerprint(account int64, entry StatementEntry, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%t|%s|%s|%d",
+		account, entry.BookedAt.Format("2006-01-02"), entry.Amount, entry.Credit,
+		strings.Join(strings.Fields(entry.Description), " "), entry.Reference, occurrence)))
+	return hex.EncodeToString(sum[:])
+}
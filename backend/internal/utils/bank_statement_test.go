@@ -0,0 +1,119 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"testing"
+)
+
+func TestParseStatementAmount(t *testing.T) {
+	tests := []struct {
+		value    string
+		want     float64
+		negative bool
+	}{
+		{"150000", 150000, false},
+		{"1.250.123", 1250123, false},
+		{"1.250.123,00", 1250123, false},
+		{"1,250,123.00", 1250123, false},
+		{"Rp 9.909,91", 9909.91, false},
+		{"-15000", 15000, true},
+		{"150.000 DB", 150000, true},
+		{"150.000,00 CR", 150000, false},
+	}
+
+	for _, tt := range tests {
+		got, negative, err := utils.ParseStatementAmount(tt.value)
+		if err != nil {
+			t.Errorf("ParseStatementAmount(%q) returned error: %v", tt.value, err)
+			continue
+		}
+		if got != models.NewMoney(tt.want) || negative != tt.negative {
+			t.Errorf("ParseStatementAmount(%q) = %v, %v, want %v, %v", tt.value, got, negative, tt.want, tt.negative)
+		}
+	}
+
+	if _, _, err := utils.ParseStatementAmount("abc"); err == nil {
+		t.Errorf("Expected error for invalid amount")
+	}
+}
+
+func TestParseBankStatement_CSV(t *testing.T) {
+	data := []byte("Tanggal;Keterangan;Jumlah;DB/CR\n" +
+		"19/10/2026;TRSF E-BANKING TRX-AB12CD34EF BUDI;150.123,00;CR\n" +
+		"19/10/2026;BIAYA ADM;10.000,00;DB\n")
+
+	entries, err := utils.ParseBankStatement(utils.StatementFormatCSV, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if !entries[0].Credit || entries[0].Amount != models.NewMoney(150123) || entries[0].BookedAt.Day() != 19 {
+		t.Errorf("Unexpected credit entry %+v", entries[0])
+	}
+	if entries[1].Credit {
+		t.Errorf("Expected debit entry, got %+v", entries[1])
+	}
+}
+
+func TestParseBankStatement_MT940(t *testing.T) {
+	data := []byte(":20:STMT1\n" +
+		":25:1234567890\n" +
+		":60F:C261018IDR1000000,00\n" +
+		":61:2610191019C150123,00NTRFNONREF//BR123\n" +
+		":86:TRSF E-BANKING TRX-AB12CD34EF\n" +
+		"BUDI SANTOSO\n" +
+		":61:261019D10000,00NCHGNONREF\n" +
+		":86:BIAYA ADM\n" +
+		":62F:C261019IDR1140123,00\n")
+
+	entries, err := utils.ParseBankStatement(utils.StatementFormatMT940, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	credit := entries[0]
+	if !credit.Credit || credit.Amount != models.NewMoney(150123) || credit.Reference != "BR123" {
+		t.Errorf("Unexpected credit entry %+v", credit)
+	}
+	if credit.Description != "TRSF E-BANKING TRX-AB12CD34EF BUDI SANTOSO" {
+		t.Errorf("Unexpected description %q", credit.Description)
+	}
+	if entries[1].Credit || entries[1].Amount != models.NewMoney(10000) {
+		t.Errorf("Unexpected debit entry %+v", entries[1])
+	}
+}
+
+func TestPickUniqueCode(t *testing.T) {
+	used := map[int64]bool{1: true, 2: true, 4: true}
+	for i := 0; i < 20; i++ {
+		code, ok := utils.PickUniqueCode(used, 4)
+		if !ok || code != 3 {
+			t.Fatalf("Expected code 3, got %d (%v)", code, ok)
+		}
+	}
+
+	used[3] = true
+	if _, ok := utils.PickUniqueCode(used, 4); ok {
+		t.Errorf("Expected no free code")
+	}
+}
+
+func TestTransactionReference(t *testing.T) {
+	tests := map[string]string{
+		"TRSF E-BANKING TRX-AB12CD34EF BUDI": "TRX-AB12CD34EF",
+		"trf trxab12cd34ef":                  "TRX-AB12CD34EF",
+		"SETORAN TUNAI":                      "",
+	}
+
+	for description, want := range tests {
+		if got := utils.TransactionReference(description); got != want {
+			t.Errorf("TransactionReference(%q) = %q, want %q", description, got, want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"e-commerce/backend/internal/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatRupiah formats an amount the Indonesian way, e.g. "Rp 1.250.000" or
+// "Rp 9.909,91" when there are cents.
+func FormatRupiah(amount models.Money) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	cents := int64(amount)
+	whole := fmt.Sprintf("%d", cents/100)
+
+	var groups []string
+	for len(whole) > 3 {
+		groups = append([]string{whole[len(whole)-3:]}, groups...)
+		whole = whole[:len(whole)-3]
+	}
+	groups = append([]string{whole}, groups...)
+
+	formatted := "Rp " + sign + strings.Join(groups, ".")
+	if cents%100 != 0 {
+		formatted += fmt.Sprintf(",%02d", cents%100)
+	}
+	return formatted
+}
+
+// ExchangeRate is one row of an exchange rates file: the value of one unit
+// of Code in the store currency.
+type ExchangeRate struct {
+	Code   string
+	Rate   float64
+	Name   string
+	Symbol string
+}
+
+var rateColumns = map[string]string{
+	"code":     "code",
+	"currency": "code",
+	"kode":     "code",
+	"rate":     "rate",
+	"kurs":     "rate",
+	"name":     "name",
+	"nama":     "name",
+	"symbol":   "symbol",
+	"simbol":   "symbol",
+}
+
+// ParseExchangeRates reads a CSV exchange rates file with a currency code
+// and its rate in the store currency on each line, optionally followed by
+// a name and a symbol, e.g. "USD,16250.50,US Dollar,$". A header row naming
+// the columns may come first; without one the columns are taken in that
+// order.
+func ParseExchangeRates(data []byte) ([]ExchangeRate, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := bufio.NewReader(bytes.NewReader(data)).ReadLine(); bytes.Contains(firstLine, []byte(";")) {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid rates file: %w", err)
+	}
+
+	columns := map[string]int{"code": 0, "rate": 1, "name": 2, "symbol": 3}
+	first := 0
+	if len(records) > 0 {
+		header := map[string]int{}
+		for i, name := range records[0] {
+			if column, ok := rateColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+				header[column] = i
+			}
+		}
+		if len(header) > 0 {
+			if _, ok := header["code"]; !ok {
+				return nil, errors.New("rates file has no code column")
+			}
+			if _, ok := header["rate"]; !ok {
+				return nil, errors.New("rates file has no rate column")
+			}
+			columns = header
+			first = 1
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rates []ExchangeRate
+	seen := map[string]bool{}
+	for i := first; i < len(records); i++ {
+		record := records[i]
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		code := strings.ToUpper(field(record, "code"))
+		if !IsCurrencyCode(code) {
+			return nil, fmt.Errorf("line %d: invalid currency code %q", i+1, code)
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("line %d: duplicate currency %s", i+1, code)
+		}
+		seen[code] = true
+
+		rate, err := parseRate(field(record, "rate"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rates = append(rates, ExchangeRate{
+			Code:   code,
+			Rate:   rate,
+			Name:   field(record, "name"),
+			Symbol: field(record, "symbol"),
+		})
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("rates file is empty")
+	}
+	return rates, nil
+}
+
+// IsCurrencyCode reports whether code looks like an ISO 4217 code: three
+// upper case letters.
+func IsCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseRate parses a positive rate written with either a decimal point or
+// a decimal comma, e.g. "16250.5", "16.250,5" or "16,250.50".
+func parseRate(value string) (float64, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	if dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ","); comma > dot {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+
+	rate, err := strconv.ParseFloat(s, 64)
+	if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+		return 0, fmt.Errorf("invalid rate %q", value)
+	}
+	return rate, nil
+}
@@ -0,0 +1,52 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/utils"
+	"testing"
+)
+
+func TestParseExchangeRates(t *testing.T) {
+	t.Run("header", func(t *testing.T) {
+		data := []byte("\xef\xbb\xbfName,Code,Rate\nUS Dollar,usd,\"16,250.50\"\nSingapore Dollar,SGD,12100\n")
+		rates, err := utils.ParseExchangeRates(data)
+		if err != nil {
+			t.Fatalf("ParseExchangeRates() error = %v", err)
+		}
+		if len(rates) != 2 {
+			t.Fatalf("got %d rates, want 2", len(rates))
+		}
+		if rates[0].Code != "USD" || rates[0].Rate != 16250.5 || rates[0].Name != "US Dollar" {
+			t.Errorf("rates[0] = %+v", rates[0])
+		}
+		if rates[1].Code != "SGD" || rates[1].Rate != 12100 {
+			t.Errorf("rates[1] = %+v", rates[1])
+		}
+	})
+
+	t.Run("no header, semicolons and decimal commas", func(t *testing.T) {
+		rates, err := utils.ParseExchangeRates([]byte("EUR;17.640,25;Euro;€\n\nJPY;108,5\n"))
+		if err != nil {
+			t.Fatalf("ParseExchangeRates() error = %v", err)
+		}
+		if len(rates) != 2 || rates[0].Rate != 17640.25 || rates[0].Symbol != "€" || rates[1].Rate != 108.5 {
+			t.Errorf("rates = %+v", rates)
+		}
+	})
+
+	errorCases := map[string]string{
+		"empty":          "",
+		"header only":    "code,rate\n",
+		"missing rate":   "code,name\nUSD,US Dollar\n",
+		"invalid code":   "US,16250\n",
+		"invalid rate":   "USD,abc\n",
+		"negative rate":  "USD,-1\n",
+		"duplicate code": "USD,16250\nusd,16300\n",
+	}
+	for name, data := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := utils.ParseExchangeRates([]byte(data)); err == nil {
+				t.Errorf("ParseExchangeRates(%q) succeeded, want error", data)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	crand "crypto/rand"
+	"math/big"
 	"math/rand"
 )
 
@@ -16,3 +18,18 @@ func Generate(identifier string) string {
 
 	return identifier + string(b)
 }
+
+// VirtualAccountNumber returns a 16 digit virtual account number starting
+// with the bank's company code prefix. The digits come from crypto/rand so
+// numbers cannot be guessed; callers still check the number is unused.
+func VirtualAccountNumber(prefix string) (string, error) {
+	b := []byte(prefix)
+	for len(b) < 16 {
+		n, err := crand.Int(crand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b = append(b, byte('0'+n.Int64()))
+	}
+	return string(b), nil
+}
@@ -0,0 +1,23 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/utils"
+	"strings"
+	"testing"
+)
+
+func TestVirtualAccountNumber(t *testing.T) {
+	number, err := utils.VirtualAccountNumber("88908")
+	if err != nil {
+		t.Fatalf("VirtualAccountNumber failed: %v", err)
+	}
+
+	if len(number) != 16 || !strings.HasPrefix(number, "88908") {
+		t.Fatalf("Expected a 16 digit number starting with the prefix, got %q", number)
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			t.Fatalf("Expected only digits, got %q", number)
+		}
+	}
+}
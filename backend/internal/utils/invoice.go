@@ -0,0 +1,9 @@
+package utils
+
+import "fmt"
+
+// FormatInvoiceNumber returns the printed number of the sequence-th invoice
+// of year, e.g. INV/2026/000042.
+func FormatInvoiceNumber(year int, sequence int64) string {
+	return fmt.Sprintf("INV/%d/%06d", year, sequence)
+}
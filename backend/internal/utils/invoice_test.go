@@ -0,0 +1,15 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/utils"
+	"testing"
+)
+
+func TestFormatInvoiceNumber(t *testing.T) {
+	if got := utils.FormatInvoiceNumber(2026, 42); got != "INV/2026/000042" {
+		t.Errorf("Expected INV/2026/000042, got %q", got)
+	}
+	if got := utils.FormatInvoiceNumber(2026, 1234567); got != "INV/2026/1234567" {
+		t.Errorf("Expected INV/2026/1234567, got %q", got)
+	}
+}
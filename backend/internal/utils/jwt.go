@@ -4,18 +4,118 @@ import (
 	"e-commerce/backend/internal/config"
 	"e-commerce/backend/internal/models"
 	"errors"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const defaultImpersonationTokenExpiry = 15 * time.Minute
+
 type JWTService struct {
 	config *config.Config
+	keys   *KeySet
 }
 
 func NewJWTService(cfg *config.Config) *JWTService {
-	return &JWTService{config: cfg}
+	service := &JWTService{config: cfg}
+
+	// A typo in JWT_ALGORITHM must not quietly downgrade signing to the
+	// shared secrets, so HS256 is only used when it is asked for.
+	switch cfg.JWT.Algorithm {
+	case AlgorithmHS256:
+	case AlgorithmRS256, AlgorithmEdDSA:
+		keys, err := loadOrCreateKeySet(cfg.JWT)
+		if err != nil {
+			log.Fatalf("❌ JWT ERROR: Failed to load signing keys: %v", err)
+		}
+		service.keys = keys
+		log.Printf("✅ JWT signing with %s key %s", keys.Active().Algorithm, keys.Active().ID)
+	default:
+		log.Fatalf("❌ JWT ERROR: Unknown JWT_ALGORITHM %q, use %s, %s or %s", cfg.JWT.Algorithm, AlgorithmHS256, AlgorithmRS256, AlgorithmEdDSA)
+	}
+
+	return service
+}
+
+// loadOrCreateKeySet loads the keys in KeysDir, generating the first key
+// when the directory is still empty.
+func loadOrCreateKeySet(cfg config.JWTConfig) (*KeySet, error) {
+	if cfg.KeysDir == "" {
+		return nil, errors.New("JWT_KEYS_DIR is required for asymmetric signing")
+	}
+
+	ids, err := ListSigningKeyIDs(cfg.KeysDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		key, err := GenerateSigningKey(cfg.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := WriteSigningKey(cfg.KeysDir, key)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("🔑 Generated initial JWT signing key: %s", path)
+	}
+
+	return LoadKeySet(cfg.KeysDir, cfg.ActiveKeyID)
+}
+
+// JWKS returns the public verification keys. It is empty when tokens are
+// signed with shared HS256 secrets.
+func (s *JWTService) JWKS() JWKS {
+	if s.keys == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return s.keys.JWKS()
+}
+
+// signToken signs claims with the active key, or with secret in HS256 mode.
+func (s *JWTService) signToken(claims jwt.MapClaims, secret string) (string, error) {
+	if s.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(secret))
+	}
+
+	key := s.keys.Active()
+	token := jwt.NewWithClaims(key.SigningMethod(), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+// keyFunc resolves the verification key from the token kid header, or
+// returns secret in HS256 mode.
+func (s *JWTService) keyFunc(secret string) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		if s.keys == nil {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(secret), nil
+		}
+
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, errors.New("missing kid header")
+		}
+
+		key, ok := s.keys.Find(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %s", kid)
+		}
+
+		if token.Method.Alg() != key.SigningMethod().Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+
+		return key.PublicKey(), nil
+	}
 }
 
 func (s *JWTService) GenerateAccessToken(user *models.User) (string, time.Time, error) {
@@ -45,8 +145,7 @@ func (s *JWTService) GenerateAccessToken(user *models.User) (string, time.Time,
 	log.Printf("✅ Generating Access Token - UserID: %d, Email: %s, RoleID: %d",
 		user.ID, user.Email, user.RoleID)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.config.JWT.Secret))
+	tokenString, err := s.signToken(claims, s.config.JWT.Secret)
 	if err != nil {
 		log.Printf("❌ JWT ERROR: Failed to sign token: %v", err)
 		return "", time.Time{}, err
@@ -56,6 +155,44 @@ func (s *JWTService) GenerateAccessToken(user *models.User) (string, time.Time,
 	return tokenString, expiresAt, nil
 }
 
+// GenerateImpersonationToken issues a short-lived access token for user that
+// carries the impersonating admin in the impersonator_id claim.
+func (s *JWTService) GenerateImpersonationToken(user *models.User, impersonatorID uint) (string, time.Time, error) {
+	if user == nil || user.ID == 0 {
+		return "", time.Time{}, errors.New("user cannot be empty")
+	}
+
+	if impersonatorID == 0 || impersonatorID == user.ID {
+		return "", time.Time{}, errors.New("invalid impersonator")
+	}
+
+	expiry := s.config.JWT.ImpersonationTokenExpiry
+	if expiry <= 0 {
+		expiry = defaultImpersonationTokenExpiry
+	}
+	expiresAt := time.Now().Add(expiry)
+
+	claims := jwt.MapClaims{
+		"user_id":         user.ID,
+		"email":           user.Email,
+		"role_id":         user.RoleID,
+		"type":            "access",
+		"impersonator_id": impersonatorID,
+		"exp":             expiresAt.Unix(),
+		"iat":             time.Now().Unix(),
+	}
+
+	log.Printf("🎭 Generating Impersonation Token - UserID: %d, ImpersonatorID: %d", user.ID, impersonatorID)
+
+	tokenString, err := s.signToken(claims, s.config.JWT.Secret)
+	if err != nil {
+		log.Printf("❌ JWT ERROR: Failed to sign impersonation token: %v", err)
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
 func (s *JWTService) GenerateRefreshToken(user *models.User) (string, time.Time, error) {
 	// 🔍 DEBUG: Validasi user sebelum generate token
 	if user == nil {
@@ -82,8 +219,7 @@ func (s *JWTService) GenerateRefreshToken(user *models.User) (string, time.Time,
 	// 🔍 DEBUG: Log claims
 	log.Printf("✅ Generating Refresh Token - UserID: %d, Email: %s", user.ID, user.Email)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.config.JWT.RefreshSecret))
+	tokenString, err := s.signToken(claims, s.config.JWT.RefreshSecret)
 	if err != nil {
 		log.Printf("❌ JWT ERROR: Failed to sign refresh token: %v", err)
 		return "", time.Time{}, err
@@ -94,12 +230,7 @@ func (s *JWTService) GenerateRefreshToken(user *models.User) (string, time.Time,
 }
 
 func (s *JWTService) ValidateAccessToken(tokenString string) (*models.JWTClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.config.JWT.Secret), nil
-	})
+	token, err := jwt.Parse(tokenString, s.keyFunc(s.config.JWT.Secret))
 
 	if err != nil {
 		log.Printf("❌ JWT VALIDATION ERROR: %v", err)
@@ -144,6 +275,16 @@ func (s *JWTService) ValidateAccessToken(tokenString string) (*models.JWTClaims,
 			Type:   tokenType,
 		}
 
+		if rawImpersonatorID, exists := claims["impersonator_id"]; exists {
+			impersonatorID, ok := rawImpersonatorID.(float64)
+			if !ok || impersonatorID <= 0 {
+				log.Println("❌ JWT ERROR: Invalid impersonator_id claim")
+				return nil, errors.New("invalid impersonator_id claim")
+			}
+			id := uint(impersonatorID)
+			jwtClaims.ImpersonatorID = &id
+		}
+
 		// 🔍 DEBUG: Log parsed claims
 		log.Printf("✅ Token Validated - UserID: %d, Email: %s, RoleID: %d",
 			jwtClaims.UserID, jwtClaims.Email, jwtClaims.RoleID)
@@ -156,12 +297,7 @@ func (s *JWTService) ValidateAccessToken(tokenString string) (*models.JWTClaims,
 }
 
 func (s *JWTService) ValidateRefreshToken(tokenString string) (*models.JWTClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.config.JWT.RefreshSecret), nil
-	})
+	token, err := jwt.Parse(tokenString, s.keyFunc(s.config.JWT.RefreshSecret))
 
 	if err != nil {
 		log.Printf("❌ REFRESH TOKEN VALIDATION ERROR: %v", err)
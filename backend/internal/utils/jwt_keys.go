@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	signingKeyExt = ".pem"
+	rsaKeyBits    = 2048
+)
+
+// SigningKey is one asymmetric key pair identified by its kid.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+}
+
+// KeySet holds every key that may verify a token and the one used to sign
+// new tokens. Retired keys stay in the set until their tokens have expired.
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// JWK is the public part of a signing key in RFC 7517 form.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// GenerateSigningKey creates a new key pair for the given algorithm. Key IDs
+// start with a UTC timestamp so the newest key sorts last.
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var signer crypto.Signer
+
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+
+	return &SigningKey{
+		ID:         time.Now().UTC().Format("20060102150405") + "-" + hex.EncodeToString(suffix),
+		Algorithm:  algorithm,
+		PrivateKey: signer,
+	}, nil
+}
+
+// WriteSigningKey stores the private key as PKCS#8 PEM in dir/<kid>.pem.
+func WriteSigningKey(dir string, key *SigningKey) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, key.ID+signingKeyExt)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// ListSigningKeyIDs returns the kids stored in dir, oldest first.
+func ListSigningKeyIDs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), signingKeyExt) {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(entry.Name(), signingKeyExt))
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// LoadKeySet reads every key in dir. The key named by activeID signs new
+// tokens; when activeID is empty the newest key is used.
+func LoadKeySet(dir string, activeID string) (*KeySet, error) {
+	ids, err := ListSigningKeyIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no signing keys found in %s", dir)
+	}
+
+	set := &KeySet{keys: make(map[string]*SigningKey, len(ids))}
+	for _, id := range ids {
+		key, err := readSigningKey(filepath.Join(dir, id+signingKeyExt), id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing key %s: %w", id, err)
+		}
+		set.keys[id] = key
+	}
+
+	if activeID == "" {
+		activeID = ids[len(ids)-1]
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active signing key %s not found in %s", activeID, dir)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+func readSigningKey(path string, id string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &SigningKey{ID: id, Algorithm: AlgorithmRS256, PrivateKey: key}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, Algorithm: AlgorithmEdDSA, PrivateKey: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+}
+
+// Active returns the key used to sign new tokens.
+func (s *KeySet) Active() *SigningKey {
+	return s.active
+}
+
+// Find returns the verification key with the given kid.
+func (s *KeySet) Find(id string) (*SigningKey, bool) {
+	key, ok := s.keys[id]
+	return key, ok
+}
+
+// JWKS returns the public keys of the set, oldest first.
+func (s *KeySet) JWKS() JWKS {
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(ids))}
+	for _, id := range ids {
+		jwks.Keys = append(jwks.Keys, s.keys[id].PublicJWK())
+	}
+
+	return jwks
+}
+
+// SigningMethod returns the jwt signing method matching the key algorithm.
+func (k *SigningKey) SigningMethod() jwt.SigningMethod {
+	if k.Algorithm == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// PublicKey returns the key used to verify signatures.
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+// PublicJWK converts the public key to its JWK representation.
+func (k *SigningKey) PublicJWK() JWK {
+	jwk := JWK{
+		KeyID:     k.ID,
+		Use:       "sig",
+		Algorithm: k.Algorithm,
+	}
+
+	switch pub := k.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
+
+// PublicKey parses a JWK published by another issuer, such as an OIDC
+// provider, into a key that can verify its signatures.
+func (j JWK) PublicKey() (crypto.PublicKey, error) {
+	switch j.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y: %w", err)
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	case "OKP":
+		if j.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", j.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.KeyType)
+	}
+}
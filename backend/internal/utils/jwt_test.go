@@ -0,0 +1,137 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"testing"
+	"time"
+)
+
+func newAsymmetricConfig(t *testing.T, algorithm string) *config.Config {
+	return &config.Config{JWT: config.JWTConfig{
+		Algorithm:          algorithm,
+		KeysDir:            t.TempDir(),
+		AccessTokenExpiry:  time.Hour,
+		RefreshTokenExpiry: time.Hour,
+	}}
+}
+
+func TestJWTService_AsymmetricSigning(t *testing.T) {
+	user := &models.User{ID: 7, Email: "user@example.com", RoleID: 2}
+
+	for _, algorithm := range []string{utils.AlgorithmRS256, utils.AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			cfg := newAsymmetricConfig(t, algorithm)
+			service := utils.NewJWTService(cfg)
+
+			token, _, err := service.GenerateAccessToken(user)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			claims, err := service.ValidateAccessToken(token)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if claims.UserID != user.ID {
+				t.Errorf("Expected user ID %d, got %d", user.ID, claims.UserID)
+			}
+
+			jwks := service.JWKS()
+			if len(jwks.Keys) != 1 || jwks.Keys[0].Algorithm != algorithm {
+				t.Errorf("Expected one %s key in JWKS, got %+v", algorithm, jwks.Keys)
+			}
+		})
+	}
+}
+
+func TestJWTService_KeyRotation(t *testing.T) {
+	user := &models.User{ID: 7, Email: "user@example.com", RoleID: 2}
+	cfg := newAsymmetricConfig(t, utils.AlgorithmEdDSA)
+
+	oldService := utils.NewJWTService(cfg)
+	oldToken, _, err := oldService.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Key IDs are timestamp prefixed, make sure the new one sorts last.
+	time.Sleep(time.Second)
+	key, err := utils.GenerateSigningKey(utils.AlgorithmRS256)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := utils.WriteSigningKey(cfg.JWT.KeysDir, key); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	newService := utils.NewJWTService(cfg)
+	if _, err := newService.ValidateAccessToken(oldToken); err != nil {
+		t.Errorf("Token signed with the previous key should still verify: %v", err)
+	}
+
+	newToken, _, err := newService.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := oldService.ValidateAccessToken(newToken); err == nil {
+		t.Error("Expected service without the new key to reject its tokens")
+	}
+
+	if len(newService.JWKS().Keys) != 2 {
+		t.Errorf("Expected 2 keys in JWKS, got %d", len(newService.JWKS().Keys))
+	}
+}
+
+func TestJWTService_RejectsAccessTokenAsRefresh(t *testing.T) {
+	user := &models.User{ID: 7, Email: "user@example.com", RoleID: 2}
+	service := utils.NewJWTService(newAsymmetricConfig(t, utils.AlgorithmEdDSA))
+
+	token, _, err := service.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := service.ValidateRefreshToken(token); err == nil {
+		t.Error("Expected access token to be rejected as refresh token")
+	}
+}
+
+func TestJWTService_ImpersonationToken(t *testing.T) {
+	user := &models.User{ID: 7, Email: "user@example.com", RoleID: 2}
+	cfg := &config.Config{JWT: config.JWTConfig{Algorithm: utils.AlgorithmHS256, Secret: "secret", AccessTokenExpiry: time.Hour}}
+	service := utils.NewJWTService(cfg)
+
+	token, expiresAt, err := service.GenerateImpersonationToken(user, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if time.Until(expiresAt) > 16*time.Minute {
+		t.Errorf("Expected the default 15m expiry, got %v", time.Until(expiresAt))
+	}
+
+	claims, err := service.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if claims.UserID != user.ID || claims.ImpersonatorID == nil || *claims.ImpersonatorID != 1 {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+
+	if _, _, err := service.GenerateImpersonationToken(user, user.ID); err == nil {
+		t.Error("Expected a user impersonating themselves to be rejected")
+	}
+
+	normal, _, err := service.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	claims, err = service.ValidateAccessToken(normal)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if claims.ImpersonatorID != nil {
+		t.Errorf("Expected no impersonator on a normal token, got %d", *claims.ImpersonatorID)
+	}
+}
@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"e-commerce/backend/internal/config"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval limits how often an unknown kid triggers a refetch of
+// the provider's keys.
+const jwksRefreshInterval = time.Minute
+
+// OIDCClaims are the ID token claims used to find or create the local user.
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Name          string
+}
+
+type cachedJWKS struct {
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// OIDCClient runs the authorization-code flow with PKCE against the
+// configured OpenID Connect providers and verifies their ID tokens.
+type OIDCClient struct {
+	providers  map[string]config.OIDCProviderConfig
+	stateTTL   time.Duration
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	jwks map[string]*cachedJWKS
+}
+
+func NewOIDCClient(cfg *config.Config) *OIDCClient {
+	stateTTL := cfg.OIDC.StateTTL
+	if stateTTL <= 0 {
+		stateTTL = 10 * time.Minute
+	}
+
+	return &OIDCClient{
+		providers:  cfg.OIDC.Providers,
+		stateTTL:   stateTTL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		jwks:       map[string]*cachedJWKS{},
+	}
+}
+
+// Provider returns the configuration of a provider by name.
+func (c *OIDCClient) Provider(name string) (config.OIDCProviderConfig, bool) {
+	provider, ok := c.providers[name]
+	return provider, ok
+}
+
+// StateTTL is how long a started login may take to complete.
+func (c *OIDCClient) StateTTL() time.Duration {
+	return c.stateTTL
+}
+
+// GeneratePKCE returns a code verifier and its S256 code challenge.
+func GeneratePKCE() (string, string, error) {
+	verifier, err := GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// AuthorizationURL builds the URL the app opens to let the user sign in at
+// the provider.
+func (c *OIDCClient) AuthorizationURL(provider config.OIDCProviderConfig, state, nonce, codeChallenge string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {provider.ClientID},
+		"redirect_uri":          {provider.RedirectURL},
+		"scope":                 {strings.Join(provider.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(provider.AuthURL, "?") {
+		separator = "&"
+	}
+	return provider.AuthURL + separator + query.Encode()
+}
+
+// ExchangeCode redeems an authorization code at the token endpoint and
+// returns the ID token.
+func (c *OIDCClient) ExchangeCode(provider config.OIDCProviderConfig, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {provider.RedirectURL},
+		"client_id":     {provider.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	if provider.ClientSecret != "" {
+		form.Set("client_secret", provider.ClientSecret)
+	}
+
+	resp, err := c.httpClient.PostForm(provider.TokenURL, form)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+
+	return token.IDToken, nil
+}
+
+// VerifyIDToken checks the signature of an ID token against the provider's
+// JWKS, its issuer, audience, expiry and nonce, and returns its claims.
+func (c *OIDCClient) VerifyIDToken(provider config.OIDCProviderConfig, idToken, nonce string) (*OIDCClaims, error) {
+	token, err := jwt.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.publicKey(provider.JWKSURL, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(provider.Issuer),
+		jwt.WithAudience(provider.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid id token claims")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	result := &OIDCClaims{Subject: subject}
+	result.Email, _ = claims["email"].(string)
+	result.GivenName, _ = claims["given_name"].(string)
+	result.FamilyName, _ = claims["family_name"].(string)
+	result.Name, _ = claims["name"].(string)
+
+	// Some providers send email_verified as a string.
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	return result, nil
+}
+
+// publicKey looks up a signing key of the provider, refetching the JWKS when
+// the kid is unknown so key rotation at the provider is picked up.
+func (c *OIDCClient) publicKey(jwksURL, kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := c.jwks[jwksURL]
+	if cached != nil {
+		if key, ok := cached.keys[kid]; ok {
+			return key, nil
+		}
+		if time.Since(cached.fetchedAt) < jwksRefreshInterval {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+
+	keys, err := c.fetchJWKS(jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	c.jwks[jwksURL] = &cachedJWKS{keys: keys, fetchedAt: time.Now()}
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func (c *OIDCClient) fetchJWKS(jwksURL string) (map[string]crypto.PublicKey, error) {
+	resp, err := c.httpClient.Get(jwksURL)
+	if err != nil {
+		return nil, fmt.Errorf("jwks request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks endpoint returned %d", resp.StatusCode)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("invalid jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,172 @@
+package utils_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/utils"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockOIDCServer is a minimal OpenID provider: it serves a JWKS and a token
+// endpoint that checks the PKCE verifier and returns a signed ID token.
+type mockOIDCServer struct {
+	*httptest.Server
+	key           *rsa.PrivateKey
+	codeChallenge string
+	idTokenClaims jwt.MapClaims
+}
+
+func newMockOIDCServer(t *testing.T) *mockOIDCServer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	m := &mockOIDCServer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(utils.JWKS{Keys: []utils.JWK{{
+			KeyType:   "RSA",
+			KeyID:     "test-key",
+			Use:       "sig",
+			Algorithm: "RS256",
+			N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if r.Form.Get("code") != "good-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != m.codeChallenge {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.idTokenClaims)
+		token.Header["kid"] = "test-key"
+		signed, _ := token.SignedString(m.key)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+func TestOIDCClient_LoginFlow(t *testing.T) {
+	server := newMockOIDCServer(t)
+
+	provider := config.OIDCProviderConfig{
+		Issuer:      server.URL,
+		ClientID:    "shop-app",
+		AuthURL:     server.URL + "/authorize",
+		TokenURL:    server.URL + "/token",
+		JWKSURL:     server.URL + "/jwks",
+		RedirectURL: "com.example.shop:/oauth2redirect",
+		Scopes:      []string{"openid", "email"},
+	}
+	client := utils.NewOIDCClient(&config.Config{OIDC: config.OIDCConfig{
+		Providers: map[string]config.OIDCProviderConfig{"mock": provider},
+	}})
+
+	verifier, challenge, err := utils.GeneratePKCE()
+	if err != nil {
+		t.Fatalf("GeneratePKCE() error = %v", err)
+	}
+	server.codeChallenge = challenge
+
+	authURL, err := url.Parse(client.AuthorizationURL(provider, "state-1", "nonce-1", challenge))
+	if err != nil {
+		t.Fatalf("invalid authorization URL: %v", err)
+	}
+	if got := authURL.Query().Get("code_challenge_method"); got != "S256" {
+		t.Errorf("code_challenge_method = %q, want S256", got)
+	}
+
+	newClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            server.URL,
+			"aud":            "shop-app",
+			"sub":            "subject-1",
+			"email":          "jane@example.com",
+			"email_verified": true,
+			"given_name":     "Jane",
+			"nonce":          "nonce-1",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		server.idTokenClaims = newClaims()
+
+		idToken, err := client.ExchangeCode(provider, "good-code", verifier)
+		if err != nil {
+			t.Fatalf("ExchangeCode() error = %v", err)
+		}
+
+		claims, err := client.VerifyIDToken(provider, idToken, "nonce-1")
+		if err != nil {
+			t.Fatalf("VerifyIDToken() error = %v", err)
+		}
+		if claims.Subject != "subject-1" || claims.Email != "jane@example.com" || !claims.EmailVerified || claims.GivenName != "Jane" {
+			t.Errorf("unexpected claims %+v", claims)
+		}
+	})
+
+	t.Run("WrongVerifier", func(t *testing.T) {
+		if _, err := client.ExchangeCode(provider, "good-code", "not-the-verifier"); err == nil {
+			t.Error("expected exchange with a wrong PKCE verifier to fail")
+		}
+	})
+
+	t.Run("NonceMismatch", func(t *testing.T) {
+		server.idTokenClaims = newClaims()
+
+		idToken, err := client.ExchangeCode(provider, "good-code", verifier)
+		if err != nil {
+			t.Fatalf("ExchangeCode() error = %v", err)
+		}
+		if _, err := client.VerifyIDToken(provider, idToken, "other-nonce"); err == nil {
+			t.Error("expected nonce mismatch to be rejected")
+		}
+	})
+
+	t.Run("WrongAudience", func(t *testing.T) {
+		server.idTokenClaims = newClaims()
+		server.idTokenClaims["aud"] = "another-app"
+
+		idToken, err := client.ExchangeCode(provider, "good-code", verifier)
+		if err != nil {
+			t.Fatalf("ExchangeCode() error = %v", err)
+		}
+		if _, err := client.VerifyIDToken(provider, idToken, "nonce-1"); err == nil {
+			t.Error("expected token for another audience to be rejected")
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		server.idTokenClaims = newClaims()
+		server.idTokenClaims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		idToken, err := client.ExchangeCode(provider, "good-code", verifier)
+		if err != nil {
+			t.Fatalf("ExchangeCode() error = %v", err)
+		}
+		if _, err := client.VerifyIDToken(provider, idToken, "nonce-1"); err == nil {
+			t.Error("expected expired token to be rejected")
+		}
+	})
+}
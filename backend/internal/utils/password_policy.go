@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"bufio"
+	"e-commerce/backend/internal/config"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const defaultPasswordMinLength = 8
+
+// PasswordPolicy checks new passwords against the configured length,
+// character class and banned password rules.
+type PasswordPolicy struct {
+	minLength     int
+	requireUpper  bool
+	requireLower  bool
+	requireDigit  bool
+	requireSymbol bool
+	banned        map[string]struct{}
+}
+
+// NewPasswordPolicy builds a policy from config, loading the banned password
+// list when BannedListFile is set.
+func NewPasswordPolicy(cfg config.PasswordPolicyConfig) (*PasswordPolicy, error) {
+	policy := &PasswordPolicy{
+		minLength:     cfg.MinLength,
+		requireUpper:  cfg.RequireUpper,
+		requireLower:  cfg.RequireLower,
+		requireDigit:  cfg.RequireDigit,
+		requireSymbol: cfg.RequireSymbol,
+		banned:        map[string]struct{}{},
+	}
+
+	if policy.minLength <= 0 {
+		policy.minLength = defaultPasswordMinLength
+	}
+
+	if cfg.BannedListFile != "" {
+		banned, err := LoadBannedPasswords(cfg.BannedListFile)
+		if err != nil {
+			return policy, err
+		}
+		policy.banned = banned
+	}
+
+	return policy, nil
+}
+
+// LoadBannedPasswords reads one password per line, skipping blank lines and
+// lines starting with #.
+func LoadBannedPasswords(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	banned := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		banned[strings.ToLower(line)] = struct{}{}
+	}
+
+	return banned, scanner.Err()
+}
+
+// Validate returns the first rule password breaks. identifiers such as the
+// email and username may not be used as the password.
+func (p *PasswordPolicy) Validate(password string, identifiers ...string) error {
+	if len([]rune(password)) < p.minLength {
+		return fmt.Errorf("password must be at least %d characters", p.minLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.requireUpper && !hasUpper {
+		return errors.New("password must contain an uppercase letter")
+	}
+	if p.requireLower && !hasLower {
+		return errors.New("password must contain a lowercase letter")
+	}
+	if p.requireDigit && !hasDigit {
+		return errors.New("password must contain a digit")
+	}
+	if p.requireSymbol && !hasSymbol {
+		return errors.New("password must contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	if _, ok := p.banned[lowered]; ok {
+		return errors.New("password is too common or has appeared in a data breach")
+	}
+
+	for _, identifier := range identifiers {
+		if identifier != "" && lowered == strings.ToLower(identifier) {
+			return errors.New("password must not match your email or username")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/config"
+	"e-commerce/backend/internal/utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	bannedFile := filepath.Join(t.TempDir(), "banned.txt")
+	if err := os.WriteFile(bannedFile, []byte("# comment\nPassword123\n\nletmein\n"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	policy, err := utils.NewPasswordPolicy(config.PasswordPolicyConfig{
+		MinLength:      10,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		BannedListFile: bannedFile,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{"Valid", "Correct7Horse", ""},
+		{"TooShort", "Ab1cdef", "password must be at least 10 characters"},
+		{"NoUpper", "correct7horse", "password must contain an uppercase letter"},
+		{"NoDigit", "CorrectHorse", "password must contain a digit"},
+		{"Banned", "pASSWORD123", "password is too common or has appeared in a data breach"},
+		{"MatchesEmail", "Jane.Doe1@Mail.com", "password must not match your email or username"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, "jane.doe1@mail.com", "janedoe")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_Defaults(t *testing.T) {
+	policy, err := utils.NewPasswordPolicy(config.PasswordPolicyConfig{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := policy.Validate("short"); err == nil {
+		t.Error("Expected the default minimum length of 8 to apply")
+	}
+	if err := policy.Validate("longenough"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package utils_test
+
+import (
+	"bytes"
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"testing"
+)
+
+func TestPDFDocument_Bytes(t *testing.T) {
+	doc := utils.NewPDFDocument()
+	doc.AddPage()
+	doc.Text(40, 60, 12, true, "Invoice (copy)")
+	doc.Line(40, 70, 555, 70)
+	doc.AddPage()
+	doc.TextRight(555, 60, 10, false, "Rp 150.000")
+
+	out := doc.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) {
+		t.Fatalf("Expected PDF header, got %q", out[:8])
+	}
+	if !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Errorf("Expected PDF trailer")
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) {
+		t.Errorf("Expected 2 pages")
+	}
+	if !bytes.Contains(out, []byte(`(Invoice \(copy\)) Tj`)) {
+		t.Errorf("Expected parentheses to be escaped")
+	}
+}
+
+func TestFitPDFText(t *testing.T) {
+	if got := utils.FitPDFText("Kaos", 10, false, 100); got != "Kaos" {
+		t.Errorf("Expected short text unchanged, got %q", got)
+	}
+
+	long := "Kaos Polos Premium Cotton Combed 30s Lengan Panjang"
+	got := utils.FitPDFText(long, 10, false, 100)
+	if got == long || utils.PDFTextWidth(got, 10, false) > 100 {
+		t.Errorf("Expected text shortened to fit, got %q", got)
+	}
+}
+
+func TestFormatRupiah(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{0, "Rp 0"},
+		{999, "Rp 999"},
+		{150000, "Rp 150.000"},
+		{1250000, "Rp 1.250.000"},
+		{9909.91, "Rp 9.909,91"},
+		{-15000, "Rp -15.000"},
+	}
+
+	for _, tt := range tests {
+		if got := utils.FormatRupiah(models.NewMoney(tt.amount)); got != tt.want {
+			t.Errorf("FormatRupiah(%v) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
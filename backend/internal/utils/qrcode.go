@@ -0,0 +1,511 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// QR code tables for error correction level M, indexed by version.
+var (
+	qrECCodewordsPerBlockM = [41]int{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28}
+	qrNumBlocksM           = [41]int{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49}
+)
+
+// QRCode is a square grid of modules encoding text in byte mode at error
+// correction level M, which is what payment apps expect for QRIS and leaves
+// room for a smudged print.
+type QRCode struct {
+	size    int
+	modules [][]bool
+	isFunc  [][]bool
+}
+
+// NewQRCode encodes data in the smallest version that fits.
+func NewQRCode(data []byte) (*QRCode, error) {
+	version := 0
+	for v := 1; v <= 40; v++ {
+		countBits := 8
+		if v > 9 {
+			countBits = 16
+		}
+		if 4+countBits+len(data)*8 <= qrDataCodewords(v)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errors.New("data is too long for a qr code")
+	}
+
+	bits := &qrBitBuffer{}
+	bits.append(0x4, 4)
+	if version > 9 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := qrDataCodewords(version) * 8
+	terminator := capacity - len(*bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(*bits)%8)%8)
+	for pad := 0xEC; len(*bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(*bits)/8)
+	for i, bit := range *bits {
+		if bit {
+			codewords[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+
+	q := &QRCode{size: version*4 + 17}
+	q.modules = make([][]bool, q.size)
+	q.isFunc = make([][]bool, q.size)
+	for i := range q.modules {
+		q.modules[i] = make([]bool, q.size)
+		q.isFunc[i] = make([]bool, q.size)
+	}
+
+	q.drawFunctionPatterns(version)
+	q.drawCodewords(qrAddECC(codewords, version))
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(bestMask)
+	q.drawFormatBits(bestMask)
+
+	return q, nil
+}
+
+// Size returns the number of modules along one side.
+func (q *QRCode) Size() int {
+	return q.size
+}
+
+// Module reports whether the module at x, y is dark.
+func (q *QRCode) Module(x, y int) bool {
+	return q.modules[y][x]
+}
+
+// PNG renders the code with scale pixels per module and the four-module
+// quiet zone scanners need around it.
+func (q *QRCode) PNG(scale int) ([]byte, error) {
+	if scale < 1 {
+		scale = 1
+	}
+	const border = 4
+	side := (q.size + border*2) * scale
+
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray((x+border)*scale+dx, (y+border)*scale+dy, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (q *QRCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunc[y][x] = true
+}
+
+func (q *QRCode) drawFunctionPatterns(version int) {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+
+	positions := qrAlignmentPositions(version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(x+dx, y+dy, qrMax(qrAbs(dx), qrAbs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn once a mask is chosen.
+	q.drawFormatBits(0)
+	q.drawVersion(version)
+}
+
+func (q *QRCode) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= q.size || y < 0 || y >= q.size {
+				continue
+			}
+			dist := qrMax(qrAbs(dx), qrAbs(dy))
+			q.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawFormatBits writes the error correction level (M) and mask, protected
+// by a BCH code, next to the finder patterns.
+func (q *QRCode) drawFormatBits(mask int) {
+	data := 0<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>uint(i)&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+func (q *QRCode) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	bits := version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 != 0
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the data in the zigzag order of the standard,
+// skipping function modules.
+func (q *QRCode) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				upward := (right+1)&2 == 0
+				y := vert
+				if upward {
+					y = q.size - 1 - vert
+				}
+				if !q.isFunc[y][x] && i < len(data)*8 {
+					q.modules[y][x] = data[i>>3]>>(7-uint(i&7))&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask XORs the data modules with a mask pattern; applying it twice
+// undoes it.
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.isFunc[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the current modules by the four rules of the standard; the
+// mask with the lowest score is kept.
+func (q *QRCode) penalty() int {
+	result := 0
+	finderLike := func(run []bool) int {
+		// 1:1:3:1:1 dark-light pattern with four light modules on one side.
+		count := 0
+		pattern := []bool{true, false, true, true, true, false, true}
+		for i := 0; i+7 <= len(run); i++ {
+			match := true
+			for k, v := range pattern {
+				if run[i+k] != v {
+					match = false
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+			before, after := true, true
+			for k := 1; k <= 4; k++ {
+				if i-k >= 0 && run[i-k] {
+					before = false
+				}
+				if i+6+k < len(run) && run[i+6+k] {
+					after = false
+				}
+			}
+			if before || after {
+				count++
+			}
+		}
+		return count
+	}
+
+	for pass := 0; pass < 2; pass++ {
+		for a := 0; a < q.size; a++ {
+			line := make([]bool, q.size)
+			for b := 0; b < q.size; b++ {
+				if pass == 0 {
+					line[b] = q.modules[a][b]
+				} else {
+					line[b] = q.modules[b][a]
+				}
+			}
+			runLength := 1
+			for b := 1; b <= q.size; b++ {
+				if b < q.size && line[b] == line[b-1] {
+					runLength++
+					continue
+				}
+				if runLength >= 5 {
+					result += runLength - 2
+				}
+				runLength = 1
+			}
+			result += finderLike(line) * 40
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.size && y+1 < q.size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+
+	total := q.size * q.size
+	k := (qrAbs(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		result += k * 10
+	}
+	return result
+}
+
+type qrBitBuffer []bool
+
+func (b *qrBitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, value>>uint(i)&1 != 0)
+	}
+}
+
+func qrAlignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, pos := count-1, version*4+10; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+// qrRawDataModules returns the number of modules available for data and
+// error correction in a version.
+func qrRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		align := version/7 + 2
+		result -= (25*align-10)*align - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func qrDataCodewords(version int) int {
+	return qrRawDataModules(version)/8 - qrECCodewordsPerBlockM[version]*qrNumBlocksM[version]
+}
+
+// qrAddECC splits data into blocks, appends Reed-Solomon error correction
+// to each and interleaves the result.
+func qrAddECC(data []byte, version int) []byte {
+	numBlocks := qrNumBlocksM[version]
+	eccLen := qrECCodewordsPerBlockM[version]
+	rawCodewords := qrRawDataModules(version) / 8
+	numShort := numBlocks - rawCodewords%numBlocks
+	shortLen := rawCodewords / numBlocks
+
+	divisor := qrReedSolomonDivisor(eccLen)
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < numBlocks; i++ {
+		dataLen := shortLen - eccLen
+		if i >= numShort {
+			dataLen++
+		}
+		block := data[k : k+dataLen]
+		k += dataLen
+		ecc := qrReedSolomonRemainder(block, divisor)
+
+		full := make([]byte, 0, shortLen+1)
+		full = append(full, block...)
+		if i < numShort {
+			// Short blocks get a placeholder so columns line up when
+			// interleaving; it is skipped below.
+			full = append(full, 0)
+		}
+		blocks[i] = append(full, ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i < len(blocks[0]); i++ {
+		for j, block := range blocks {
+			if i != shortLen-eccLen || j >= numShort {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			result[j] = qrGFMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrGFMultiply(root, 0x02)
+	}
+	return result
+}
+
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= qrGFMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+// qrGFMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func qrGFMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>uint(i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+func qrAbs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func qrMax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,192 @@
+package utils_test
+
+import (
+	"bytes"
+	"e-commerce/backend/internal/utils"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestNewQRCode(t *testing.T) {
+	tests := []struct {
+		data string
+		size int
+	}{
+		{"TRX-AB12CD34EF", 21},
+		{strings.Repeat("x", 14), 21},
+		{strings.Repeat("x", 15), 25},
+		{strings.Repeat("x", 180), 53},
+		{strings.Repeat("x", 181), 57},
+	}
+	for _, tt := range tests {
+		q, err := utils.NewQRCode([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("%d bytes: expected no error, got %v", len(tt.data), err)
+		}
+		if q.Size() != tt.size {
+			t.Errorf("%d bytes: expected size %d, got %d", len(tt.data), tt.size, q.Size())
+		}
+
+		// Finder pattern corners and the timing pattern are fixed.
+		last := q.Size() - 1
+		for _, p := range [][2]int{{0, 0}, {last, 0}, {0, last}, {2, 2}, {8, 6}} {
+			if !q.Module(p[0], p[1]) {
+				t.Errorf("%d bytes: expected dark module at %v", len(tt.data), p)
+			}
+		}
+		if q.Module(7, 7) || q.Module(1, 1) || q.Module(9, 6) {
+			t.Errorf("%d bytes: expected light separator and timing modules", len(tt.data))
+		}
+	}
+
+	if _, err := utils.NewQRCode(make([]byte, 3000)); err == nil {
+		t.Error("Expected an error for data beyond version 40")
+	}
+}
+
+// TestNewQRCode_KnownAnswer compares whole symbols with the output of a
+// reference encoder (github.com/skip2/go-qrcode at level M, without the
+// quiet zone). Version 7 also covers the version information and several
+// error correction blocks.
+func TestNewQRCode_KnownAnswer(t *testing.T) {
+	tests := []struct {
+		data string
+		want []string
+	}{
+		{
+			"https://shop.example.com/pay",
+			[]string{
+				"#######..###.#..####..#######",
+				"#.....#...#.####..#.#.#.....#",
+				"#.###.#.....##..##.#..#.###.#",
+				"#.###.#..#.#.###..##..#.###.#",
+				"#.###.#...#####.###...#.###.#",
+				"#.....#.#...###...###.#.....#",
+				"#######.#.#.#.#.#.#.#.#######",
+				".........#.#..#..####........",
+				"#..#.##.####...#..##.#.#.....",
+				".###....#.....##.....###.#..#",
+				".###.###..#.....##.#.##.####.",
+				".#####..##.#..##..##.##...##.",
+				"..##.###.##.#...##..####.#.##",
+				"#..#....#.#....#.#####.......",
+				".#.##.##.##.#..####..##..####",
+				"##...#.#.#.#.##.##.##...##.#.",
+				".....##......#####..##.....#.",
+				"...###.######..#......##.#..#",
+				"#.....#.##.###....#.#...#..##",
+				"..##.#..##..##.####..####..##",
+				"#.#.####.##.#.#.#...#####.#..",
+				"........#....#.##.#.#...#.###",
+				"#######..##.####..#.#.#.#..#.",
+				"#.....#.#...##..#.#.#...####.",
+				"#.###.#...#...##..#.#####..#.",
+				"#.###.#.#########..#..#####.#",
+				"#.###.#..#..#.#....##...###.#",
+				"#.....#....###.#...###..#..#.",
+				"#######.##.#.#......##..#..#.",
+			},
+		},
+		{
+			"https://shop.example.com/checkout/confirm?method=qris&return=https://shop.example.com/orders/mine&lang=en&theme=light",
+			[]string{
+				"#######..#.##.#.#..#.#.......###....#.#######",
+				"#.....#..#.......##.###.#.#..#.#.#.#..#.....#",
+				"#.###.#.##..#...###....####.######.#..#.###.#",
+				"#.###.#.#.###.##.##.####.#....##...##.#.###.#",
+				"#.###.#.##.#.#.#.#.#######...##.#.###.#.###.#",
+				"#.....#.#......##.#.#...#.##...#.#....#.....#",
+				"#######.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#######",
+				"........##.#..#..####...##.##..###.#.........",
+				"#.#####...####.##.#.######....##..#...#####..",
+				"..#.##.#..##.....###..##...#.###...##...#####",
+				".##..######.#.##..#...###.#.#..#.###.###.###.",
+				"#.##...#.#..#...##.##...#...######..#######..",
+				"......##.....#...##.###...##..##.###.###.#..#",
+				".##.##...##..#..#..##....#...###...###...##.#",
+				"##..####...###..##..##..#.#....#.####.#...##.",
+				"##...#.#.##.###....#.#.######...###...#.#####",
+				"###.###.#...#.#.#.#..#.#..#...##.#.#..##.#.##",
+				"##..##.##.....#.##.#..##.#.#.##.#..##...##.##",
+				".#..####..##.#....###.###.#.#..#####..##.###.",
+				".#.........#####...#.....##.#...#..#.#.####..",
+				".##.#####.#.#.##.########.....#...#.#####...#",
+				"#...#...###...#.##..#...##...##.##.##...#####",
+				".#.##.#.##..###.##.##.#.#.#.#..##.###.#.#.##.",
+				"#..##...##.###.##...#...#####.#.###.#...#####",
+				"#..##########.####..#####.#..###.#.#######.##",
+				"...##......###..#.########.#.##.#...#.#..##.#",
+				"..#.#.##.###.#.#.#........##...####..#..#.##.",
+				"..#.##.#.......#.#.....##.###...#.##..##..##.",
+				".###..##.#..#....####..#.#....##......#.#..#.",
+				".##.....#.#.##.###....##.#...###.#.#..#..##.#",
+				"#.....######......#.#.#..##.#..##.###..#..#..",
+				"####.#..#....###......###..##.#.#.##..##.####",
+				"#.#.###..#..#.#.#.#....#.##..##...#.######...",
+				"##.##......#.####..##.####.#.##....#..#.....#",
+				"....#.###.....#...#...#...##......#.##.#####.",
+				".####..#....####..##.####.#.##..#.###.#.####.",
+				"#..##.#.###..#...#..######.#.#.#....#####..##",
+				"........#.#####..####...##.#.###...##...###.#",
+				"#######..#..##..#..##.#.#.#....#.####.#.#.##.",
+				"#.....#.#..##...#.###...#...##..#.###...#####",
+				"#.###.#.#.#..#.#....#######..##...########..#",
+				"#.###.#.#...######....##.#...##......##.##.##",
+				"#.###.#.##..#...#..#.#.#..#.##....#####..#.#.",
+				"#.....#..##..####.#.##.....####.#.#.#..#.##..",
+				"#######.####...#.###..#.###..###..##..##...#.",
+			},
+		},
+	}
+	for _, tt := range tests {
+		q, err := utils.NewQRCode([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", tt.data, err)
+		}
+		if q.Size() != len(tt.want) {
+			t.Fatalf("%q: expected size %d, got %d", tt.data, len(tt.want), q.Size())
+		}
+
+		for y, row := range tt.want {
+			var got strings.Builder
+			for x := 0; x < q.Size(); x++ {
+				if q.Module(x, y) {
+					got.WriteByte('#')
+				} else {
+					got.WriteByte('.')
+				}
+			}
+			if got.String() != row {
+				t.Errorf("%q: row %d\n got %s\nwant %s", tt.data, y, got.String(), row)
+			}
+		}
+	}
+}
+
+func TestQRCode_PNG(t *testing.T) {
+	q, err := utils.NewQRCode([]byte("TRX-AB12CD34EF"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := q.PNG(4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("Expected a valid PNG, got %v", err)
+	}
+
+	side := (21 + 8) * 4
+	if b := img.Bounds(); b.Dx() != side || b.Dy() != side {
+		t.Errorf("Expected %dx%d image, got %v", side, side, b)
+	}
+	if r, _, _, _ := img.At(0, 0).RGBA(); r == 0 {
+		t.Error("Expected a light quiet zone")
+	}
+	if r, _, _, _ := img.At(16, 16).RGBA(); r != 0 {
+		t.Error("Expected the finder pattern to start after the quiet zone")
+	}
+}
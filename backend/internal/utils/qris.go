@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"e-commerce/backend/internal/models"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EMVCo merchant-presented QR tags used by QRIS.
+const (
+	qrisTagInitiation     = "01"
+	qrisTagAmount         = "54"
+	qrisTagTipIndicator   = "55"
+	qrisTagTipFixed       = "56"
+	qrisTagTipPercent     = "57"
+	qrisTagMerchantName   = "59"
+	qrisTagAdditionalData = "62"
+	qrisTagCRC            = "63"
+
+	qrisDynamic = "12"
+
+	// qrisSubTagReference is the reference label inside the additional data
+	// field, which issuers echo back in the payment notification.
+	qrisSubTagReference = "05"
+)
+
+// qrisField is one ID-length-value element of an EMVCo QR payload.
+type qrisField struct {
+	id    string
+	value string
+}
+
+// parseQRISFields splits an EMVCo payload into its top-level fields.
+func parseQRISFields(payload string) ([]qrisField, error) {
+	var fields []qrisField
+	for i := 0; i < len(payload); {
+		if i+4 > len(payload) {
+			return nil, errors.New("qris payload is truncated")
+		}
+		id := payload[i : i+2]
+		length, err := strconv.Atoi(payload[i+2 : i+4])
+		if err != nil {
+			return nil, fmt.Errorf("qris field %s has an invalid length", id)
+		}
+		i += 4
+		if i+length > len(payload) {
+			return nil, fmt.Errorf("qris field %s is truncated", id)
+		}
+		fields = append(fields, qrisField{id: id, value: payload[i : i+length]})
+		i += length
+	}
+	return fields, nil
+}
+
+func encodeQRISFields(fields []qrisField) string {
+	var sb strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&sb, "%s%02d%s", f.id, len(f.value), f.value)
+	}
+	return sb.String()
+}
+
+// CRC16CCITT returns the CRC-16/CCITT-FALSE checksum of data, the checksum
+// EMVCo QR payloads end with.
+func CRC16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// qrisChecksum returns the CRC field for a payload that already ends with
+// the CRC tag and length, "6304".
+func qrisChecksum(payload string) string {
+	return fmt.Sprintf("%04X", CRC16CCITT([]byte(payload)))
+}
+
+// ValidateQRIS checks that payload is a well-formed QRIS whose checksum
+// matches its content.
+func ValidateQRIS(payload string) error {
+	if !strings.HasPrefix(payload, "000201") {
+		return errors.New("qris payload must start with the payload format indicator")
+	}
+	fields, err := parseQRISFields(payload)
+	if err != nil {
+		return err
+	}
+
+	last := fields[len(fields)-1]
+	if last.id != qrisTagCRC || len(last.value) != 4 {
+		return errors.New("qris payload must end with its crc")
+	}
+	if !strings.EqualFold(qrisChecksum(payload[:len(payload)-4]), last.value) {
+		return errors.New("qris payload crc does not match its content")
+	}
+	return nil
+}
+
+// QRISMerchantName returns the merchant name of a QRIS payload, or an empty
+// string when it has none.
+func QRISMerchantName(payload string) string {
+	fields, err := parseQRISFields(payload)
+	if err != nil {
+		return ""
+	}
+	for _, f := range fields {
+		if f.id == qrisTagMerchantName {
+			return f.value
+		}
+	}
+	return ""
+}
+
+// DynamicQRIS turns the merchant's static QRIS into a single-use dynamic one
+// for amount, carrying reference as the reference label so the payment can
+// be traced back to the transaction. Tip fields are dropped, since the
+// customer must pay exactly the amount, and the CRC is recomputed.
+func DynamicQRIS(static string, amount models.Money, reference string) (string, error) {
+	if err := ValidateQRIS(static); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", errors.New("qris amount must be greater than zero")
+	}
+	if len(reference) > 25 {
+		return "", errors.New("qris reference must be at most 25 characters")
+	}
+
+	fields, err := parseQRISFields(static)
+	if err != nil {
+		return "", err
+	}
+
+	var additional []qrisField
+	kept := fields[:0]
+	for _, f := range fields {
+		switch f.id {
+		case qrisTagCRC, qrisTagAmount, qrisTagTipIndicator, qrisTagTipFixed, qrisTagTipPercent:
+		case qrisTagAdditionalData:
+			additional, err = parseQRISFields(f.value)
+			if err != nil {
+				return "", fmt.Errorf("qris additional data: %w", err)
+			}
+		case qrisTagInitiation:
+			f.value = qrisDynamic
+			kept = append(kept, f)
+		default:
+			kept = append(kept, f)
+		}
+	}
+
+	kept = append(kept, qrisField{id: qrisTagAmount, value: formatQRISAmount(amount)})
+
+	subFields := additional[:0]
+	for _, f := range additional {
+		if f.id != qrisSubTagReference {
+			subFields = append(subFields, f)
+		}
+	}
+	if reference != "" {
+		subFields = append(subFields, qrisField{id: qrisSubTagReference, value: reference})
+	}
+	if len(subFields) > 0 {
+		sort.SliceStable(subFields, func(i, j int) bool { return subFields[i].id < subFields[j].id })
+		value := encodeQRISFields(subFields)
+		if len(value) > 99 {
+			return "", errors.New("qris additional data is too long")
+		}
+		kept = append(kept, qrisField{id: qrisTagAdditionalData, value: value})
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].id < kept[j].id })
+	payload := encodeQRISFields(kept) + qrisTagCRC + "04"
+	return payload + qrisChecksum(payload), nil
+}
+
+// formatQRISAmount writes amount the way QRIS expects it: rupiah without
+// thousands separators, and decimals only when there is a fraction.
+func formatQRISAmount(amount models.Money) string {
+	if amount%100 == 0 {
+		return strconv.FormatInt(int64(amount/100), 10)
+	}
+	return amount.String()
+}
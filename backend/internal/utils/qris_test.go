@@ -0,0 +1,83 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// staticQRIS is a static merchant QRIS with a tip prompt and a terminal
+// label in its additional data.
+func staticQRIS() string {
+	payload := "000201" +
+		"010211" +
+		"26570011ID.DANA.WWW011893600915300000000102090000000010303UMI" +
+		"52045814" +
+		"5303360" +
+		"550201" +
+		"5802ID" +
+		"5909TOKO MAJU" +
+		"6007JAKARTA" +
+		"610512345" +
+		"62070703A01" +
+		"6304"
+	return payload + fmt.Sprintf("%04X", utils.CRC16CCITT([]byte(payload)))
+}
+
+func TestCRC16CCITT(t *testing.T) {
+	if got := utils.CRC16CCITT([]byte("123456789")); got != 0x29B1 {
+		t.Errorf("Expected 0x29B1, got %#04x", got)
+	}
+}
+
+func TestValidateQRIS(t *testing.T) {
+	valid := staticQRIS()
+	if err := utils.ValidateQRIS(valid); err != nil {
+		t.Fatalf("Expected valid QRIS, got %v", err)
+	}
+
+	tests := map[string]string{
+		"wrong crc":       valid[:len(valid)-4] + "0000",
+		"missing crc":     valid[:len(valid)-8],
+		"truncated field": valid[:20],
+		"not emv":         "https://example.com/pay",
+	}
+	for name, payload := range tests {
+		if err := utils.ValidateQRIS(payload); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestDynamicQRIS(t *testing.T) {
+	payload, err := utils.DynamicQRIS(staticQRIS(), models.Rupiah(150123), "TRX-AB12CD34EF")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := utils.ValidateQRIS(payload); err != nil {
+		t.Fatalf("Expected a valid payload, got %v", err)
+	}
+	for _, want := range []string{"010212", "5406150123", "62250514TRX-AB12CD34EF0703A01", "5909TOKO MAJU"} {
+		if !strings.Contains(payload, want) {
+			t.Errorf("Expected payload to contain %q, got %s", want, payload)
+		}
+	}
+	if strings.Contains(payload, "550201") {
+		t.Errorf("Expected tip indicator to be dropped, got %s", payload)
+	}
+	if name := utils.QRISMerchantName(payload); name != "TOKO MAJU" {
+		t.Errorf("Expected merchant name TOKO MAJU, got %q", name)
+	}
+
+	again, _ := utils.DynamicQRIS(payload, models.NewMoney(99.5), "")
+	if !strings.Contains(again, "540599.50") || strings.Contains(again, "5406150123") {
+		t.Errorf("Expected the amount to be replaced, got %s", again)
+	}
+
+	if _, err := utils.DynamicQRIS(staticQRIS(), 0, "TRX-AB12CD34EF"); err == nil {
+		t.Error("Expected an error for a zero amount")
+	}
+}
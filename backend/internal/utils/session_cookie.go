@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"crypto/subtle"
+	"e-commerce/backend/internal/config"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	CSRFTokenCookie    = "csrf_token"
+	CSRFTokenHeader    = "X-CSRF-Token"
+
+	// refreshCookiePath keeps the refresh token from being sent anywhere but
+	// the auth endpoints.
+	refreshCookiePath = "/api/v1/auth"
+)
+
+// SetSessionCookies stores the access and refresh tokens in httpOnly cookies
+// and issues a fresh CSRF token. The CSRF token is set in a cookie readable by
+// the web app and in the X-CSRF-Token response header; the app must echo it in
+// the X-CSRF-Token request header on mutating requests.
+func SetSessionCookies(w http.ResponseWriter, cfg config.SessionConfig, accessToken string, accessExpiresAt time.Time, refreshToken string, refreshExpiresAt time.Time) error {
+	csrfToken, err := GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, sessionCookie(cfg, AccessTokenCookie, accessToken, "/", accessExpiresAt, true))
+	if refreshToken != "" {
+		http.SetCookie(w, sessionCookie(cfg, RefreshTokenCookie, refreshToken, refreshCookiePath, refreshExpiresAt, true))
+	}
+	http.SetCookie(w, sessionCookie(cfg, CSRFTokenCookie, csrfToken, "/", refreshExpiresAt, false))
+	w.Header().Set(CSRFTokenHeader, csrfToken)
+
+	return nil
+}
+
+// ClearSessionCookies expires every session cookie.
+func ClearSessionCookies(w http.ResponseWriter, cfg config.SessionConfig) {
+	for _, cookie := range []*http.Cookie{
+		sessionCookie(cfg, AccessTokenCookie, "", "/", time.Unix(0, 0), true),
+		sessionCookie(cfg, RefreshTokenCookie, "", refreshCookiePath, time.Unix(0, 0), true),
+		sessionCookie(cfg, CSRFTokenCookie, "", "/", time.Unix(0, 0), false),
+	} {
+		cookie.MaxAge = -1
+		http.SetCookie(w, cookie)
+	}
+}
+
+// ValidCSRFToken reports whether the X-CSRF-Token header matches the
+// csrf_token cookie.
+func ValidCSRFToken(r *http.Request) bool {
+	cookie, err := r.Cookie(CSRFTokenCookie)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+
+	header := r.Header.Get(CSRFTokenHeader)
+	return subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) == 1
+}
+
+func sessionCookie(cfg config.SessionConfig, name, value, path string, expires time.Time, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   cfg.CookieDomain,
+		Expires:  expires,
+		Secure:   cfg.CookieSecure,
+		HttpOnly: httpOnly,
+		SameSite: parseSameSite(cfg.SameSite),
+	}
+}
+
+func parseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
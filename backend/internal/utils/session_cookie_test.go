@@ -0,0 +1,38 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidCSRFToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie string
+		header string
+		want   bool
+	}{
+		{name: "Matching", cookie: "abc123", header: "abc123", want: true},
+		{name: "Mismatch", cookie: "abc123", header: "other", want: false},
+		{name: "MissingHeader", cookie: "abc123", header: "", want: false},
+		{name: "MissingCookie", cookie: "", header: "abc123", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/products", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: utils.CSRFTokenCookie, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set(utils.CSRFTokenHeader, tt.header)
+			}
+
+			if got := utils.ValidCSRFToken(req); got != tt.want {
+				t.Errorf("ValidCSRFToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
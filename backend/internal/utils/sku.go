@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxSKULength is the longest SKU a size variant can have.
+const MaxSKULength = 64
+
+// NormalizeSKU trims and upper-cases a stock-keeping unit so "ts-001-m" and
+// "TS-001-M " are the same code. SKUs may only use letters, digits, dashes,
+// underscores and dots.
+func NormalizeSKU(sku string) (string, error) {
+	sku = strings.ToUpper(strings.TrimSpace(sku))
+	if sku == "" {
+		return "", errors.New("SKU is empty")
+	}
+	if len(sku) > MaxSKULength {
+		return "", errors.New("SKU is too long")
+	}
+	for _, r := range sku {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return "", errors.New("SKU may only contain letters, digits, '-', '_' and '.'")
+		}
+	}
+	return sku, nil
+}
+
+// NormalizeBarcode strips the spaces scanners and labels put in a barcode
+// and checks it is an EAN-8, UPC-A, EAN-13 or GTIN-14 with a valid check
+// digit.
+func NormalizeBarcode(barcode string) (string, error) {
+	barcode = strings.Join(strings.Fields(barcode), "")
+	switch len(barcode) {
+	case 8, 12, 13, 14:
+	default:
+		return "", errors.New("barcode must have 8, 12, 13 or 14 digits")
+	}
+
+	sum := 0
+	for i := 0; i < len(barcode)-1; i++ {
+		c := barcode[i]
+		if c < '0' || c > '9' {
+			return "", errors.New("barcode may only contain digits")
+		}
+		digit := int(c - '0')
+		// GS1 weights digits 3, 1, 3, ... counting from the right, check
+		// digit excluded
+		if (len(barcode)-1-i)%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	check := barcode[len(barcode)-1]
+	if check < '0' || check > '9' {
+		return "", errors.New("barcode may only contain digits")
+	}
+	if int(check-'0') != (10-sum%10)%10 {
+		return "", errors.New("barcode check digit is wrong")
+	}
+	return barcode, nil
+}
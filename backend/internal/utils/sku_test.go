@@ -0,0 +1,64 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/utils"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeSKU(t *testing.T) {
+	tests := []struct {
+		name    string
+		sku     string
+		want    string
+		wantErr bool
+	}{
+		{"upper-cases and trims", " ts-001-m ", "TS-001-M", false},
+		{"dots and underscores", "KAOS_POLOS.XL", "KAOS_POLOS.XL", false},
+		{"empty", "  ", "", true},
+		{"space inside", "TS 001", "", true},
+		{"too long", strings.Repeat("A", utils.MaxSKULength+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.NormalizeSKU(tt.sku)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeSKU(%q) error = %v, wantErr %v", tt.sku, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeSKU(%q) = %q, want %q", tt.sku, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		barcode string
+		want    string
+		wantErr bool
+	}{
+		{"EAN-13", "8992761166014", "8992761166014", false},
+		{"EAN-13 with spaces", "899 2761 16601 4", "8992761166014", false},
+		{"EAN-8", "96385074", "96385074", false},
+		{"UPC-A", "036000291452", "036000291452", false},
+		{"GTIN-14", "18992761166011", "18992761166011", false},
+		{"wrong check digit", "8992761166017", "", true},
+		{"letters", "89927611660A7", "", true},
+		{"wrong length", "123456", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.NormalizeBarcode(tt.barcode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeBarcode(%q) error = %v, wantErr %v", tt.barcode, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeBarcode(%q) = %q, want %q", tt.barcode, got, tt.want)
+			}
+		})
+	}
+}
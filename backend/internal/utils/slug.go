@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxSlugLength is the longest URL slug Slugify returns, in bytes.
+const MaxSlugLength = 100
+
+// Slugify turns a name into a URL slug: lower-case letters and digits with
+// single dashes between words, so "Outer & Cardigan" becomes
+// "outer-cardigan". Letters with accents are kept as they are.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		// cut before the rune that crosses the limit so an accented letter
+		// is never split in half
+		slug = slug[:MaxSlugLength]
+		for !utf8.ValidString(slug) {
+			_, size := utf8.DecodeLastRuneInString(slug)
+			slug = slug[:len(slug)-size]
+		}
+		slug = strings.TrimRight(slug, "-")
+	}
+	return slug
+}
+
+// IsSlug reports whether s is already a slug as Slugify makes them.
+func IsSlug(s string) bool {
+	return s != "" && Slugify(s) == s
+}
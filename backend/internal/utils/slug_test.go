@@ -0,0 +1,47 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/utils"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Outer & Cardigan", "outer-cardigan"},
+		{"  Kemeja Wanita  ", "kemeja-wanita"},
+		{"Men > Shoes > Sneakers", "men-shoes-sneakers"},
+		{"T-Shirt 2.0", "t-shirt-2-0"},
+		{"&&", ""},
+		{strings.Repeat("ab ", 60), strings.TrimRight(strings.Repeat("ab-", 34)[:utils.MaxSlugLength], "-")},
+		// 49 two-byte letters fill 98 bytes, the next one would end at 101
+		{"a" + strings.Repeat("é", 60), "a" + strings.Repeat("é", 49)},
+	}
+
+	for _, tt := range tests {
+		got := utils.Slugify(tt.name)
+		if got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+		if !utf8.ValidString(got) || len(got) > utils.MaxSlugLength {
+			t.Errorf("Slugify(%q) = %q is not a valid slug of at most %d bytes", tt.name, got, utils.MaxSlugLength)
+		}
+	}
+}
+
+func TestIsSlug(t *testing.T) {
+	for _, s := range []string{"sneakers", "men-shoes", "t-shirt-2-0"} {
+		if !utils.IsSlug(s) {
+			t.Errorf("IsSlug(%q) = false, want true", s)
+		}
+	}
+	for _, s := range []string{"", "Sneakers", "men--shoes", "-men", "men shoes"} {
+		if utils.IsSlug(s) {
+			t.Errorf("IsSlug(%q) = true, want false", s)
+		}
+	}
+}
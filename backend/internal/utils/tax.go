@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"e-commerce/backend/internal/models"
+	"math"
+)
+
+// CalculateTax returns the tax on amount at ratePercent, rounded to cents.
+// With inclusive pricing the tax is already part of amount and is backed
+// out of it; otherwise it is added on top.
+func CalculateTax(amount models.Money, ratePercent float64, inclusive bool) models.Money {
+	if amount <= 0 || ratePercent <= 0 {
+		return 0
+	}
+
+	if inclusive {
+		net := models.Money(math.Round(float64(amount) * 100 / (100 + ratePercent)))
+		return amount - net
+	}
+	return amount.Percent(ratePercent)
+}
@@ -0,0 +1,31 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/models"
+	"e-commerce/backend/internal/utils"
+	"testing"
+)
+
+func TestCalculateTax(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    float64
+		rate      float64
+		inclusive bool
+		want      float64
+	}{
+		{"exclusive", 100000, 11, false, 11000},
+		{"inclusive", 111000, 11, true, 11000},
+		{"inclusive rounds to cents", 100000, 11, true, 9909.91},
+		{"zero rate", 100000, 0, false, 0},
+		{"zero amount", 0, 11, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.CalculateTax(models.NewMoney(tt.amount), tt.rate, tt.inclusive); got != models.NewMoney(tt.want) {
+				t.Errorf("CalculateTax(%v, %v, %v) = %v, want %v", tt.amount, tt.rate, tt.inclusive, got, tt.want)
+			}
+		})
+	}
+}
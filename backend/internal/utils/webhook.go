@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignWebhook returns the hex HMAC-SHA256 of body with secret, the signature
+// a webhook sender puts in its signature header.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidWebhookSignature checks a webhook signature, with or without a
+// "sha256=" prefix, in constant time.
+func ValidWebhookSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+
+	expected, err := hex.DecodeString(SignWebhook(secret, body))
+	if err != nil {
+		return false
+	}
+	given, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, given)
+}
@@ -0,0 +1,35 @@
+package utils_test
+
+import (
+	"e-commerce/backend/internal/utils"
+	"testing"
+)
+
+func TestValidWebhookSignature(t *testing.T) {
+	body := []byte(`{"tracking_number":"JNE123","status":"delivered"}`)
+	signature := utils.SignWebhook("carrier-secret", body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"Valid", "carrier-secret", body, signature, true},
+		{"ValidWithPrefix", "carrier-secret", body, "sha256=" + signature, true},
+		{"WrongSecret", "other-secret", body, signature, false},
+		{"TamperedBody", "carrier-secret", []byte(`{"tracking_number":"JNE123","status":"returned"}`), signature, false},
+		{"NotHex", "carrier-secret", body, "not-a-signature", false},
+		{"Missing", "carrier-secret", body, "", false},
+		{"NoSecret", "", body, signature, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.ValidWebhookSignature(tt.secret, tt.body, tt.signature); got != tt.want {
+				t.Errorf("ValidWebhookSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}